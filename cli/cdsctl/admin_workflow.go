@@ -14,6 +14,7 @@ var adminWorkflowsCmd = cli.Command{
 func adminWorkflows() *cobra.Command {
 	return cli.NewCommand(adminWorkflowsCmd, nil, []*cobra.Command{
 		cli.NewCommand(adminWorkflowUpdateMaxRunCmd, adminWorkflowUpdateMaxRun, nil),
+		cli.NewCommand(adminWorkflowUpdatePriorityCmd, adminWorkflowUpdatePriority, nil),
 	})
 }
 
@@ -40,3 +41,28 @@ func adminWorkflowUpdateMaxRun(v cli.Values) error {
 	}
 	return client.AdminWorkflowUpdateMaxRuns(v.GetString("projectKey"), v.GetString("workflowName"), maxRuns)
 }
+
+var adminWorkflowUpdatePriorityCmd = cli.Command{
+	Name:  "priority",
+	Short: "Update the priority of the workflow jobs in the queue",
+	Long:  "Jobs of a workflow with a higher priority run before the other jobs of the project, the default priority is 0.",
+	Args: []cli.Arg{
+		{
+			Name: "projectKey",
+		},
+		{
+			Name: "workflowName",
+		},
+		{
+			Name: "priority",
+		},
+	},
+}
+
+func adminWorkflowUpdatePriority(v cli.Values) error {
+	priority, err := v.GetInt64("priority")
+	if err != nil {
+		return err
+	}
+	return client.AdminWorkflowUpdatePriority(v.GetString("projectKey"), v.GetString("workflowName"), priority)
+}
@@ -31,6 +31,7 @@ func projectCommands() []*cobra.Command {
 		projectVariable(),
 		projectIntegration(),
 		projectRepositoryManager(),
+		projectCalendar(),
 	}
 }
 
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectCalendarCmd = cli.Command{
+	Name:    "calendars",
+	Aliases: []string{"calendar"},
+	Short:   "Manage CDS project calendars used to exclude periods from scheduler hooks",
+}
+
+func projectCalendar() *cobra.Command {
+	return cli.NewCommand(projectCalendarCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectCalendarListCmd, projectCalendarListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectCalendarExportCmd, projectCalendarExportRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectCalendarImportCmd, projectCalendarImportRun, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectCalendarDeleteCmd, projectCalendarDeleteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectCalendarListCmd = cli.Command{
+	Name:  "list",
+	Short: "List CDS project calendars",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectCalendarListRun(v cli.Values) (cli.ListResult, error) {
+	calendars, err := client.ProjectCalendarList(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(calendars), nil
+}
+
+var projectCalendarExportCmd = cli.Command{
+	Name:    "export",
+	Short:   "Export a CDS project calendar as a yaml file",
+	Aliases: []string{"show"},
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectCalendarExportRun(v cli.Values) error {
+	calendar, err := client.ProjectCalendarGet(v.GetString(_ProjectKey), v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	btes, err := yaml.Marshal(calendar)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(btes))
+	return nil
+}
+
+var projectCalendarImportCmd = cli.Command{
+	Name:    "import",
+	Short:   "Import a CDS project calendar from a yaml file",
+	Aliases: []string{"add"},
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "file"},
+	},
+}
+
+func projectCalendarImportRun(v cli.Values) error {
+	btes, err := ioutil.ReadFile(v.GetString("file"))
+	if err != nil {
+		return err
+	}
+	var calendar sdk.ProjectCalendar
+	if err := yaml.Unmarshal(btes, &calendar); err != nil {
+		return err
+	}
+
+	key := v.GetString(_ProjectKey)
+	if _, err := client.ProjectCalendarGet(key, calendar.Name); err != nil {
+		if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if err := client.ProjectCalendarCreate(key, &calendar); err != nil {
+			return err
+		}
+		fmt.Printf("Calendar %s created in project %s\n", calendar.Name, key)
+		return nil
+	}
+
+	if err := client.ProjectCalendarUpdate(key, &calendar); err != nil {
+		return err
+	}
+	fmt.Printf("Calendar %s updated in project %s\n", calendar.Name, key)
+	return nil
+}
+
+var projectCalendarDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a CDS project calendar",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectCalendarDeleteRun(v cli.Values) error {
+	err := client.ProjectCalendarDelete(v.GetString(_ProjectKey), v.GetString("name"))
+	if v.GetBool("force") && sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -131,6 +131,7 @@ func queueRun(v cli.Values) (cli.ListResult, error) {
 }
 
 type jobCLI struct {
+	Rank         string        `cli:"rank"`
 	Run          string        `cli:"run,key"`
 	ProjectKey   string        `cli:"project_key"`
 	WorkflowName string        `cli:"workflow_name"`
@@ -141,6 +142,8 @@ type jobCLI struct {
 	Duration     time.Duration `cli:"-"`
 	BookedBy     string        `cli:"booked_by"`
 	TriggeredBy  string        `cli:"triggered_by"`
+	Priority     int64         `cli:"priority"`
+	Boosted      bool          `cli:"boosted"`
 }
 
 func getJobQueue(status ...string) ([]jobCLI, error) {
@@ -168,6 +171,11 @@ func getJobQueue(status ...string) ([]jobCLI, error) {
 			Duration:     time.Since(jr.Queued),
 			BookedBy:     jr.BookedBy.Name,
 			TriggeredBy:  getVarsInPbj("cds.triggered_by.username", jr.Parameters),
+			Priority:     jr.Priority,
+			Boosted:      jr.Boosted,
+		}
+		if jr.QueueRank > 0 {
+			jobsUI[k].Rank = strconv.Itoa(jr.QueueRank)
 		}
 	}
 
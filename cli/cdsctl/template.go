@@ -33,6 +33,8 @@ func template() *cobra.Command {
 			cli.NewCommand(templateInstancesExportCmd, templateInstancesExportRun, nil, withAllCommandModifiers()...),
 		}),
 		cli.NewCommand(templateDetachCmd, templateDetachRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(templateUpgradeCmd, templateUpgradeRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(templatePinCmd, templatePinRun, nil, withAllCommandModifiers()...),
 	})
 }
 
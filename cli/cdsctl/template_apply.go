@@ -4,10 +4,12 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	repo "github.com/fsamin/go-repo"
@@ -212,22 +214,27 @@ func templateApplyRun(v cli.Values) error {
 		var listRepositories []string
 		var listSSHKeys []string
 		var listPGPKeys []string
+		var listEnvironments []string
+		var listIntegrations []string
 		var localRepoPath string
 
 		// if there are params of type repository in list of params to fill prepare
 		// the list of repositories for project
 		var withRepository bool
 		var withKey bool
+		var withEnvironment bool
+		var withIntegration bool
 		for _, p := range wt.Parameters {
 			if _, ok := params[p.Key]; !ok {
-				if p.Type == sdk.ParameterTypeRepository {
+				switch p.Type {
+				case sdk.ParameterTypeRepository:
 					withRepository = true
-				}
-				if p.Type == sdk.ParameterTypeSSHKey || p.Type == sdk.ParameterTypePGPKey {
+				case sdk.ParameterTypeSSHKey, sdk.ParameterTypePGPKey:
 					withKey = true
-				}
-				if withRepository && withKey {
-					break
+				case sdk.ParameterTypeEnvironment:
+					withEnvironment = true
+				case sdk.ParameterTypeIntegration:
+					withIntegration = true
 				}
 			}
 		}
@@ -266,6 +273,24 @@ func templateApplyRun(v cli.Values) error {
 				}
 			}
 		}
+		if withEnvironment {
+			envs, err := client.EnvironmentList(projectKey)
+			if err != nil {
+				return err
+			}
+			for _, e := range envs {
+				listEnvironments = append(listEnvironments, e.Name)
+			}
+		}
+		if withIntegration {
+			integs, err := client.ProjectIntegrationList(projectKey)
+			if err != nil {
+				return err
+			}
+			for _, i := range integs {
+				listIntegrations = append(listIntegrations, i.Name)
+			}
+		}
 
 		// for each param not already fill ask for the value
 		for _, p := range wt.Parameters {
@@ -293,9 +318,55 @@ func templateApplyRun(v cli.Values) error {
 					}
 				case sdk.ParameterTypeBoolean:
 					choice = fmt.Sprintf("%t", cli.AskConfirm(fmt.Sprintf("Set value to 'true' for param '%s'", p.Key)))
+				case sdk.ParameterTypeList:
+					selected := cli.AskChoice(label, p.Values...)
+					choice = p.Values[selected]
+				case sdk.ParameterTypeMultiSelect:
+					selected := []string{}
+					for _, i := range cli.AskSelect(label, p.Values...) {
+						selected = append(selected, p.Values[i])
+					}
+					buf, err := json.Marshal(selected)
+					if err != nil {
+						return fmt.Errorf("cannot marshal selected values: %v", err)
+					}
+					choice = string(buf)
+				case sdk.ParameterTypeEnvironment:
+					// the environment can also be generated by the template
+					if len(listEnvironments) > 0 {
+						opts := append(append([]string{}, listEnvironments...), "Other environment")
+						if selected := cli.AskChoice(label, opts...); selected < len(listEnvironments) {
+							choice = listEnvironments[selected]
+						}
+					}
+				case sdk.ParameterTypeIntegration:
+					if len(listIntegrations) > 0 {
+						selected := cli.AskChoice(label, listIntegrations...)
+						choice = listIntegrations[selected]
+					}
+				case sdk.ParameterTypeSecret:
+					choice = cli.AskPassword(label)
+				case sdk.ParameterTypeNumber:
+					if p.Min != nil || p.Max != nil {
+						label = fmt.Sprintf("%s between %s and %s", label, templateParamBound(p.Min), templateParamBound(p.Max))
+					}
+				case sdk.ParameterTypeRegexp:
+					label = fmt.Sprintf("%s matching %s", label, p.Pattern)
 				}
-				if choice == "" {
-					choice = cli.AskValue(label)
+				// secret values should never be echoed, even when asked again
+				ask := cli.AskValue
+				if p.Type == sdk.ParameterTypeSecret {
+					ask = cli.AskPassword
+				}
+				for choice == "" {
+					choice = ask(label)
+					if choice == "" && !p.Required {
+						break
+					}
+					if err := p.CheckValue(choice); err != nil {
+						fmt.Println(cli.Red(fmt.Sprintf("%s", sdk.Cause(err))))
+						choice = ""
+					}
 				}
 
 				params[p.Key] = choice
@@ -418,3 +489,10 @@ func teeTarReader(r *tar.Reader, buf io.Writer) (*tar.Reader, error) {
 
 	return tar.NewReader(&b), nil
 }
+
+func templateParamBound(b *float64) string {
+	if b == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*b, 'f', -1, 64)
+}
@@ -2,11 +2,10 @@ package main
 
 import (
 	"bytes"
-	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
@@ -495,8 +494,26 @@ func templateBulkRun(v cli.Values) error {
 								return err
 							}
 							value = fmt.Sprintf("%t", result)
+						case sdk.ParameterTypeList:
+							if err := survey.AskOne(&survey.Select{Message: label, Options: p.Values}, &value, nil); err != nil {
+								return err
+							}
+						case sdk.ParameterTypeMultiSelect:
+							selected := []string{}
+							if err := survey.AskOne(&survey.MultiSelect{Message: label, Options: p.Values}, &selected, nil); err != nil {
+								return err
+							}
+							buf, err := json.Marshal(selected)
+							if err != nil {
+								return fmt.Errorf("cannot marshal selected values: %v", err)
+							}
+							value = string(buf)
+						case sdk.ParameterTypeSecret:
+							if err := survey.AskOne(&survey.Password{Message: label}, &value, nil); err != nil {
+								return err
+							}
 						default:
-							if err := survey.AskOne(&survey.Input{Message: label}, &value, nil); err != nil {
+							if err := survey.AskOne(&survey.Input{Message: label}, &value, templateParamValidator(p)); err != nil {
 								return err
 							}
 						}
@@ -529,40 +546,24 @@ func templateBulkRun(v cli.Values) error {
 	fmt.Printf("Bulk request with id %d successfully created for template %s/%s with %d operations\n", res.ID, wt.Group.Name, wt.Slug, len(res.Operations))
 
 	if v.GetBool("track") {
-		var currentDisplay = new(cli.Display)
-		currentDisplay.Printf("Looking for bulk %d...\n", b.ID)
-		currentDisplay.Do(context.Background())
-
-		for {
-			res, err = client.TemplateGetBulk(wt.Group.Name, wt.Slug, res.ID)
-			if err != nil {
-				return err
-			}
-
-			var out string
-			for _, o := range res.Operations {
-				var status string
-				switch o.Status {
-				case sdk.OperationStatusPending:
-					status = cli.Blue("pending")
-				case sdk.OperationStatusProcessing:
-					status = cli.Yellow("processing")
-				case sdk.OperationStatusDone:
-					status = cli.Green("done")
-				case sdk.OperationStatusError:
-					status = cli.Red("error")
-				}
-				out += fmt.Sprintf("%s/%s -> %s %s\n", o.Request.ProjectKey, o.Request.WorkflowName, status, o.Error)
-			}
-
-			currentDisplay.Printf(out)
-
-			time.Sleep(500 * time.Millisecond)
-			if res.IsDone() {
-				break
-			}
+		if _, err := templateTrackBulk(wt.Group.Name, wt.Slug, res.ID); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
+
+// templateParamValidator checks the value given for a parameter, empty values are checked with the template request.
+func templateParamValidator(p sdk.WorkflowTemplateParameter) survey.Validator {
+	return func(ans interface{}) error {
+		s, _ := ans.(string)
+		if s == "" {
+			return nil
+		}
+		if err := p.CheckValue(s); err != nil {
+			return fmt.Errorf("%s", sdk.Cause(err))
+		}
+		return nil
+	}
+}
@@ -44,6 +44,7 @@ func templateInstancesRun(v cli.Values) (cli.ListResult, error) {
 		Workflow string `cli:"workflow"`
 		Params   string `cli:"params"`
 		Version  int64  `cli:"version"`
+		Pinned   int64  `cli:"pinned"`
 		UpToDate bool   `cli:"uptodate"`
 	}
 
@@ -62,6 +63,7 @@ func templateInstancesRun(v cli.Values) (cli.ListResult, error) {
 			tids[i].Params = fmt.Sprintf("%s%s:%s\n", tids[i].Params, k, v)
 		}
 		tids[i].Version = wtis[i].WorkflowTemplateVersion
+		tids[i].Pinned = wtis[i].PinnedVersion
 		tids[i].UpToDate = wtis[i].WorkflowTemplateVersion == wt.Version
 	}
 
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var templateUpgradeCmd = cli.Command{
+	Name:  "upgrade",
+	Short: "Upgrade the workflows generated by a CDS workflow template to a version of the template",
+	Long: `Show the changes on each workflow generated by the template then upgrade them to the given version of the template.
+Workflows pinned to another version of the template are skipped. Use --batch to upgrade workflows by groups, the upgrade
+stops if a workflow of a group can't be upgraded.`,
+	Example: "cdsctl template upgrade group-name/template-slug --version 3 --batch 5",
+	OptionalArgs: []cli.Arg{
+		{Name: "template-path"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "version",
+			Usage: "Specify the version of the template, default is the latest version",
+		},
+		{
+			Type:      cli.FlagArray,
+			Name:      "instances",
+			ShortHand: "i",
+			Usage:     "Specify instances path to upgrade like --instances PROJ1/workflow1, default is all instances",
+			Default:   "",
+		},
+		{
+			Name:    "batch",
+			Usage:   "Specify the number of workflows to upgrade at once, default is all workflows",
+			Default: "0",
+		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "dry-run",
+			Usage: "Only show the changes, workflows are not upgraded",
+		},
+		{
+			Type:      cli.FlagBool,
+			Name:      "force",
+			ShortHand: "f",
+			Usage:     "Do not ask confirmation before upgrading workflows",
+		},
+	},
+}
+
+func templateUpgradeRun(v cli.Values) error {
+	wt, err := getTemplateFromCLI(v)
+	if err != nil {
+		return err
+	}
+	if wt == nil {
+		wt, err = suggestTemplate()
+		if err != nil {
+			return err
+		}
+	}
+
+	var version int64
+	if s := v.GetString("version"); s != "" {
+		version, err = strconv.ParseInt(s, 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid given version %s", s)
+		}
+	}
+	batch, err := strconv.Atoi(v.GetString("batch"))
+	if err != nil || batch < 0 {
+		return fmt.Errorf("invalid given batch size %s", v.GetString("batch"))
+	}
+
+	minstances, err := templateExtractAndValidateInstances(v.GetStringArray("instances"))
+	if err != nil {
+		return err
+	}
+
+	upgrades, err := client.TemplateGetUpgrades(wt.Group.Name, wt.Slug, version)
+	if err != nil {
+		return err
+	}
+
+	var operations []sdk.WorkflowTemplateBulkOperation
+	for _, u := range upgrades {
+		key := fmt.Sprintf("%s/%s", u.ProjectKey, u.WorkflowName)
+		if len(minstances) > 0 {
+			if _, ok := minstances[key]; !ok {
+				continue
+			}
+		}
+		version = u.ToVersion
+
+		fmt.Printf("%s: version %d -> %d\n", cli.Blue(key), u.FromVersion, u.ToVersion)
+		switch {
+		case u.Error != "":
+			fmt.Printf("%s %s\n\n", cli.Red("skipped:"), u.Error)
+			continue
+		case u.Diff == "" && u.FromVersion == u.ToVersion:
+			fmt.Printf("up to date\n\n")
+			continue
+		case u.Diff == "":
+			fmt.Printf("no change on the workflow\n\n")
+		default:
+			fmt.Println(templateColorDiff(u.Diff))
+		}
+		operations = append(operations, sdk.WorkflowTemplateBulkOperation{Request: u.Request})
+	}
+
+	if len(operations) == 0 {
+		fmt.Println("Nothing to do")
+		return nil
+	}
+	if v.GetBool("dry-run") {
+		return nil
+	}
+
+	if batch == 0 {
+		batch = len(operations)
+	}
+	for i := 0; i < len(operations); i += batch {
+		end := i + batch
+		if end > len(operations) {
+			end = len(operations)
+		}
+		ops := operations[i:end]
+
+		keys := make([]string, len(ops))
+		for j := range ops {
+			keys[j] = fmt.Sprintf("%s/%s", ops[j].Request.ProjectKey, ops[j].Request.WorkflowName)
+		}
+		if !v.GetBool("force") && !cli.AskConfirm(fmt.Sprintf("Upgrade %s to version %d of template %s", strings.Join(keys, ", "), version, wt.Path())) {
+			return fmt.Errorf("operation aborted")
+		}
+
+		res, err := client.TemplateBulk(wt.Group.Name, wt.Slug, sdk.WorkflowTemplateBulk{
+			Version:    version,
+			Operations: ops,
+		})
+		if err != nil {
+			return err
+		}
+		res, err = templateTrackBulk(wt.Group.Name, wt.Slug, res.ID)
+		if err != nil {
+			return err
+		}
+		for _, o := range res.Operations {
+			if o.Status == sdk.OperationStatusError {
+				return fmt.Errorf("upgrade stopped, some workflows were not upgraded")
+			}
+		}
+	}
+
+	return nil
+}
+
+// templateTrackBulk displays the status of bulk operations until the bulk is over.
+func templateTrackBulk(groupName, templateSlug string, id int64) (*sdk.WorkflowTemplateBulk, error) {
+	var currentDisplay = new(cli.Display)
+	currentDisplay.Printf("Looking for bulk %d...\n", id)
+	currentDisplay.Do(context.Background())
+
+	for {
+		res, err := client.TemplateGetBulk(groupName, templateSlug, id)
+		if err != nil {
+			return nil, err
+		}
+
+		var out string
+		for _, o := range res.Operations {
+			var status string
+			switch o.Status {
+			case sdk.OperationStatusPending:
+				status = cli.Blue("pending")
+			case sdk.OperationStatusProcessing:
+				status = cli.Yellow("processing")
+			case sdk.OperationStatusDone:
+				status = cli.Green("done")
+			case sdk.OperationStatusError:
+				status = cli.Red("error")
+			}
+			out += fmt.Sprintf("%s/%s -> %s %s\n", o.Request.ProjectKey, o.Request.WorkflowName, status, o.Error)
+		}
+
+		currentDisplay.Printf(out)
+
+		time.Sleep(500 * time.Millisecond)
+		if res.IsDone() {
+			return res, nil
+		}
+	}
+}
+
+func templateColorDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "+++"), strings.HasPrefix(l, "---"):
+		case strings.HasPrefix(l, "+"):
+			lines[i] = cli.Green(l)
+		case strings.HasPrefix(l, "-"):
+			lines[i] = cli.Red(l)
+		case strings.HasPrefix(l, "@@"):
+			lines[i] = cli.Blue(l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var templatePinCmd = cli.Command{
+	Name:    "pin",
+	Short:   "Pin a workflow generated by a CDS workflow template to a version of the template",
+	Long:    "The workflow will only be generated from the given version of the template, use version 0 to follow the latest version.",
+	Example: "cdsctl template pin group-name/template-slug PROJ1/workflow1 3",
+	Args: []cli.Arg{
+		{Name: "template-path"},
+		{Name: "instance-path"},
+		{Name: "version"},
+	},
+}
+
+func templatePinRun(v cli.Values) error {
+	groupName, templateSlug, err := cli.ParsePath(v.GetString("template-path"))
+	if err != nil {
+		return err
+	}
+	minstances, err := templateExtractAndValidateInstances([]string{v.GetString("instance-path")})
+	if err != nil {
+		return err
+	}
+	path := minstances[v.GetString("instance-path")]
+	version, err := strconv.ParseInt(v.GetString("version"), 10, 64)
+	if err != nil || version < 0 {
+		return fmt.Errorf("invalid given version %s", v.GetString("version"))
+	}
+
+	wtis, err := client.TemplateGetInstances(groupName, templateSlug)
+	if err != nil {
+		return err
+	}
+	var wti *sdk.WorkflowTemplateInstance
+	for i := range wtis {
+		if wtis[i].Project != nil && wtis[i].Project.Key == path.ProjectKey && wtis[i].Request.WorkflowName == path.WorkflowName {
+			wti = &wtis[i]
+			break
+		}
+	}
+	if wti == nil {
+		return fmt.Errorf("no instance found for workflow %s", path.Key())
+	}
+
+	if _, err := client.TemplatePinInstance(groupName, templateSlug, wti.ID, version); err != nil {
+		return err
+	}
+	if version == 0 {
+		fmt.Printf("Workflow %s now follows the latest version of template %s/%s\n", path.Key(), groupName, templateSlug)
+	} else {
+		fmt.Printf("Workflow %s pinned to version %d of template %s/%s\n", path.Key(), version, groupName, templateSlug)
+	}
+	return nil
+}
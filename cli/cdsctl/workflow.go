@@ -31,10 +31,12 @@ func workflow() *cobra.Command {
 		cli.NewCommand(workflowImportCmd, workflowImportRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowPullCmd, workflowPullRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowPushCmd, workflowPushRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowLintCmd, workflowLintRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowFavoriteCmd, workflowFavoriteRun, nil, withAllCommandModifiers()...),
 		cli.NewGetCommand(workflowTransformAsCodeCmd, workflowTransformAsCodeRun, nil, withAllCommandModifiers()...),
 		workflowLabel(),
 		workflowArtifact(),
+		workflowHook(),
 		workflowLog(),
 		workflowAdvanced(),
 	})
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowHookCmd = cli.Command{
+	Name:    "hook",
+	Aliases: []string{"hooks"},
+	Short:   "Manage Workflow hooks",
+}
+
+func workflowHook() *cobra.Command {
+	return cli.NewCommand(workflowHookCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowHookDeliveriesCmd, workflowHookDeliveriesRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowHookDeliveriesCmd = cli.Command{
+	Name:  "deliveries",
+	Short: "List outgoing webhook delivery attempts of one Workflow Run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+}
+
+func workflowHookDeliveriesRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	deliveries, err := client.WorkflowRunOutgoingHookDeliveries(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(deliveries), nil
+}
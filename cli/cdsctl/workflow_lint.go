@@ -0,0 +1,154 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	repo "github.com/fsamin/go-repo"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var workflowLintCmd = cli.Command{
+	Name:  "lint",
+	Short: "Check the workflow as code files of a repository",
+	Long: `
+Validate the files of the .cds directory without changing anything in CDS. The workflows are imported like for a push
+from a branch that is not the default one, then syntax errors, permission problems and the diff with the current workflows are displayed.
+
+The command fails if the files are not valid, so it can be used to check a pull request:
+
+	cdsctl workflow lint MYPROJ
+	cdsctl workflow lint MYPROJ ./.cds --branch my-feature
+
+	`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "directory"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "branch",
+			Usage: "Branch of the files, the current git branch is used by default.",
+		},
+		{
+			Name:  "repository",
+			Usage: "Url of the repository of the files, by default the repository of the existing workflows is used.",
+		},
+	},
+}
+
+func workflowLintRun(v cli.Values) error {
+	dir := v.GetString("directory")
+	if dir == "" {
+		dir = ".cds"
+	}
+
+	branch := v.GetString("branch")
+	if branch == "" {
+		ctx := context.Background()
+		if r, err := repo.New(ctx, filepath.Dir(filepath.Clean(dir))); err == nil {
+			branch, _ = r.CurrentBranch(ctx)
+		}
+	}
+	if branch == "" {
+		return fmt.Errorf("unable to get the current git branch, please use flag --branch")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := workflowLintDirToTarWriter(dir, buf); err != nil {
+		return err
+	}
+
+	var mods []cdsclient.RequestModifier
+	if repositoryURL := v.GetString("repository"); repositoryURL != "" {
+		mods = append(mods, func(r *http.Request) { r.Header.Set(sdk.WorkflowAsCodeHeader, repositoryURL) })
+	}
+	res, err := client.WorkflowAsCodeLint(v.GetString(_ProjectKey), branch, buf, mods...)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range res.Workflows {
+		status := "update"
+		if !w.Exists {
+			status = "create"
+		}
+		fmt.Printf("Workflow %s (%s)\n", cli.Magenta(w.Name), status)
+		for _, msg := range w.Messages {
+			fmt.Println(msg)
+		}
+		if w.Diff != "" {
+			fmt.Println(templateColorDiff(w.Diff))
+		}
+	}
+
+	for _, e := range res.Errors {
+		fmt.Println(cli.Red(e.String()))
+	}
+	if !res.Valid {
+		return fmt.Errorf("%d error(s) found in workflow as code files", len(res.Errors))
+	}
+
+	fmt.Println("Workflow as code files are valid")
+	return nil
+}
+
+// workflowLintDirToTarWriter writes the yaml and json files of given directory in a tar, files are named
+// as in the .cds directory of a repository.
+func workflowLintDirToTarWriter(dir string, buf *bytes.Buffer) error {
+	tw := tar.NewWriter(buf)
+	var count int
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yml", ".yaml", ".json":
+		default:
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		btes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name: ".cds/" + filepath.ToSlash(rel),
+			Mode: 0600,
+			Size: int64(len(btes)),
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(btes); err != nil {
+			return err
+		}
+		count++
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return fmt.Errorf("no workflow as code file found in directory %s", dir)
+	}
+	return tw.Close()
+}
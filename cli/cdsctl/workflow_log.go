@@ -35,6 +35,9 @@ var workflowLogCmd = cli.Command{
 	# download only one file, for run number 1
 	$ cdsctl workflow logs download KEY WF 1 --pattern="MyJob"
 	# this will download file WF-1.0-pipeline.myPipeline-stage.MyStage-job.MyJob-status.Success-step.0.log
+
+	# search a pattern in the logs of runs 10 to 20
+	$ cdsctl workflow logs search KEY WF "FAIL: Test[a-zA-Z]+" --from=10 --to=20
 `,
 }
 
@@ -43,6 +46,7 @@ func workflowLog() *cobra.Command {
 		cli.NewCommand(workflowLogListCmd, workflowLogListRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowLogDownloadCmd, workflowLogDownloadRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowLogStreamCmd, workflowLogStreamRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowLogSearchCmd, workflowLogSearchRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -480,3 +484,83 @@ func workflowLogStreamRun(v cli.Values) error {
 		}
 	}
 }
+
+var workflowLogSearchCmd = cli.Command{
+	Name:  "search",
+	Short: "Search a pattern in logs from workflow runs.",
+	Long: `Search a regular expression in the completed logs of a range of workflow runs.
+
+	# search in the logs of all runs
+	$ cdsctl workflow logs search KEY WF "FAIL: Test[a-zA-Z]+"
+
+	# search in the logs of runs 10 to 20
+	$ cdsctl workflow logs search KEY WF "FAIL: Test[a-zA-Z]+" --from=10 --to=20
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "pattern"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "from",
+			Usage: "Search from this run number",
+		},
+		{
+			Name:  "to",
+			Usage: "Search until this run number",
+		},
+		{
+			Name:    "limit",
+			Usage:   "Maximum count of matching lines",
+			Default: "100",
+		},
+	},
+}
+
+func workflowLogSearchRun(v cli.Values) (cli.ListResult, error) {
+	projectKey := v.GetString(_ProjectKey)
+	workflowName := v.GetString(_WorkflowName)
+
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := sdk.CDNLogSearchOptions{
+		ProjectKey:   projectKey,
+		WorkflowName: workflowName,
+		Pattern:      v.GetString("pattern"),
+		Limit:        limit,
+	}
+
+	// The CDN knows run ids, so run numbers given by the user are converted
+	for _, f := range []struct {
+		flag string
+		id   *int64
+	}{{"from", &opts.FromRunID}, {"to", &opts.ToRunID}} {
+		num, err := v.GetInt64(f.flag)
+		if err != nil {
+			return nil, err
+		}
+		if num <= 0 {
+			continue
+		}
+		wr, err := client.WorkflowRunGet(projectKey, workflowName, num)
+		if err != nil {
+			return nil, err
+		}
+		*f.id = wr.ID
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := client.WorkflowLogSearch(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(res), nil
+}
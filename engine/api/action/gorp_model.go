@@ -39,6 +39,7 @@ type actionEdge struct {
 	Optional       bool   `db:"optional"`
 	AlwaysExecuted bool   `db:"always_executed"`
 	StepName       string `db:"step_name"`
+	Timeout        int64  `db:"timeout"`
 	// aggregates
 	Parameters []actionEdgeParameter `db:"-"`
 	Child      *sdk.Action           `db:"-"`
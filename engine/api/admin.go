@@ -382,3 +382,28 @@ func (api *API) postWorkflowMaxRunHandler() service.Handler {
 		return nil
 	}
 }
+
+func (api *API) postWorkflowPriorityHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		var request sdk.UpdateWorkflowPriorityRequest
+		if err := service.UnmarshalBody(r, &request); err != nil {
+			return err
+		}
+
+		proj, err := project.Load(ctx, api.mustDBWithCtx(ctx), key)
+		if err != nil {
+			return err
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDBWithCtx(ctx), api.Cache, *proj, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return err
+		}
+
+		return workflow.UpdatePriorityByID(api.mustDBWithCtx(ctx), wf.ID, request.Priority)
+	}
+}
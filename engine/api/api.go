@@ -205,6 +205,12 @@ type Configuration struct {
 	Workflow struct {
 		MaxRuns int64 `toml:"maxRuns" comment:"Maximum of runs by workflow" json:"maxRuns" default:"255"`
 	} `toml:"workflow" comment:"######################\n 'Workflow' global configuration \n######################" json:"workflow"`
+	Queue struct {
+		DefaultShare  int            `toml:"defaultShare" comment:"Share of the queue given to a project without specific share" json:"defaultShare" default:"1"`
+		ProjectShares map[string]int `toml:"projectShares" comment:"Share of the queue by project key. Example: projectShares = { MYPROJ = 4 }" json:"projectShares"`
+		GroupShares   map[string]int `toml:"groupShares" comment:"Share of the queue by group name, for projects without project share. The highest share of the groups allowed to run the job is used" json:"groupShares"`
+		AgeBoost      int64          `toml:"ageBoost" comment:"Jobs waiting in queue since more than this number of seconds are moved ahead of the queue, 0 to disable" json:"ageBoost" default:"1800"`
+	} `toml:"queue" comment:"######################\n Job queue scheduling. \n Projects take turns in the queue according to their share and their jobs already building, \n the jobs of a project are ordered by workflow priority then by age. \n######################" json:"queue"`
 }
 
 // DefaultValues is the struc for API Default configuration default values
@@ -691,10 +697,16 @@ func (a *API) Serve(ctx context.Context) error {
 	a.GoRoutines.Run(ctx, "api.WorkflowRunCraft", func(ctx context.Context) {
 		a.WorkflowRunCraft(ctx, 100*time.Millisecond)
 	}, a.PanicDump())
+	a.GoRoutines.Run(ctx, "api.StopTimedOutJobs", func(ctx context.Context) {
+		a.StopTimedOutJobs(ctx, time.Minute)
+	}, a.PanicDump())
 
 	migrate.Add(ctx, sdk.Migration{Name: "RunsSecrets", Release: "0.47.0", Blocker: false, Automatic: true, ExecFunc: func(ctx context.Context) error {
 		return migrate.RunsSecrets(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper))
 	}})
+	migrate.Add(ctx, sdk.Migration{Name: "WorkflowHookSecrets", Release: "0.48.0", Blocker: false, Automatic: true, ExecFunc: func(ctx context.Context) error {
+		return migrate.WorkflowHookSecrets(ctx, a.Cache, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper))
+	}})
 
 	isFreshInstall, errF := version.IsFreshInstall(a.mustDB())
 	if errF != nil {
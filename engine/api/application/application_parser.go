@@ -148,6 +148,7 @@ func ParseAndImport(ctx context.Context, db gorpmapper.SqlExecutorWithTx, cache
 		User:           eapp.VCSUser,
 		SSHKey:         eapp.VCSSSHKey,
 		PGPKey:         eapp.VCSPGPKey,
+		MergeResult:    eapp.VCSMergeResult,
 	}
 
 	if app.RepositoryStrategy.ConnectionType == "" {
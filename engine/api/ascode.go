@@ -13,10 +13,8 @@ import (
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/workflow"
-	"github.com/ovh/cds/engine/api/workflowtemplate"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
-	"github.com/ovh/cds/sdk/exportentities"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -159,9 +157,9 @@ func (api *API) postPerformImportAsCodeHandler() service.Handler {
 			return sdk.WithStack(sdk.ErrMethodNotAllowed)
 		}
 
-		tr, err := workflow.ReadCDSFiles(ope.LoadFiles.Results)
+		components, err := workflow.ReadWorkflowsComponents(ctx, ope.LoadFiles.Results)
 		if err != nil {
-			return sdk.WrapError(err, "unable to read cds files")
+			return err
 		}
 
 		//TODO: Delete branch and default branch
@@ -176,35 +174,18 @@ func (api *API) postPerformImportAsCodeHandler() service.Handler {
 			IsDefaultBranch:    ope.Setup.Checkout.Branch == ope.RepositoryInfo.DefaultBranch,
 		}
 
-		data, err := exportentities.UntarWorkflowComponents(ctx, tr)
-		if err != nil {
-			return err
-		}
-
 		consumer := getAPIConsumer(ctx)
 
-		mods := []workflowtemplate.TemplateRequestModifierFunc{
-			workflowtemplate.TemplateRequestModifiers.DefaultKeys(*proj),
-		}
-		if !opt.IsDefaultBranch {
-			mods = append(mods, workflowtemplate.TemplateRequestModifiers.Detached)
-		}
-		if opt.FromRepository != "" {
-			mods = append(mods, workflowtemplate.TemplateRequestModifiers.DefaultNameAndRepositories(*proj, opt.FromRepository))
-		}
+		// All the workflows declared in the repository are imported
 		var allMsg []sdk.Message
-		msgTemplate, wti, err := workflowtemplate.CheckAndExecuteTemplate(ctx, api.mustDB(), api.Cache, *consumer, *proj, &data, mods...)
-		allMsg = append(allMsg, msgTemplate...)
-		if err != nil {
-			return err
-		}
-		msgPush, wrkflw, _, _, err := workflow.Push(ctx, api.mustDB(), api.Cache, proj, data, opt, getAPIConsumer(ctx), project.DecryptWithBuiltinKey)
-		allMsg = append(allMsg, msgPush...)
-		if err != nil {
-			return sdk.WrapError(err, "unable to push workflow")
-		}
-		if err := workflowtemplate.UpdateTemplateInstanceWithWorkflow(ctx, api.mustDB(), *wrkflw, *consumer, wti); err != nil {
-			return err
+		wrkflws := make([]sdk.Workflow, 0, len(components))
+		for _, data := range components {
+			msgPush, wrkflw, _, err := workflow.PushFromRepository(ctx, api.mustDB(), api.Cache, proj, &data, opt, *consumer, project.DecryptWithBuiltinKey)
+			allMsg = append(allMsg, msgPush...)
+			if err != nil {
+				return sdk.WrapError(err, "unable to push workflow")
+			}
+			wrkflws = append(wrkflws, *wrkflw)
 		}
 		msgListString := translate(r, allMsg)
 
@@ -233,12 +214,13 @@ func (api *API) postPerformImportAsCodeHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 
-		if wrkflw != nil {
-			w.Header().Add(sdk.ResponseWorkflowIDHeader, fmt.Sprintf("%d", wrkflw.ID))
-			w.Header().Add(sdk.ResponseWorkflowNameHeader, wrkflw.Name)
-		}
+		// The first workflow is returned in headers for compatibility with repositories with only one workflow
+		w.Header().Add(sdk.ResponseWorkflowIDHeader, fmt.Sprintf("%d", wrkflws[0].ID))
+		w.Header().Add(sdk.ResponseWorkflowNameHeader, wrkflws[0].Name)
 
-		event.PublishWorkflowAdd(ctx, proj.Key, *wrkflw, getAPIConsumer(ctx))
+		for i := range wrkflws {
+			event.PublishWorkflowAdd(ctx, proj.Key, wrkflws[i], consumer)
+		}
 
 		return service.WriteJSON(w, msgListString, http.StatusOK)
 	}
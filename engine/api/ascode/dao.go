@@ -3,6 +3,7 @@ package ascode
 import (
 	"context"
 	"database/sql"
+	"strconv"
 
 	"github.com/lib/pq"
 
@@ -30,8 +31,18 @@ func LoadEventByWorkflowIDAndPullRequest(ctx context.Context, db gorp.SqlExecuto
 	return &event, nil
 }
 
+// LoadEventsByWorkflowIDs returns as code events held by given workflows or that concern given workflows. Workflows declared
+// in the same repository can have events held by another workflow of the repository.
 func LoadEventsByWorkflowIDs(ctx context.Context, db gorp.SqlExecutor, workflowIDs []int64) ([]sdk.AsCodeEvent, error) {
-	query := gorpmapping.NewQuery("SELECT * FROM as_code_events where workflow_id = ANY($1)").Args(pq.Int64Array(workflowIDs))
+	keys := make([]string, len(workflowIDs))
+	for i := range workflowIDs {
+		keys[i] = strconv.FormatInt(workflowIDs[i], 10)
+	}
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM as_code_events
+    WHERE workflow_id = ANY($1) OR data->'workflows' ?| $2
+  `).Args(pq.Int64Array(workflowIDs), pq.StringArray(keys))
 	var events []dbAsCodeEvents
 	if err := gorpmapping.GetAll(ctx, db, query, &events); err != nil {
 		return nil, sdk.WrapError(err, "Unable to load as code events")
@@ -18,7 +18,8 @@ type SyncResult struct {
 	Merged         bool
 }
 
-// SyncEvents checks if workflow as to become ascode.
+// SyncEvents removes the events of the workflow which pull request was merged or closed and checks if workflow as to become ascode.
+// The events of the other workflows of the repository that concern the workflow are also synchronized.
 func SyncEvents(ctx context.Context, db *gorp.DbMap, store cache.Store, proj sdk.Project, workflowHolder sdk.Workflow, u sdk.Identifiable) (SyncResult, error) {
 	var res SyncResult
 
@@ -56,7 +57,7 @@ func SyncEvents(ctx context.Context, db *gorp.DbMap, store cache.Store, proj sdk
 	}
 	res.FromRepository = fromRepo
 
-	asCodeEvents, err := LoadEventsByWorkflowID(ctx, tx, workflowHolder.ID)
+	asCodeEvents, err := LoadEventsByWorkflowIDs(ctx, tx, []int64{workflowHolder.ID})
 	if err != nil {
 		return res, err
 	}
@@ -74,8 +75,8 @@ func SyncEvents(ctx context.Context, db *gorp.DbMap, store cache.Store, proj sdk
 			log.Debug("Pull request %s #%d not found", rootApp.RepositoryFullname, int(ascodeEvt.PullRequestID))
 		}
 
-		// If the PR was merged we want to set the repo url on the workflow
-		if ascodeEvt.Migrate && len(ascodeEvt.Data.Workflows) == 1 {
+		// If the PR that migrates the workflow was merged we want to set the repo url on the workflow
+		if _, ok := ascodeEvt.Data.Workflows[workflowHolder.ID]; ok && ascodeEvt.Migrate && len(ascodeEvt.Data.Workflows) == 1 {
 			if pr.Merged {
 				res.Merged = true
 			}
@@ -110,6 +110,12 @@ func LoadConsumerByTypeAndUserExternalID(ctx context.Context, db gorp.SqlExecuto
 	return getConsumer(ctx, db, query, opts...)
 }
 
+// LoadConsumerByTypeAndUsername returns an auth consumer from database for given type and username given by the auth driver.
+func LoadConsumerByTypeAndUsername(ctx context.Context, db gorp.SqlExecutor, consumerType sdk.AuthConsumerType, username string, opts ...LoadConsumerOptionFunc) (*sdk.AuthConsumer, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer WHERE type = $1 AND (data->>'username')::text = $2").Args(consumerType, username)
+	return getConsumer(ctx, db, query, opts...)
+}
+
 // InsertConsumer in database.
 func InsertConsumer(ctx context.Context, db gorpmapper.SqlExecutorWithTx, ac *sdk.AuthConsumer) error {
 	// Because we need to create consumers before CDS first start with the init token, the consumer id can be set.
@@ -33,8 +33,13 @@ func (api *API) ConfigVCShandler() service.Handler {
 
 func (api *API) ConfigCDNHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		// Only hatcheries need the tcp address to send logs, other consumers can get the http address
 		if !isHatchery(ctx) {
-			return sdk.WithStack(sdk.ErrForbidden)
+			httpURL, err := services.GetCDNPublicHTTPAdress(ctx, api.mustDB())
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, sdk.CDNConfig{HTTPURL: httpURL}, http.StatusOK)
 		}
 		tcpURL, err := services.GetCDNPublicTCPAdress(ctx, api.mustDB())
 		if err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -93,6 +94,7 @@ func (api *API) getHookPollingVCSEvents() service.Handler {
 				return sdk.WrapError(errB, "getHookPollingVCSEvents> Cannot check existing builds for push events")
 			}
 			if !exist {
+				pushEvent.ChangedFiles = api.hookPollingChangedFiles(ctx, client, h, pushEvent.Branch.DisplayID, "", pushEvent.Commit.Hash)
 				repoEvents.PushEvents = append(repoEvents.PushEvents, pushEvent)
 			}
 		}
@@ -103,6 +105,7 @@ func (api *API) getHookPollingVCSEvents() service.Handler {
 				return sdk.WrapError(errB, "getHookPollingVCSEvents> Cannot check existing builds for pull request events")
 			}
 			if !exist {
+				pullRequestEvent.Head.ChangedFiles = api.hookPollingChangedFiles(ctx, client, h, pullRequestEvent.Head.Branch.DisplayID, pullRequestEvent.Base.Branch.DisplayID, pullRequestEvent.Head.Commit.Hash)
 				repoEvents.PullRequestEvents = append(repoEvents.PullRequestEvents, pullRequestEvent)
 			}
 		}
@@ -112,3 +115,88 @@ func (api *API) getHookPollingVCSEvents() service.Handler {
 		return service.WriteJSON(w, repoEvents, http.StatusOK)
 	}
 }
+
+// hookPollingChangedFiles returns the files changed on a branch since its last build, or since the base branch for pull requests.
+// The changes are only computed for hooks with a path filter, nil is returned if they are unknown.
+func (api *API) hookPollingChangedFiles(ctx context.Context, client sdk.VCSAuthorizedClientService, h sdk.NodeHook, branch, baseBranch, head string) []string {
+	if sdk.NewHookPathFilter(h.Config).IsEmpty() {
+		return nil
+	}
+
+	base := baseBranch
+	if base == "" {
+		runs, _, _, _, err := workflow.LoadRunsSummaries(api.mustDB(), h.Config[sdk.HookConfigProject].Value, h.Config[sdk.HookConfigWorkflow].Value, 0, 1,
+			map[string]string{"git.branch": strings.TrimPrefix(branch, "refs/heads/")})
+		if err != nil {
+			log.Warning(ctx, "hookPollingChangedFiles> unable to load last run on branch %s: %v", branch, err)
+			return nil
+		}
+		if len(runs) == 0 {
+			return nil
+		}
+		for _, t := range runs[0].Tags {
+			if t.Tag == "git.hash" {
+				base = t.Value
+			}
+		}
+	}
+	if base == "" || base == head {
+		return nil
+	}
+
+	commits, err := client.CommitsBetweenRefs(ctx, h.Config[sdk.HookConfigRepoFullName].Value, base, head)
+	if err != nil {
+		log.Warning(ctx, "hookPollingChangedFiles> unable to get commits between %s and %s: %v", base, head, err)
+		return nil
+	}
+	return sdk.VCSCommitsFiles(commits)
+}
+
+func (api *API) getHookCommitsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !isService(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		uuid := vars["uuid"]
+		base := r.FormValue("base")
+		head := r.FormValue("head")
+		if base == "" || head == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing base or head ref")
+		}
+
+		h, err := workflow.LoadHookByUUID(api.mustDB(), uuid)
+		if err != nil {
+			return err
+		}
+		if h.Config[sdk.HookConfigVCSServer].Value == "" || h.Config[sdk.HookConfigRepoFullName].Value == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "hook %s is not linked to a repository", uuid)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		vcsServer, err := repositoriesmanager.LoadProjectVCSServerLinkByProjectKeyAndVCSServerName(ctx, tx, h.Config[sdk.HookConfigProject].Value, h.Config[sdk.HookConfigVCSServer].Value)
+		if err != nil {
+			return err
+		}
+		client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, h.Config[sdk.HookConfigProject].Value, vcsServer)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		commits, err := client.CommitsBetweenRefs(ctx, h.Config[sdk.HookConfigRepoFullName].Value, base, head)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, commits, http.StatusOK)
+	}
+}
@@ -12,6 +12,9 @@ import (
 
 type DecryptFunc func(gorp.SqlExecutor, int64, string) (string, error)
 
+// EncryptFunc encrypts a named content for a project and returns a token
+type EncryptFunc func(gorp.SqlExecutor, int64, string, string) (string, error)
+
 // Parse and decrypts an exported key
 func Parse(db gorp.SqlExecutor, projID int64, kname string, kval exportentities.KeyValue, decryptFunc DecryptFunc) (*sdk.Key, error) {
 	k := new(sdk.Key)
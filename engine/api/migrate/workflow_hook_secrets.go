@@ -0,0 +1,62 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// WorkflowHookSecrets sets a secret on the repository webhooks created before the deliveries were signed, so the
+// hooks µService can reject the deliveries that were not sent by the repository manager.
+func WorkflowHookSecrets(ctx context.Context, store cache.Store, dbFunc func() *gorp.DbMap) error {
+	db := dbFunc()
+	hooks, err := workflow.LoadAllHooks(db)
+	if err != nil {
+		return err
+	}
+	secrets, err := workflow.LoadAllHookSecrets(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	var nbErrors int
+	for _, h := range hooks {
+		if !h.IsRepositoryWebHook() && !h.IsCommentCommandHook() {
+			continue
+		}
+		if _, ok := secrets[h.UUID]; ok {
+			continue
+		}
+		if err := migrateWorkflowHookSecret(ctx, store, db, h); err != nil {
+			log.Error(ctx, "migrate.WorkflowHookSecrets: unable to migrate hook %s: %v", h.UUID, err)
+			nbErrors++
+		}
+	}
+	if nbErrors > 0 {
+		return sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to set the secret of %d hooks", nbErrors)
+	}
+	return nil
+}
+
+func migrateWorkflowHookSecret(ctx context.Context, store cache.Store, db *gorp.DbMap, h sdk.NodeHook) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	proj, err := project.Load(ctx, tx, h.Config[sdk.HookConfigProject].Value)
+	if err != nil {
+		return err
+	}
+	if err := workflow.SetHookSecret(ctx, tx, store, *proj, h); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/go-gorp/gorp"
@@ -25,12 +26,14 @@ func (dbPipes Pipelines) Cast() []sdk.Pipeline {
 }
 
 type pipelineAction struct {
-	ID              int64     `db:"id"`
-	PipelineStageID int64     `db:"pipeline_stage_id"`
-	ActionID        int64     `db:"action_id"`
-	Args            *string   `db:"args"`
-	Enabled         bool      `db:"enabled"`
-	LastModified    time.Time `db:"last_modified"`
+	ID              int64          `db:"id"`
+	PipelineStageID int64          `db:"pipeline_stage_id"`
+	ActionID        int64          `db:"action_id"`
+	Args            *string        `db:"args"`
+	Enabled         bool           `db:"enabled"`
+	LastModified    time.Time      `db:"last_modified"`
+	Matrix          sql.NullString `db:"matrix"`
+	Timeout         int64          `db:"timeout"`
 }
 
 func pipelineActionsToIDs(pas []pipelineAction) []int64 {
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -66,9 +68,14 @@ func InsertJob(db gorp.SqlExecutor, job *sdk.Job, stageID int64, pip *sdk.Pipeli
 	}
 	job.PipelineStageID = stage.ID
 
+	matrix, err := jobMatrixToNullString(job.Matrix)
+	if err != nil {
+		return err
+	}
+
 	// Create pipeline action
-	query := `INSERT INTO pipeline_action (pipeline_stage_id, action_id, enabled) VALUES ($1, $2, $3) RETURNING id`
-	return sdk.WithStack(db.QueryRow(query, job.PipelineStageID, job.Action.ID, job.Enabled).Scan(&job.PipelineActionID))
+	query := `INSERT INTO pipeline_action (pipeline_stage_id, action_id, enabled, matrix, timeout) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	return sdk.WithStack(db.QueryRow(query, job.PipelineStageID, job.Action.ID, job.Enabled, matrix, job.Timeout).Scan(&job.PipelineActionID))
 }
 
 // UpdateJob  updates the job by actionData.PipelineActionID and actionData.ID
@@ -99,11 +106,23 @@ func DeleteJob(db gorp.SqlExecutor, job sdk.Job) error {
 
 // UpdatePipelineAction Update an action in a pipeline
 func UpdatePipelineAction(db gorp.SqlExecutor, job sdk.Job) error {
-	query := `UPDATE pipeline_action set action_id=$1, pipeline_stage_id=$2, enabled=$3 WHERE id=$4`
-	_, err := db.Exec(query, job.Action.ID, job.PipelineStageID, job.Enabled, job.PipelineActionID)
+	matrix, err := jobMatrixToNullString(job.Matrix)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE pipeline_action set action_id=$1, pipeline_stage_id=$2, enabled=$3, matrix=$4, timeout=$5 WHERE id=$6`
+	_, err = db.Exec(query, job.Action.ID, job.PipelineStageID, job.Enabled, matrix, job.Timeout, job.PipelineActionID)
 	return sdk.WithStack(err)
 }
 
+func jobMatrixToNullString(m *sdk.JobMatrix) (sql.NullString, error) {
+	if m == nil {
+		return sql.NullString{}, nil
+	}
+	res, err := gorpmapping.JSONToNullString(m)
+	return res, sdk.WrapError(err, "cannot marshal job matrix")
+}
+
 //CheckJob validate a job
 func CheckJob(ctx context.Context, db gorp.SqlExecutor, job *sdk.Job) error {
 	t := time.Now()
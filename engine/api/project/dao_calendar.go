@@ -0,0 +1,82 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func getCalendars(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.ProjectCalendar, error) {
+	var dbCalendars []dbCalendar
+	if err := gorpmapping.GetAll(ctx, db, q, &dbCalendars); err != nil {
+		return nil, sdk.WrapError(err, "cannot get calendars")
+	}
+	calendars := make([]sdk.ProjectCalendar, len(dbCalendars))
+	for i := range dbCalendars {
+		calendars[i] = sdk.ProjectCalendar(dbCalendars[i])
+	}
+	return calendars, nil
+}
+
+// LoadCalendars returns all the calendars of a project.
+func LoadCalendars(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.ProjectCalendar, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM project_calendar WHERE project_id = $1 ORDER BY name").Args(projectID)
+	return getCalendars(ctx, db, query)
+}
+
+// LoadCalendarByName returns a calendar of a project for given name.
+func LoadCalendarByName(ctx context.Context, db gorp.SqlExecutor, projectID int64, name string) (*sdk.ProjectCalendar, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM project_calendar WHERE project_id = $1 AND name = $2").Args(projectID, name)
+	var c dbCalendar
+	found, err := gorpmapping.Get(ctx, db, query, &c)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get calendar %s for project %d", name, projectID)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	calendar := sdk.ProjectCalendar(c)
+	return &calendar, nil
+}
+
+// InsertCalendar inserts a calendar in a project.
+func InsertCalendar(db gorp.SqlExecutor, c *sdk.ProjectCalendar) error {
+	if err := c.IsValid(); err != nil {
+		return err
+	}
+	c.Created = time.Now()
+	c.LastModified = c.Created
+	dbc := dbCalendar(*c)
+	if err := gorpmapping.Insert(db, &dbc); err != nil {
+		return sdk.WrapError(err, "cannot insert calendar %s", c.Name)
+	}
+	*c = sdk.ProjectCalendar(dbc)
+	return nil
+}
+
+// UpdateCalendar updates a calendar of a project.
+func UpdateCalendar(db gorp.SqlExecutor, c *sdk.ProjectCalendar) error {
+	if err := c.IsValid(); err != nil {
+		return err
+	}
+	c.LastModified = time.Now()
+	dbc := dbCalendar(*c)
+	if err := gorpmapping.Update(db, &dbc); err != nil {
+		return sdk.WrapError(err, "cannot update calendar %s", c.Name)
+	}
+	*c = sdk.ProjectCalendar(dbc)
+	return nil
+}
+
+// DeleteCalendar deletes a calendar of a project.
+func DeleteCalendar(db gorp.SqlExecutor, c sdk.ProjectCalendar) error {
+	dbc := dbCalendar(c)
+	if err := gorpmapping.Delete(db, &dbc); err != nil {
+		return sdk.WrapError(err, "cannot delete calendar %s", c.Name)
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ func (e dbProjectKey) Canonical() gorpmapper.CanonicalForms {
 }
 
 type dbLabel sdk.Label
+type dbCalendar sdk.ProjectCalendar
 
 type dbProjectVariable struct {
 	gorpmapper.SignedEntity
@@ -88,6 +89,7 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbProjectVariableAudit{}, "project_variable_audit", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectKey{}, "project_key", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbLabel{}, "project_label", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbCalendar{}, "project_calendar", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectVariable{}, "project_variable", true, "id"))
 }
 
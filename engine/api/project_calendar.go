@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectCalendarsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		calendars, err := project.LoadCalendars(ctx, api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, calendars, http.StatusOK)
+	}
+}
+
+func (api *API) getProjectCalendarHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		name := vars["calendarName"]
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		calendar, err := project.LoadCalendarByName(ctx, api.mustDB(), p.ID, name)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, calendar, http.StatusOK)
+	}
+}
+
+func (api *API) postProjectCalendarHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var calendar sdk.ProjectCalendar
+		if err := service.UnmarshalBody(r, &calendar); err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		if _, err := project.LoadCalendarByName(ctx, api.mustDB(), p.ID, calendar.Name); err == nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "calendar %s already exists", calendar.Name)
+		} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+
+		calendar.ProjectID = p.ID
+		if err := project.InsertCalendar(api.mustDB(), &calendar); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, calendar, http.StatusCreated)
+	}
+}
+
+func (api *API) putProjectCalendarHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		name := vars["calendarName"]
+
+		var calendar sdk.ProjectCalendar
+		if err := service.UnmarshalBody(r, &calendar); err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		old, err := project.LoadCalendarByName(ctx, api.mustDB(), p.ID, name)
+		if err != nil {
+			return err
+		}
+
+		calendar.ID = old.ID
+		calendar.ProjectID = p.ID
+		calendar.Created = old.Created
+		if err := project.UpdateCalendar(api.mustDB(), &calendar); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, calendar, http.StatusOK)
+	}
+}
+
+func (api *API) deleteProjectCalendarHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		name := vars["calendarName"]
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		calendar, err := project.LoadCalendarByName(ctx, api.mustDB(), p.ID, name)
+		if err != nil {
+			return err
+		}
+
+		if err := project.DeleteCalendar(api.mustDB(), *calendar); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
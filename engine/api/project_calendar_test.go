@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_crudProjectCalendarHandlers(t *testing.T) {
+	api, db, router := newTestAPI(t)
+
+	u, pass := assets.InsertAdminUser(t, db)
+
+	pkey := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, pkey, pkey)
+
+	start := time.Date(2020, 12, 18, 18, 0, 0, 0, time.UTC)
+	calendar := sdk.ProjectCalendar{
+		Name: "release-freeze",
+		Periods: sdk.CalendarPeriods{
+			{Name: "end-of-year", Start: start, End: start.AddDate(0, 0, 17)},
+		},
+	}
+
+	// Create the calendar
+	uri := router.GetRoute("POST", api.postProjectCalendarHandler, map[string]string{"permProjectKey": proj.Key})
+	req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, calendar)
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 201, w.Code)
+
+	// A calendar name is unique in a project
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, calendar)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 403, w.Code)
+
+	// Periods are checked
+	invalid := sdk.ProjectCalendar{Name: "invalid", Periods: sdk.CalendarPeriods{{Start: start, End: start.Add(-time.Hour)}}}
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, invalid)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 400, w.Code)
+
+	// Update the calendar
+	calendar.Description = "No deployment during the end of year"
+	calendar.Periods = append(calendar.Periods, sdk.CalendarPeriod{Name: "christmas", Start: start.AddDate(0, 0, 6), End: start.AddDate(0, 0, 8), Yearly: true})
+	uri = router.GetRoute("PUT", api.putProjectCalendarHandler, map[string]string{"permProjectKey": proj.Key, "calendarName": calendar.Name})
+	req = assets.NewAuthentifiedRequest(t, u, pass, "PUT", uri, calendar)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	// List the calendars
+	uri = router.GetRoute("GET", api.getProjectCalendarsHandler, map[string]string{"permProjectKey": proj.Key})
+	req = assets.NewAuthentifiedRequest(t, u, pass, "GET", uri, nil)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	var calendars []sdk.ProjectCalendar
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &calendars))
+	require.Len(t, calendars, 1)
+	require.Equal(t, "No deployment during the end of year", calendars[0].Description)
+	require.Len(t, calendars[0].Periods, 2)
+	require.True(t, calendars[0].Periods[1].Yearly)
+
+	// Delete the calendar
+	uri = router.GetRoute("DELETE", api.deleteProjectCalendarHandler, map[string]string{"permProjectKey": proj.Key, "calendarName": calendar.Name})
+	req = assets.NewAuthentifiedRequest(t, u, pass, "DELETE", uri, nil)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	uri = router.GetRoute("GET", api.getProjectCalendarHandler, map[string]string{"permProjectKey": proj.Key, "calendarName": calendar.Name})
+	req = assets.NewAuthentifiedRequest(t, u, pass, "GET", uri, nil)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 404, w.Code)
+}
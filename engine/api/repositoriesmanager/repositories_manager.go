@@ -611,6 +611,7 @@ type WebhooksInfos struct {
 	GerritHookDisabled bool     `json:"gerrithook_disabled"`
 	Icon               string   `json:"webhooks_icon"`
 	Events             []string `json:"events"`
+	CommentEvents      []string `json:"comment_events"`
 }
 
 // GetWebhooksInfos returns webhooks_supported, webhooks_disabled, webhooks_creation_supported, webhooks_creation_disabled for a vcs server
@@ -105,6 +105,10 @@ func (api *API) postTemplateHandler() service.Handler {
 		}
 
 		// execute template with no instance only to check if parsing is ok
+		data.Group = grp
+		if err := workflowtemplate.LoadDependencies(ctx, api.mustDB(), &data); err != nil {
+			return err
+		}
 		if _, err := workflowtemplate.Parse(data); err != nil {
 			return err
 		}
@@ -247,6 +251,10 @@ func (api *API) putTemplateHandler() service.Handler {
 		clone.Update(data)
 
 		// execute template with no instance only to check if golang template parsing is ok
+		clone.Group = grp
+		if err := workflowtemplate.LoadDependencies(ctx, tx, &clone); err != nil {
+			return err
+		}
 		if _, err := workflowtemplate.Parse(clone); err != nil {
 			return err
 		}
@@ -453,6 +461,7 @@ func (api *API) postTemplateApplyHandler() service.Handler {
 
 		mods := []workflowtemplate.TemplateRequestModifierFunc{
 			workflowtemplate.TemplateRequestModifiers.DefaultKeys(*p),
+			workflowtemplate.TemplateRequestModifiers.Secrets(*p, project.EncryptWithBuiltinKey, project.DecryptWithBuiltinKey),
 		}
 		if req.Detached {
 			mods = append(mods, workflowtemplate.TemplateRequestModifiers.Detached)
@@ -527,6 +536,12 @@ func (api *API) postTemplateBulkHandler() service.Handler {
 		if err := service.UnmarshalBody(r, &req); err != nil {
 			return err
 		}
+		// the bulk can apply a previous version of the template
+		target, err := workflowtemplate.LoadVersion(ctx, api.mustDB(), *wt, req.Version)
+		if err != nil {
+			return err
+		}
+
 		m := make(map[string]struct{}, len(req.Operations))
 		for _, o := range req.Operations {
 			// check for duplicated request
@@ -537,7 +552,7 @@ func (api *API) postTemplateBulkHandler() service.Handler {
 			m[key] = struct{}{}
 
 			// check request params
-			if err := wt.CheckParams(o.Request); err != nil {
+			if err := target.CheckParams(o.Request); err != nil {
 				return err
 			}
 		}
@@ -553,11 +568,38 @@ func (api *API) postTemplateBulkHandler() service.Handler {
 			}
 		}
 
+		// secrets should be encrypted before storing the bulk request
+		var hasSecrets bool
+		for _, p := range target.Parameters {
+			hasSecrets = hasSecrets || p.Type == sdk.ParameterTypeSecret
+		}
+		if hasSecrets {
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			for i := range req.Operations {
+				p, err := project.Load(ctx, tx, req.Operations[i].Request.ProjectKey)
+				if err != nil {
+					return err
+				}
+				mod := workflowtemplate.TemplateRequestModifiers.Secrets(*p, project.EncryptWithBuiltinKey, project.DecryptWithBuiltinKey)
+				if err := mod(ctx, tx, api.Cache, *target, &req.Operations[i].Request); err != nil {
+					return err
+				}
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+		}
+
 		// store the bulk request
 		bulk := sdk.WorkflowTemplateBulk{
 			UserID:             consumer.AuthentifiedUser.ID,
 			WorkflowTemplateID: wt.ID,
 			Operations:         make([]sdk.WorkflowTemplateBulkOperation, len(req.Operations)),
+			Version:            target.Version,
 		}
 		for i := range req.Operations {
 			bulk.Operations[i].Status = sdk.OperationStatusPending
@@ -615,7 +657,7 @@ func (api *API) postTemplateBulkHandler() service.Handler {
 					data := exportentities.WorkflowComponents{
 						Template: exportentities.TemplateInstance{
 							Name:       bulk.Operations[i].Request.WorkflowName,
-							From:       wt.PathWithVersion(),
+							From:       target.PathWithVersion(),
 							Parameters: bulk.Operations[i].Request.Parameters,
 						},
 					}
@@ -713,6 +755,7 @@ func (api *API) postTemplateBulkHandler() service.Handler {
 
 					mods := []workflowtemplate.TemplateRequestModifierFunc{
 						workflowtemplate.TemplateRequestModifiers.DefaultKeys(*p),
+						workflowtemplate.TemplateRequestModifiers.Secrets(*p, project.EncryptWithBuiltinKey, project.DecryptWithBuiltinKey),
 					}
 					_, wti, err = workflowtemplate.CheckAndExecuteTemplate(ctx, api.mustDB(), api.Cache, *consumer, *p, &data, mods...)
 					if err != nil {
@@ -903,6 +946,152 @@ func (api *API) deleteTemplateInstanceHandler() service.Handler {
 	}
 }
 
+func (api *API) getTemplateUpgradeHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["groupName"]
+		templateSlug := vars["templateSlug"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName, group.LoadOptions.WithMembers)
+		if err != nil {
+			return err
+		}
+		if !(isGroupMember(ctx, g) || isMaintainer(ctx)) {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		wt, err := workflowtemplate.LoadBySlugAndGroupID(ctx, api.mustDB(), templateSlug, g.ID, workflowtemplate.LoadOptions.Default)
+		if err != nil {
+			return err
+		}
+
+		target, err := workflowtemplate.LoadVersion(ctx, api.mustDB(), *wt, service.FormInt64(r, "version"))
+		if err != nil {
+			return err
+		}
+		if err := workflowtemplate.LoadDependencies(ctx, api.mustDB(), target); err != nil {
+			return err
+		}
+
+		var ps sdk.Projects
+		if isMaintainer(ctx) {
+			ps, err = project.LoadAll(ctx, api.mustDB(), api.Cache)
+		} else {
+			ps, err = project.LoadAllByGroupIDs(ctx, api.mustDB(), api.Cache, getAPIConsumer(ctx).GetGroupIDs())
+		}
+		if err != nil {
+			return err
+		}
+		mProjects := make(map[int64]sdk.Project, len(ps))
+		for i := range ps {
+			mProjects[ps[i].ID] = ps[i]
+		}
+
+		is, err := workflowtemplate.LoadInstancesByTemplateIDAndProjectIDs(ctx, api.mustDB(), wt.ID, sdk.ProjectsToIDs(ps))
+		if err != nil {
+			return err
+		}
+
+		upgrades := make([]sdk.WorkflowTemplateInstanceUpgrade, 0, len(is))
+		for i := range is {
+			// ignore instances that didn't generate a workflow
+			if is[i].WorkflowID == nil {
+				continue
+			}
+			p := mProjects[is[i].ProjectID]
+			is[i].Project = &p
+			upgrades = append(upgrades, workflowtemplate.ComputeInstanceUpgrade(ctx, api.mustDB(), *wt, *target, is[i]))
+		}
+
+		return service.WriteJSON(w, upgrades, http.StatusOK)
+	}
+}
+
+func (api *API) postTemplateInstancePinHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["groupName"]
+		templateSlug := vars["templateSlug"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName, group.LoadOptions.WithMembers)
+		if err != nil {
+			return err
+		}
+		if !(isGroupMember(ctx, g) || isMaintainer(ctx)) {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		wt, err := workflowtemplate.LoadBySlugAndGroupID(ctx, api.mustDB(), templateSlug, g.ID)
+		if err != nil {
+			return err
+		}
+
+		var req sdk.WorkflowTemplateInstancePin
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if req.Version < 0 || req.Version > wt.Version {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid version %d for template %s, latest version is %d", req.Version, wt.Slug, wt.Version)
+		}
+
+		var ps sdk.Projects
+		if isMaintainer(ctx) {
+			ps, err = project.LoadAll(ctx, api.mustDB(), api.Cache)
+		} else {
+			ps, err = project.LoadAllByGroupIDs(ctx, api.mustDB(), api.Cache, getAPIConsumer(ctx).GetGroupIDs())
+		}
+		if err != nil {
+			return err
+		}
+
+		instanceID, err := requestVarInt(r, "instanceID")
+		if err != nil {
+			return err
+		}
+
+		wti, err := workflowtemplate.LoadInstanceByIDForTemplateIDAndProjectIDs(ctx, api.mustDB(), instanceID, wt.ID, sdk.ProjectsToIDs(ps))
+		if err != nil {
+			return err
+		}
+		if wti == nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no workflow template instance found")
+		}
+
+		// only users that can update the workflow can pin its template version, admins are allowed by the permission check
+		for i := range ps {
+			if ps[i].ID != wti.ProjectID {
+				continue
+			}
+			if err := api.checkProjectPermissions(ctx, ps[i].Key, sdk.PermissionReadWriteExecute, nil); err != nil {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "write permission on project required to pin template version")
+			}
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		old := sdk.WorkflowTemplateInstance(*wti)
+		wti.PinnedVersion = req.Version
+		if err := workflowtemplate.UpdateInstance(tx, wti); err != nil {
+			return err
+		}
+		if err := workflowtemplate.CreateAuditInstanceUpdate(tx, old, *wti, getAPIConsumer(ctx)); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, wti, http.StatusOK)
+	}
+}
+
 func (api *API) postTemplatePullHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
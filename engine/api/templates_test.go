@@ -6,8 +6,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/go-gorp/gorp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -320,3 +324,250 @@ func Test_getTemplateInstancesHandler(t *testing.T) {
 		getUsage(t, jwtLambdaInGroupOneAndTwo, []string{workflowProjectOneName, workflowProjectTwoName})
 	})
 }
+
+// insertVersionedTemplate inserts a template at version 1 then updates it to version 2, the workflow of the
+// second version contains a description.
+func insertVersionedTemplate(t *testing.T, db gorp.SqlExecutor, groupID int64, u sdk.Identifiable) *sdk.WorkflowTemplate {
+	pipelineName := sdk.RandomString(10)
+	template := generateTemplate(groupID, pipelineName)
+	template.Version = 1
+	require.NoError(t, workflowtemplate.Insert(db, template))
+	require.NoError(t, workflowtemplate.CreateAuditAdd(db, *template, u))
+
+	old := *template
+	template.Version = 2
+	template.Workflow = base64.StdEncoding.EncodeToString([]byte(
+		`name: [[.name]]
+version: v2.0
+description: upgraded
+workflow:
+  Node-1:
+    pipeline: ` + pipelineName,
+	))
+	require.NoError(t, workflowtemplate.Update(db, template))
+	require.NoError(t, workflowtemplate.CreateAuditUpdate(db, old, *template, "upgrade", u))
+
+	return template
+}
+
+func Test_postTemplateInstancePinHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	projectGroup := &proj.ProjectGroups[0].Group
+
+	sharedInfraGroup, err := group.LoadByName(context.TODO(), api.mustDB(), "shared.infra")
+	require.NoError(t, err)
+
+	admin, jwtAdmin := assets.InsertAdminUser(t, db)
+	_, jwtMaintainer := assets.InsertMaintainerUser(t, db)
+	_, jwtLambdaInGroup := assets.InsertLambdaUser(t, db, projectGroup)
+	_, jwtLambdaNotInGroup := assets.InsertLambdaUser(t, db)
+
+	template := insertVersionedTemplate(t, db, sharedInfraGroup.ID, admin)
+
+	// generate a workflow from the latest version
+	uri := api.Router.GetRoute(http.MethodPost, api.postTemplateApplyHandler, map[string]string{
+		"groupName":    sharedInfraGroup.Name,
+		"templateSlug": template.Slug,
+	})
+	test.NotEmpty(t, uri)
+	wtr := sdk.WorkflowTemplateRequest{
+		ProjectKey:   proj.Key,
+		WorkflowName: sdk.RandomString(10),
+	}
+	req := assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodPost, uri+"?import=true", wtr)
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	wti, err := workflowtemplate.LoadInstanceByTemplateIDAndProjectIDAndRequestWorkflowName(context.TODO(), db, template.ID, proj.ID, wtr.WorkflowName)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), wti.WorkflowTemplateVersion)
+
+	pin := func(t *testing.T, jwt string, version int64) *httptest.ResponseRecorder {
+		uri := api.Router.GetRoute(http.MethodPost, api.postTemplateInstancePinHandler, map[string]string{
+			"groupName":    sharedInfraGroup.Name,
+			"templateSlug": template.Slug,
+			"instanceID":   strconv.FormatInt(wti.ID, 10),
+		})
+		test.NotEmpty(t, uri)
+		req := assets.NewJWTAuthentifiedRequest(t, jwt, http.MethodPost, uri, sdk.WorkflowTemplateInstancePin{Version: version})
+		rec := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Pin to an unknown version", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, pin(t, jwtLambdaInGroup, 3).Code)
+	})
+	t.Run("Pin by a maintainer without write permission on the project", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, pin(t, jwtMaintainer, 1).Code)
+	})
+	t.Run("Pin by a user not in the project group", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, pin(t, jwtLambdaNotInGroup, 1).Code)
+	})
+	t.Run("Pin by a user in the project group", func(t *testing.T) {
+		rec := pin(t, jwtLambdaInGroup, 1)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res sdk.WorkflowTemplateInstance
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Equal(t, int64(1), res.PinnedVersion)
+
+		wti, err := workflowtemplate.LoadInstanceByTemplateIDAndProjectIDAndRequestWorkflowName(context.TODO(), db, template.ID, proj.ID, wtr.WorkflowName)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), wti.PinnedVersion)
+	})
+	t.Run("Apply the latest version on a pinned instance", func(t *testing.T) {
+		req := assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodPost, uri+"?import=true", wtr)
+		rec := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("Unpin by an admin", func(t *testing.T) {
+		rec := pin(t, jwtAdmin, 0)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res sdk.WorkflowTemplateInstance
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Equal(t, int64(0), res.PinnedVersion)
+	})
+}
+
+func Test_getTemplateUpgradeHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+
+	sharedInfraGroup, err := group.LoadByName(context.TODO(), api.mustDB(), "shared.infra")
+	require.NoError(t, err)
+
+	admin, jwtAdmin := assets.InsertAdminUser(t, db)
+
+	template := insertVersionedTemplate(t, db, sharedInfraGroup.ID, admin)
+
+	// generate two workflows from the first version of the template, then pin the second one
+	v1, err := workflowtemplate.LoadVersion(context.TODO(), db, *template, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), v1.Version)
+	require.NotEqual(t, template.Workflow, v1.Workflow)
+
+	workflowNames := []string{sdk.RandomString(10), sdk.RandomString(10)}
+	for _, name := range workflowNames {
+		wti := sdk.WorkflowTemplateInstance{
+			ProjectID:               proj.ID,
+			WorkflowTemplateID:      template.ID,
+			WorkflowTemplateVersion: 1,
+			Request: sdk.WorkflowTemplateRequest{
+				ProjectKey:   proj.Key,
+				WorkflowName: name,
+			},
+		}
+		require.NoError(t, workflowtemplate.InsertInstance(db, &wti))
+
+		w := assets.InsertTestWorkflow(t, db, api.Cache, proj, name)
+		wti.WorkflowID = &w.ID
+		if name == workflowNames[1] {
+			wti.PinnedVersion = 1
+		}
+		require.NoError(t, workflowtemplate.UpdateInstance(db, &wti))
+	}
+
+	uri := api.Router.GetRoute(http.MethodGet, api.getTemplateUpgradeHandler, map[string]string{
+		"groupName":    sharedInfraGroup.Name,
+		"templateSlug": template.Slug,
+	})
+	test.NotEmpty(t, uri)
+	req := assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodGet, uri+"?version=2", nil)
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var upgrades []sdk.WorkflowTemplateInstanceUpgrade
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &upgrades))
+	require.Len(t, upgrades, 2)
+	sort.Slice(upgrades, func(i, j int) bool { return upgrades[i].InstanceID < upgrades[j].InstanceID })
+
+	assert.Equal(t, workflowNames[0], upgrades[0].WorkflowName)
+	assert.Equal(t, int64(1), upgrades[0].FromVersion)
+	assert.Equal(t, int64(2), upgrades[0].ToVersion)
+	assert.Empty(t, upgrades[0].Error)
+	assert.Contains(t, upgrades[0].Diff, "+description: upgraded")
+
+	assert.Equal(t, workflowNames[1], upgrades[1].WorkflowName)
+	assert.Equal(t, int64(1), upgrades[1].PinnedVersion)
+	assert.Empty(t, upgrades[1].Diff)
+	assert.Contains(t, upgrades[1].Error, "is pinned to version 1")
+
+	// an unknown version can't be upgraded to
+	req = assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodGet, uri+"?version=3", nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func Test_postTemplateBulkHandlerWithVersion(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+
+	sharedInfraGroup, err := group.LoadByName(context.TODO(), api.mustDB(), "shared.infra")
+	require.NoError(t, err)
+
+	admin, jwtAdmin := assets.InsertAdminUser(t, db)
+
+	template := insertVersionedTemplate(t, db, sharedInfraGroup.ID, admin)
+
+	uri := api.Router.GetRoute(http.MethodPost, api.postTemplateBulkHandler, map[string]string{
+		"groupName":    sharedInfraGroup.Name,
+		"templateSlug": template.Slug,
+	})
+	test.NotEmpty(t, uri)
+
+	// the bulk can't apply an unknown version
+	wtb := sdk.WorkflowTemplateBulk{
+		Version: 3,
+		Operations: []sdk.WorkflowTemplateBulkOperation{{
+			Request: sdk.WorkflowTemplateRequest{
+				ProjectKey:   proj.Key,
+				WorkflowName: sdk.RandomString(10),
+			},
+		}},
+	}
+	req := assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodPost, uri, wtb)
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	// apply the first version of the template
+	wtb.Version = 1
+	req = assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodPost, uri, wtb)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var result sdk.WorkflowTemplateBulk
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	require.Equal(t, int64(1), result.Version)
+
+	// wait for the bulk to be done
+	uri = api.Router.GetRoute(http.MethodGet, api.getTemplateBulkHandler, map[string]string{
+		"groupName":    sharedInfraGroup.Name,
+		"templateSlug": template.Slug,
+		"bulkID":       strconv.FormatInt(result.ID, 10),
+	})
+	test.NotEmpty(t, uri)
+	for i := 0; i < 20 && !result.IsDone(); i++ {
+		time.Sleep(500 * time.Millisecond)
+		req = assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodGet, uri, nil)
+		rec = httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	}
+	require.True(t, result.IsDone())
+	require.Equal(t, sdk.OperationStatusDone, result.Operations[0].Status, result.Operations[0].Error)
+
+	wti, err := workflowtemplate.LoadInstanceByTemplateIDAndProjectIDAndRequestWorkflowName(context.TODO(), db, template.ID, proj.ID, wtb.Operations[0].Request.WorkflowName)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), wti.WorkflowTemplateVersion)
+}
@@ -144,6 +144,16 @@ func (api *API) getWorkerHandler() service.Handler {
 	}
 }
 
+func (api *API) getWorkerAssignmentHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wk, err := worker.LoadByConsumerID(ctx, api.mustDB(), getAPIConsumer(ctx).ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, wk, http.StatusOK)
+	}
+}
+
 func (api *API) getWorkersHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		var workers []sdk.Worker
@@ -112,6 +112,17 @@ func LoadByID(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.Worker,
 	return get(ctx, db, query)
 }
 
+// LoadAndLockByID loads and locks a worker for update
+func LoadAndLockByID(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.Worker, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM worker
+    WHERE id = $1
+    FOR UPDATE SKIP LOCKED
+  `).Args(id)
+	return get(ctx, db, query)
+}
+
 func LoadAll(ctx context.Context, db gorp.SqlExecutor) ([]sdk.Worker, error) {
 	query := gorpmapping.NewQuery(`
     SELECT *
@@ -176,6 +187,13 @@ func SetToBuilding(ctx context.Context, db gorpmapper.SqlExecutorWithTx, workerI
 	return nil
 }
 
+// SetJobRunID assigns a job to an idle worker, the worker will take it
+func SetJobRunID(ctx context.Context, db gorpmapper.SqlExecutorWithTx, w *sdk.Worker, jobRunID int64) error {
+	w.JobRunID = &jobRunID
+	dbData := &dbWorker{Worker: *w}
+	return gorpmapping.UpdateAndSign(ctx, db, dbData)
+}
+
 // LoadWorkerByIDWithDecryptKey load worker with decrypted private key
 func LoadWorkerByNameWithDecryptKey(ctx context.Context, db gorp.SqlExecutor, workerName string) (*sdk.Worker, error) {
 	query := gorpmapping.NewQuery(`SELECT * FROM worker WHERE name = $1`).Args(workerName)
@@ -63,7 +63,7 @@ func RegisterWorker(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store
 		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unauthorized to register a worker without a name")
 	}
 
-	if !spawnArgs.RegisterOnly && spawnArgs.JobID == 0 {
+	if !spawnArgs.RegisterOnly && !spawnArgs.Warm && spawnArgs.JobID == 0 {
 		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unauthorized to register a worker for a job without a JobID")
 	}
 
@@ -277,6 +277,12 @@ func UpdateMaxRunsByID(db gorp.SqlExecutor, workflowID int64, maxRuns int64) err
 	return sdk.WithStack(err)
 }
 
+// UpdatePriorityByID updates the priority given to the jobs of the workflow in the queue.
+func UpdatePriorityByID(db gorp.SqlExecutor, workflowID int64, priority int64) error {
+	_, err := db.Exec("UPDATE workflow set priority = $1 WHERE id = $2", priority, workflowID)
+	return sdk.WithStack(err)
+}
+
 // Insert inserts a new workflow
 func Insert(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, proj sdk.Project, w *sdk.Workflow) error {
 	if err := CompleteWorkflow(ctx, db, w, proj, LoadOptions{}); err != nil {
@@ -358,8 +364,14 @@ func Insert(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.St
 		}
 	}
 
-	// Manage new hooks
-	if len(w.WorkflowData.Node.Hooks) > 0 {
+	// Manage new hooks, hooks of a workflow as code are only registered from the default branch
+	if w.DerivationBranch != "" {
+		for i := range w.WorkflowData.Node.Hooks {
+			if w.WorkflowData.Node.Hooks[i].UUID == "" {
+				w.WorkflowData.Node.Hooks[i].UUID = sdk.UUID()
+			}
+		}
+	} else if len(w.WorkflowData.Node.Hooks) > 0 {
 		if err := hookRegistration(ctx, db, store, proj, w, nil); err != nil {
 			return err
 		}
@@ -618,8 +630,9 @@ func Update(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.St
 		return sdk.WrapError(err, "Unable to load existing workflow with proj:%s ID:%d", proj.Key, wf.ID)
 	}
 
-	// Keep MaxRun
+	// Keep MaxRun and Priority
 	wf.MaxRuns = oldWf.MaxRuns
+	wf.Priority = oldWf.Priority
 
 	if err := DeleteWorkflowData(db, *oldWf); err != nil {
 		return sdk.WrapError(err, "unable to delete from old workflow data(%d - %s)", wf.ID, wf.Name)
@@ -877,6 +890,9 @@ func checkHooks(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
 		if h.HookModelName == sdk.RepositoryWebHookModelName && (n.Context == nil || n.Context.ApplicationID == 0) {
 			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to find application for the repository webhook: %s/%s", w.Name, n.Name)
 		}
+		if h.HookModelName == sdk.CommentCommandHookModelName && (n.Context == nil || n.Context.ApplicationID == 0) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to find application for the comment command hook: %s/%s", w.Name, n.Name)
+		}
 
 		// Add missing default value for hook
 		model := w.HookModels[h.HookModelID]
@@ -908,6 +924,11 @@ func checkHooks(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
 			v.Configurable = d.Configurable
 			h.Config[k] = v
 		}
+		if model.Name == sdk.SchedulerModelName {
+			if err := checkSchedulerHookConfig(db, w, h.Config); err != nil {
+				return err
+			}
+		}
 		// Check hooks duplication
 		for j := range n.Hooks {
 			h2 := n.Hooks[j]
@@ -920,6 +941,28 @@ func checkHooks(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
 	return nil
 }
 
+// checkSchedulerHookConfig checks the jitter and that the excluded calendars exist in the project, otherwise
+// all the executions of the scheduler would fail.
+func checkSchedulerHookConfig(db gorp.SqlExecutor, w *sdk.Workflow, cfg sdk.WorkflowNodeHookConfig) error {
+	if _, err := cfg.SchedulerJitter(); err != nil {
+		return err
+	}
+	calendarNames := cfg.SchedulerCalendars()
+	if len(calendarNames) == 0 {
+		return nil
+	}
+	var existing []string
+	if _, err := db.Select(&existing, "SELECT name FROM project_calendar WHERE project_id = $1", w.ProjectID); err != nil {
+		return sdk.WrapError(err, "unable to load calendars of project %d", w.ProjectID)
+	}
+	for _, name := range calendarNames {
+		if !sdk.IsInArray(name, existing) {
+			return sdk.NewErrorFrom(sdk.ErrInvalidHookConfiguration, "calendar %s not found in project %s", name, w.ProjectKey)
+		}
+	}
+	return nil
+}
+
 // CheckProjectIntegration checks CheckProjectIntegration data
 func checkProjectIntegration(proj sdk.Project, w *sdk.Workflow, n *sdk.Node) error {
 	if n.Context.ProjectIntegrationID != 0 {
@@ -1124,7 +1167,8 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 		appDB, appSecrets, msgList, err := application.ParseAndImport(ctx, tx, store, *proj, &app, application.ImportOptions{Force: true, FromRepository: fromRepo}, decryptFunc, u)
 		allMsg = append(allMsg, msgList...)
 		if err != nil {
-			return allMsg, nil, nil, nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import application %s/%s", proj.Key, app.Name)
+			err = sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import application %s/%s", proj.Key, app.Name)
+			return allMsg, nil, nil, nil, sdk.WithData(err, sdk.AsCodeEntity{Type: sdk.AsCodeEntityApplication, Name: app.Name})
 		}
 		proj.SetApplication(*appDB)
 		allSecrets.ApplicationsSecrets[appDB.ID] = appSecrets
@@ -1138,7 +1182,8 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 		envDB, envsSecrets, msgList, err := environment.ParseAndImport(tx, *proj, env, environment.ImportOptions{Force: true, FromRepository: fromRepo}, decryptFunc, u)
 		allMsg = append(allMsg, msgList...)
 		if err != nil {
-			return allMsg, nil, nil, nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import environment %s/%s", proj.Key, env.Name)
+			err = sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import environment %s/%s", proj.Key, env.Name)
+			return allMsg, nil, nil, nil, sdk.WithData(err, sdk.AsCodeEntity{Type: sdk.AsCodeEntityEnvironment, Name: env.Name})
 		}
 		proj.SetEnvironment(*envDB)
 		allSecrets.EnvironmentdSecrets[envDB.ID] = envsSecrets
@@ -1152,7 +1197,8 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 		pipDB, msgList, err := pipeline.ParseAndImport(ctx, tx, store, *proj, &pip, u, pipeline.ImportOptions{Force: true, FromRepository: fromRepo})
 		allMsg = append(allMsg, msgList...)
 		if err != nil {
-			return allMsg, nil, nil, nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import pipeline %s/%s", proj.Key, pip.Name)
+			err = sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import pipeline %s/%s", proj.Key, pip.Name)
+			return allMsg, nil, nil, nil, sdk.WithData(err, sdk.AsCodeEntity{Type: sdk.AsCodeEntityPipeline, Name: pip.Name})
 		}
 		proj.SetPipeline(*pipDB)
 	}
@@ -1179,7 +1225,8 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 	wf, msgList, err := ParseAndImport(ctx, tx, store, *proj, oldWf, data.Workflow, u, importOptions)
 	allMsg = append(allMsg, msgList...)
 	if err != nil {
-		return allMsg, nil, nil, nil, sdk.WrapError(err, "unable to import workflow %s", data.Workflow.GetName())
+		err = sdk.WrapError(err, "unable to import workflow %s", data.Workflow.GetName())
+		return allMsg, nil, nil, nil, sdk.WithData(err, sdk.AsCodeEntity{Type: sdk.AsCodeEntityWorkflow, Name: data.Workflow.GetName()})
 	}
 
 	// If the workflow is "as-code", it should always be linked to a git repository
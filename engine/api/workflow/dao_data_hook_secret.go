@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// loadHookSecret returns the secret used to sign the deliveries of a repository webhook, or an empty string.
+func loadHookSecret(ctx context.Context, db gorp.SqlExecutor, hookUUID string) (string, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM w_node_hook_secret WHERE hook_uuid = $1").Args(hookUUID)
+	var s dbNodeHookSecret
+	found, err := gorpmapping.Get(ctx, db, query, &s, gorpmapping.GetOptions.WithDecryption)
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot load secret of hook %s", hookUUID)
+	}
+	if !found {
+		return "", nil
+	}
+	isValid, err := gorpmapping.CheckSignature(s, s.Signature)
+	if err != nil {
+		return "", err
+	}
+	if !isValid {
+		log.Error(ctx, "workflow.loadHookSecret> secret of hook %s corrupted", hookUUID)
+		return "", nil
+	}
+	return s.Secret, nil
+}
+
+// LoadAllHookSecrets returns the secrets of the repository webhooks by hook uuid.
+func LoadAllHookSecrets(ctx context.Context, db gorp.SqlExecutor) (map[string]string, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM w_node_hook_secret")
+	var ss []dbNodeHookSecret
+	if err := gorpmapping.GetAll(ctx, db, query, &ss, gorpmapping.GetOptions.WithDecryption); err != nil {
+		return nil, sdk.WrapError(err, "cannot load hook secrets")
+	}
+	res := make(map[string]string, len(ss))
+	for i := range ss {
+		isValid, err := gorpmapping.CheckSignature(ss[i], ss[i].Signature)
+		if err != nil {
+			return nil, err
+		}
+		if !isValid {
+			log.Error(ctx, "workflow.LoadAllHookSecrets> secret of hook %s corrupted", ss[i].HookUUID)
+			continue
+		}
+		res[ss[i].HookUUID] = ss[i].Secret
+	}
+	return res, nil
+}
+
+func insertHookSecret(ctx context.Context, db gorpmapper.SqlExecutorWithTx, hookUUID, secret string) error {
+	s := dbNodeHookSecret{HookUUID: hookUUID, Secret: secret}
+	if err := gorpmapping.InsertAndSign(ctx, db, &s); err != nil {
+		return sdk.WrapError(err, "cannot insert secret of hook %s", hookUUID)
+	}
+	return nil
+}
+
+func deleteHookSecret(db gorp.SqlExecutor, hookUUID string) error {
+	if _, err := db.Exec("DELETE FROM w_node_hook_secret WHERE hook_uuid = $1", hookUUID); err != nil {
+		return sdk.WrapError(err, "cannot delete secret of hook %s", hookUUID)
+	}
+	return nil
+}
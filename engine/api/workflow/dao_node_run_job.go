@@ -286,6 +286,33 @@ func LoadDeadNodeJobRun(ctx context.Context, db gorp.SqlExecutor, store cache.St
 	return deadJobs, nil
 }
 
+// LoadTimedOutNodeJobRuns loads the building node job runs which reached their timeout before the given time.
+func LoadTimedOutNodeJobRuns(ctx context.Context, db gorp.SqlExecutor, store cache.Store, before time.Time) ([]sdk.WorkflowNodeJobRun, error) {
+	var jobsDB []JobRun
+	query := `
+		SELECT workflow_node_run_job.*
+		FROM workflow_node_run_job
+		WHERE status = $1
+		AND COALESCE((job->>'timeout')::BIGINT, 0) > 0
+		AND start + (job->>'timeout')::BIGINT * interval '1 second' < $2`
+	if _, err := db.Select(&jobsDB, query, sdk.StatusBuilding, before); err != nil {
+		return nil, sdk.WrapError(err, "cannot load timed out node job runs")
+	}
+
+	jobs := make([]sdk.WorkflowNodeJobRun, len(jobsDB))
+	for i := range jobsDB {
+		if store != nil {
+			getHatcheryInfo(ctx, store, &jobsDB[i])
+		}
+		jr, err := jobsDB[i].WorkflowNodeRunJob()
+		if err != nil {
+			return nil, err
+		}
+		jobs[i] = jr
+	}
+	return jobs, nil
+}
+
 //LoadAndLockNodeJobRunWait load for update a NodeJobRun given its ID
 func LoadAndLockNodeJobRunWait(ctx context.Context, db gorp.SqlExecutor, store cache.Store, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	j := JobRun{}
@@ -441,16 +441,22 @@ func addJobsToQueue(ctx context.Context, db gorp.SqlExecutor, stage *sdk.Stage,
 	}
 	next()
 
+	// Matrix jobs are expanded into one job by combination of their axes
+	var jobs []sdk.Job
+	for j := range stage.Jobs {
+		jobs = append(jobs, stage.Jobs[j].MatrixJobs()...)
+	}
+
 	skippedOrDisabledJobs := 0
 	failedJobs := 0
 	//Browse the jobs
 jobLoop:
-	for j := range stage.Jobs {
-		job := &stage.Jobs[j]
+	for j := range jobs {
+		job := &jobs[j]
 
 		if previousStage != nil {
 			for _, rj := range previousStage.RunJobs {
-				if rj.Job.PipelineActionID == job.PipelineActionID && rj.Status != sdk.StatusFail && sdk.StatusIsTerminated(rj.Status) {
+				if rj.Job.PipelineActionID == job.PipelineActionID && rj.Job.MatrixValues.Equal(job.MatrixValues) && rj.Status != sdk.StatusFail && rj.Status != sdk.StatusTimeout && sdk.StatusIsTerminated(rj.Status) {
 					stage.RunJobs = append(stage.RunJobs, rj)
 					continue jobLoop
 				}
@@ -500,6 +506,7 @@ jobLoop:
 			},
 			Header:          nr.Header,
 			ContainsService: containsService,
+			Priority:        wr.Workflow.Priority,
 		}
 		if wm != nil {
 			wjob.ModelType = wm.Type
@@ -559,7 +566,7 @@ jobLoop:
 		report.Add(ctx, wjob)
 	}
 
-	if skippedOrDisabledJobs == len(stage.Jobs) {
+	if skippedOrDisabledJobs == len(jobs) {
 		stage.Status = sdk.StatusSkipped
 	}
 
@@ -672,7 +679,7 @@ func syncStage(ctx context.Context, db gorp.SqlExecutor, store cache.Store, stag
 				if finalStatus == sdk.StatusBuilding || finalStatus == sdk.StatusDisabled {
 					finalStatus = sdk.StatusSkipped
 				}
-			case sdk.StatusFail:
+			case sdk.StatusFail, sdk.StatusTimeout:
 				finalStatus = sdk.StatusFail
 				break finalStageLoop
 			case sdk.StatusSuccess:
@@ -94,6 +94,7 @@ type JobRun struct {
 	Header                    sql.NullString `db:"header"`
 	HatcheryName              string         `db:"hatchery_name"`
 	WorkerName                string         `db:"worker_name"`
+	Priority                  int64          `db:"priority"`
 }
 
 // ToJobRun transform the JobRun with data of the provided sdk.WorkflowNodeJobRun
@@ -120,6 +121,7 @@ func (j *JobRun) ToJobRun(jr *sdk.WorkflowNodeJobRun) (err error) {
 	j.ExecGroups, err = gorpmapping.JSONToNullString(jr.ExecGroups)
 	j.WorkerName = jr.WorkerName
 	j.HatcheryName = jr.HatcheryName
+	j.Priority = jr.Priority
 	if err != nil {
 		return sdk.WrapError(err, "column exec_groups")
 	}
@@ -151,6 +153,7 @@ func (j JobRun) WorkflowNodeRunJob() (sdk.WorkflowNodeJobRun, error) {
 		HatcheryName:      j.HatcheryName,
 		WorkerName:        j.WorkerName,
 		Model:             j.Model,
+		Priority:          j.Priority,
 	}
 	if err := gorpmapping.JSONNullString(j.Job, &jr.Job); err != nil {
 		return jr, sdk.WrapError(err, "column job")
@@ -228,6 +231,21 @@ func (e dbWorkflowRunSecret) Canonical() gorpmapper.CanonicalForms {
 	}
 }
 
+// dbNodeHookSecret is the secret used to sign the deliveries of a repository webhook, it is stored
+// apart from the hook config to not be returned with the workflow.
+type dbNodeHookSecret struct {
+	gorpmapper.SignedEntity
+	HookUUID string `db:"hook_uuid"`
+	Secret   string `db:"cipher_secret" gorpmapping:"encrypted,HookUUID"`
+}
+
+func (e dbNodeHookSecret) Canonical() gorpmapper.CanonicalForms {
+	var _ = []interface{}{e.HookUUID}
+	return gorpmapper.CanonicalForms{
+		"{{.HookUUID}}",
+	}
+}
+
 type dbAsCodeEvents sdk.AsCodeEvent
 
 func init() {
@@ -252,4 +270,5 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbNodeJoinData{}, "w_node_join", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbAsCodeEvents{}, "as_code_events", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbWorkflowRunSecret{}, "workflow_run_secret", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbNodeHookSecret{}, "w_node_hook_secret", false, "hook_uuid"))
 }
@@ -39,7 +39,7 @@ func hookUnregistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, st
 
 	// Delete from vcs configuration if needed
 	for _, h := range hookToDelete {
-		if h.HookModelName == sdk.RepositoryWebHookModelName {
+		if h.HookModelName == sdk.RepositoryWebHookModelName || h.HookModelName == sdk.CommentCommandHookModelName {
 			// Call VCS to know if repository allows webhook and get the configuration fields
 			projectVCSServer, err := repositoriesmanager.LoadProjectVCSServerLinkByProjectKeyAndVCSServerName(ctx, db, proj.Key, h.Config["vcsServer"].Value)
 			if err == nil {
@@ -57,6 +57,9 @@ func hookUnregistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, st
 					log.Error(ctx, "hookUnregistration> Cannot delete hook on repository %s", err)
 				}
 			}
+			if err := deleteHookSecret(db, h.UUID); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -100,9 +103,13 @@ func hookRegistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, stor
 	}
 
 	hookToUpdate := make(map[string]sdk.NodeHook)
+	secrets := make(map[string]string)
 	for i := range wf.WorkflowData.Node.Hooks {
 		h := &wf.WorkflowData.Node.Hooks[i]
 
+		// The secret of repository webhooks is never stored in the hook config
+		delete(h.Config, sdk.HookConfigWebHookSecret)
+
 		h.Config[sdk.HookConfigProject] = sdk.WorkflowNodeHookConfigValue{
 			Value:        wf.ProjectKey,
 			Configurable: false,
@@ -133,7 +140,7 @@ func hookRegistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, stor
 			if has && h.Equals(*previousHook) {
 				// If this a repowebhook with an empty eventFilter, let's keep the old one because vcs won't be called to get the default eventFilter
 				eventFilter, has := h.GetConfigValue(sdk.HookConfigEventFilter)
-				if previousHook.IsRepositoryWebHook() && h.IsRepositoryWebHook() &&
+				if ((previousHook.IsRepositoryWebHook() && h.IsRepositoryWebHook()) || (previousHook.IsCommentCommandHook() && h.IsCommentCommandHook())) &&
 					(!has || eventFilter == "") {
 					h.Config[sdk.HookConfigEventFilter] = previousHook.Config[sdk.HookConfigEventFilter]
 				}
@@ -146,7 +153,7 @@ func hookRegistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, stor
 			h.UUID = sdk.UUID()
 		}
 
-		if h.IsRepositoryWebHook() || h.HookModelName == sdk.GitPollerModelName || h.HookModelName == sdk.GerritHookModelName {
+		if h.IsRepositoryWebHook() || h.IsCommentCommandHook() || h.HookModelName == sdk.GitPollerModelName || h.HookModelName == sdk.GerritHookModelName {
 			if wf.WorkflowData.Node.Context.ApplicationID == 0 || wf.Applications[wf.WorkflowData.Node.Context.ApplicationID].RepositoryFullname == "" || wf.Applications[wf.WorkflowData.Node.Context.ApplicationID].VCSServer == "" {
 				return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot create a git poller or repository webhook on an application without a repository")
 			}
@@ -163,7 +170,22 @@ func hookRegistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, stor
 		if err := updateSchedulerPayload(ctx, db, store, proj, wf, h); err != nil {
 			return err
 		}
-		hookToUpdate[h.UUID] = *h
+
+		// The secret used by the VCS to sign the webhook deliveries is only given to the hooks µService that checks them
+		task := *h
+		if h.IsRepositoryWebHook() || h.IsCommentCommandHook() {
+			secret, err := loadOrCreateHookSecret(ctx, db, h.UUID)
+			if err != nil {
+				return err
+			}
+			secrets[h.UUID] = secret
+			task.Config = h.Config.Clone()
+			task.Config[sdk.HookConfigWebHookSecret] = sdk.WorkflowNodeHookConfigValue{
+				Value:        secret,
+				Configurable: false,
+			}
+		}
+		hookToUpdate[h.UUID] = task
 		log.Debug("workflow.hookrRegistration> following hook must be updated: %+v", h)
 	}
 
@@ -177,6 +199,7 @@ func hookRegistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, stor
 		hooks := wf.WorkflowData.GetHooks()
 		for i := range hookToUpdate {
 			hooks[i].Config = hookToUpdate[i].Config
+			delete(hooks[i].Config, sdk.HookConfigWebHookSecret)
 		}
 
 		// Create vcs configuration ( always after hook creation to have webhook URL) + update hook in DB
@@ -190,19 +213,19 @@ func hookRegistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, stor
 			if h.IsRepositoryWebHook() {
 				log.Debug("workflow.hookRegistration> managing vcs configuration: %+v", h)
 			}
-			if h.IsRepositoryWebHook() && h.Config["vcsServer"].Value != "" {
+			if (h.IsRepositoryWebHook() || h.IsCommentCommandHook()) && h.Config["vcsServer"].Value != "" {
 				if !ok || v.Value == "" {
-					if err := createVCSConfiguration(ctx, db, store, proj, h); err != nil {
+					if err := createVCSConfiguration(ctx, db, store, proj, h, secrets[h.UUID]); err != nil {
 						return sdk.WithStack(err)
 					}
 				}
 				if ok && v.Value != "" {
-					if err := updateVCSConfiguration(ctx, db, store, proj, h); err != nil {
+					if err := updateVCSConfiguration(ctx, db, store, proj, h, secrets[h.UUID]); err != nil {
 						// hook not found on VCS, perhaps manually deleted on vcs
 						// we try to create a new hook
 						if sdk.ErrorIs(err, sdk.ErrNotFound) {
 							log.Warning(ctx, "hook %s not found on %s/%s", v.Value, h.Config["vcsServer"].Value, h.Config["repoFullName"].Value)
-							if err := createVCSConfiguration(ctx, db, store, proj, h); err != nil {
+							if err := createVCSConfiguration(ctx, db, store, proj, h, secrets[h.UUID]); err != nil {
 								return err
 							}
 						} else {
@@ -217,6 +240,67 @@ func hookRegistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, stor
 	return nil
 }
 
+// loadOrCreateHookSecret returns the secret of a repository webhook, a new one is generated for a new hook.
+func loadOrCreateHookSecret(ctx context.Context, db gorpmapper.SqlExecutorWithTx, hookUUID string) (string, error) {
+	secret, err := loadHookSecret(ctx, db, hookUUID)
+	if err != nil || secret != "" {
+		return secret, err
+	}
+	secret, err = sdk.GenerateHash()
+	if err != nil {
+		return "", err
+	}
+	if err := insertHookSecret(ctx, db, hookUUID, secret); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// SetHookSecret sets a secret on a repository webhook created without it. The secret is given to the repository manager
+// and to the hooks µService, then removed from the hook config if it was stored there.
+func SetHookSecret(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, proj sdk.Project, h sdk.NodeHook) error {
+	secret := h.Config[sdk.HookConfigWebHookSecret].Value
+	known := secret != ""
+	if !known {
+		var err error
+		secret, err = sdk.GenerateHash()
+		if err != nil {
+			return err
+		}
+	}
+	if err := insertHookSecret(ctx, db, h.UUID, secret); err != nil {
+		return err
+	}
+
+	if !known {
+		if h.Config[sdk.HookConfigVCSServer].Value != "" && h.Config[sdk.HookConfigWebHookID].Value != "" {
+			if err := updateVCSConfiguration(ctx, db, store, proj, &h, secret); err != nil {
+				return err
+			}
+		}
+
+		srvs, err := services.LoadAllByType(ctx, db, sdk.TypeHooks)
+		if err != nil {
+			return sdk.WrapError(err, "unable to get services")
+		}
+		task := h
+		task.Config = h.Config.Clone()
+		task.Config[sdk.HookConfigWebHookSecret] = sdk.WorkflowNodeHookConfigValue{
+			Value:        secret,
+			Configurable: false,
+		}
+		_, code, err := services.NewClient(db, srvs).DoJSONRequest(ctx, http.MethodPost, "/task/bulk", map[string]sdk.NodeHook{h.UUID: task}, nil)
+		if err != nil || code >= 400 {
+			return sdk.WrapError(err, "unable to update hook %s [%d]", h.UUID, code)
+		}
+	}
+
+	if _, err := db.Exec("UPDATE w_node_hook SET config = config - $1 WHERE uuid = $2", sdk.HookConfigWebHookSecret, h.UUID); err != nil {
+		return sdk.WrapError(err, "unable to remove secret from config of hook %s", h.UUID)
+	}
+	return nil
+}
+
 func updateSchedulerPayload(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, proj sdk.Project, wf *sdk.Workflow, h *sdk.NodeHook) error {
 	ctx, end := telemetry.Span(ctx, "workflow.updateSchedulerPayload")
 	defer end()
@@ -287,7 +371,7 @@ func updateSchedulerPayload(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 	return nil
 }
 
-func createVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, proj sdk.Project, h *sdk.NodeHook) error {
+func createVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, proj sdk.Project, h *sdk.NodeHook, secret string) error {
 	ctx, end := telemetry.Span(ctx, "workflow.createVCSConfiguration", telemetry.Tag("UUID", h.UUID))
 	defer end()
 	// Call VCS to know if repository allows webhook and get the configuration fields
@@ -323,12 +407,16 @@ func createVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 		Method:   "POST",
 		URL:      h.Config["webHookURL"].Value,
 		Workflow: true,
+		Secret:   secret,
 	}
 
 	// Set given event filters if exists, else default values will be set by CreateHook func.
 	if c, ok := h.Config[sdk.HookConfigEventFilter]; ok && c.Value != "" {
 		vcsHook.Events = strings.Split(c.Value, ";")
 	}
+	if err := setCommentCommandEvents(h, webHookInfo, &vcsHook); err != nil {
+		return err
+	}
 
 	if err := client.CreateHook(ctx, h.Config["repoFullName"].Value, &vcsHook); err != nil {
 		return sdk.WrapError(err, "Cannot create hook on repository: %+v", vcsHook)
@@ -345,13 +433,13 @@ func createVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 	}
 	h.Config[sdk.HookConfigEventFilter] = sdk.WorkflowNodeHookConfigValue{
 		Type:         sdk.HookConfigTypeMultiChoice,
-		Configurable: true,
+		Configurable: !h.IsCommentCommandHook(),
 		Value:        strings.Join(vcsHook.Events, ";"),
 	}
 	return nil
 }
 
-func updateVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, proj sdk.Project, h *sdk.NodeHook) error {
+func updateVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, proj sdk.Project, h *sdk.NodeHook, secret string) error {
 	ctx, end := telemetry.Span(ctx, "workflow.updateVCSConfiguration", telemetry.Tag("UUID", h.UUID))
 	defer end()
 	// Call VCS to know if repository allows webhook and get the configuration fields
@@ -375,12 +463,16 @@ func updateVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 		Method:   "POST",
 		URL:      h.Config["webHookURL"].Value,
 		Workflow: true,
+		Secret:   secret,
 	}
 
 	// Set given event filters if exists, else default values will be set by CreateHook func.
 	if c, ok := h.Config[sdk.HookConfigEventFilter]; ok && c.Value != "" {
 		vcsHook.Events = strings.Split(c.Value, ";")
 	}
+	if err := setCommentCommandEvents(h, webHookInfo, &vcsHook); err != nil {
+		return err
+	}
 
 	if err := client.UpdateHook(ctx, h.Config["repoFullName"].Value, &vcsHook); err != nil {
 		return sdk.WrapError(err, "Cannot update hook on repository: %+v", vcsHook)
@@ -392,12 +484,24 @@ func updateVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 	}
 	h.Config[sdk.HookConfigEventFilter] = sdk.WorkflowNodeHookConfigValue{
 		Type:         sdk.HookConfigTypeMultiChoice,
-		Configurable: true,
+		Configurable: !h.IsCommentCommandHook(),
 		Value:        strings.Join(vcsHook.Events, ";"),
 	}
 	return nil
 }
 
+// setCommentCommandEvents subscribes comment command hooks to the pull request comment events of the repository manager
+func setCommentCommandEvents(h *sdk.NodeHook, webHookInfo repositoriesmanager.WebhooksInfos, vcsHook *sdk.VCSHook) error {
+	if !h.IsCommentCommandHook() {
+		return nil
+	}
+	if len(webHookInfo.CommentEvents) == 0 {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "pull request comment commands are not supported by the repository manager %s", h.Config[sdk.HookConfigVCSServer].Value)
+	}
+	vcsHook.Events = webHookInfo.CommentEvents
+	return nil
+}
+
 // DefaultPayload returns the default payload for the workflow root
 func DefaultPayload(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, proj sdk.Project, wf *sdk.Workflow) (interface{}, error) {
 	if wf.WorkflowData.Node.Context == nil || wf.WorkflowData.Node.Context.ApplicationID == 0 {
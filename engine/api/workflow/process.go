@@ -105,7 +105,7 @@ func computeRunStatus(status string, counter *statusCounter) {
 		counter.success++
 	case sdk.StatusBuilding, sdk.StatusWaiting:
 		counter.building++
-	case sdk.StatusFail:
+	case sdk.StatusFail, sdk.StatusTimeout:
 		counter.failed++
 	case sdk.StatusStopped:
 		counter.stoppped++
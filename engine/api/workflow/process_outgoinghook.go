@@ -109,18 +109,22 @@ func processNodeOutGoingHook(ctx context.Context, db gorpmapper.SqlExecutorWithT
 			ID:            node.OutGoingHookContext.ID,
 			Config:        make(map[string]sdk.WorkflowNodeHookConfigValue, len(node.OutGoingHookContext.Config)),
 		}
-		for k, v := range node.OutGoingHookContext.Config {
-			// If payload run interpolate
-			if k == sdk.Payload {
-				// Take all parent parameters without any exceptions
-				allParentParams := make([]sdk.Parameter, 0, len(hookRun.BuildParameters))
-				for _, parentNodeRun := range parentNodeRun {
-					allParentParams = append(allParentParams, parentNodeRun.BuildParameters...)
-				}
+		// Take all parent parameters without any exceptions, completed by the hook run parameters
+		// and the status of the parents
+		allParentParams := make([]sdk.Parameter, 0, len(hookRun.BuildParameters))
+		for _, parentNodeRun := range parentNodeRun {
+			allParentParams = append(allParentParams, parentNodeRun.BuildParameters...)
+		}
+		hookRunParams := sdk.ParametersToMap(hookRun.BuildParameters)
+		templateParams := sdk.ParametersMapMerge(sdk.ParametersToMap(hookRun.BuildParameters), sdk.ParametersToMap(allParentParams))
+		templateParams["cds.status"] = hookRunParams["cds.status"]
 
-				result, err := interpolate.Do(v.Value, sdk.ParametersToMap(allParentParams))
+		for k, v := range node.OutGoingHookContext.Config {
+			// If payload or headers run interpolate
+			if k == sdk.Payload || k == sdk.OutgoingWebHookModelHeaders {
+				result, err := interpolate.Do(v.Value, templateParams)
 				if err != nil {
-					return nil, true, sdk.WrapError(err, "unable to interpolate payload %s", v.Value)
+					return nil, true, sdk.WrapError(err, "unable to interpolate %s %s", k, v.Value)
 				}
 				v.Value = result
 			}
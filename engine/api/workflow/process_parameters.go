@@ -18,6 +18,9 @@ func getNodeJobRunParameters(j sdk.Job, run *sdk.WorkflowNodeRun, stage *sdk.Sta
 		"cds.stage": stage.Name,
 		"cds.job":   j.Action.Name,
 	}
+	for k, v := range j.MatrixValues {
+		tmp["cds.matrix."+k] = v
+	}
 	errm := &sdk.MultiError{}
 
 	for k, v := range tmp {
@@ -137,6 +140,9 @@ func getBuildParameterFromNodeContext(proj sdk.Project, w sdk.Workflow, runConte
 		if runContext.Application.VCSServer != "" {
 			vars["git.server"] = runContext.Application.VCSServer
 		}
+		if runContext.Application.RepositoryStrategy.MergeResult {
+			vars["git.merge_result"] = "true"
+		}
 	} else {
 		// remove vcs strategy variable
 		delete(vars, "git.ssh.key")
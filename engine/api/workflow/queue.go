@@ -0,0 +1,143 @@
+package workflow
+
+import (
+	"sort"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// QueueScheduling describes how the waiting jobs of the queue are ordered.
+type QueueScheduling struct {
+	// DefaultShare is the share of a project without specific configuration
+	DefaultShare int
+	// ProjectShares contains the shares by project key
+	ProjectShares map[string]int
+	// GroupShares contains the shares by group name, used for projects without project share
+	GroupShares map[string]int
+	// AgeBoost moves ahead the jobs waiting since more than this duration, 0 to disable
+	AgeBoost time.Duration
+}
+
+// share returns the weight of the project in the queue.
+func (s QueueScheduling) share(projectKey string, groups sdk.Groups) int {
+	if share, ok := s.ProjectShares[projectKey]; ok && share > 0 {
+		return share
+	}
+	var res int
+	for _, g := range groups {
+		if share := s.GroupShares[g.Name]; share > res {
+			res = share
+		}
+	}
+	if res > 0 {
+		return res
+	}
+	if s.DefaultShare > 0 {
+		return s.DefaultShare
+	}
+	return 1
+}
+
+// CountBuildingNodeJobRunByProject returns the number of jobs being built by project id.
+func CountBuildingNodeJobRunByProject(db gorp.SqlExecutor) (map[int64]int, error) {
+	var rows []struct {
+		ProjectID int64 `db:"project_id"`
+		Count     int   `db:"count"`
+	}
+	query := `
+		SELECT project_id, COUNT(1) AS count
+		FROM workflow_node_run_job
+		WHERE status = $1
+		GROUP BY project_id`
+	if _, err := db.Select(&rows, query, sdk.StatusBuilding); err != nil {
+		return nil, sdk.WrapError(err, "unable to count building jobs")
+	}
+	res := make(map[int64]int, len(rows))
+	for _, r := range rows {
+		res[r.ProjectID] = r.Count
+	}
+	return res, nil
+}
+
+// SortQueue orders the waiting jobs of the queue and sets their rank.
+// Jobs waiting for longer than the age boost come first, oldest first. Then projects
+// take turns according to their share and their jobs already building: the next job
+// is taken from the project with the lowest usage by share. Inside a project, jobs are
+// ordered by workflow priority then by age. Jobs that are not waiting are kept at the end, all the jobs are ranked.
+func SortQueue(jobs []sdk.WorkflowNodeJobRun, building map[int64]int, s QueueScheduling, now time.Time) []sdk.WorkflowNodeJobRun {
+	res := make([]sdk.WorkflowNodeJobRun, 0, len(jobs))
+	var others []sdk.WorkflowNodeJobRun
+
+	var boosted []sdk.WorkflowNodeJobRun
+	byProject := make(map[int64][]sdk.WorkflowNodeJobRun)
+	shares := make(map[int64]int)
+	for _, j := range jobs {
+		if j.Status != sdk.StatusWaiting {
+			others = append(others, j)
+			continue
+		}
+		if s.AgeBoost > 0 && now.Sub(j.Queued) >= s.AgeBoost {
+			j.Boosted = true
+			boosted = append(boosted, j)
+			continue
+		}
+		byProject[j.ProjectID] = append(byProject[j.ProjectID], j)
+		projectKey := sdk.ParameterValue(j.Parameters, "cds.project")
+		if share := s.share(projectKey, j.ExecGroups); share > shares[j.ProjectID] {
+			shares[j.ProjectID] = share
+		}
+	}
+
+	sort.SliceStable(boosted, func(i, j int) bool { return boosted[i].Queued.Before(boosted[j].Queued) })
+	res = append(res, boosted...)
+
+	projectIDs := make([]int64, 0, len(byProject))
+	for id, js := range byProject {
+		sort.SliceStable(js, func(i, j int) bool { return isBefore(js[i], js[j]) })
+		projectIDs = append(projectIDs, id)
+	}
+	sort.Slice(projectIDs, func(i, j int) bool { return projectIDs[i] < projectIDs[j] })
+
+	used := make(map[int64]int, len(building))
+	for id, nb := range building {
+		used[id] = nb
+	}
+	for len(projectIDs) > 0 {
+		var next int
+		for i := 1; i < len(projectIDs); i++ {
+			cur, best := projectIDs[i], projectIDs[next]
+			// Compare used/share without float division
+			a, b := used[cur]*shares[best], used[best]*shares[cur]
+			if a < b || (a == b && isBefore(byProject[cur][0], byProject[best][0])) {
+				next = i
+			}
+		}
+		id := projectIDs[next]
+		res = append(res, byProject[id][0])
+		used[id]++
+		byProject[id] = byProject[id][1:]
+		if len(byProject[id]) == 0 {
+			projectIDs = append(projectIDs[:next], projectIDs[next+1:]...)
+		}
+	}
+
+	res = append(res, others...)
+	for i := range res {
+		res[i].QueueRank = i + 1
+	}
+	return res
+}
+
+// isBefore returns true if the job a should run before the job b of the same share.
+func isBefore(a, b sdk.WorkflowNodeJobRun) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if !a.Queued.Equal(b.Queued) {
+		return a.Queued.Before(b.Queued)
+	}
+	return a.ID < b.ID
+}
@@ -0,0 +1,74 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSortQueue(t *testing.T) {
+	now := time.Now()
+	job := func(id, projectID int64, projectKey string, priority int64, queued time.Duration) sdk.WorkflowNodeJobRun {
+		return sdk.WorkflowNodeJobRun{
+			ID:         id,
+			ProjectID:  projectID,
+			Status:     sdk.StatusWaiting,
+			Priority:   priority,
+			Queued:     now.Add(-queued),
+			Parameters: []sdk.Parameter{{Name: "cds.project", Value: projectKey}},
+			ExecGroups: sdk.Groups{{Name: projectKey + "-group"}},
+		}
+	}
+	ids := func(jobs []sdk.WorkflowNodeJobRun) []int64 {
+		res := make([]int64, len(jobs))
+		for i := range jobs {
+			res[i] = jobs[i].ID
+			if jobs[i].Status == sdk.StatusWaiting {
+				require.Equal(t, i+1, jobs[i].QueueRank)
+			}
+		}
+		return res
+	}
+
+	// A project with many jobs does not starve the others
+	jobs := []sdk.WorkflowNodeJobRun{
+		job(1, 1, "BIG", 0, 10*time.Minute),
+		job(2, 1, "BIG", 0, 9*time.Minute),
+		job(3, 1, "BIG", 0, 8*time.Minute),
+		job(4, 1, "BIG", 0, 7*time.Minute),
+		job(5, 2, "SMALL", 0, 2*time.Minute),
+		job(6, 2, "SMALL", 0, time.Minute),
+	}
+	res := SortQueue(jobs, nil, QueueScheduling{}, now)
+	require.Equal(t, []int64{1, 5, 2, 6, 3, 4}, ids(res))
+
+	// Jobs already building count in the usage of the project
+	res = SortQueue(jobs, map[int64]int{2: 2}, QueueScheduling{}, now)
+	require.Equal(t, []int64{1, 2, 3, 5, 4, 6}, ids(res))
+
+	// Project with a bigger share gets more jobs
+	res = SortQueue(jobs, nil, QueueScheduling{ProjectShares: map[string]int{"BIG": 3}}, now)
+	require.Equal(t, []int64{1, 5, 2, 3, 4, 6}, ids(res))
+
+	// Group share is used when no project share is defined
+	res = SortQueue(jobs, nil, QueueScheduling{DefaultShare: 2, GroupShares: map[string]int{"SMALL-group": 4}}, now)
+	require.Equal(t, []int64{1, 5, 6, 2, 3, 4}, ids(res))
+
+	// Workflow priority orders the jobs of a project
+	jobs[3].Priority = 10
+	res = SortQueue(jobs, nil, QueueScheduling{}, now)
+	require.Equal(t, []int64{4, 5, 1, 6, 2, 3}, ids(res))
+
+	// Old jobs are boosted, other jobs are kept at the end and ranked too
+	jobs = append(jobs, sdk.WorkflowNodeJobRun{ID: 7, ProjectID: 1, Status: sdk.StatusBuilding})
+	res = SortQueue(jobs, nil, QueueScheduling{AgeBoost: 8 * time.Minute}, now)
+	require.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, ids(res))
+	require.True(t, res[2].Boosted)
+	require.False(t, res[3].Boosted)
+	for i := range res {
+		require.Equal(t, i+1, res[i].QueueRank)
+	}
+}
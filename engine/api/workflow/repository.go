@@ -5,7 +5,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/fsamin/go-dump"
 	"github.com/go-gorp/gorp"
@@ -23,6 +27,14 @@ import (
 // WorkflowAsCodePattern is the default code pattern to find cds files
 const WorkflowAsCodePattern = ".cds/**/*.yml"
 
+// WorkflowAsCodeDirectory contains the workflow files of a repository that declares several workflows,
+// the applications, pipelines and environments of the .cds directory are shared by these workflows.
+const WorkflowAsCodeDirectory = ".cds/workflows/"
+
+// syncRepositoryWorkflowsLockDuration is the time during which the other workflows triggered by a push
+// don't synchronize the repository again.
+const syncRepositoryWorkflowsLockDuration = 10 * time.Minute
+
 // PushOption is the set of options for workflow push
 type PushOption struct {
 	VCSServer          string
@@ -96,10 +108,15 @@ func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *
 	defer end()
 	var allMsgs []sdk.Message
 	// Read files
-	tr, err := ReadCDSFiles(ope.LoadFiles.Results)
+	components, err := ReadWorkflowsComponents(ctx, ope.LoadFiles.Results)
+	if err != nil {
+		allMsgs = append(allMsgs, sdk.NewMessage(sdk.MsgWorkflowErrorBadCdsDir))
+		return nil, allMsgs, err
+	}
+	data, others, err := selectWorkflowComponents(components, wf.Name)
 	if err != nil {
 		allMsgs = append(allMsgs, sdk.NewMessage(sdk.MsgWorkflowErrorBadCdsDir))
-		return nil, allMsgs, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "unable to read cds files"))
+		return nil, allMsgs, err
 	}
 
 	ope.RepositoryStrategy.SSHKeyContent = sdk.PasswordPlaceholder
@@ -115,13 +132,45 @@ func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *
 		OldWorkflow:        *wf,
 	}
 
-	data, err := exportentities.UntarWorkflowComponents(ctx, tr)
+	msgPush, workflowPushed, secrets, err := PushFromRepository(ctx, db, store, p, &data, opt, consumer, decryptFunc)
+	allMsgs = append(allMsgs, msgPush...)
 	if err != nil {
 		return nil, allMsgs, err
 	}
 
+	if wf.Name != workflowPushed.Name {
+		log.Debug("workflow.extractWorkflow> Workflow has been renamed from %s to %s", wf.Name, workflowPushed.Name)
+	}
+	*wf = *workflowPushed
+
+	// All the workflows of the repository are triggered by the same push, only the first one synchronizes the repository
+	if len(components) > 1 && opt.IsDefaultBranch {
+		lockKey := cache.Key("workflow:syncRepositoryWorkflows", p.Key, opt.FromRepository, ope.Setup.Checkout.Commit)
+		locked, err := store.Lock(lockKey, syncRepositoryWorkflowsLockDuration, 0, 1)
+		if err != nil {
+			log.Error(ctx, "workflow.extractWorkflow> cannot lock repository %s synchronization: %v", opt.FromRepository, err)
+		}
+		if locked {
+			allMsgs = append(allMsgs, syncRepositoryWorkflows(ctx, db, store, p, *wf, others, *opt, consumer, decryptFunc)...)
+			// Without commit the lock can't identify the push, release it to allow the next synchronization
+			if ope.Setup.Checkout.Commit == "" {
+				_ = store.Unlock(lockKey)
+			}
+		}
+	}
+
+	return secrets, allMsgs, nil
+}
+
+// PushFromRepository executes the template of given components if any then pushes the workflow.
+// Given components are replaced by the result of the template.
+func PushFromRepository(ctx context.Context, db *gorp.DbMap, store cache.Store, p *sdk.Project, data *exportentities.WorkflowComponents,
+	opt *PushOption, consumer sdk.AuthConsumer, decryptFunc keys.DecryptFunc) ([]sdk.Message, *sdk.Workflow, *PushSecrets, error) {
+	var allMsgs []sdk.Message
+
 	mods := []workflowtemplate.TemplateRequestModifierFunc{
 		workflowtemplate.TemplateRequestModifiers.DefaultKeys(*p),
+		workflowtemplate.TemplateRequestModifiers.Secrets(*p, nil, decryptFunc),
 	}
 	if !opt.IsDefaultBranch {
 		mods = append(mods, workflowtemplate.TemplateRequestModifiers.Detached)
@@ -129,12 +178,12 @@ func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *
 	if opt.FromRepository != "" {
 		mods = append(mods, workflowtemplate.TemplateRequestModifiers.DefaultNameAndRepositories(*p, opt.FromRepository))
 	}
-	msgTemplate, wti, err := workflowtemplate.CheckAndExecuteTemplate(ctx, db, store, consumer, *p, &data, mods...)
+	msgTemplate, wti, err := workflowtemplate.CheckAndExecuteTemplate(ctx, db, store, consumer, *p, data, mods...)
 	allMsgs = append(allMsgs, msgTemplate...)
 	if err != nil {
-		return nil, allMsgs, err
+		return allMsgs, nil, nil, err
 	}
-	msgPush, workflowPushed, _, secrets, err := Push(ctx, db, store, p, data, opt, consumer, decryptFunc)
+	msgPush, workflowPushed, _, secrets, err := Push(ctx, db, store, p, *data, opt, consumer, decryptFunc)
 	// Filter workflow push message if generated from template
 	for i := range msgPush {
 		if wti != nil && msgPush[i].ID == sdk.MsgWorkflowDeprecatedVersion.ID {
@@ -143,18 +192,182 @@ func extractWorkflow(ctx context.Context, db *gorp.DbMap, store cache.Store, p *
 		allMsgs = append(allMsgs, msgPush[i])
 	}
 	if err != nil {
-		return nil, allMsgs, sdk.WrapError(err, "unable to get workflow from file")
+		return allMsgs, nil, nil, sdk.WrapError(err, "unable to get workflow from file")
 	}
 	if err := workflowtemplate.UpdateTemplateInstanceWithWorkflow(ctx, db, *workflowPushed, consumer, wti); err != nil {
-		return nil, allMsgs, err
+		return allMsgs, nil, nil, err
 	}
-	*wf = *workflowPushed
 
-	if wf.Name != workflowPushed.Name {
-		log.Debug("workflow.extractWorkflow> Workflow has been renamed from %s to %s", wf.Name, workflowPushed.Name)
+	return allMsgs, workflowPushed, secrets, nil
+}
+
+// syncRepositoryWorkflows creates the workflows declared in the repository that don't exist yet and deletes the workflows
+// of the repository that are not declared anymore if they opted in with the delete_if_undeclared metadata, the other
+// ones are only reported. Existing workflows are updated by their own repository webhook.
+func syncRepositoryWorkflows(ctx context.Context, db *gorp.DbMap, store cache.Store, p *sdk.Project, holder sdk.Workflow,
+	others []exportentities.WorkflowComponents, opt PushOption, consumer sdk.AuthConsumer, decryptFunc keys.DecryptFunc) []sdk.Message {
+	ctx, end := telemetry.Span(ctx, "workflow.syncRepositoryWorkflows")
+	defer end()
+
+	var msgs []sdk.Message
+	declared := map[string]struct{}{holder.Name: {}}
+	for _, data := range others {
+		name := WorkflowComponentsName(data)
+		declared[name] = struct{}{}
+
+		exists, err := Exists(db, p.Key, name)
+		if err != nil {
+			log.Error(ctx, "syncRepositoryWorkflows> cannot check if workflow %s/%s exists: %v", p.Key, name, err)
+			msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeSyncError, name, sdk.Cause(err).Error()))
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		pushOpt := opt
+		pushOpt.HookUUID = ""
+		pushOpt.OldWorkflow = sdk.Workflow{}
+		pushMsgs, _, _, err := PushFromRepository(ctx, db, store, p, &data, &pushOpt, consumer, decryptFunc)
+		msgs = append(msgs, pushMsgs...)
+		if err != nil {
+			log.Error(ctx, "syncRepositoryWorkflows> cannot create workflow %s/%s: %v", p.Key, name, err)
+			msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeSyncError, name, sdk.Cause(err).Error()))
+		}
 	}
 
-	return secrets, allMsgs, nil
+	var dao WorkflowDAO
+	dao.Filters.ProjectKey = p.Key
+	dao.Filters.FromRepository = opt.FromRepository
+	ws, err := dao.LoadAll(ctx, db)
+	if err != nil {
+		log.Error(ctx, "syncRepositoryWorkflows> cannot load workflows of repository %s: %v", opt.FromRepository, err)
+		return msgs
+	}
+	for i := range ws {
+		if _, ok := declared[ws[i].Name]; ok {
+			continue
+		}
+		if ws[i].Metadata[sdk.WorkflowMetadataDeleteIfUndeclared] != "true" {
+			msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeNotDeclared, ws[i].Name))
+			continue
+		}
+		if err := deleteFromRepository(ctx, db, store, *p, &ws[i]); err != nil {
+			log.Error(ctx, "syncRepositoryWorkflows> cannot delete workflow %s/%s: %v", p.Key, ws[i].Name, err)
+			msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeSyncError, ws[i].Name, sdk.Cause(err).Error()))
+			continue
+		}
+		msgs = append(msgs, sdk.NewMessage(sdk.MsgWorkflowAsCodeDeleted, ws[i].Name))
+	}
+
+	return msgs
+}
+
+func deleteFromRepository(ctx context.Context, db *gorp.DbMap, store cache.Store, p sdk.Project, w *sdk.Workflow) error {
+	if err := CompleteWorkflow(ctx, db, w, p, LoadOptions{}); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	if err := Delete(ctx, tx, store, p, w); err != nil {
+		return err
+	}
+
+	return sdk.WithStack(tx.Commit())
+}
+
+// ReadWorkflowsComponents returns the components of the workflows declared in given cds files. If the files contain
+// a workflows directory, each workflow of the directory is returned with the applications, pipelines and environments
+// of the .cds directory, else the files should contain only one workflow.
+func ReadWorkflowsComponents(ctx context.Context, files map[string][]byte) ([]exportentities.WorkflowComponents, error) {
+	shared := make(map[string][]byte)
+	var workflowFiles []string
+	for fname, fcontent := range files {
+		if strings.HasPrefix(path.Clean(filepath.ToSlash(fname)), WorkflowAsCodeDirectory) && !exportentities.IsWorkflowDependencyFile(filepath.Base(fname)) {
+			workflowFiles = append(workflowFiles, fname)
+			continue
+		}
+		shared[fname] = fcontent
+	}
+
+	if len(workflowFiles) == 0 {
+		data, err := readWorkflowComponents(ctx, files)
+		if err != nil {
+			return nil, err
+		}
+		return []exportentities.WorkflowComponents{data}, nil
+	}
+
+	for fname := range shared {
+		if !exportentities.IsWorkflowDependencyFile(filepath.Base(fname)) {
+			return nil, sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "workflow file %s should be in directory %s", fname, WorkflowAsCodeDirectory)
+		}
+	}
+
+	sort.Strings(workflowFiles)
+	res := make([]exportentities.WorkflowComponents, 0, len(workflowFiles))
+	names := make(map[string]string, len(workflowFiles))
+	for _, fname := range workflowFiles {
+		fs := make(map[string][]byte, len(shared)+1)
+		for k, v := range shared {
+			fs[k] = v
+		}
+		fs[fname] = files[fname]
+
+		data, err := readWorkflowComponents(ctx, fs)
+		if err != nil {
+			return nil, err
+		}
+
+		name := WorkflowComponentsName(data)
+		if name == "" {
+			return nil, sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "missing workflow name in file %s", fname)
+		}
+		if other, ok := names[name]; ok {
+			return nil, sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "workflow %s is declared in files %s and %s", name, other, fname)
+		}
+		names[name] = fname
+
+		res = append(res, data)
+	}
+
+	return res, nil
+}
+
+func readWorkflowComponents(ctx context.Context, files map[string][]byte) (exportentities.WorkflowComponents, error) {
+	tr, err := ReadCDSFiles(files)
+	if err != nil {
+		return exportentities.WorkflowComponents{}, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "unable to read cds files"))
+	}
+	return exportentities.UntarWorkflowComponents(ctx, tr)
+}
+
+// WorkflowComponentsName returns the name of the workflow declared by given components.
+func WorkflowComponentsName(data exportentities.WorkflowComponents) string {
+	if data.Workflow != nil {
+		return data.Workflow.GetName()
+	}
+	return data.Template.Name
+}
+
+// selectWorkflowComponents returns the components declaring the workflow with given name and the other components.
+// A repository with only one workflow always declares the workflow, this allows to rename it.
+func selectWorkflowComponents(components []exportentities.WorkflowComponents, name string) (exportentities.WorkflowComponents, []exportentities.WorkflowComponents, error) {
+	if len(components) == 1 {
+		return components[0], nil, nil
+	}
+	for i := range components {
+		if WorkflowComponentsName(components[i]) == name {
+			others := append(append([]exportentities.WorkflowComponents{}, components[:i]...), components[i+1:]...)
+			return components[i], others, nil
+		}
+	}
+	return exportentities.WorkflowComponents{}, nil, sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "workflow %s is not declared in directory %s", name, WorkflowAsCodeDirectory)
 }
 
 // ReadCDSFiles reads CDS files
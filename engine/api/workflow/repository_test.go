@@ -0,0 +1,72 @@
+package workflow_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestReadWorkflowsComponents(t *testing.T) {
+	pip := []byte(`version: v1.0
+name: build`)
+	app := []byte(`version: v1.0
+name: my-app`)
+	wf := func(name string) []byte {
+		return []byte(`name: ` + name + `
+version: v2.0
+application: my-app
+pipeline: build`)
+	}
+
+	// a repository with only one workflow
+	components, err := workflow.ReadWorkflowsComponents(context.TODO(), map[string][]byte{
+		".cds/build.pip.yml":   pip,
+		".cds/my-app.app.yml":  app,
+		".cds/my-workflow.yml": wf("my-workflow"),
+	})
+	require.NoError(t, err)
+	require.Len(t, components, 1)
+	assert.Equal(t, "my-workflow", workflow.WorkflowComponentsName(components[0]))
+	assert.Len(t, components[0].Pipelines, 1)
+	assert.Len(t, components[0].Applications, 1)
+
+	// a repository with several workflows that share the pipeline and the application
+	components, err = workflow.ReadWorkflowsComponents(context.TODO(), map[string][]byte{
+		".cds/build.pip.yml":            pip,
+		".cds/my-app.app.yml":           app,
+		".cds/workflows/front.yml":      wf("front"),
+		".cds/workflows/back.yml":       wf("back"),
+		".cds/workflows/tools.pip.yml":  []byte("version: v1.0\nname: tools"),
+		".cds/workflows/deploy-api.yml": []byte("name: deploy-api\nfrom: shared.infra/deploy"),
+	})
+	require.NoError(t, err)
+	require.Len(t, components, 3)
+	assert.Equal(t, "back", workflow.WorkflowComponentsName(components[0]))
+	assert.Equal(t, "deploy-api", workflow.WorkflowComponentsName(components[1]))
+	assert.Equal(t, "front", workflow.WorkflowComponentsName(components[2]))
+	for _, c := range components {
+		assert.Len(t, c.Pipelines, 2)
+		assert.Len(t, c.Applications, 1)
+	}
+
+	// workflow files should all be in the workflows directory
+	_, err = workflow.ReadWorkflowsComponents(context.TODO(), map[string][]byte{
+		".cds/my-workflow.yml":     wf("my-workflow"),
+		".cds/workflows/front.yml": wf("front"),
+	})
+	require.Error(t, err)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrWorkflowInvalid))
+
+	// a workflow should only be declared once
+	_, err = workflow.ReadWorkflowsComponents(context.TODO(), map[string][]byte{
+		".cds/workflows/front.yml":  wf("front"),
+		".cds/workflows/front2.yml": wf("front"),
+	})
+	require.Error(t, err)
+	assert.Equal(t, "workflow front is declared in files .cds/workflows/front.yml and .cds/workflows/front2.yml", sdk.Cause(err).Error())
+}
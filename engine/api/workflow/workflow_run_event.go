@@ -280,6 +280,16 @@ func (e *VCSEventMessenger) sendVCSEventStatus(ctx context.Context, db gorp.SqlE
 				Report:     report,
 				URL:        url,
 			}
+			if review := notif.Settings.Template.Gerrit; review != nil {
+				eventWNR.GerritChange.Labels = review.Votes(nodeRun.Status)
+				if review.InlineComments && sdk.StatusIsTerminated(nodeRun.Status) {
+					comments, err := gerritCommentsFromNodeRun(db, nodeRun)
+					if err != nil {
+						return err
+					}
+					eventWNR.GerritChange.Comments = comments
+				}
+			}
 		}
 
 	}
@@ -307,6 +317,22 @@ func (e *VCSEventMessenger) sendVCSEventStatus(ctx context.Context, db gorp.SqlE
 	return nil
 }
 
+// gerritCommentsFromNodeRun builds Gerrit inline comments from the failed tests of the node run
+func gerritCommentsFromNodeRun(db gorp.SqlExecutor, nodeRun *sdk.WorkflowNodeRun) ([]sdk.GerritComment, error) {
+	tests := nodeRun.Tests
+	if tests == nil {
+		nr, err := LoadNodeRunByID(db, nodeRun.ID, LoadRunOptions{WithTests: true})
+		if err != nil {
+			return nil, err
+		}
+		tests = nr.Tests
+	}
+	if tests == nil || tests.TotalKO == 0 {
+		return nil, nil
+	}
+	return sdk.GerritCommentsFromTests(tests), nil
+}
+
 func (e *VCSEventMessenger) sendVCSPullRequestComment(ctx context.Context, db gorp.SqlExecutor, wr sdk.WorkflowRun, nodeRun *sdk.WorkflowNodeRun, notif *sdk.WorkflowNotification, vcsServerName string) error {
 	if notif == nil || notif.Settings.Template == nil || (notif.Settings.Template.DisableComment != nil && *notif.Settings.Template.DisableComment) {
 		return nil
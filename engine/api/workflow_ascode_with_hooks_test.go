@@ -54,6 +54,7 @@ func Test_WorkflowAsCodeWithNoHook_ShouldGive_AnAutomaticRepoWebHook(t *testing.
 	assert.NoError(t, repositoriesmanager.InsertProjectVCSServerLink(context.TODO(), db, &vcsServer))
 
 	UUID := sdk.UUID()
+	var hookSecret string
 
 	servicesClients.EXPECT().
 		DoJSONRequest(gomock.Any(), "POST", "/operations", gomock.Any(), gomock.Any()).
@@ -131,6 +132,8 @@ version: v1.0`),
 					assert.Equal(t, "fsamin/go-repo", h.Config["repoFullName"].Value)
 					assert.Equal(t, "github", h.Config["vcsServer"].Value)
 					assert.Equal(t, "w-go-repo", h.Config["workflow"].Value)
+					hookSecret = h.Config[sdk.HookConfigWebHookSecret].Value
+					assert.NotEmpty(t, hookSecret, "the secret should be given to the hooks service")
 					h.Config["webHookURL"] = sdk.WorkflowNodeHookConfigValue{
 						Value:        "http://lolcat.host",
 						Configurable: false,
@@ -176,6 +179,7 @@ version: v1.0`),
 
 				assert.Equal(t, "POST", vcsHooks.Method)
 				assert.Equal(t, "http://lolcat.host", vcsHooks.URL)
+				assert.Equal(t, hookSecret, vcsHooks.Secret)
 				vcsHooks.ID = sdk.UUID()
 				*(out.(*sdk.VCSHook)) = *vcsHooks
 
@@ -228,6 +232,8 @@ version: v1.0`),
 		assert.Equal(t, "github", h.Config["vcsServer"].Value)
 		assert.Equal(t, wk.Name, h.Config["workflow"].Value)
 		assert.NotEmpty(t, h.Config["webHookID"].Value)
+		_, hasSecret := h.Config[sdk.HookConfigWebHookSecret]
+		assert.False(t, hasSecret, "the secret should not be stored in the hook config")
 	}
 }
 
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -27,6 +28,22 @@ func (api *API) getWorkflowHooksHandler() service.Handler {
 			return err
 		}
 
+		// The hooks µService needs the secrets to check the signature of repository webhook deliveries
+		if isService(ctx) {
+			secrets, err := workflow.LoadAllHookSecrets(ctx, api.mustDB())
+			if err != nil {
+				return err
+			}
+			for i := range hooks {
+				if secret, ok := secrets[hooks[i].UUID]; ok {
+					hooks[i].Config[sdk.HookConfigWebHookSecret] = sdk.WorkflowNodeHookConfigValue{
+						Value:        secret,
+						Configurable: false,
+					}
+				}
+			}
+		}
+
 		return service.WriteJSON(w, hooks, http.StatusOK)
 	}
 }
@@ -111,10 +128,11 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 		}
 
 		hasKafka := false
+		hookIntegrationModels := make(map[string]bool)
 		for _, integration := range p.Integrations {
 			if integration.Model.Hook {
 				hasKafka = true
-				break
+				hookIntegrationModels[integration.Model.Name] = true
 			}
 		}
 
@@ -143,6 +161,16 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 					}
 					models = append(models, m[i])
 				}
+			case sdk.CommentCommandHookModelName:
+				if repoWebHookEnable && len(webHookInfo.CommentEvents) > 0 {
+					m[i].DefaultConfig[sdk.HookConfigEventFilter] = sdk.WorkflowNodeHookConfigValue{
+						Type:               sdk.HookConfigTypeMultiChoice,
+						Value:              strings.Join(webHookInfo.CommentEvents, ";"),
+						Configurable:       false,
+						MultipleChoiceList: webHookInfo.CommentEvents,
+					}
+					models = append(models, m[i])
+				}
 			case sdk.GitPollerModelName:
 				if repoPollerEnable {
 					models = append(models, m[i])
@@ -151,6 +179,14 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 				if hasKafka {
 					models = append(models, m[i])
 				}
+			case sdk.NATSHookModelName:
+				if hookIntegrationModels[sdk.NATSIntegrationModel] {
+					models = append(models, m[i])
+				}
+			case sdk.RedisStreamHookModelName:
+				if hookIntegrationModels[sdk.RedisIntegrationModel] {
+					models = append(models, m[i])
+				}
 			default:
 				models = append(models, m[i])
 			}
@@ -276,6 +312,11 @@ func (api *API) postWorkflowJobHookCallbackHandler() service.Handler {
 		// Hide secrets in payload
 		for _, s := range secrets {
 			callback.Log = strings.Replace(callback.Log, s.Value, "**"+s.Name+"**", -1)
+			for i := range callback.Deliveries {
+				callback.Deliveries[i].URL = strings.Replace(callback.Deliveries[i].URL, s.Value, "**"+s.Name+"**", -1)
+				callback.Deliveries[i].Error = strings.Replace(callback.Deliveries[i].Error, s.Value, "**"+s.Name+"**", -1)
+				callback.Deliveries[i].Response = strings.Replace(callback.Deliveries[i].Response, s.Value, "**"+s.Name+"**", -1)
+			}
 		}
 
 		report, err := workflow.UpdateOutgoingHookRunStatus(ctx, tx, api.Cache, *proj, wr, hookRunID, callback)
@@ -333,3 +374,111 @@ func (api *API) getWorkflowJobHookDetailsHandler() service.Handler {
 		return service.WriteJSON(w, hr, http.StatusOK)
 	}
 }
+
+func (api *API) getWorkflowRunOutgoingHookDeliveriesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		workflowName := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, workflowName, number, workflow.LoadRunOptions{
+			DisableDetailledNodeRun: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		deliveries := []sdk.OutgoingHookDelivery{}
+		for _, nodeRuns := range wr.WorkflowNodeRuns {
+			for _, nr := range nodeRuns {
+				if nr.OutgoingHook == nil || nr.Callback == nil {
+					continue
+				}
+				var nodeName string
+				if n := wr.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID); n != nil {
+					nodeName = n.Name
+				}
+				for _, d := range nr.Callback.Deliveries {
+					d.HookRunID = nr.UUID
+					d.NodeName = nodeName
+					deliveries = append(deliveries, d)
+				}
+			}
+		}
+		sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].Date.Before(deliveries[j].Date) })
+
+		return service.WriteJSON(w, deliveries, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowOutgoingHookValidateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var tmpl sdk.OutgoingWebHookTemplate
+		if err := service.UnmarshalBody(r, &tmpl); err != nil {
+			return err
+		}
+
+		if err := tmpl.IsValid(); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowOutgoingHookPreviewHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		workflowName := vars["permWorkflowName"]
+
+		var tmpl sdk.OutgoingWebHookTemplate
+		if err := service.UnmarshalBody(r, &tmpl); err != nil {
+			return err
+		}
+
+		// Use the build parameters of an existing run, secrets are not available in the preview
+		params := make(map[string]string)
+		if tmpl.RunNumber > 0 {
+			wr, err := workflow.LoadRun(ctx, api.mustDB(), key, workflowName, tmpl.RunNumber, workflow.LoadRunOptions{
+				DisableDetailledNodeRun: true,
+			})
+			if err != nil {
+				return err
+			}
+			nodeRun := outgoingHookPreviewNodeRun(wr, tmpl.NodeName)
+			if nodeRun == nil {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find a run of node %q in workflow run %d", tmpl.NodeName, tmpl.RunNumber)
+			}
+			params = sdk.ParametersToMap(nodeRun.BuildParameters)
+		}
+		for k, v := range tmpl.Parameters {
+			params[k] = v
+		}
+		tmpl.Parameters = params
+
+		preview, err := tmpl.Render()
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, preview, http.StatusOK)
+	}
+}
+
+// outgoingHookPreviewNodeRun returns the last run of given node, or the root node run if no name is given.
+func outgoingHookPreviewNodeRun(wr *sdk.WorkflowRun, nodeName string) *sdk.WorkflowNodeRun {
+	if nodeName == "" {
+		return wr.RootRun()
+	}
+	for _, nodeRuns := range wr.WorkflowNodeRuns {
+		if len(nodeRuns) > 0 && nodeRuns[0].WorkflowNodeName == nodeName {
+			return &nodeRuns[0]
+		}
+	}
+	return nil
+}
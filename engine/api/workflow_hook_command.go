@@ -0,0 +1,362 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// postWorkflowHookCommandHandler executes a command written in a pull request comment.
+// The command is sent by the hooks µService, the result is replied as a comment on the pull request.
+func (api *API) postWorkflowHookCommandHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		// This handler can only be called by the hooks µService that checked the repository webhook signature
+		if !isService(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		uuid := vars["uuid"]
+
+		var cmd sdk.HookCommentCommand
+		if err := service.UnmarshalBody(r, &cmd); err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithIntegrations,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, name, workflow.LoadOptions{
+			DeepPipeline:          true,
+			WithAsCodeUpdateEvent: true,
+			WithIcon:              true,
+			WithIntegrations:      true,
+			WithTemplate:          true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		hook := wf.WorkflowData.Node.GetHook(uuid)
+		if hook == nil || !hook.IsCommentCommandHook() {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find comment command hook %s", uuid)
+		}
+
+		repoFullName := hook.Config[sdk.HookConfigRepoFullName].Value
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		vcsServer, err := repositoriesmanager.LoadProjectVCSServerLinkByProjectKeyAndVCSServerName(ctx, tx, p.Key, hook.Config[sdk.HookConfigVCSServer].Value)
+		if err != nil {
+			return err
+		}
+		client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, p.Key, vcsServer)
+		if err != nil {
+			return sdk.WrapError(err, "cannot get vcs client")
+		}
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		res, err := api.executeHookCommentCommand(ctx, client, p, wf, *hook, cmd)
+		if err != nil {
+			return err
+		}
+
+		if res.Message != "" {
+			comment := sdk.VCSPullRequestCommentRequest{Message: res.Message}
+			comment.ID = int(cmd.PullRequestID)
+			if err := client.PullRequestComment(ctx, repoFullName, comment); err != nil {
+				log.Error(ctx, "postWorkflowHookCommandHandler> unable to reply on pull request %d of %s: %v", cmd.PullRequestID, repoFullName, err)
+			}
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// executeHookCommentCommand checks that the author of the comment is allowed to execute the workflow then executes the command.
+// Refused and invalid commands are not errors, the reason is returned in the result message to be replied on the pull request.
+func (api *API) executeHookCommentCommand(ctx context.Context, client sdk.VCSAuthorizedClientService, p *sdk.Project, wf *sdk.Workflow, hook sdk.NodeHook, cmd sdk.HookCommentCommand) (sdk.HookCommentCommandResult, error) {
+	var res sdk.HookCommentCommandResult
+
+	if err := cmd.IsValid(); err != nil {
+		res.Message = fmt.Sprintf("@%s %s", cmd.Author, sdk.ExtractHTTPError(err, "").From)
+		return res, nil
+	}
+
+	consumer, err := loadHookCommentCommandConsumer(ctx, api.mustDB(), cmd)
+	if err != nil {
+		return res, err
+	}
+	if consumer == nil {
+		res.Message = fmt.Sprintf("@%s no CDS user matches your account, the command %q was ignored", cmd.Author, cmd.Name)
+		return res, nil
+	}
+	if !isHookCommentCommandAllowed(ctx, api.mustDB(), wf, hook, *consumer) {
+		res.Message = fmt.Sprintf("@%s you are not allowed to execute the workflow %s, the command %q was ignored", cmd.Author, wf.Name, cmd.Name)
+		return res, nil
+	}
+
+	repoFullName := hook.Config[sdk.HookConfigRepoFullName].Value
+	pr, err := client.PullRequest(ctx, repoFullName, strconv.FormatInt(cmd.PullRequestID, 10))
+	if err != nil {
+		return res, sdk.WrapError(err, "unable to get pull request %d of %s", cmd.PullRequestID, repoFullName)
+	}
+
+	switch cmd.Name {
+	case sdk.HookCommentCommandRun:
+		return api.runHookCommentCommand(ctx, wf, hook, cmd, pr, *consumer)
+	case sdk.HookCommentCommandRetry:
+		return api.retryHookCommentCommand(ctx, p, wf, cmd, pr, *consumer)
+	case sdk.HookCommentCommandStop:
+		return api.stopHookCommentCommand(ctx, p, wf, cmd, pr, *consumer)
+	}
+	return res, nil
+}
+
+func (api *API) runHookCommentCommand(ctx context.Context, wf *sdk.Workflow, hook sdk.NodeHook, cmd sdk.HookCommentCommand, pr sdk.VCSPullRequest, consumer sdk.AuthConsumer) (sdk.HookCommentCommandResult, error) {
+	var res sdk.HookCommentCommandResult
+
+	payload := make(map[string]string, len(cmd.Payload))
+	for k, v := range cmd.Payload {
+		payload[k] = v
+	}
+	repository := pr.Head.Repo
+	if repository == "" {
+		repository = hook.Config[sdk.HookConfigRepoFullName].Value
+	}
+	payload["git.repository"] = repository
+	payload["git.branch"] = pr.Head.Branch.DisplayID
+	payload["git.hash"] = pr.Head.Commit.Hash
+	payload["git.hash.short"] = sdk.StringFirstN(pr.Head.Commit.Hash, 7)
+	payload["git.repository.dest"] = hook.Config[sdk.HookConfigRepoFullName].Value
+	payload["git.branch.dest"] = pr.Base.Branch.DisplayID
+	payload["git.pr.id"] = strconv.FormatInt(cmd.PullRequestID, 10)
+	payload["git.pr.title"] = pr.Title
+	payload["cds.triggered_by.username"] = consumer.GetUsername()
+	payload["cds.triggered_by.fullname"] = consumer.GetFullname()
+	payload["cds.triggered_by.email"] = consumer.GetEmail()
+
+	run, err := workflow.CreateRun(api.mustDB(), wf, sdk.WorkflowRunPostHandlerOption{
+		Hook: &sdk.WorkflowNodeRunHookEvent{
+			WorkflowNodeHookUUID: hook.UUID,
+			Payload:              payload,
+		},
+		AuthConsumerID: consumer.ID,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	res.WorkflowRunNumber = run.Number
+	res.Message = fmt.Sprintf("Workflow %s #%d started by %s on commit %s: %s", wf.Name, run.Number, consumer.GetUsername(),
+		sdk.StringFirstN(pr.Head.Commit.Hash, 7), api.workflowRunURL(wf, run.Number))
+	return res, nil
+}
+
+func (api *API) retryHookCommentCommand(ctx context.Context, p *sdk.Project, wf *sdk.Workflow, cmd sdk.HookCommentCommand, pr sdk.VCSPullRequest, consumer sdk.AuthConsumer) (sdk.HookCommentCommandResult, error) {
+	var res sdk.HookCommentCommandResult
+
+	lastRun, err := loadLastRunForCommit(ctx, api.mustDB(), p.Key, wf.Name, pr.Head.Commit.Hash)
+	if err != nil {
+		return res, err
+	}
+	if lastRun == nil {
+		res.Message = fmt.Sprintf("@%s no run of workflow %s found for commit %s", cmd.Author, wf.Name, sdk.StringFirstN(pr.Head.Commit.Hash, 7))
+		return res, nil
+	}
+	if lastRun.ReadOnly {
+		res.Message = fmt.Sprintf("@%s workflow %s #%d is on read only mode, it cannot be run anymore", cmd.Author, wf.Name, lastRun.Number)
+		return res, nil
+	}
+	if !sdk.StatusIsTerminated(lastRun.Status) {
+		res.Message = fmt.Sprintf("@%s workflow %s #%d is still %s, stop it before retrying", cmd.Author, wf.Name, lastRun.Number, strings.ToLower(lastRun.Status))
+		return res, nil
+	}
+
+	var pipelineName string
+	if len(cmd.Args) > 0 {
+		pipelineName = cmd.Args[0]
+	}
+	fromNode := hookCommentCommandRetryNode(lastRun, pipelineName)
+	if fromNode == nil {
+		if pipelineName != "" {
+			res.Message = fmt.Sprintf("@%s no pipeline %s found in workflow %s #%d", cmd.Author, pipelineName, wf.Name, lastRun.Number)
+		} else {
+			res.Message = fmt.Sprintf("@%s nothing to retry, workflow %s #%d has no failed pipeline", cmd.Author, wf.Name, lastRun.Number)
+		}
+		return res, nil
+	}
+	if !permission.AccessToWorkflowNode(ctx, api.mustDB(), &lastRun.Workflow, fromNode, consumer, sdk.PermissionReadExecute) {
+		res.Message = fmt.Sprintf("@%s you are not allowed to execute the pipeline %s, the command %q was ignored", cmd.Author, fromNode.Name, cmd.Name)
+		return res, nil
+	}
+
+	opts := sdk.WorkflowRunPostHandlerOption{
+		Number:      &lastRun.Number,
+		FromNodeIDs: []int64{fromNode.ID},
+		Manual: &sdk.WorkflowNodeRunManual{
+			Username: consumer.GetUsername(),
+			Fullname: consumer.GetFullname(),
+			Email:    consumer.GetEmail(),
+		},
+		AuthConsumerID: consumer.ID,
+	}
+
+	runWorkflow := &lastRun.Workflow
+	if runWorkflow.Name != wf.Name {
+		runWorkflow.Name = wf.Name
+	}
+	lastRun.Status = sdk.StatusWaiting
+	api.GoRoutines.Exec(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", lastRun.ID), func(ctx context.Context) {
+		api.initWorkflowRun(ctx, p.Key, runWorkflow, lastRun, opts)
+	}, api.PanicDump())
+
+	res.WorkflowRunNumber = lastRun.Number
+	res.Message = fmt.Sprintf("Workflow %s #%d restarted from pipeline %s by %s: %s", wf.Name, lastRun.Number, fromNode.Name,
+		consumer.GetUsername(), api.workflowRunURL(wf, lastRun.Number))
+	return res, nil
+}
+
+func (api *API) stopHookCommentCommand(ctx context.Context, p *sdk.Project, wf *sdk.Workflow, cmd sdk.HookCommentCommand, pr sdk.VCSPullRequest, consumer sdk.AuthConsumer) (sdk.HookCommentCommandResult, error) {
+	var res sdk.HookCommentCommandResult
+
+	lastRun, err := loadLastRunForCommit(ctx, api.mustDB(), p.Key, wf.Name, pr.Head.Commit.Hash)
+	if err != nil {
+		return res, err
+	}
+	if lastRun == nil {
+		res.Message = fmt.Sprintf("@%s no run of workflow %s found for commit %s", cmd.Author, wf.Name, sdk.StringFirstN(pr.Head.Commit.Hash, 7))
+		return res, nil
+	}
+	res.WorkflowRunNumber = lastRun.Number
+	if sdk.StatusIsTerminated(lastRun.Status) {
+		res.Message = fmt.Sprintf("@%s workflow %s #%d is already %s", cmd.Author, wf.Name, lastRun.Number, strings.ToLower(lastRun.Status))
+		return res, nil
+	}
+
+	// The run is stopped on behalf of the author of the comment
+	report, err := api.stopWorkflowRun(context.WithValue(ctx, contextAPIConsumer, &consumer), p, lastRun, 0)
+	if err != nil {
+		return res, sdk.WrapError(err, "unable to stop workflow")
+	}
+	go api.WorkflowSendEvent(context.Background(), *p, report)
+
+	res.Message = fmt.Sprintf("Workflow %s #%d stopped by %s: %s", wf.Name, lastRun.Number, consumer.GetUsername(), api.workflowRunURL(wf, lastRun.Number))
+	return res, nil
+}
+
+func (api *API) workflowRunURL(wf *sdk.Workflow, number int64) string {
+	return fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", api.Config.URL.UI, wf.ProjectKey, wf.Name, number)
+}
+
+// loadLastRunForCommit returns the last run of the workflow for given commit, or nil if the commit was never built.
+func loadLastRunForCommit(ctx context.Context, db gorp.SqlExecutor, projectKey, workflowName, hash string) (*sdk.WorkflowRun, error) {
+	runs, _, _, _, err := workflow.LoadRunsSummaries(db, projectKey, workflowName, 0, 1, map[string]string{"git.hash": hash})
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	return workflow.LoadRun(ctx, db, projectKey, workflowName, runs[0].Number, workflow.LoadRunOptions{})
+}
+
+// hookCommentCommandRetryNode returns the node of the run to restart. If no pipeline name is given,
+// the first node whose last execution failed or was stopped is returned.
+func hookCommentCommandRetryNode(run *sdk.WorkflowRun, pipelineName string) *sdk.Node {
+	for _, n := range run.Workflow.WorkflowData.Array() {
+		if pipelineName != "" {
+			if n.Name == pipelineName {
+				return n
+			}
+			if n.Context != nil {
+				if pip, ok := run.Workflow.Pipelines[n.Context.PipelineID]; ok && pip.Name == pipelineName {
+					return n
+				}
+			}
+			continue
+		}
+		nodeRuns := run.WorkflowNodeRuns[n.ID]
+		if len(nodeRuns) > 0 && (nodeRuns[0].Status == sdk.StatusFail || nodeRuns[0].Status == sdk.StatusStopped) {
+			return n
+		}
+	}
+	return nil
+}
+
+// loadHookCommentCommandConsumer returns the consumer of the CDS user matching the author of a pull request comment, or nil if no user matches.
+// Only the users that linked their account on the repository manager, by signing in CDS with it, can be matched. Usernames and emails
+// are not checked by the repository managers, they can't be used to match a CDS user.
+func loadHookCommentCommandConsumer(ctx context.Context, db gorp.SqlExecutor, cmd sdk.HookCommentCommand) (*sdk.AuthConsumer, error) {
+	var consumerType sdk.AuthConsumerType
+	switch cmd.VCSType {
+	case string(sdk.ConsumerGithub), string(sdk.ConsumerGitlab):
+		consumerType = sdk.AuthConsumerType(cmd.VCSType)
+	default:
+		return nil, nil
+	}
+
+	c, err := authentication.LoadConsumerByTypeAndUsername(ctx, db, consumerType, cmd.Author,
+		authentication.LoadConsumerOptions.WithAuthentifiedUserWithContacts,
+		authentication.LoadConsumerOptions.WithConsumerGroups,
+	)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if c.Disabled {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// isHookCommentCommandAllowed checks that the consumer can execute the workflow and belongs to one of the groups allowed by the hook.
+func isHookCommentCommandAllowed(ctx context.Context, db gorp.SqlExecutor, wf *sdk.Workflow, hook sdk.NodeHook, consumer sdk.AuthConsumer) bool {
+	if !permission.AccessToWorkflowNode(ctx, db, wf, &wf.WorkflowData.Node, consumer, sdk.PermissionReadExecute) {
+		return false
+	}
+	if consumer.Admin() {
+		return true
+	}
+
+	allowedGroups := strings.FieldsFunc(hook.Config[sdk.HookConfigCommandGroups].Value, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	})
+	if len(allowedGroups) == 0 {
+		return true
+	}
+	for _, g := range consumer.AuthentifiedUser.Groups {
+		if sdk.IsInArray(g.Name, allowedGroups) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_loadHookCommentCommandConsumer(t *testing.T) {
+	_, db, _ := newTestAPI(t)
+
+	u, _ := assets.InsertLambdaUser(t, db)
+	githubUsername := sdk.RandomString(10)
+	c, err := authentication.NewConsumerExternal(context.TODO(), db, u.ID, sdk.ConsumerGithub, sdk.AuthDriverUserInfo{
+		ExternalID: sdk.RandomString(10),
+		Username:   githubUsername,
+		Email:      u.GetEmail(),
+	})
+	require.NoError(t, err)
+
+	// The author is matched with its GitHub account
+	res, err := loadHookCommentCommandConsumer(context.TODO(), db, sdk.HookCommentCommand{VCSType: "github", Author: githubUsername})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, c.ID, res.ID)
+
+	// A GitLab author with the same username is not the same user
+	res, err = loadHookCommentCommandConsumer(context.TODO(), db, sdk.HookCommentCommand{VCSType: "gitlab", Author: githubUsername})
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	// Repository managers without authentication driver can't match a CDS user, even with the same username
+	res, err = loadHookCommentCommandConsumer(context.TODO(), db, sdk.HookCommentCommand{VCSType: "bitbucketserver", Author: u.Username})
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
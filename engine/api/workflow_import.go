@@ -259,15 +259,10 @@ func (api *API) postWorkflowPushHandler() service.Handler {
 			telemetry.Tag(telemetry.TagProjectKey, key),
 		)
 
-		if r.Body == nil {
-			return sdk.WithStack(sdk.ErrWrongRequest)
-		}
-
-		btes, err := ioutil.ReadAll(r.Body)
+		btes, err := readAsCodeTar(w, r)
 		if err != nil {
-			return sdk.NewErrorWithStack(err, sdk.ErrWrongRequest)
+			return err
 		}
-		defer r.Body.Close()
 
 		log.Debug("Read %d bytes from body", len(btes))
 		tr := tar.NewReader(bytes.NewReader(btes))
@@ -306,6 +301,7 @@ func (api *API) postWorkflowPushHandler() service.Handler {
 
 		mods := []workflowtemplate.TemplateRequestModifierFunc{
 			workflowtemplate.TemplateRequestModifiers.DefaultKeys(*proj),
+			workflowtemplate.TemplateRequestModifiers.Secrets(*proj, project.EncryptWithBuiltinKey, project.DecryptWithBuiltinKey),
 		}
 		if pushOptions != nil && pushOptions.FromRepository != "" {
 			mods = append(mods, workflowtemplate.TemplateRequestModifiers.DefaultNameAndRepositories(*proj, pushOptions.FromRepository))
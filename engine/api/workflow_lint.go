@@ -0,0 +1,273 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	v2 "github.com/ovh/cds/sdk/exportentities/v2"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// asCodeTarMaxSize is the max size of the tar of as code files given to the API.
+const asCodeTarMaxSize = 10 * 1024 * 1024
+
+// readAsCodeTar reads the body of a request that contains a tar of as code files.
+func readAsCodeTar(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, sdk.WithStack(sdk.ErrWrongRequest)
+	}
+	defer r.Body.Close()
+	btes, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, asCodeTarMaxSize))
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read tar file of maximum %d bytes", asCodeTarMaxSize))
+	}
+	return btes, nil
+}
+
+// postWorkflowAsCodeLintHandler validates the as code files of a repository given in a tar. The workflows are imported
+// like for a push from a branch that is not the default one, so all changes are rolled back.
+func (api *API) postWorkflowAsCodeLintHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		branch := FormString(r, "branch")
+		if branch == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing branch")
+		}
+
+		btes, err := readAsCodeTar(w, r)
+		if err != nil {
+			return err
+		}
+
+		files := make(map[string][]byte)
+		tr := tar.NewReader(bytes.NewReader(btes))
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read tar file"))
+			}
+			if hdr.Typeflag == tar.TypeDir {
+				continue
+			}
+			buff := new(bytes.Buffer)
+			if _, err := io.Copy(buff, tr); err != nil {
+				return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to read tar file"))
+			}
+			files[filepath.ToSlash(filepath.Clean(hdr.Name))] = buff.Bytes()
+		}
+
+		var res sdk.AsCodeLint
+
+		// Check the syntax of all the files before importing workflows
+		fileNames := make([]string, 0, len(files))
+		for name := range files {
+			fileNames = append(fileNames, name)
+		}
+		sort.Strings(fileNames)
+		entityFiles := make(map[sdk.AsCodeEntity]string)
+		for _, name := range fileNames {
+			line, err := exportentities.CheckWorkflowFile(name, files[name])
+			if err != nil {
+				res.Errors = append(res.Errors, sdk.AsCodeLintError{
+					Type:    sdk.AsCodeLintErrorSyntax,
+					File:    name,
+					Line:    line,
+					Message: sdk.Cause(err).Error(),
+				})
+				continue
+			}
+			if entity, ok := asCodeFileEntity(name, files[name]); ok {
+				entityFiles[entity] = name
+			}
+		}
+		if len(res.Errors) > 0 {
+			return service.WriteJSON(w, lintResult(res), http.StatusOK)
+		}
+
+		// Check that the consumer will be able to push the workflows
+		if err := api.checkProjectPermissions(ctx, key, sdk.PermissionReadWriteExecute, nil); err != nil {
+			if !sdk.ErrorIs(err, sdk.ErrForbidden) {
+				return err
+			}
+			res.Errors = append(res.Errors, sdk.AsCodeLintError{
+				Type:    sdk.AsCodeLintErrorPermission,
+				Message: fmt.Sprintf("write permission on project %s is required to import workflows", key),
+			})
+		}
+
+		components, err := workflow.ReadWorkflowsComponents(ctx, files)
+		if err != nil {
+			if !sdk.ErrorIs(err, sdk.ErrWorkflowInvalid) && !sdk.ErrorIs(err, sdk.ErrWrongRequest) {
+				return err
+			}
+			res.Errors = append(res.Errors, sdk.AsCodeLintError{
+				Type:    sdk.AsCodeLintErrorImport,
+				Message: sdk.Cause(err).Error(),
+			})
+			return service.WriteJSON(w, lintResult(res), http.StatusOK)
+		}
+
+		consumer := getAPIConsumer(ctx)
+		for i := range components {
+			name := workflow.WorkflowComponentsName(components[i])
+			lintWorkflow, errs, err := api.lintWorkflowComponents(ctx, r, key, branch, &components[i], *consumer)
+			if err != nil {
+				return err
+			}
+			// errors are reported on the file of the entity that failed, entities generated by a template are
+			// reported on the template instance file
+			workflowFile := entityFiles[sdk.AsCodeEntity{Type: sdk.AsCodeEntityWorkflow, Name: name}]
+			for j := range errs {
+				errs[j].Workflow = name
+				errs[j].File = workflowFile
+				if errs[j].Entity != nil {
+					if f, ok := entityFiles[*errs[j].Entity]; ok {
+						errs[j].File = f
+					}
+				}
+			}
+			res.Errors = append(res.Errors, errs...)
+			res.Workflows = append(res.Workflows, lintWorkflow)
+		}
+
+		return service.WriteJSON(w, lintResult(res), http.StatusOK)
+	}
+}
+
+func lintResult(res sdk.AsCodeLint) sdk.AsCodeLint {
+	res.Valid = len(res.Errors) == 0
+	return res
+}
+
+// lintWorkflowComponents pushes given components in a rolled back transaction then computes the diff with the existing workflow.
+// Errors due to the content of the components are returned in the list of lint errors.
+func (api *API) lintWorkflowComponents(ctx context.Context, r *http.Request, key, branch string, data *exportentities.WorkflowComponents,
+	consumer sdk.AuthConsumer) (sdk.AsCodeLintWorkflow, []sdk.AsCodeLintError, error) {
+	res := sdk.AsCodeLintWorkflow{Name: workflow.WorkflowComponentsName(*data)}
+
+	// The project is loaded for each workflow because it's updated by the push
+	proj, err := project.Load(ctx, api.mustDB(), key,
+		project.LoadOptions.WithGroups,
+		project.LoadOptions.WithApplications,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithKeys,
+	)
+	if err != nil {
+		return res, nil, sdk.WrapError(err, "cannot load project %s", key)
+	}
+
+	opt := &workflow.PushOption{
+		Branch:          branch,
+		FromRepository:  r.Header.Get(sdk.WorkflowAsCodeHeader),
+		IsDefaultBranch: false,
+	}
+
+	var from exportentities.WorkflowComponents
+	res.Exists, err = workflow.Exists(api.mustDB(), key, res.Name)
+	if err != nil {
+		return res, nil, err
+	}
+	if res.Exists {
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, res.Name, workflow.LoadOptions{})
+		if err != nil {
+			return res, nil, err
+		}
+		if opt.FromRepository == "" {
+			opt.FromRepository = wf.FromRepository
+		}
+		from, err = workflow.Pull(ctx, api.mustDB(), api.Cache, *proj, res.Name, project.EncryptWithBuiltinKey, v2.WorkflowSkipIfOnlyOneRepoWebhook)
+		if err != nil {
+			return res, nil, err
+		}
+	}
+
+	msgs, _, _, err := workflow.PushFromRepository(ctx, api.mustDB(), api.Cache, proj, data, opt, consumer, project.DecryptWithBuiltinKey)
+	res.Messages = translate(r, msgs)
+	if err != nil {
+		lintErrs, err := asCodeLintErrors(err)
+		if err != nil {
+			return res, nil, err
+		}
+		return res, lintErrs, nil
+	}
+
+	res.Diff, err = exportentities.DiffWorkflowComponents(from, *data)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, nil, nil
+}
+
+// asCodeLintErrors converts an error returned by a push to lint errors, unexpected errors are returned.
+func asCodeLintErrors(err error) ([]sdk.AsCodeLintError, error) {
+	httpErr := sdk.ExtractHTTPError(err, "")
+	if httpErr.Status >= http.StatusInternalServerError {
+		return nil, err
+	}
+	if sdk.ErrorIs(err, sdk.ErrForbidden) || sdk.ErrorIs(err, sdk.ErrWorkflowAlreadyAsCode) {
+		return []sdk.AsCodeLintError{{Type: sdk.AsCodeLintErrorPermission, Message: sdk.Cause(err).Error()}}, nil
+	}
+	switch data := httpErr.Data.(type) {
+	case []sdk.WorkflowTemplateError:
+		res := make([]sdk.AsCodeLintError, len(data))
+		for i := range data {
+			res[i] = sdk.AsCodeLintError{Type: sdk.AsCodeLintErrorImport, Message: data[i].Error()}
+		}
+		return res, nil
+	case sdk.AsCodeEntity:
+		return []sdk.AsCodeLintError{{Type: sdk.AsCodeLintErrorImport, Entity: &data, Message: sdk.Cause(err).Error()}}, nil
+	}
+	return []sdk.AsCodeLintError{{Type: sdk.AsCodeLintErrorImport, Message: sdk.Cause(err).Error()}}, nil
+}
+
+// asCodeFileEntity returns the application, environment, pipeline or workflow declared in given file.
+func asCodeFileEntity(name string, b []byte) (sdk.AsCodeEntity, bool) {
+	var entity sdk.AsCodeEntity
+	base := filepath.Base(name)
+	switch {
+	case strings.Contains(base, ".app."):
+		entity.Type = sdk.AsCodeEntityApplication
+	case strings.Contains(base, ".pip."):
+		entity.Type = sdk.AsCodeEntityPipeline
+	case strings.Contains(base, ".env."):
+		entity.Type = sdk.AsCodeEntityEnvironment
+	default:
+		entity.Type = sdk.AsCodeEntityWorkflow
+	}
+	format, err := exportentities.GetFormatFromPath(name)
+	if err != nil {
+		return entity, false
+	}
+	var e struct {
+		Name string `json:"name" yaml:"name"`
+	}
+	if err := exportentities.Unmarshal(b, format, &e); err != nil {
+		log.Debug("asCodeFileEntity> cannot read name in file %s: %v", name, err)
+		return entity, false
+	}
+	entity.Name = e.Name
+	return entity, entity.Name != ""
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_postWorkflowAsCodeLintHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	u, pass := assets.InsertAdminUser(t, db)
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+
+	lint := func(files map[string]string) sdk.AsCodeLint {
+		buf := new(bytes.Buffer)
+		tw := tar.NewWriter(buf)
+		for name, content := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+
+		uri := api.Router.GetRoute("POST", api.postWorkflowAsCodeLintHandler, map[string]string{"permProjectKey": proj.Key})
+		require.NotEmpty(t, uri)
+		req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri+"?branch=my-branch", nil)
+		req.Body = ioutil.NopCloser(buf)
+		req.Header.Set("Content-Type", "application/tar")
+
+		rec := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(rec, req)
+		require.Equal(t, 200, rec.Code, rec.Body.String())
+
+		var res sdk.AsCodeLint
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res
+	}
+
+	// syntax errors are reported with the file and the line
+	res := lint(map[string]string{
+		".cds/build.pip.yml":       "version: v1.0\nname: build\nstages: Compile",
+		".cds/workflows/front.yml": "name: front\nversion: v2.0\npipeline: build",
+	})
+	assert.False(t, res.Valid)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, sdk.AsCodeLintErrorSyntax, res.Errors[0].Type)
+	assert.Equal(t, ".cds/build.pip.yml", res.Errors[0].File)
+	assert.Equal(t, 3, res.Errors[0].Line)
+
+	// valid files are imported in a rolled back transaction
+	res = lint(map[string]string{
+		".cds/build.pip.yml":       "version: v1.0\nname: build\nstages:\n- Compile",
+		".cds/workflows/front.yml": "name: front\nversion: v2.0\npipeline: build",
+		".cds/workflows/back.yml":  "name: back\nversion: v2.0\npipeline: unknown",
+	})
+	assert.False(t, res.Valid)
+	require.Len(t, res.Workflows, 2)
+	assert.Equal(t, "back", res.Workflows[0].Name)
+	assert.Equal(t, "front", res.Workflows[1].Name)
+	assert.False(t, res.Workflows[1].Exists)
+	assert.Contains(t, res.Workflows[1].Diff, "+++ b/front.yml")
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, sdk.AsCodeLintErrorImport, res.Errors[0].Type)
+	assert.Equal(t, "back", res.Errors[0].Workflow)
+	assert.Equal(t, ".cds/workflows/back.yml", res.Errors[0].File)
+
+	// errors of dependencies are reported on their file
+	res = lint(map[string]string{
+		".cds/build.pip.yml":       "version: v1.0\nname: build\nstages:\n- Compile",
+		".cds/tools.pip.yml":       "version: v1.0\nname: tools\nstages:\n- bad/stage",
+		".cds/workflows/front.yml": "name: front\nversion: v2.0\npipeline: build",
+	})
+	assert.False(t, res.Valid)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, sdk.AsCodeLintErrorImport, res.Errors[0].Type)
+	assert.Equal(t, "front", res.Errors[0].Workflow)
+	assert.Equal(t, ".cds/tools.pip.yml", res.Errors[0].File)
+	require.NotNil(t, res.Errors[0].Entity)
+	assert.Equal(t, sdk.AsCodeEntity{Type: sdk.AsCodeEntityPipeline, Name: "tools"}, *res.Errors[0].Entity)
+
+	exists, err := workflow.Exists(db, proj.Key, "front")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
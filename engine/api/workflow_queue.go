@@ -13,6 +13,7 @@ import (
 	"github.com/sguiheux/go-coverage"
 
 	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/metrics"
@@ -24,6 +25,7 @@ import (
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/featureflipping"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/jws"
@@ -104,13 +106,20 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 			return sdk.WrapError(err, "cannot takeJob nodeJobRunID:%d", id)
 		}
 
-		// Get CDN TCP Addr
 		// Get CDN TCP Addr
 		pbji.GelfServiceAddr, err = services.GetCDNPublicTCPAdress(ctx, api.mustDB())
 		if err != nil {
 			return err
 		}
 
+		// Get CDN HTTP Addr if artifacts should be stored in CDN
+		if featureflipping.IsEnabled(ctx, gorpmapping.Mapper, api.mustDB(), "cdn-artifact", map[string]string{"project_key": p.Key}) {
+			pbji.CDNHttpAddr, err = services.GetCDNPublicHTTPAdress(ctx, api.mustDB())
+			if err != nil {
+				return err
+			}
+		}
+
 		workflow.ResyncNodeRunsWithCommits(ctx, api.mustDB(), api.Cache, *p, report)
 		go api.WorkflowSendEvent(context.Background(), *p, report)
 
@@ -248,6 +257,75 @@ func (api *API) postBookWorkflowJobHandler() service.Handler {
 	}
 }
 
+func (api *API) postAssignWorkflowJobHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		if ok := isHatchery(ctx); !ok {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var form sdk.WorkerAssignForm
+		if err := service.UnmarshalBody(r, &form); err != nil {
+			return err
+		}
+
+		s, err := services.LoadByID(ctx, api.mustDB(), getAPIConsumer(ctx).Service.ID)
+		if err != nil {
+			return err
+		}
+
+		// The job must be booked by the hatchery that owns the worker
+		if _, err := workflow.BookNodeJobRun(ctx, api.Cache, id, s); err != nil {
+			return sdk.WrapError(err, "job already booked")
+		}
+
+		jobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return err
+		}
+		if jobRun.Status != sdk.StatusWaiting {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %d is not waiting: %s", id, jobRun.Status)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		wk, err := worker.LoadAndLockByID(ctx, tx, form.WorkerID)
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "worker %s is not available", form.WorkerID))
+		}
+		if wk.HatcheryID == nil || *wk.HatcheryID != s.ID {
+			return sdk.WrapError(sdk.ErrForbidden, "worker %s was not started by hatchery %s", wk.Name, s.Name)
+		}
+		if wk.Status != sdk.StatusWaiting || wk.JobRunID != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "worker %s is not idle", wk.Name)
+		}
+
+		// Checks that the worker will be allowed to take the job
+		consumer, err := authentication.LoadConsumerByID(ctx, tx, wk.ConsumerID)
+		if err != nil {
+			return err
+		}
+		grantedGroupIDs := append(consumer.GetGroupIDs(), group.SharedInfraGroup.ID)
+		if !jobRun.ExecGroups.HasOneOf(grantedGroupIDs...) {
+			return sdk.WrapError(sdk.ErrForbidden, "worker %s is not authorized to take this job:%d execGroups:%+v", wk.Name, id, jobRun.ExecGroups)
+		}
+
+		if err := worker.SetJobRunID(ctx, tx, wk, id); err != nil {
+			return err
+		}
+
+		return sdk.WithStack(tx.Commit())
+	}
+}
+
 func (api *API) deleteBookWorkflowJobHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		id, err := requestVarInt(r, "permJobID")
@@ -800,10 +878,25 @@ func (api *API) getWorkflowJobQueueHandler() service.Handler {
 			return sdk.WrapError(err, "Unable to load queue")
 		}
 
+		building, err := workflow.CountBuildingNodeJobRunByProject(api.mustDB())
+		if err != nil {
+			return err
+		}
+		jobs = workflow.SortQueue(jobs, building, api.queueScheduling(), time.Now())
+
 		return service.WriteJSON(w, jobs, http.StatusOK)
 	}
 }
 
+func (api *API) queueScheduling() workflow.QueueScheduling {
+	return workflow.QueueScheduling{
+		DefaultShare:  api.Config.Queue.DefaultShare,
+		ProjectShares: api.Config.Queue.ProjectShares,
+		GroupShares:   api.Config.Queue.GroupShares,
+		AgeBoost:      time.Duration(api.Config.Queue.AgeBoost) * time.Second,
+	}
+}
+
 func getModelTypeRatioService(ctx context.Context, r *http.Request) (string, *int, error) {
 	modelType := FormString(r, "modelType")
 	if modelType != "" {
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"runtime"
 	"testing"
 	"time"
 
@@ -38,6 +39,7 @@ import (
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -551,6 +553,80 @@ func Test_postBookWorkflowJobHandler(t *testing.T) {
 	require.Equal(t, 200, rec.Code)
 }
 
+func Test_postAssignWorkflowJobHandler(t *testing.T) {
+	api, db, router := newTestAPI(t)
+
+	ctx := testRunWorkflow(t, api, router)
+	testGetWorkflowJobAsHatchery(t, api, db, router, &ctx)
+	require.NotNil(t, ctx.job)
+
+	g, err := group.LoadByID(context.TODO(), api.mustDB(), ctx.user.Groups[0].ID)
+	require.NoError(t, err)
+	model := LoadOrCreateWorkerModel(t, api, db, g.ID, "Test1")
+	hSrv, hPrivKey, _, hatcheryJWT := assets.InsertHatchery(t, db, *g)
+
+	// Register an idle worker, without job
+	jwt, err := hatchery.NewWorkerToken(hSrv.Name, hPrivKey, time.Now().Add(time.Hour), hatchery.SpawnArguments{
+		HatcheryName: hSrv.Name,
+		Model:        model,
+		WorkerName:   hSrv.Name + "-warm",
+		Warm:         true,
+	})
+	require.NoError(t, err)
+	uri := router.GetRoute("POST", api.postRegisterWorkerHandler, nil)
+	req := assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, sdk.WorkerRegistrationForm{
+		Arch:    runtime.GOARCH,
+		OS:      runtime.GOOS,
+		Version: sdk.VERSION,
+	})
+	rec := httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	var wk sdk.Worker
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wk))
+	workerJWT := rec.Header().Get("X-CDS-JWT")
+
+	// No job assigned yet
+	uriAssignment := router.GetRoute("GET", api.getWorkerAssignmentHandler, nil)
+	require.NotEmpty(t, uriAssignment)
+	req = assets.NewJWTAuthentifiedRequest(t, workerJWT, "GET", uriAssignment, nil)
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wk))
+	require.Nil(t, wk.JobRunID)
+
+	uri = router.GetRoute("POST", api.postAssignWorkflowJobHandler, map[string]string{
+		"permJobID": fmt.Sprintf("%d", ctx.job.ID),
+	})
+	require.NotEmpty(t, uri)
+
+	req = assets.NewJWTAuthentifiedRequest(t, hatcheryJWT, "POST", uri, sdk.WorkerAssignForm{WorkerID: wk.ID})
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	// The worker is no more idle
+	req = assets.NewJWTAuthentifiedRequest(t, hatcheryJWT, "POST", uri, sdk.WorkerAssignForm{WorkerID: wk.ID})
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 400, rec.Code)
+
+	// The job is booked by the hatchery of the worker
+	req = assets.NewJWTAuthentifiedRequest(t, ctx.hatcheryToken, "POST", uri, sdk.WorkerAssignForm{WorkerID: wk.ID})
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 403, rec.Code)
+
+	req = assets.NewJWTAuthentifiedRequest(t, workerJWT, "GET", uriAssignment, nil)
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wk))
+	require.NotNil(t, wk.JobRunID)
+	require.Equal(t, ctx.job.ID, *wk.JobRunID)
+}
+
 func Test_postWorkflowJobResultHandler(t *testing.T) {
 	api, db, router := newTestAPI(t)
 
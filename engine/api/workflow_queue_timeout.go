@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/worker"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// jobTimeoutGracePeriod is the delay given to a worker to send the result of a job
+// which reached its timeout, after this delay the job is failed by the API.
+const jobTimeoutGracePeriod = 5 * time.Minute
+
+// StopTimedOutJobs sets the timeout status on the jobs still building after their timeout. The worker stops
+// a job when its timeout is reached, so this only handles jobs whose worker went silent.
+func (api *API) StopTimedOutJobs(ctx context.Context, tick time.Duration) error {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := api.stopTimedOutJobs(ctx, time.Now().Add(-jobTimeoutGracePeriod)); err != nil {
+				log.Error(ctx, "StopTimedOutJobs> %v", err)
+			}
+		}
+	}
+}
+
+func (api *API) stopTimedOutJobs(ctx context.Context, before time.Time) error {
+	jobs, err := workflow.LoadTimedOutNodeJobRuns(ctx, api.mustDB(), api.Cache, before)
+	if err != nil {
+		return err
+	}
+	for i := range jobs {
+		if err := api.stopTimedOutJob(ctx, jobs[i].ID); err != nil {
+			log.Error(ctx, "StopTimedOutJobs> unable to stop job %d: %v", jobs[i].ID, err)
+		}
+	}
+	return nil
+}
+
+func (api *API) stopTimedOutJob(ctx context.Context, id int64) error {
+	proj, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id, project.LoadOptions.WithVariables)
+	if err != nil {
+		return sdk.WrapError(err, "cannot load project from job %d", id)
+	}
+
+	tx, err := api.mustDBWithCtx(ctx).Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	job, err := workflow.LoadAndLockNodeJobRunSkipLocked(ctx, tx, api.Cache, id)
+	if err != nil {
+		// The job is locked when its result is being posted by the worker
+		if sdk.ErrorIs(err, sdk.ErrLocked) {
+			return nil
+		}
+		return err
+	}
+	if job.Status != sdk.StatusBuilding {
+		return nil
+	}
+
+	timeout := time.Duration(job.Job.Timeout) * time.Second
+	msg := sdk.SpawnMsg{ID: sdk.MsgSpawnInfoJobTimeout.ID, Args: []interface{}{timeout.String()}}
+	infos := []sdk.SpawnInfo{{
+		RemoteTime:  time.Now(),
+		Message:     msg,
+		UserMessage: msg.DefaultUserMessage(),
+	}}
+	if err := workflow.AddSpawnInfosNodeJobRun(tx, job.WorkflowNodeRunID, job.ID, workflow.PrepareSpawnInfos(infos)); err != nil {
+		return sdk.WrapError(err, "cannot save spawn info job %d", job.ID)
+	}
+
+	job.Job.Reason = fmt.Sprintf("job exceeded its timeout of %s", timeout)
+	for i := range job.Job.StepStatus {
+		if job.Job.StepStatus[i].Status == sdk.StatusBuilding {
+			job.Job.StepStatus[i].Status = sdk.StatusTimeout
+			job.Job.StepStatus[i].Done = time.Now()
+		}
+	}
+
+	// Disable the silent worker so it will not be used anymore
+	if job.Job.WorkerID != "" {
+		if err := worker.SetStatus(ctx, tx, job.Job.WorkerID, sdk.StatusDisabled); err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return sdk.WrapError(err, "cannot disable worker %s", job.Job.WorkerID)
+		}
+	}
+
+	report, err := workflow.UpdateNodeJobRunStatus(ctx, tx, api.Cache, *proj, job, sdk.StatusTimeout)
+	if err != nil {
+		return sdk.WrapError(err, "cannot update node job run %d status", job.ID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	for i := range report.WorkflowRuns() {
+		run := &report.WorkflowRuns()[i]
+		reportParent, err := api.updateParentWorkflowRun(ctx, run)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		go api.WorkflowSendEvent(context.Background(), *proj, reportParent)
+	}
+
+	workflow.ResyncNodeRunsWithCommits(ctx, api.mustDB(), api.Cache, *proj, report)
+	go api.WorkflowSendEvent(context.Background(), *proj, report)
+
+	return nil
+}
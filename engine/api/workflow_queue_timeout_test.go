@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_stopTimedOutJobs(t *testing.T) {
+	api, db, router := newTestAPI(t)
+
+	s, _ := assets.InitCDNService(t, db)
+	defer func() {
+		_ = services.Delete(db, s)
+	}()
+
+	ctx := testRunWorkflow(t, api, router)
+	testGetWorkflowJobAsWorker(t, api, db, router, &ctx)
+	require.NotNil(t, ctx.job)
+	testRegisterWorker(t, api, db, router, &ctx)
+
+	uri := router.GetRoute("POST", api.postTakeWorkflowJobHandler, map[string]string{
+		"id": fmt.Sprintf("%d", ctx.job.ID),
+	})
+	req := assets.NewJWTAuthentifiedRequest(t, ctx.workerToken, "POST", uri, nil)
+	rec := httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	// The job has a timeout of 10 minutes and started 5 minutes ago
+	job, err := workflow.LoadNodeJobRun(context.TODO(), db, api.Cache, ctx.job.ID)
+	require.NoError(t, err)
+	require.Equal(t, sdk.StatusBuilding, job.Status)
+	job.Job.Timeout = 600
+	job.Start = time.Now().Add(-5 * time.Minute)
+	job.Job.StepStatus = []sdk.StepStatus{{StepOrder: 0, Status: sdk.StatusBuilding, Start: job.Start}}
+	require.NoError(t, workflow.UpdateNodeJobRun(context.TODO(), db, job))
+
+	// The job is not stopped before its timeout
+	require.NoError(t, api.stopTimedOutJobs(context.TODO(), time.Now()))
+	job, err = workflow.LoadNodeJobRun(context.TODO(), db, api.Cache, ctx.job.ID)
+	require.NoError(t, err)
+	require.Equal(t, sdk.StatusBuilding, job.Status)
+
+	// The job is stopped after its timeout
+	require.NoError(t, api.stopTimedOutJobs(context.TODO(), time.Now().Add(10*time.Minute)))
+
+	nodeRun, err := workflow.LoadNodeRunByID(db, job.WorkflowNodeRunID, workflow.LoadRunOptions{})
+	require.NoError(t, err)
+	require.Equal(t, sdk.StatusFail, nodeRun.Status)
+	require.Len(t, nodeRun.Stages, 1)
+	require.Len(t, nodeRun.Stages[0].RunJobs, 1)
+	runJob := nodeRun.Stages[0].RunJobs[0]
+	require.Equal(t, sdk.StatusTimeout, runJob.Status)
+	require.Len(t, runJob.Job.StepStatus, 1)
+	require.Equal(t, sdk.StatusTimeout, runJob.Job.StepStatus[0].Status)
+	require.Equal(t, "job exceeded its timeout of 10m0s", runJob.Job.Reason)
+
+	var hasTimeoutInfo bool
+	for _, info := range runJob.SpawnInfos {
+		if info.Message.ID == sdk.MsgSpawnInfoJobTimeout.ID {
+			hasTimeoutInfo = true
+		}
+	}
+	require.True(t, hasTimeoutInfo)
+}
@@ -0,0 +1,302 @@
+package workflowtemplate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// Templates are composed with directives written in their files. [[ import "group/slug@version" ]] makes the blocks
+// defined in the files of another template available. [[ extends "group/slug@version" ]] in the workflow file generates
+// the files of a parent template, the blocks defined in the workflow file override the blocks of the parent.
+// The version is optional, the latest version of the template is used if not given.
+const (
+	templateDirectiveImport  = "import"
+	templateDirectiveExtends = "extends"
+)
+
+var templateDirectiveRegexp = regexp.MustCompile(`\[\[-?\s*(import|extends)\s+"([^"]*)"\s*-?\]\]`)
+
+// directives are template actions that should render nothing.
+var templateDirectiveFuncs = template.FuncMap{
+	templateDirectiveImport:  func(string) string { return "" },
+	templateDirectiveExtends: func(string) string { return "" },
+}
+
+type templateDirective struct {
+	Kind string
+	Path string
+	Line int
+}
+
+// templateSource is the decoded content of a file of a template.
+type templateSource struct {
+	Type   string
+	Number int
+	Value  string
+}
+
+func (s templateSource) id() string {
+	if s.Type == "workflow" {
+		return s.Type
+	}
+	return fmt.Sprintf("%s.%d", s.Type, s.Number)
+}
+
+func (s templateSource) error(line int, format string, args ...interface{}) error {
+	return sdk.WithStack(sdk.WorkflowTemplateError{
+		Type:    s.Type,
+		Number:  s.Number,
+		Line:    line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (s templateSource) directives() []templateDirective {
+	var ds []templateDirective
+	for i, line := range strings.Split(s.Value, "\n") {
+		for _, m := range templateDirectiveRegexp.FindAllStringSubmatch(line, -1) {
+			ds = append(ds, templateDirective{Kind: m[1], Path: m[2], Line: i + 1})
+		}
+	}
+	return ds
+}
+
+func templateSources(wt sdk.WorkflowTemplate) ([]templateSource, error) {
+	v, err := decodeTemplateValue(wt.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	srcs := []templateSource{{Type: "workflow", Value: v}}
+
+	for i, p := range wt.Pipelines {
+		v, err := decodeTemplateValue(p.Value)
+		if err != nil {
+			return nil, err
+		}
+		srcs = append(srcs, templateSource{Type: "pipeline", Number: i, Value: v})
+	}
+
+	for i, a := range wt.Applications {
+		v, err := decodeTemplateValue(a.Value)
+		if err != nil {
+			return nil, err
+		}
+		srcs = append(srcs, templateSource{Type: "application", Number: i, Value: v})
+	}
+
+	for i, e := range wt.Environments {
+		v, err := decodeTemplateValue(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		srcs = append(srcs, templateSource{Type: "environment", Number: i, Value: v})
+	}
+
+	return srcs, nil
+}
+
+// templateLoaderFunc returns a template for given group name, slug and version, 0 for the latest version.
+type templateLoaderFunc func(groupName, templateSlug string, version int64) (*sdk.WorkflowTemplate, error)
+
+// LoadDependencies loads the templates imported or extended by given template, recursively.
+// A template can only depend on templates of its group or of the shared.infra group.
+func LoadDependencies(ctx context.Context, db gorp.SqlExecutor, wt *sdk.WorkflowTemplate) error {
+	return loadDependencies(wt, nil, func(groupName, templateSlug string, version int64) (*sdk.WorkflowTemplate, error) {
+		grp, err := group.LoadByName(ctx, db, groupName)
+		if err != nil {
+			return nil, err
+		}
+		dep, err := LoadBySlugAndGroupID(ctx, db, templateSlug, grp.ID, LoadOptions.Default)
+		if err != nil {
+			return nil, err
+		}
+		return LoadVersion(ctx, db, *dep, version)
+	})
+}
+
+func loadDependencies(wt *sdk.WorkflowTemplate, stack []string, load templateLoaderFunc) error {
+	if wt.Group == nil {
+		return sdk.WithStack(fmt.Errorf("missing group for template %s", wt.Slug))
+	}
+	stack = append(append([]string{}, stack...), wt.Path())
+
+	srcs, err := templateSources(*wt)
+	if err != nil {
+		return err
+	}
+
+	var multiErr sdk.MultiError
+	wt.Dependencies = make(map[string]sdk.WorkflowTemplate)
+	for _, s := range srcs {
+		for _, d := range s.directives() {
+			if _, ok := wt.Dependencies[d.Path]; ok {
+				continue
+			}
+
+			groupName, templateSlug, version, err := exportentities.TemplateInstance{From: d.Path}.ParseFrom()
+			if err != nil {
+				multiErr.Append(s.error(d.Line, "invalid template path %q", d.Path))
+				continue
+			}
+			if groupName != wt.Group.Name && groupName != sdk.SharedInfraGroupName {
+				multiErr.Append(s.error(d.Line, "template %s should be in group %s or %s", d.Path, wt.Group.Name, sdk.SharedInfraGroupName))
+				continue
+			}
+			path := fmt.Sprintf("%s/%s", groupName, templateSlug)
+			if sdk.IsInArray(path, stack) {
+				multiErr.Append(s.error(d.Line, "cyclic %s of template %s: %s", d.Kind, d.Path, strings.Join(append(stack, path), " -> ")))
+				continue
+			}
+
+			dep, err := load(groupName, templateSlug, version)
+			if err != nil {
+				if sdk.ErrorIs(err, sdk.ErrNotFound) {
+					multiErr.Append(s.error(d.Line, "could not find template %s", d.Path))
+					continue
+				}
+				return err
+			}
+
+			// errors in the dependency are reported on the directive
+			err = loadDependencies(dep, stack, load)
+			if err == nil {
+				_, err = Parse(*dep)
+			}
+			if err != nil {
+				if !sdk.ErrorIs(err, sdk.ErrCannotParseTemplate) {
+					return err
+				}
+				multiErr.Append(s.error(d.Line, "invalid template %s: %s", d.Path, templateParseErrorMessage(err)))
+				continue
+			}
+
+			wt.Dependencies[d.Path] = *dep
+		}
+	}
+
+	if !multiErr.IsEmpty() {
+		return newTemplateParseError(multiErr)
+	}
+	return nil
+}
+
+// templateUnit is a file generated by a template, with the sources of the blocks that it can use.
+type templateUnit struct {
+	source templateSource
+	// blocks of imports are overridden by the blocks of the source
+	imports []templateSource
+	// blocks of overrides override the blocks of the source
+	overrides []templateSource
+}
+
+func (u templateUnit) parse() (*template.Template, error) {
+	tmpl := newTemplate(u.source.id())
+	for i, s := range u.imports {
+		if _, err := tmpl.New(fmt.Sprintf("import.%d", i)).Parse(s.Value); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+	}
+	if _, err := tmpl.Parse(u.source.Value); err != nil {
+		return nil, parseTemplateError(u.source, err)
+	}
+	for i, s := range u.overrides {
+		if _, err := tmpl.New(fmt.Sprintf("override.%d", i)).Parse(s.Value); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+	}
+	return tmpl, nil
+}
+
+// templateUnits returns the files generated by given template. If the template extends a parent, the files of the parent
+// are generated first then the pipelines, applications and environments of the template.
+func templateUnits(wt sdk.WorkflowTemplate) ([]templateUnit, error) {
+	srcs, err := templateSources(wt)
+	if err != nil {
+		return nil, err
+	}
+
+	var multiErr sdk.MultiError
+	var imports []templateSource
+	var parent *sdk.WorkflowTemplate
+	imported := make(map[string]struct{})
+	for _, s := range srcs {
+		for _, d := range s.directives() {
+			dep, ok := wt.Dependencies[d.Path]
+			if !ok {
+				multiErr.Append(s.error(d.Line, "unknown template %s", d.Path))
+				continue
+			}
+			switch d.Kind {
+			case templateDirectiveExtends:
+				if s.Type != "workflow" {
+					multiErr.Append(s.error(d.Line, "%s is only allowed in the workflow", d.Kind))
+					continue
+				}
+				if parent != nil {
+					multiErr.Append(s.error(d.Line, "a template can only extend one template"))
+					continue
+				}
+				parent = &dep
+			case templateDirectiveImport:
+				if _, ok := imported[d.Path]; ok {
+					continue
+				}
+				imported[d.Path] = struct{}{}
+				depSrcs, err := templateDefinitionSources(dep)
+				if err != nil {
+					return nil, err
+				}
+				imports = append(imports, depSrcs...)
+			}
+		}
+	}
+	if !multiErr.IsEmpty() {
+		return nil, newTemplateParseError(multiErr)
+	}
+
+	var units []templateUnit
+	if parent == nil {
+		for _, s := range srcs {
+			units = append(units, templateUnit{source: s, imports: imports})
+		}
+		return units, nil
+	}
+
+	parentUnits, err := templateUnits(*parent)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range parentUnits {
+		u.imports = append(append([]templateSource{}, u.imports...), imports...)
+		u.overrides = append(append([]templateSource{}, u.overrides...), srcs[0])
+		units = append(units, u)
+	}
+	for _, s := range srcs[1:] {
+		units = append(units, templateUnit{source: s, imports: imports})
+	}
+	return units, nil
+}
+
+// templateDefinitionSources returns the sources of all the blocks that can be used in the files of given template.
+func templateDefinitionSources(wt sdk.WorkflowTemplate) ([]templateSource, error) {
+	units, err := templateUnits(wt)
+	if err != nil {
+		return nil, err
+	}
+	var imports, srcs, overrides []templateSource
+	for _, u := range units {
+		imports = append(imports, u.imports...)
+		srcs = append(srcs, u.source)
+		overrides = append(overrides, u.overrides...)
+	}
+	return append(append(imports, srcs...), overrides...), nil
+}
@@ -0,0 +1,81 @@
+package workflowtemplate_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestLoadDependencies(t *testing.T) {
+	db, _ := test.SetupPG(t, bootstrap.InitiliazeDB)
+
+	grp1 := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	grp2 := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	defer func() {
+		assets.DeleteTestGroup(t, db, grp1)
+		assets.DeleteTestGroup(t, db, grp2)
+	}()
+
+	partials := sdk.WorkflowTemplate{
+		GroupID:  grp1.ID,
+		Slug:     "partials",
+		Name:     "partials",
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`[[ define "build" ]]make build[[ end ]]`)),
+		Version:  1,
+	}
+	require.NoError(t, workflowtemplate.Insert(db, &partials))
+	other := sdk.WorkflowTemplate{
+		GroupID:  grp2.ID,
+		Slug:     "other",
+		Name:     "other",
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`name: [[.name]]`)),
+		Version:  1,
+	}
+	require.NoError(t, workflowtemplate.Insert(db, &other))
+
+	tmpl := sdk.WorkflowTemplate{
+		GroupID: grp1.ID,
+		Group:   grp1,
+		Slug:    "my-template",
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`name: [[.name]]
+[[ import "` + grp1.Name + `/partials" ]]`)),
+	}
+	require.NoError(t, workflowtemplate.LoadDependencies(context.TODO(), db, &tmpl))
+	require.Len(t, tmpl.Dependencies, 1)
+	assert.Equal(t, partials.ID, tmpl.Dependencies[grp1.Name+"/partials"].ID)
+
+	// templates of other groups can't be imported
+	tmpl.Workflow = base64.StdEncoding.EncodeToString([]byte(`[[ import "` + grp2.Name + `/other" ]]`))
+	err := workflowtemplate.LoadDependencies(context.TODO(), db, &tmpl)
+	require.Error(t, err)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrCannotParseTemplate))
+
+	// cyclic dependencies are reported on the directive of the template
+	partials.Workflow = base64.StdEncoding.EncodeToString([]byte(`
+[[ extends "` + grp1.Name + `/my-template" ]]`))
+	require.NoError(t, workflowtemplate.Update(db, &partials))
+	tmpl.Slug = "my-template"
+	tmpl.Name = "my-template"
+	tmpl.Workflow = base64.StdEncoding.EncodeToString([]byte(`[[ import "` + grp1.Name + `/partials" ]]`))
+	tmpl.Version = 1
+	require.NoError(t, workflowtemplate.Insert(db, &tmpl))
+
+	err = workflowtemplate.LoadDependencies(context.TODO(), db, &tmpl)
+	require.Error(t, err)
+	e := sdk.ExtractHTTPError(err, "")
+	errs, ok := e.Data.([]sdk.WorkflowTemplateError)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "workflow", errs[0].Type)
+	assert.Equal(t, 1, errs[0].Line)
+	assert.Contains(t, errs[0].Message, "cyclic extends of template "+grp1.Name+"/my-template")
+}
@@ -36,6 +36,15 @@ func prepareParams(wt sdk.WorkflowTemplate, r sdk.WorkflowTemplateRequest) inter
 				// safely ignore the error because the value of v has been validated on apply submit
 				_ = json.Unmarshal([]byte(v), &res)
 				m[p.Key] = res
+			case sdk.ParameterTypeMultiSelect:
+				// safely ignore the error because the value of v has been validated on apply submit
+				m[p.Key], _ = sdk.ParseTemplateMultiSelectValue(v)
+			case sdk.ParameterTypeNumber:
+				if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+					m[p.Key] = i
+				} else {
+					m[p.Key], _ = strconv.ParseFloat(v, 64)
+				}
 			default:
 				m[p.Key] = v
 			}
@@ -44,34 +53,50 @@ func prepareParams(wt sdk.WorkflowTemplate, r sdk.WorkflowTemplateRequest) inter
 	return m
 }
 
-func parseTemplate(templateType string, number int, t string) (*template.Template, error) {
-	var id string
-	switch templateType {
-	case "workflow":
-		id = templateType
-	default:
-		id = fmt.Sprintf("%s.%d", templateType, number)
-	}
+func newTemplate(id string) *template.Template {
+	return template.New(id).Delims("[[", "]]").Funcs(interpolate.InterpolateHelperFuncs).Funcs(templateDirectiveFuncs)
+}
 
-	tmpl, err := template.New(id).Delims("[[", "]]").Funcs(interpolate.InterpolateHelperFuncs).Parse(t)
+func parseTemplateError(s templateSource, err error) error {
+	reg := regexp.MustCompile(`template: ([0-9a-zA-Z.]+):([0-9]+): (.*)$`)
+	submatch := reg.FindStringSubmatch(err.Error())
+	if len(submatch) != 4 {
+		return sdk.WithStack(err)
+	}
+	line, err := strconv.Atoi(submatch[2])
 	if err != nil {
-		reg := regexp.MustCompile(`template: ([0-9a-zA-Z.]+):([0-9]+): (.*)$`)
-		submatch := reg.FindStringSubmatch(err.Error())
-		if len(submatch) != 4 {
-			return nil, sdk.WithStack(err)
-		}
-		line, err := strconv.Atoi(submatch[2])
-		if err != nil {
-			return nil, sdk.WithStack(err)
-		}
-		return nil, sdk.WithStack(sdk.WorkflowTemplateError{
-			Type:    templateType,
-			Number:  number,
-			Line:    line,
-			Message: submatch[3],
-		})
-	}
-	return tmpl, nil
+		return sdk.WithStack(err)
+	}
+	return s.error(line, "%s", submatch[3])
+}
+
+func newTemplateParseError(multiErr sdk.MultiError) error {
+	var errs []sdk.WorkflowTemplateError
+	causes := make([]string, len(multiErr))
+	for i, err := range multiErr {
+		cause := sdk.Cause(err)
+		if e, ok := cause.(sdk.WorkflowTemplateError); ok {
+			errs = append(errs, e)
+		}
+		causes[i] = cause.Error()
+	}
+	return sdk.NewErrorFrom(sdk.Error{
+		ID:     sdk.ErrCannotParseTemplate.ID,
+		Status: sdk.ErrCannotParseTemplate.Status,
+		Data:   errs,
+	}, strings.Join(causes, ", "))
+}
+
+func templateParseErrorMessage(err error) string {
+	es, ok := sdk.ExtractHTTPError(err, "").Data.([]sdk.WorkflowTemplateError)
+	if !ok || len(es) == 0 {
+		return fmt.Sprintf("%s", sdk.Cause(err))
+	}
+	ss := make([]string, len(es))
+	for i := range es {
+		ss[i] = es[i].Error()
+	}
+	return strings.Join(ss, ", ")
 }
 
 func executeTemplate(tmpl *template.Template, data map[string]interface{}) (string, error) {
@@ -93,73 +118,35 @@ func decodeTemplateValue(value string) (string, error) {
 	return string(v), nil
 }
 
-// Parse return a template with parsed content.
+// Parse return a template with parsed content, the dependencies of the template should be loaded.
 func Parse(wt sdk.WorkflowTemplate) (sdk.WorkflowTemplateParsed, error) {
-	result := sdk.WorkflowTemplateParsed{
-		Pipelines:    make([]*template.Template, len(wt.Pipelines)),
-		Applications: make([]*template.Template, len(wt.Applications)),
-		Environments: make([]*template.Template, len(wt.Environments)),
-	}
+	var result sdk.WorkflowTemplateParsed
 
-	var multiErr sdk.MultiError
-
-	v, err := decodeTemplateValue(wt.Workflow)
+	units, err := templateUnits(wt)
 	if err != nil {
 		return result, err
 	}
-	result.Workflow, err = parseTemplate("workflow", 0, v)
-	if err != nil {
-		multiErr.Append(err)
-	}
 
-	for i, p := range wt.Pipelines {
-		v, err := decodeTemplateValue(p.Value)
-		if err != nil {
-			return result, err
-		}
-		result.Pipelines[i], err = parseTemplate("pipeline", i, v)
-		if err != nil {
-			multiErr.Append(err)
-		}
-	}
-
-	for i, a := range wt.Applications {
-		v, err := decodeTemplateValue(a.Value)
-		if err != nil {
-			return result, err
-		}
-		result.Applications[i], err = parseTemplate("application", i, v)
+	var multiErr sdk.MultiError
+	for _, u := range units {
+		tmpl, err := u.parse()
 		if err != nil {
 			multiErr.Append(err)
 		}
-	}
-
-	for i, e := range wt.Environments {
-		v, err := decodeTemplateValue(e.Value)
-		if err != nil {
-			return result, err
-		}
-		result.Environments[i], err = parseTemplate("environment", i, v)
-		if err != nil {
-			multiErr.Append(err)
+		switch u.source.Type {
+		case "workflow":
+			result.Workflow = tmpl
+		case "pipeline":
+			result.Pipelines = append(result.Pipelines, tmpl)
+		case "application":
+			result.Applications = append(result.Applications, tmpl)
+		case "environment":
+			result.Environments = append(result.Environments, tmpl)
 		}
 	}
 
 	if !multiErr.IsEmpty() {
-		var errs []sdk.WorkflowTemplateError
-		causes := make([]string, len(multiErr))
-		for i, err := range multiErr {
-			cause := sdk.Cause(err)
-			if e, ok := cause.(sdk.WorkflowTemplateError); ok {
-				errs = append(errs, e)
-			}
-			causes[i] = cause.Error()
-		}
-		return result, sdk.NewErrorFrom(sdk.Error{
-			ID:     sdk.ErrCannotParseTemplate.ID,
-			Status: sdk.ErrCannotParseTemplate.Status,
-			Data:   errs,
-		}, strings.Join(causes, ", "))
+		return result, newTemplateParseError(multiErr)
 	}
 
 	return result, nil
@@ -167,23 +154,23 @@ func Parse(wt sdk.WorkflowTemplate) (sdk.WorkflowTemplateParsed, error) {
 
 // Execute returns yaml file from template.
 func Execute(wt sdk.WorkflowTemplate, instance sdk.WorkflowTemplateInstance) (exportentities.WorkflowComponents, error) {
-	result := exportentities.WorkflowComponents{
-		Pipelines:    make([]exportentities.PipelineV1, len(wt.Pipelines)),
-		Applications: make([]exportentities.Application, len(wt.Applications)),
-		Environments: make([]exportentities.Environment, len(wt.Environments)),
+	var result exportentities.WorkflowComponents
+
+	parsedTemplate, err := Parse(wt)
+	if err != nil {
+		return result, err
 	}
 
+	result.Pipelines = make([]exportentities.PipelineV1, len(parsedTemplate.Pipelines))
+	result.Applications = make([]exportentities.Application, len(parsedTemplate.Applications))
+	result.Environments = make([]exportentities.Environment, len(parsedTemplate.Environments))
+
 	data := map[string]interface{}{
 		"id":     instance.ID,
 		"name":   instance.Request.WorkflowName,
 		"params": prepareParams(wt, instance.Request),
 	}
 
-	parsedTemplate, err := Parse(wt)
-	if err != nil {
-		return result, err
-	}
-
 	workflowYaml, err := executeTemplate(parsedTemplate.Workflow, data)
 	if err != nil {
 		return result, err
@@ -198,3 +198,126 @@ name: Environment-[[if .id]]`)),
 	}}
 	assert.Equal(t, errs, e.Data)
 }
+
+func TestExecuteTemplateWithComposition(t *testing.T) {
+	partials := sdk.WorkflowTemplate{
+		Slug:  "partials",
+		Group: &sdk.Group{Name: sdk.SharedInfraGroupName},
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`
+[[- define "build" -]]
+- job: Build
+  stage: Build
+  steps:
+  - script:
+    - make build
+[[- end -]]`)),
+	}
+
+	base := sdk.WorkflowTemplate{
+		Slug:  "base",
+		Group: &sdk.Group{Name: "my-group"},
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`
+name: [[.name]]
+version: v2.0
+workflow:
+  root:
+    pipeline: [[.name]]-build`)),
+		Pipelines: []sdk.PipelineTemplate{{
+			Value: base64.StdEncoding.EncodeToString([]byte(`[[ import "shared.infra/partials" ]]
+version: v1.0
+name: [[.name]]-build
+stages:
+- Build
+- Test
+jobs:
+[[ template "build" . ]]
+[[ block "tests" . -]]
+- job: Test
+  stage: Test
+  steps:
+  - script:
+    - make test
+[[- end ]]`)),
+		}},
+		Dependencies: map[string]sdk.WorkflowTemplate{
+			"shared.infra/partials": partials,
+		},
+	}
+
+	tmpl := sdk.WorkflowTemplate{
+		Slug: "child",
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`[[ extends "my-group/base@2" ]]
+[[- define "tests" -]]
+- job: Integration
+  stage: Test
+  steps:
+  - script:
+    - make integration
+[[- end -]]`)),
+		Environments: []sdk.EnvironmentTemplate{{
+			Value: base64.StdEncoding.EncodeToString([]byte(`name: [[.name]]-env`)),
+		}},
+		Dependencies: map[string]sdk.WorkflowTemplate{
+			"my-group/base@2": base,
+		},
+	}
+
+	res, err := workflowtemplate.Execute(tmpl, sdk.WorkflowTemplateInstance{
+		Request: sdk.WorkflowTemplateRequest{WorkflowName: "my-workflow"},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, res.Workflow)
+	assert.Equal(t, "my-workflow", res.Workflow.GetName())
+	require.Len(t, res.Pipelines, 1)
+	require.Len(t, res.Environments, 1)
+	assert.Equal(t, "my-workflow-env", res.Environments[0].Name)
+
+	buf, err := yaml.Marshal(res.Pipelines[0])
+	require.NoError(t, err)
+	assert.Equal(t, `version: v1.0
+name: my-workflow-build
+stages:
+- Build
+- Test
+jobs:
+- job: Build
+  stage: Build
+  steps:
+  - script:
+    - make build
+- job: Integration
+  stage: Test
+  steps:
+  - script:
+    - make integration
+`, string(buf))
+}
+
+func TestParseTemplateWithInvalidDirectives(t *testing.T) {
+	tmpl := sdk.WorkflowTemplate{
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`
+name: [[.name]]
+[[ import "shared.infra/unknown" ]]`)),
+		Pipelines: []sdk.PipelineTemplate{{
+			Value: base64.StdEncoding.EncodeToString([]byte(`[[ extends "shared.infra/base" ]]`)),
+		}},
+		Dependencies: map[string]sdk.WorkflowTemplate{
+			"shared.infra/base": {},
+		},
+	}
+
+	_, err := workflowtemplate.Parse(tmpl)
+	require.Error(t, err)
+	e := sdk.ExtractHTTPError(err, "")
+	assert.Equal(t, sdk.ErrCannotParseTemplate.ID, e.ID)
+	assert.Equal(t, []sdk.WorkflowTemplateError{{
+		Type:    "workflow",
+		Line:    3,
+		Message: "unknown template shared.infra/unknown",
+	}, {
+		Type:    "pipeline",
+		Line:    1,
+		Message: "extends is only allowed in the workflow",
+	}}, e.Data)
+}
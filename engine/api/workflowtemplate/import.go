@@ -38,6 +38,9 @@ func Push(ctx context.Context, db gorp.SqlExecutor, wt *sdk.WorkflowTemplate, u
 	clone.Update(*wt)
 
 	// execute template with no instance only to check if parsing is ok
+	if err := LoadDependencies(ctx, db, &clone); err != nil {
+		return nil, err
+	}
 	if _, err := Parse(clone); err != nil {
 		return nil, err
 	}
@@ -9,7 +9,10 @@ import (
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/api/environment"
 	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/keys"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
@@ -23,10 +26,12 @@ var TemplateRequestModifiers = struct {
 	Detached                   TemplateRequestModifierFunc
 	DefaultKeys                func(proj sdk.Project) TemplateRequestModifierFunc
 	DefaultNameAndRepositories func(proj sdk.Project, repoURL string) TemplateRequestModifierFunc
+	Secrets                    func(proj sdk.Project, encrypt keys.EncryptFunc, decrypt keys.DecryptFunc) TemplateRequestModifierFunc
 }{
 	Detached:                   requestModifyDetached,
 	DefaultKeys:                requestModifyDefaultKeysfunc,
 	DefaultNameAndRepositories: requestModifyDefaultNameAndRepositories,
+	Secrets:                    requestModifySecrets,
 }
 
 func requestModifyDetached(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, wt sdk.WorkflowTemplate, req *sdk.WorkflowTemplateRequest) error {
@@ -111,6 +116,70 @@ func requestModifyDefaultNameAndRepositories(proj sdk.Project, repoURL string) T
 	}
 }
 
+// requestModifySecrets replaces the values of secret parameters by encrypted data tokens of the project, so secrets are
+// never stored in template instances. Values that are already tokens are kept, if no encrypt func is given the values
+// of secret parameters should be tokens.
+func requestModifySecrets(proj sdk.Project, encrypt keys.EncryptFunc, decrypt keys.DecryptFunc) TemplateRequestModifierFunc {
+	return func(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, wt sdk.WorkflowTemplate, req *sdk.WorkflowTemplateRequest) error {
+		for _, p := range wt.Parameters {
+			v, ok := req.Parameters[p.Key]
+			if p.Type != sdk.ParameterTypeSecret || !ok || v == "" {
+				continue
+			}
+			if _, err := decrypt(db, proj.ID, v); err == nil {
+				continue
+			}
+			if encrypt == nil {
+				return sdk.NewErrorFrom(sdk.ErrInvalidData, "Value of secret param %s should be encrypted", p.Key)
+			}
+			token, err := encrypt(db, proj.ID, fmt.Sprintf("template:%s:%s", req.WorkflowName, p.Key), v)
+			if err != nil {
+				return err
+			}
+			req.Parameters[p.Key] = token
+		}
+		return nil
+	}
+}
+
+// checkProjectParams checks that the values of environment and integration parameters exist in the project,
+// environments generated by the template are allowed.
+func checkProjectParams(db gorp.SqlExecutor, p sdk.Project, wt sdk.WorkflowTemplate, req sdk.WorkflowTemplateRequest, result exportentities.WorkflowComponents) error {
+	for _, param := range wt.Parameters {
+		v := req.Parameters[param.Key]
+		if v == "" {
+			continue
+		}
+		switch param.Type {
+		case sdk.ParameterTypeEnvironment:
+			var generated bool
+			for _, e := range result.Environments {
+				if e.Name == v {
+					generated = true
+					break
+				}
+			}
+			if generated {
+				continue
+			}
+			if _, err := environment.LoadEnvironmentByName(db, p.Key, v); err != nil {
+				if sdk.ErrorIs(err, sdk.ErrEnvironmentNotFound) {
+					return sdk.NewErrorFrom(sdk.ErrInvalidData, "Given environment %s for %s doesn't exist in project %s", v, param.Key, p.Key)
+				}
+				return err
+			}
+		case sdk.ParameterTypeIntegration:
+			if _, err := integration.LoadProjectIntegrationByName(db, p.Key, v); err != nil {
+				if sdk.ErrorIs(err, sdk.ErrNotFound) {
+					return sdk.NewErrorFrom(sdk.ErrInvalidData, "Given integration %s for %s doesn't exist in project %s", v, param.Key, p.Key)
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // CheckAndExecuteTemplate will execute the workflow template if given workflow components contains a template instance.
 // When detached is set this will not create/update any template instance in database (this is useful for workflow ascode branches).
 func CheckAndExecuteTemplate(ctx context.Context, db *gorp.DbMap, store cache.Store, consumer sdk.AuthConsumer, p sdk.Project,
@@ -170,6 +239,9 @@ func CheckAndExecuteTemplate(ctx context.Context, db *gorp.DbMap, store cache.St
 	if err := LoadOptions.Default(ctx, tx, wt); err != nil {
 		return allMsgs, nil, err
 	}
+	if err := LoadDependencies(ctx, tx, wt); err != nil {
+		return allMsgs, nil, err
+	}
 	allMsgs = append(allMsgs, sdk.NewMessage(sdk.MsgWorkflowGeneratedFromTemplateVersion, wt.PathWithVersion()))
 
 	req := sdk.WorkflowTemplateRequest{
@@ -203,6 +275,9 @@ func CheckAndExecuteTemplate(ctx context.Context, db *gorp.DbMap, store cache.St
 		if err != nil {
 			return allMsgs, nil, err
 		}
+		if err := checkProjectParams(tx, p, *wt, req, result); err != nil {
+			return allMsgs, nil, err
+		}
 
 		// do not return an instance if detached
 		*data = result
@@ -218,6 +293,9 @@ func CheckAndExecuteTemplate(ctx context.Context, db *gorp.DbMap, store cache.St
 	// if a previous instance exist for the same workflow update it, else create a new one
 	var old *sdk.WorkflowTemplateInstance
 	if wti != nil {
+		if err := wti.CheckPinnedVersion(wt.Version); err != nil {
+			return allMsgs, nil, err
+		}
 		clone := sdk.WorkflowTemplateInstance(*wti)
 		old = &clone
 		wti.WorkflowTemplateVersion = wt.Version
@@ -243,6 +321,9 @@ func CheckAndExecuteTemplate(ctx context.Context, db *gorp.DbMap, store cache.St
 	if err != nil {
 		return allMsgs, nil, err
 	}
+	if err := checkProjectParams(tx, p, *wt, req, result); err != nil {
+		return allMsgs, nil, err
+	}
 
 	// parse the generated workflow to find its name an update it in instance if not detached
 	// also set the template path in generated workflow if not detached
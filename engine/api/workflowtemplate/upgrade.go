@@ -0,0 +1,76 @@
+package workflowtemplate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// LoadVersion returns the template at given version, previous versions are loaded from template audits.
+// The current template is returned if given version is 0.
+func LoadVersion(ctx context.Context, db gorp.SqlExecutor, wt sdk.WorkflowTemplate, version int64) (*sdk.WorkflowTemplate, error) {
+	if version == 0 || version == wt.Version {
+		return &wt, nil
+	}
+	if version < 0 || version > wt.Version {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "invalid version %d for template %s, latest version is %d", version, wt.Slug, wt.Version)
+	}
+	wta, err := LoadAuditByTemplateIDAndVersion(ctx, db, wt.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	res := wta.DataAfter
+	// aggregates are not stored in audits
+	res.Group = wt.Group
+	return &res, nil
+}
+
+// ComputeInstanceUpgrade returns the changes on the workflow generated by given instance if it's upgraded to target
+// template version. The error of the upgrade is set if the instance is pinned to another version or if the template
+// can't be executed. The dependencies of the target template should be loaded.
+func ComputeInstanceUpgrade(ctx context.Context, db gorp.SqlExecutor, wt, target sdk.WorkflowTemplate, wti sdk.WorkflowTemplateInstance) sdk.WorkflowTemplateInstanceUpgrade {
+	res := sdk.WorkflowTemplateInstanceUpgrade{
+		InstanceID:    wti.ID,
+		ProjectKey:    wti.Request.ProjectKey,
+		WorkflowName:  wti.Request.WorkflowName,
+		FromVersion:   wti.WorkflowTemplateVersion,
+		ToVersion:     target.Version,
+		PinnedVersion: wti.PinnedVersion,
+		Request:       wti.Request,
+	}
+	if wti.Project != nil {
+		res.ProjectKey = wti.Project.Key
+	}
+	if err := wti.CheckPinnedVersion(target.Version); err != nil {
+		res.Error = fmt.Sprintf("%s", sdk.Cause(err))
+		return res
+	}
+
+	current, err := LoadVersion(ctx, db, wt, wti.WorkflowTemplateVersion)
+	if err == nil {
+		err = LoadDependencies(ctx, db, current)
+	}
+	if err != nil {
+		res.Error = fmt.Sprintf("%s", sdk.Cause(err))
+		return res
+	}
+	from, err := Execute(*current, wti)
+	if err != nil {
+		res.Error = fmt.Sprintf("cannot execute template version %d: %s", current.Version, sdk.Cause(err))
+		return res
+	}
+	to, err := Execute(target, wti)
+	if err != nil {
+		res.Error = fmt.Sprintf("cannot execute template version %d: %s", target.Version, sdk.Cause(err))
+		return res
+	}
+	res.Diff, err = exportentities.DiffWorkflowComponents(from, to)
+	if err != nil {
+		res.Error = fmt.Sprintf("%s", sdk.Cause(err))
+	}
+	return res
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/ovh/cds/engine/cdn/item"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/jws"
+	"github.com/ovh/cds/sdk/log"
 	"github.com/ovh/cds/sdk/telemetry"
 )
 
@@ -18,6 +20,12 @@ var (
 	keyPermission = cache.Key("cdn", "permission")
 )
 
+type contextKey int
+
+const (
+	contextWorkerSignature contextKey = iota
+)
+
 func (s *Service) jwtMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
 	ctx, end := telemetry.Span(ctx, "router.jwtMiddleware")
 	defer end()
@@ -62,6 +70,44 @@ func (s *Service) itemAccessMiddleware(ctx context.Context, w http.ResponseWrite
 	return ctx, s.itemAccessCheck(ctx, *item)
 }
 
+// workerSignatureMiddleware checks the signature sent by a worker, it is the same signature that the one used for step logs.
+func (s *Service) workerSignatureMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
+	ctx, end := telemetry.Span(ctx, "router.workerSignatureMiddleware")
+	defer end()
+
+	sig := req.Header.Get(sdk.CDNWorkerSignatureHeader)
+	if sig == "" {
+		return ctx, sdk.WithStack(sdk.ErrUnauthorized)
+	}
+
+	// Unsafe parse of signature to get the worker name
+	var signature log.Signature
+	if err := jws.UnsafeParse(sig, &signature); err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+	if signature.Worker == nil {
+		return ctx, sdk.WithStack(sdk.ErrUnauthorized)
+	}
+
+	workerData, err := s.getClearWorker(ctx, signature.Worker.WorkerName)
+	if err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+	if err := jws.Verify(workerData.PrivateKey, sig, &signature); err != nil {
+		return ctx, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+	if workerData.JobRunID == nil || *workerData.JobRunID != signature.JobID || workerData.ID != signature.Worker.WorkerID {
+		return ctx, sdk.WithStack(sdk.ErrForbidden)
+	}
+
+	return context.WithValue(ctx, contextWorkerSignature, &signature), nil
+}
+
+func (s *Service) workerSignature(ctx context.Context) *log.Signature {
+	signature, _ := ctx.Value(contextWorkerSignature).(*log.Signature)
+	return signature
+}
+
 func (s *Service) sessionID(ctx context.Context) string {
 	iSessionID := ctx.Value(service.ContextSessionID)
 	if iSessionID != nil {
@@ -74,12 +120,18 @@ func (s *Service) sessionID(ctx context.Context) string {
 }
 
 func (s *Service) itemAccessCheck(ctx context.Context, item sdk.CDNItem) error {
+	return s.workflowAccessCheck(ctx, item.APIRef.ProjectKey, item.APIRef.WorkflowName, string(item.Type), item.APIRefHash)
+}
+
+// workflowAccessCheck checks that the current session can read the logs of given workflow, the permission
+// is cached for the session with given key parts.
+func (s *Service) workflowAccessCheck(ctx context.Context, projectKey, workflowName string, keyParts ...string) error {
 	sessionID := s.sessionID(ctx)
 	if sessionID == "" {
 		return sdk.WithStack(sdk.ErrUnauthorized)
 	}
 
-	keyWorkflowPermissionForSession := cache.Key(keyPermission, string(item.Type), item.APIRefHash, sessionID)
+	keyWorkflowPermissionForSession := cache.Key(append(append([]string{keyPermission}, keyParts...), sessionID)...)
 
 	exists, err := s.Cache.Exist(keyWorkflowPermissionForSession)
 	if err != nil {
@@ -89,7 +141,7 @@ func (s *Service) itemAccessCheck(ctx context.Context, item sdk.CDNItem) error {
 		return nil
 	}
 
-	if err := s.Client.WorkflowLogAccess(ctx, item.APIRef.ProjectKey, item.APIRef.WorkflowName, sessionID); err != nil {
+	if err := s.Client.WorkflowLogAccess(ctx, projectKey, workflowName, sessionID); err != nil {
 		return sdk.NewErrorWithStack(err, sdk.ErrNotFound)
 	}
 
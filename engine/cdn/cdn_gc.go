@@ -142,7 +142,7 @@ func (s *Service) cleanBuffer(ctx context.Context) error {
 	if cdsBackendID == "" {
 		return nil
 	}
-	itemIDs, err := storage.LoadAllItemsIDInBufferAndAllUnitsExceptCDS(s.mustDBWithCtx(ctx), cdsBackendID)
+	itemIDs, err := s.Units.LoadAllItemsIDInBufferAndAllUnitsExceptCDS(s.mustDBWithCtx(ctx), cdsBackendID)
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"os"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/ovh/cds/engine/cdn/item"
 	"github.com/ovh/cds/engine/cdn/storage"
+	"github.com/ovh/cds/engine/cdn/storage/cds"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -35,8 +37,11 @@ type downloadOpts struct {
 func (s *Service) downloadItem(ctx context.Context, t sdk.CDNItemType, apiRefHash string, w http.ResponseWriter, opts downloadOpts) error {
 	t0 := time.Now()
 
+	if t == sdk.CDNTypeItemArtifact {
+		return s.downloadArtifact(ctx, apiRefHash, w)
+	}
 	if !t.IsLog() {
-		return sdk.NewErrorFrom(sdk.ErrNotImplemented, "only log item can be download for now")
+		return sdk.NewErrorFrom(sdk.ErrNotImplemented, "only log and artifact items can be download for now")
 	}
 
 	it, _, rc, filename, err := s.getItemLogValue(ctx, t, apiRefHash, sdk.CDNReaderFormatText, 0, 0, opts.Log.Sort)
@@ -120,6 +125,8 @@ func (s *Service) pushItemLogIntoCache(ctx context.Context, it sdk.CDNItem) erro
 	if err != nil {
 		return err
 	}
+	itemUnits = s.Units.FilterItemUnitsFromColdUnits(itemUnits)
+
 	// Random pick a unit
 	idx := 0
 	if len(itemUnits) > 1 {
@@ -277,3 +284,189 @@ func (s *Service) completeItem(ctx context.Context, tx gorpmapper.SqlExecutorWit
 
 	return nil
 }
+
+// artifactStorageUnit returns the storage unit that receives uploaded artifacts, other units will get them from the sync process.
+// Units whose rules accept the artifact are selected, non cold units first.
+func (s *Service) artifactStorageUnit(it sdk.CDNItem) (storage.StorageUnit, error) {
+	var coldUnit storage.StorageUnit
+	for _, unit := range s.Units.Storages {
+		if _, isCDS := unit.(*cds.CDS); isCDS {
+			continue
+		}
+		rules := unit.Rules()
+		if !rules.Match(it, time.Now()) {
+			continue
+		}
+		if rules.Cold {
+			if coldUnit == nil {
+				coldUnit = unit
+			}
+			continue
+		}
+		return unit, nil
+	}
+	if coldUnit != nil {
+		return coldUnit, nil
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "no storage unit available to store artifacts")
+}
+
+func (s *Service) storeArtifact(ctx context.Context, apiRef sdk.CDNLogAPIRef, r io.Reader) (*sdk.CDNItem, error) {
+	t0 := time.Now()
+
+	unit, err := s.artifactStorageUnit(sdk.CDNItem{Type: sdk.CDNTypeItemArtifact, APIRef: apiRef})
+	if err != nil {
+		return nil, err
+	}
+
+	apiRefHash, err := apiRef.ToHash()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := item.LoadByAPIRefHashAndType(ctx, s.Mapper, s.mustDBWithCtx(ctx), apiRefHash, sdk.CDNTypeItemArtifact); err == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrConflictData, "artifact %s already exists", apiRef.ArtifactName)
+	} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+
+	// Content hash is needed to compute the convergent locator, so the content is written in a temporary file before being pushed to the unit
+	tmpFile, err := ioutil.TempFile("", "cdn-artifact-")
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer os.Remove(tmpFile.Name()) // nolint
+	defer tmpFile.Close()           // nolint
+
+	md5Hash := md5.New()
+	sha512Hash := sha512.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, md5Hash, sha512Hash), r)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to read artifact %s", apiRef.ArtifactName)
+	}
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	it := &sdk.CDNItem{
+		Type:       sdk.CDNTypeItemArtifact,
+		APIRef:     apiRef,
+		APIRefHash: apiRefHash,
+		Status:     sdk.CDNStatusItemCompleted,
+		Hash:       hex.EncodeToString(sha512Hash.Sum(nil)),
+		MD5:        hex.EncodeToString(md5Hash.Sum(nil)),
+		Size:       size,
+	}
+
+	tx, err := s.mustDBWithCtx(ctx).Begin()
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	if err := item.Insert(ctx, s.Mapper, tx, it); err != nil {
+		return nil, err
+	}
+
+	iu, err := s.Units.NewItemUnit(ctx, unit, it)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.InsertItemUnit(ctx, s.Mapper, tx, iu); err != nil {
+		return nil, err
+	}
+
+	// Deduplication: if the content is already known by the unit, there is no need to write it again
+	otherItemUnits, err := s.Units.GetItemUnitByLocatorByUnit(ctx, iu.Locator, unit.ID())
+	if err != nil {
+		return nil, err
+	}
+	if len(otherItemUnits) == 0 {
+		writer, err := unit.NewWriter(ctx, *iu)
+		if err != nil {
+			return nil, err
+		}
+		if err := unit.Write(*iu, tmpFile, writer); err != nil {
+			_ = writer.Close()
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	log.InfoWithFields(ctx, log.Fields{
+		"item_apiref":               it.APIRefHash,
+		"duration_milliseconds_num": time.Since(t0).Milliseconds(),
+		"item_size_num":             it.Size,
+		"deduplicated":              len(otherItemUnits) > 0,
+	}, "storeArtifact> artifact %s has been stored on %s", it.ID, unit.Name())
+
+	return it, nil
+}
+
+func (s *Service) downloadArtifact(ctx context.Context, apiRefHash string, w http.ResponseWriter) error {
+	t0 := time.Now()
+
+	it, err := item.LoadByAPIRefHashAndType(ctx, s.Mapper, s.mustDBWithCtx(ctx), apiRefHash, sdk.CDNTypeItemArtifact)
+	if err != nil {
+		return err
+	}
+
+	itemUnits, err := storage.LoadAllItemUnitsByItemID(ctx, s.Mapper, s.mustDBWithCtx(ctx), it.ID)
+	if err != nil {
+		return err
+	}
+
+	itemUnits = s.Units.FilterItemUnitsFromColdUnits(itemUnits)
+
+	// Random pick a unit that really stores the content
+	var units []storage.StorageUnit
+	var refItemUnits []sdk.CDNItemUnit
+	for _, iu := range itemUnits {
+		for _, unit := range s.Units.Storages {
+			if _, isCDS := unit.(*cds.CDS); isCDS || unit.ID() != iu.UnitID {
+				continue
+			}
+			units = append(units, unit)
+			refItemUnits = append(refItemUnits, iu)
+		}
+	}
+	if len(units) == 0 {
+		return sdk.WrapError(sdk.ErrNotFound, "no storage found that contains given item %s", apiRefHash)
+	}
+	idx := 0
+	if len(units) > 1 {
+		idx = rnd.Intn(len(units))
+	}
+
+	refItemUnit, err := storage.LoadItemUnitByID(ctx, s.Mapper, s.mustDBWithCtx(ctx), refItemUnits[idx].ID, gorpmapper.GetOptions.WithDecryption)
+	if err != nil {
+		return err
+	}
+
+	reader, err := units[idx].NewReader(ctx, *refItemUnit)
+	if err != nil {
+		return err
+	}
+	defer reader.Close() // nolint
+
+	w.Header().Add("Content-Type", "application/octet-stream")
+	w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", it.APIRef.ToFilename()))
+	w.Header().Add("X-CDS-ARTIFACT-PERM", fmt.Sprintf("%d", it.APIRef.ArtifactPerm))
+
+	if err := units[idx].Read(*refItemUnit, reader, w); err != nil {
+		return err
+	}
+
+	log.InfoWithFields(ctx, log.Fields{
+		"item_apiref":               it.APIRefHash,
+		"duration_milliseconds_num": time.Since(t0).Milliseconds(),
+	}, "downloadArtifact> item %s has been downloaded from %s", it.ID, units[idx].Name())
+
+	return nil
+}
@@ -29,6 +29,12 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/item/{type}/{apiRef}/download", nil, r.GET(s.getItemDownloadHandler, service.OverrideAuth(s.itemAccessMiddleware)))
 	r.Handle("/item/{type}/{apiRef}/lines", nil, r.GET(s.getItemLogsLinesHandler, service.OverrideAuth(s.itemAccessMiddleware)))
 
+	r.Handle("/search/logs", nil, r.GET(s.searchLogsHandler, service.OverrideAuth(s.validJWTMiddleware)))
+
+	r.Handle("/artifact", nil, r.GET(s.getArtifactsHandler, service.OverrideAuth(s.workerSignatureMiddleware)))
+	r.Handle("/artifact/upload", nil, r.POST(s.postUploadArtifactHandler, service.OverrideAuth(s.workerSignatureMiddleware)))
+	r.Handle("/artifact/{apiRef}/download", nil, r.GET(s.getArtifactDownloadHandler, service.OverrideAuth(s.workerSignatureMiddleware)))
+
 	r.Handle("/sync/projects", nil, r.POST(s.syncProjectsHandler))
 
 	r.Handle("/size/item/project/{projectKey}", nil, r.GET(s.getSizeByProjectHandler))
@@ -112,6 +112,12 @@ func (s *Service) syncProjectLogs(ctx context.Context, cdsStorage *cds.CDS, pKey
 		return nil
 	}
 
+	// Skip the project if its logs are excluded from all the storage units
+	if !s.isProjectLogsStored(pKey) {
+		log.Info(ctx, "cdn:cds:sync:log: project %s logs are not stored by any unit, skipping", pKey)
+		return nil
+	}
+
 	statusSync.runPerProjectDone[pKey] = 0
 	statusSync.runPerProjectFailed[pKey] = 0
 	statusSync.runPerProjectTotal[pKey] = 0
@@ -179,6 +185,25 @@ func (s *Service) syncProjectLogs(ctx context.Context, cdsStorage *cds.CDS, pKey
 	return nil
 }
 
+// isProjectLogsStored returns true if at least one storage unit (except cds backend) accepts logs for the given project.
+// If there is no other unit than the cds backend, logs are kept in the buffer.
+func (s *Service) isProjectLogsStored(pKey string) bool {
+	now := time.Now()
+	var hasUnit bool
+	for _, unit := range s.Units.Storages {
+		if _, isCDS := unit.(*cds.CDS); isCDS {
+			continue
+		}
+		hasUnit = true
+		for _, t := range []sdk.CDNItemType{sdk.CDNTypeItemStepLog, sdk.CDNTypeItemServiceLog} {
+			if unit.Rules().Match(sdk.CDNItem{Type: t, APIRef: sdk.CDNLogAPIRef{ProjectKey: pKey}}, now) {
+				return true
+			}
+		}
+	}
+	return !hasUnit
+}
+
 func (s *Service) syncNodeRunJob(ctx context.Context, cdsStorage *cds.CDS, pKey string, jobs <-chan sdk.WorkflowNodeRunIdentifiers, results chan<- error) {
 	for j := range jobs {
 		results <- s.syncNodeRun(ctx, cdsStorage, pKey, j)
@@ -137,6 +137,15 @@ func MarkToDeleteByRunIDs(db gorpmapper.SqlExecutorWithTx, runID int64) error {
 	return sdk.WrapError(err, "unable to mark item to delete for run %d", runID)
 }
 
+// MarkToDeleteByIDs marks the given items to delete, they will be deleted from all the units by the purge.
+func MarkToDeleteByIDs(db gorp.SqlExecutor, ids []string) error {
+	query := `
+		UPDATE item SET to_delete = true WHERE id = ANY($1)
+	`
+	_, err := db.Exec(query, pq.StringArray(ids))
+	return sdk.WrapError(err, "unable to mark items to delete")
+}
+
 // LoadByAPIRefHashAndType load an item by his job id, step order and type
 func LoadByAPIRefHashAndType(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, hash string, itemType sdk.CDNItemType, opts ...gorpmapper.GetOptionFunc) (*sdk.CDNItem, error) {
 	query := gorpmapper.NewQuery(`
@@ -149,6 +158,38 @@ func LoadByAPIRefHashAndType(ctx context.Context, m *gorpmapper.Mapper, db gorp.
 	return getItem(ctx, m, db, query, opts...)
 }
 
+// LoadByRunIDAndType load all the items of given type for a workflow run
+func LoadByRunIDAndType(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, projectKey string, runID int64, itemType sdk.CDNItemType, opts ...gorpmapper.GetOptionFunc) ([]sdk.CDNItem, error) {
+	query := gorpmapper.NewQuery(`
+		SELECT *
+		FROM item
+		WHERE api_ref->>'project_key' = $1
+		AND (api_ref->>'run_id')::int = $2
+		AND type = $3
+		AND to_delete = false
+		ORDER BY created
+	`).Args(projectKey, runID, itemType)
+	return getItems(ctx, m, db, query, opts...)
+}
+
+// LoadCompletedLogsByWorkflowAndRunRange load completed log items for a workflow, for runs between given run ids (0 means no limit)
+func LoadCompletedLogsByWorkflowAndRunRange(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, projectKey, workflowName string, fromRunID, toRunID int64, limit int64, opts ...gorpmapper.GetOptionFunc) ([]sdk.CDNItem, error) {
+	query := gorpmapper.NewQuery(`
+		SELECT *
+		FROM item
+		WHERE api_ref->>'project_key' = $1
+		AND api_ref->>'workflow_name' = $2
+		AND ($3 = 0 OR (api_ref->>'run_id')::int >= $3)
+		AND ($4 = 0 OR (api_ref->>'run_id')::int <= $4)
+		AND type = ANY($5)
+		AND status = $6
+		AND to_delete = false
+		ORDER BY (api_ref->>'run_id')::int DESC, created
+		LIMIT $7
+	`).Args(projectKey, workflowName, fromRunID, toRunID, pq.StringArray{string(sdk.CDNTypeItemStepLog), string(sdk.CDNTypeItemServiceLog)}, sdk.CDNStatusItemCompleted, limit)
+	return getItems(ctx, m, db, query, opts...)
+}
+
 // ComputeSizeByIDs returns the size used by givenn item IDs
 func ComputeSizeByIDs(db gorp.SqlExecutor, itemIDs []string) (int64, error) {
 	query := `
@@ -0,0 +1,102 @@
+package cdn
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/cdn/item"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (s *Service) postUploadArtifactHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !s.Cfg.EnableLogProcessing {
+			return sdk.NewErrorFrom(sdk.ErrNotImplemented, "cdn database features are disabled")
+		}
+
+		signature := s.workerSignature(ctx)
+		if signature == nil {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		name := r.FormValue("name")
+		if name == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing artifact name")
+		}
+		perm, _ := strconv.ParseUint(r.FormValue("perm"), 10, 32)
+		if perm == 0 {
+			perm = uint64(os.FileMode(0644))
+		}
+
+		apiRef := sdk.CDNLogAPIRef{
+			ProjectKey:     signature.ProjectKey,
+			WorkflowName:   signature.WorkflowName,
+			WorkflowID:     signature.WorkflowID,
+			RunID:          signature.RunID,
+			NodeRunID:      signature.NodeRunID,
+			NodeRunName:    signature.NodeRunName,
+			NodeRunJobID:   signature.JobID,
+			NodeRunJobName: signature.JobName,
+			ArtifactName:   name,
+			ArtifactTag:    r.FormValue("tag"),
+			ArtifactPerm:   uint32(perm),
+		}
+
+		it, err := s.storeArtifact(ctx, apiRef, r.Body)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, it, http.StatusOK)
+	}
+}
+
+func (s *Service) getArtifactsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		signature := s.workerSignature(ctx)
+		if signature == nil {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		// A worker can only list artifacts from its own project
+		runID := service.FormInt64(r, "runID")
+		if runID <= 0 {
+			runID = signature.RunID
+		}
+
+		its, err := item.LoadByRunIDAndType(ctx, s.Mapper, s.mustDBWithCtx(ctx), signature.ProjectKey, runID, sdk.CDNTypeItemArtifact)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, its, http.StatusOK)
+	}
+}
+
+func (s *Service) getArtifactDownloadHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		signature := s.workerSignature(ctx)
+		if signature == nil {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		apiRef := mux.Vars(r)["apiRef"]
+
+		it, err := item.LoadByAPIRefHashAndType(ctx, s.Mapper, s.mustDBWithCtx(ctx), apiRef, sdk.CDNTypeItemArtifact)
+		if err != nil {
+			return err
+		}
+		if it.APIRef.ProjectKey != signature.ProjectKey {
+			log.Warning(ctx, "worker %s can't download artifact %s from project %s", signature.Worker.WorkerName, it.ID, it.APIRef.ProjectKey)
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		return s.downloadItem(ctx, sdk.CDNTypeItemArtifact, apiRef, w, downloadOpts{})
+	}
+}
@@ -0,0 +1,119 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/require"
+
+	cdntest "github.com/ovh/cds/engine/cdn/test"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/jws"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func TestPostUploadArtifactHandler(t *testing.T) {
+	s, db := newTestService(t)
+	s.Cfg.EnableLogProcessing = true
+	cdntest.ClearItem(t, context.TODO(), s.Mapper, db)
+	defer logCache.Flush()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	t.Cleanup(cancel)
+	s.Units = newRunningStorageUnits(t, s.Mapper, db.DbMap, ctx)
+
+	// Create worker private key and signer
+	key, err := jws.NewRandomSymmetricKey(32)
+	require.NoError(t, err)
+	sign, err := jws.NewHMacSigner(key)
+	require.NoError(t, err)
+
+	signature := log.Signature{
+		Worker: &log.SignatureWorker{
+			WorkerID:   "abcdef-123456",
+			WorkerName: "myworker",
+		},
+		ProjectKey:   sdk.RandomString(10),
+		WorkflowName: "MyWorkflow",
+		RunID:        1,
+		JobID:        1,
+		NodeRunID:    1,
+		Timestamp:    time.Now().UnixNano(),
+	}
+	logCache.Set(fmt.Sprintf("worker-%s", signature.Worker.WorkerName), sdk.Worker{
+		Name:       signature.Worker.WorkerName,
+		ID:         signature.Worker.WorkerID,
+		PrivateKey: key,
+		JobRunID:   &signature.JobID,
+	}, gocache.DefaultExpiration)
+
+	signatureField, err := jws.Sign(sign, signature)
+	require.NoError(t, err)
+
+	// Upload an artifact
+	uri := s.Router.GetRoute("POST", s.postUploadArtifactHandler, nil)
+	require.NotEmpty(t, uri)
+	req, err := http.NewRequest("POST", uri+"?name=myfile.txt&tag=v1", bytes.NewBufferString("my artifact content"))
+	require.NoError(t, err)
+	req.Header.Set(sdk.CDNWorkerSignatureHeader, signatureField)
+	rec := httptest.NewRecorder()
+	s.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	var it sdk.CDNItem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &it))
+	require.Equal(t, sdk.CDNTypeItemArtifact, it.Type)
+	require.Equal(t, sdk.CDNStatusItemCompleted, it.Status)
+	require.Equal(t, int64(19), it.Size)
+
+	// Uploading the same artifact twice is forbidden
+	req, err = http.NewRequest("POST", uri+"?name=myfile.txt&tag=v1", bytes.NewBufferString("my artifact content"))
+	require.NoError(t, err)
+	req.Header.Set(sdk.CDNWorkerSignatureHeader, signatureField)
+	rec = httptest.NewRecorder()
+	s.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 409, rec.Code)
+
+	// Upload without signature
+	req, err = http.NewRequest("POST", uri+"?name=other.txt", bytes.NewBufferString("my artifact content"))
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	s.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 401, rec.Code)
+
+	// List artifacts for current run
+	uri = s.Router.GetRoute("GET", s.getArtifactsHandler, nil)
+	require.NotEmpty(t, uri)
+	req, err = http.NewRequest("GET", uri, nil)
+	require.NoError(t, err)
+	req.Header.Set(sdk.CDNWorkerSignatureHeader, signatureField)
+	rec = httptest.NewRecorder()
+	s.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	var its []sdk.CDNItem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &its))
+	require.Len(t, its, 1)
+	require.Equal(t, "myfile.txt", its[0].APIRef.ArtifactName)
+	require.Equal(t, "v1", its[0].APIRef.ArtifactTag)
+
+	// Download the artifact
+	uri = s.Router.GetRoute("GET", s.getArtifactDownloadHandler, map[string]string{
+		"apiRef": its[0].APIRefHash,
+	})
+	require.NotEmpty(t, uri)
+	req, err = http.NewRequest("GET", uri, nil)
+	require.NoError(t, err)
+	req.Header.Set(sdk.CDNWorkerSignatureHeader, signatureField)
+	rec = httptest.NewRecorder()
+	s.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	require.Equal(t, "my artifact content", rec.Body.String())
+}
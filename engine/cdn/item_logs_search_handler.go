@@ -0,0 +1,135 @@
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/cdn/item"
+	"github.com/ovh/cds/engine/cdn/redis"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	searchLogsDefaultLimit = 1000
+	searchLogsMaxLimit     = 10000
+	searchLogsMaxItems     = 500
+	searchLogsTimeout      = 30 * time.Second
+)
+
+func (s *Service) searchLogsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		opts := sdk.CDNLogSearchOptions{
+			ProjectKey:   r.FormValue("project"),
+			WorkflowName: r.FormValue("workflow"),
+			FromRunID:    service.FormInt64(r, "fromRunID"),
+			ToRunID:      service.FormInt64(r, "toRunID"),
+			Pattern:      r.FormValue("pattern"),
+			Limit:        service.FormInt64(r, "limit"),
+		}
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+
+		if err := s.workflowAccessCheck(ctx, opts.ProjectKey, opts.WorkflowName, "workflow", opts.ProjectKey, opts.WorkflowName); err != nil {
+			return err
+		}
+
+		res, err := s.searchLogs(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// searchLogs scans the completed log items of a workflow for the given run range and returns lines that match the pattern.
+// The scan is bounded by a number of items and a duration, the lines found until then are returned.
+func (s *Service) searchLogs(ctx context.Context, opts sdk.CDNLogSearchOptions) ([]sdk.CDNLogSearchResult, error) {
+	reg, err := regexp.Compile(opts.Pattern)
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid search pattern: %v", err)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = searchLogsDefaultLimit
+	}
+	if limit > searchLogsMaxLimit {
+		limit = searchLogsMaxLimit
+	}
+
+	its, err := item.LoadCompletedLogsByWorkflowAndRunRange(ctx, s.Mapper, s.mustDBWithCtx(ctx), opts.ProjectKey, opts.WorkflowName, opts.FromRunID, opts.ToRunID, searchLogsMaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, searchLogsTimeout)
+	defer cancel()
+
+	res := []sdk.CDNLogSearchResult{}
+	for _, it := range its {
+		if ctx.Err() != nil {
+			log.Warning(ctx, "searchLogs> search in logs of workflow %s/%s stopped: %v", opts.ProjectKey, opts.WorkflowName, ctx.Err())
+			break
+		}
+		lines, err := s.searchItemLogs(ctx, it, reg, limit-int64(len(res)))
+		if err != nil {
+			log.Warning(ctx, "searchLogs> unable to search in item %s: %v", it.ID, err)
+			continue
+		}
+		res = append(res, lines...)
+		if int64(len(res)) >= limit {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (s *Service) searchItemLogs(ctx context.Context, it sdk.CDNItem, reg *regexp.Regexp, limit int64) ([]sdk.CDNLogSearchResult, error) {
+	_, _, rc, _, err := s.getItemLogValue(ctx, it.Type, it.APIRefHash, sdk.CDNReaderFormatJSON, 0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if rc == nil {
+		return nil, sdk.WrapError(sdk.ErrNotFound, "no storage found that contains given item %s", it.APIRefHash)
+	}
+	defer rc.Close() // nolint
+
+	dec := json.NewDecoder(rc)
+	if _, err := dec.Token(); err != nil { // opening bracket
+		return nil, sdk.WithStack(err)
+	}
+
+	var res []sdk.CDNLogSearchResult
+	for dec.More() && int64(len(res)) < limit {
+		var line redis.Line
+		if err := dec.Decode(&line); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		if !reg.MatchString(line.Value) {
+			continue
+		}
+		res = append(res, sdk.CDNLogSearchResult{
+			APIRef:         it.APIRefHash,
+			ItemType:       it.Type,
+			RunID:          it.APIRef.RunID,
+			NodeRunName:    it.APIRef.NodeRunName,
+			NodeRunJobName: it.APIRef.NodeRunJobName,
+			StepOrder:      it.APIRef.StepOrder,
+			StepName:       it.APIRef.StepName,
+			ServiceName:    it.APIRef.RequirementServiceName,
+			LineNumber:     line.Number,
+			Line:           strings.TrimSuffix(line.Value, "\n"),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,110 @@
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/authentication"
+	"github.com/ovh/cds/engine/test"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/log/hook"
+)
+
+func TestSearchLogsHandler(t *testing.T) {
+	projectKey := sdk.RandomString(10)
+
+	// Create cdn service with need storage and test item
+	s, db := newTestService(t)
+	s.Client = cdsclient.New(cdsclient.Config{Host: "http://lolcat.api", InsecureSkipVerifyTLS: false})
+	gock.InterceptClient(s.Client.(cdsclient.Raw).HTTPClient())
+	t.Cleanup(gock.Off)
+	gock.New("http://lolcat.api").Get("/project/" + projectKey + "/workflows/MyWorkflow/log/access").Reply(http.StatusOK).JSON(nil)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	t.Cleanup(cancel)
+	s.Units = newRunningStorageUnits(t, s.Mapper, db.DbMap, ctx)
+
+	signature := log.Signature{
+		ProjectKey:   projectKey,
+		WorkflowID:   1,
+		WorkflowName: "MyWorkflow",
+		RunID:        1,
+		NodeRunID:    1,
+		NodeRunName:  "MyPipeline",
+		JobName:      "MyJob",
+		JobID:        1,
+		Worker: &log.SignatureWorker{
+			StepName:  "script1",
+			StepOrder: 1,
+		},
+	}
+	for i, msg := range []string{"=== RUN TestFoo", "--- FAIL: TestFoo (0.01s)", "FAIL"} {
+		status := sdk.StatusBuilding
+		if i == 2 {
+			status = sdk.StatusFail
+		}
+		hm := handledMessage{
+			Msg:       hook.Message{Full: msg},
+			Status:    status,
+			Line:      int64(i),
+			Signature: signature,
+		}
+		require.NoError(t, s.storeLogs(context.TODO(), sdk.CDNTypeItemStepLog, hm.Signature, hm.Status, buildMessage(hm), hm.Line))
+	}
+
+	signer, err := authentication.NewSigner("cdn-test", test.SigningKey)
+	require.NoError(t, err)
+	s.Common.ParsedAPIPublicKey = signer.GetVerifyKey()
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS512, sdk.AuthSessionJWTClaims{
+		ID: sdk.UUID(),
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    "test",
+			Subject:   sdk.UUID(),
+			Id:        sdk.UUID(),
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(time.Minute).Unix(),
+		},
+	})
+	jwtTokenRaw, err := signer.SignJWT(jwtToken)
+	require.NoError(t, err)
+
+	uri := s.Router.GetRoute("GET", s.searchLogsHandler, nil)
+	require.NotEmpty(t, uri)
+	params := url.Values{}
+	params.Set("project", projectKey)
+	params.Set("workflow", "MyWorkflow")
+	params.Set("fromRunID", "1")
+	params.Set("toRunID", "1")
+	params.Set("pattern", "FAIL: Test[a-zA-Z]+")
+	req := assets.NewJWTAuthentifiedRequest(t, jwtTokenRaw, "GET", uri+"?"+params.Encode(), nil)
+	rec := httptest.NewRecorder()
+	s.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	var res []sdk.CDNLogSearchResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res, 1)
+	require.Equal(t, int64(1), res[0].LineNumber)
+	require.Equal(t, "script1", res[0].StepName)
+	require.Equal(t, int64(1), res[0].RunID)
+	require.Contains(t, res[0].Line, "--- FAIL: TestFoo (0.01s)")
+
+	// Invalid pattern
+	params.Set("pattern", "FAIL: (")
+	req = assets.NewJWTAuthentifiedRequest(t, jwtTokenRaw, "GET", uri+"?"+params.Encode(), nil)
+	rec = httptest.NewRecorder()
+	s.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 400, rec.Code)
+}
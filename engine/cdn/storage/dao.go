@@ -141,23 +141,48 @@ func DeleteItemsUnit(db gorp.SqlExecutor, unitID string, itemIDs []string) error
 	return sdk.WrapError(err, "unable to remove items from unit %s", itemIDs)
 }
 
-// LoadAllItemsIDInBufferAndAllUnitsExceptCDS loads all that are presents in all backend ( except cds backend )
-func LoadAllItemsIDInBufferAndAllUnitsExceptCDS(db gorp.SqlExecutor, cdsBackendID string) ([]string, error) {
-	var itemIDs []string
+type itemInBuffer struct {
+	ItemID     string         `db:"item_id"`
+	Type       string         `db:"type"`
+	ProjectKey string         `db:"project_key"`
+	Created    time.Time      `db:"created"`
+	UnitIDs    pq.StringArray `db:"unit_ids"`
+}
+
+// LoadAllItemsIDInBufferAndAllUnitsExceptCDS loads all items from the buffer that are presents in all backends (except cds backend)
+// that should store them according to their rules.
+func (x *RunningStorageUnits) LoadAllItemsIDInBufferAndAllUnitsExceptCDS(db gorp.SqlExecutor, cdsBackendID string) ([]string, error) {
+	var items []itemInBuffer
 	query := `
-		SELECT item_id 
-		FROM (
-			SELECT COUNT(*) as nb, item_id 
-			FROM storage_unit_item
-			WHERE unit_id != $1
-			AND to_delete = false
-			GROUP BY item_id
-		) as cc
-		WHERE nb = (SELECT COUNT(*) FROM storage_unit WHERE id != $1)
+		SELECT item.id as item_id, item.type, COALESCE(item.api_ref->>'project_key', '') as project_key, item.created, array_agg(sui.unit_id) as unit_ids
+		FROM item
+		JOIN storage_unit_item buf ON buf.item_id = item.id AND buf.unit_id = $1
+		JOIN storage_unit_item sui ON sui.item_id = item.id AND sui.to_delete = false AND sui.unit_id <> $1 AND sui.unit_id <> $2
+		GROUP BY item.id, item.type, item.api_ref, item.created
 	`
-	if _, err := db.Select(&itemIDs, query, cdsBackendID); err != nil {
+	if _, err := db.Select(&items, query, x.Buffer.ID(), cdsBackendID); err != nil {
 		return nil, sdk.WrapError(err, "unable to get item ids")
 	}
+
+	now := time.Now()
+	var itemIDs []string
+itemLoop:
+	for _, i := range items {
+		it := sdk.CDNItem{
+			Type:    sdk.CDNItemType(i.Type),
+			Created: i.Created,
+			APIRef:  sdk.CDNLogAPIRef{ProjectKey: i.ProjectKey},
+		}
+		for _, s := range x.Storages {
+			if s.ID() == cdsBackendID || !s.Rules().Match(it, now) {
+				continue
+			}
+			if !sdk.IsInArray(s.ID(), i.UnitIDs) {
+				continue itemLoop
+			}
+		}
+		itemIDs = append(itemIDs, i.ItemID)
+	}
 	return itemIDs, nil
 }
 
@@ -184,6 +209,13 @@ func LoadItemUnitsByUnit(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlE
 	return getAllItemUnits(ctx, m, db, query, opts...)
 }
 
+// LoadItemUnitsByUnitAndHashLocator returns the item units of a storage unit with given locator hash. The item units
+// stored before the hash of the locators have an empty hash and are also returned, their locator has to be checked.
+func LoadItemUnitsByUnitAndHashLocator(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, unitID string, hashLocator string, opts ...gorpmapper.GetOptionFunc) ([]sdk.CDNItemUnit, error) {
+	query := gorpmapper.NewQuery("SELECT * FROM storage_unit_item WHERE unit_id = $1 AND (hash_locator = $2 OR hash_locator = '') AND to_delete = false ORDER BY last_modified ASC").Args(unitID, hashLocator)
+	return getAllItemUnits(ctx, m, db, query, opts...)
+}
+
 func LoadItemUnitByID(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, id string, opts ...gorpmapper.GetOptionFunc) (*sdk.CDNItemUnit, error) {
 	query := gorpmapper.NewQuery("SELECT * FROM storage_unit_item WHERE id = $1 AND to_delete = false").Args(id)
 	return getItemUnit(ctx, m, db, query, opts...)
@@ -287,7 +319,8 @@ func CountItemUnitByUnit(db gorp.SqlExecutor, unitID string) (int64, error) {
 	return db.SelectInt("SELECT COUNT(*) from storage_unit_item WHERE unit_id = $1", unitID)
 }
 
-func LoadAllItemIDUnknownByUnitOrderByUnitID(db gorp.SqlExecutor, unitID string, orderUnitID string, limit int64) ([]string, error) {
+func LoadAllItemIDUnknownByUnitOrderByUnitID(db gorp.SqlExecutor, unitID string, orderUnitID string, rules StorageUnitRules, limit int64) ([]string, error) {
+	rulesFilter, rulesArgs := rules.sqlFilter(time.Now(), 4, false)
 	query := `
 	WITH filteredItem as (
 			SELECT item.id, sui.unit_id
@@ -296,6 +329,7 @@ func LoadAllItemIDUnknownByUnitOrderByUnitID(db gorp.SqlExecutor, unitID string,
 			LEFT JOIN storage_unit_item iu2 ON item.id = iu2.item_id AND iu2.unit_id = $1
 			WHERE item.status = $3 AND iu2.unit_id is null
 			AND item.to_delete = false
+			AND ` + rulesFilter + `
 	)
 	SELECT id FROM filteredItem
 	ORDER BY CASE WHEN unit_id = $4 THEN 1
@@ -303,13 +337,68 @@ func LoadAllItemIDUnknownByUnitOrderByUnitID(db gorp.SqlExecutor, unitID string,
 			  END
 	LIMIT $2`
 	var res []string
-	if _, err := db.Select(&res, query, unitID, limit, sdk.CDNStatusItemCompleted, orderUnitID); err != nil {
+	args := append([]interface{}{unitID, limit, sdk.CDNStatusItemCompleted, orderUnitID}, rulesArgs...)
+	if _, err := db.Select(&res, query, args...); err != nil {
 		return nil, sdk.WithStack(err)
 	}
 
 	return res, nil
 }
 
+// LoadAllItemUnitIDsOutOfRulesByUnit loads the item units of a unit that don't match the unit rules anymore.
+// Only item units for items that are stored on another unit (except the buffer) are returned, so the content is never lost.
+func LoadAllItemUnitIDsOutOfRulesByUnit(db gorp.SqlExecutor, unitID string, bufferID string, rules StorageUnitRules, limit int64) ([]string, error) {
+	rulesFilter, rulesArgs := rules.sqlFilter(time.Now(), 3, true)
+	query := `
+	SELECT sui.id
+	FROM storage_unit_item sui
+	JOIN item ON item.id = sui.item_id
+	WHERE sui.unit_id = $1
+	AND sui.to_delete = false
+	AND item.to_delete = false
+	AND ` + rulesFilter + `
+	AND EXISTS (
+		SELECT 1 FROM storage_unit_item other
+		WHERE other.item_id = sui.item_id
+		AND other.unit_id <> $1
+		AND other.unit_id <> $2
+		AND other.to_delete = false
+	)
+	LIMIT $3`
+	var res []string
+	args := append([]interface{}{unitID, bufferID, limit}, rulesArgs...)
+	if _, err := db.Select(&res, query, args...); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return res, nil
+}
+
+// LoadAllItemIDsExpiredOnLastUnit returns the ids of the items older than the max age of the unit rules that are not
+// stored on another unit than the given one and the buffer.
+func LoadAllItemIDsExpiredOnLastUnit(db gorp.SqlExecutor, unitID string, bufferID string, rules StorageUnitRules, limit int64) ([]string, error) {
+	query := `
+	SELECT sui.item_id
+	FROM storage_unit_item sui
+	JOIN item ON item.id = sui.item_id
+	WHERE sui.unit_id = $1
+	AND sui.to_delete = false
+	AND item.to_delete = false
+	AND item.created < $4
+	AND NOT EXISTS (
+		SELECT 1 FROM storage_unit_item other
+		WHERE other.item_id = sui.item_id
+		AND other.unit_id <> $1
+		AND other.unit_id <> $2
+		AND other.to_delete = false
+	)
+	LIMIT $3`
+	var res []string
+	if _, err := db.Select(&res, query, unitID, bufferID, limit, rules.maxAgeLimit(time.Now())); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return res, nil
+}
+
 type Stat struct {
 	StorageName string `db:"storage_name"`
 	Type        string `db:"type"`
@@ -2,7 +2,11 @@ package storage_test
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -190,10 +194,125 @@ func TestLoadAllItemIDUnknownByUnitOrderByUnitID(t *testing.T) {
 	}
 	require.NoError(t, storage.InsertItemUnit(context.TODO(), m, db, &iu4))
 
-	itemIDS, err := storage.LoadAllItemIDUnknownByUnitOrderByUnitID(db, cdnUnits.Storages[0].ID(), cdnUnits.Buffer.ID(), 100)
+	itemIDS, err := storage.LoadAllItemIDUnknownByUnitOrderByUnitID(db, cdnUnits.Storages[0].ID(), cdnUnits.Buffer.ID(), storage.StorageUnitRules{}, 100)
 	require.NoError(t, err)
 
 	require.Equal(t, 3, len(itemIDS))
 	// Check that redis one is the first
 	require.Equal(t, i2.ID, itemIDS[0])
 }
+
+func initTestStorageUnits(t *testing.T, m *gorpmapper.Mapper, db *test.FakeTransaction, rules ...storage.StorageUnitRules) *storage.RunningStorageUnits {
+	cfg := test.LoadTestingConf(t, sdk.TypeCDN)
+	tmpDir, err := ioutil.TempDir("", t.Name()+"-cdn-1-*")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(tmpDir) }) // nolint
+
+	var storages []storage.StorageConfiguration
+	for i := range rules {
+		storages = append(storages, storage.StorageConfiguration{
+			Name:  fmt.Sprintf("local_storage%d", i),
+			Rules: rules[i],
+			Local: &storage.LocalStorageConfiguration{
+				Path: filepath.Join(tmpDir, strconv.Itoa(i)),
+				Encryption: []convergent.ConvergentEncryptionConfig{
+					{
+						Cipher:      aesgcm.CipherName,
+						LocatorSalt: "secret_locator_salt",
+						SecretValue: "secret_value",
+					},
+				},
+			},
+		})
+	}
+	cdnUnits, err := storage.Init(context.TODO(), m, db.DbMap, sdk.NewGoRoutines(), storage.Configuration{
+		Buffer: storage.BufferConfiguration{
+			Name: "redis_buffer",
+			Redis: storage.RedisBufferConfiguration{
+				Host:     cfg["redisHost"],
+				Password: cfg["redisPassword"],
+			},
+		},
+		Storages: storages,
+	})
+	require.NoError(t, err)
+	return cdnUnits
+}
+
+// insertTestItem inserts an item created at given date and stored on the given units.
+func insertTestItem(t *testing.T, m *gorpmapper.Mapper, db *test.FakeTransaction, projectKey string, created time.Time, unitIDs ...string) sdk.CDNItem {
+	i := sdk.CDNItem{
+		APIRefHash: sdk.RandomString(10),
+		APIRef:     sdk.CDNLogAPIRef{ProjectKey: projectKey},
+		Type:       sdk.CDNTypeItemStepLog,
+		Status:     sdk.CDNStatusItemCompleted,
+	}
+	require.NoError(t, item.Insert(context.TODO(), m, db, &i))
+	_, err := db.Exec("UPDATE item SET created = $1 WHERE id = $2", created, i.ID)
+	require.NoError(t, err)
+	for _, unitID := range unitIDs {
+		iu := sdk.CDNItemUnit{ID: sdk.UUID(), ItemID: i.ID, UnitID: unitID}
+		require.NoError(t, storage.InsertItemUnit(context.TODO(), m, db, &iu))
+	}
+	return i
+}
+
+func TestLoadAllItemUnitIDsOutOfRulesByUnit(t *testing.T) {
+	m := gorpmapper.New()
+	item.InitDBMapping(m)
+	storage.InitDBMapping(m)
+	db, _ := test.SetupPGWithMapper(t, m, sdk.TypeCDN)
+
+	cdntest.ClearItem(t, context.TODO(), m, db)
+	cdntest.ClearUnits(t, context.TODO(), m, db)
+
+	rules := storage.StorageUnitRules{MaxItemAge: 24}
+	cdnUnits := initTestStorageUnits(t, m, db, rules, storage.StorageUnitRules{})
+	hot, cold := cdnUnits.Storages[0].ID(), cdnUnits.Storages[1].ID()
+
+	old := time.Now().Add(-48 * time.Hour)
+	oldItem := insertTestItem(t, m, db, "PROJ", old, cdnUnits.Buffer.ID(), hot, cold)
+	lastCopyItem := insertTestItem(t, m, db, "PROJ", old, cdnUnits.Buffer.ID(), hot)
+	insertTestItem(t, m, db, "PROJ", time.Now(), hot, cold)
+
+	// Only the expired item stored on another unit than the buffer is purged
+	ids, err := storage.LoadAllItemUnitIDsOutOfRulesByUnit(db, hot, cdnUnits.Buffer.ID(), rules, 100)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	iu, err := storage.LoadItemUnitByID(context.TODO(), m, db, ids[0])
+	require.NoError(t, err)
+	require.Equal(t, oldItem.ID, iu.ItemID)
+
+	// The expired item only stored on the unit is the last copy
+	itemIDs, err := storage.LoadAllItemIDsExpiredOnLastUnit(db, hot, cdnUnits.Buffer.ID(), rules, 100)
+	require.NoError(t, err)
+	require.Equal(t, []string{lastCopyItem.ID}, itemIDs)
+
+	require.NoError(t, item.MarkToDeleteByIDs(db, itemIDs))
+	itemIDs, err = storage.LoadAllItemIDsExpiredOnLastUnit(db, hot, cdnUnits.Buffer.ID(), rules, 100)
+	require.NoError(t, err)
+	require.Empty(t, itemIDs)
+}
+
+func TestLoadAllItemsIDInBufferAndAllUnitsExceptCDS(t *testing.T) {
+	m := gorpmapper.New()
+	item.InitDBMapping(m)
+	storage.InitDBMapping(m)
+	db, _ := test.SetupPGWithMapper(t, m, sdk.TypeCDN)
+
+	cdntest.ClearItem(t, context.TODO(), m, db)
+	cdntest.ClearUnits(t, context.TODO(), m, db)
+
+	cdnUnits := initTestStorageUnits(t, m, db, storage.StorageUnitRules{}, storage.StorageUnitRules{Projects: []string{"PROJ"}})
+	buffer, all, proj := cdnUnits.Buffer.ID(), cdnUnits.Storages[0].ID(), cdnUnits.Storages[1].ID()
+
+	// The item of another project is not expected on the unit of PROJ
+	otherItem := insertTestItem(t, m, db, "OTHER", time.Now(), buffer, all)
+	insertTestItem(t, m, db, "PROJ", time.Now(), buffer, all)
+	syncItem := insertTestItem(t, m, db, "PROJ", time.Now(), buffer, all, proj)
+	insertTestItem(t, m, db, "PROJ", time.Now(), buffer)
+
+	itemIDs, err := cdnUnits.LoadAllItemsIDInBufferAndAllUnitsExceptCDS(db, "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{otherItem.ID, syncItem.ID}, itemIDs)
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// StorageUnitRules defines which items are kept on a storage unit.
+type StorageUnitRules struct {
+	MaxItemAge       int64    `toml:"maxItemAge" json:"max_item_age" default:"0" comment:"Max age (in hours) of the items kept on this unit, older items are purged if they are stored elsewhere (see expireLastCopy). 0 means no limit"`
+	ItemTypes        []string `toml:"itemTypes" json:"item_types" comment:"Types of item stored on this unit (step-log, service-log, artifact). Empty means all"`
+	Projects         []string `toml:"projects" json:"projects" comment:"Only items from these projects are stored on this unit. Empty means all"`
+	ExcludedProjects []string `toml:"excludedProjects" json:"excluded_projects" comment:"Items from these projects are never stored on this unit"`
+	Cold             bool     `toml:"cold" json:"cold" default:"false" comment:"A cold unit is only used to read items that are not available on other units"`
+	ExpireLastCopy   bool     `toml:"expireLastCopy" json:"expire_last_copy" default:"false" comment:"Items older than maxItemAge are also deleted when this unit has their last copy, they are then lost"`
+}
+
+// IsEmpty returns true if no filtering rule is set.
+func (r StorageUnitRules) IsEmpty() bool {
+	return r.MaxItemAge <= 0 && len(r.ItemTypes) == 0 && len(r.Projects) == 0 && len(r.ExcludedProjects) == 0
+}
+
+// expireLastCopy returns true if the items older than the max age should be deleted from CDN when the unit has their last copy.
+func (r StorageUnitRules) expireLastCopy() bool {
+	return r.ExpireLastCopy && r.MaxItemAge > 0
+}
+
+// maxAgeLimit returns the creation date before which items are expired for the unit.
+func (r StorageUnitRules) maxAgeLimit(now time.Time) time.Time {
+	return now.Add(-time.Duration(r.MaxItemAge) * time.Hour)
+}
+
+// Match returns true if the given item should be stored on a unit with these rules.
+func (r StorageUnitRules) Match(i sdk.CDNItem, now time.Time) bool {
+	if r.MaxItemAge > 0 && !i.Created.IsZero() && i.Created.Before(r.maxAgeLimit(now)) {
+		return false
+	}
+	if len(r.ItemTypes) > 0 && !sdk.IsInArray(string(i.Type), r.ItemTypes) {
+		return false
+	}
+	if len(r.Projects) > 0 && !sdk.IsInArray(i.APIRef.ProjectKey, r.Projects) {
+		return false
+	}
+	if sdk.IsInArray(i.APIRef.ProjectKey, r.ExcludedProjects) {
+		return false
+	}
+	return true
+}
+
+// sqlFilter returns SQL conditions on the item table matching the rules (or not matching them if negate is true).
+// Arguments are numbered from argOffset+1.
+func (r StorageUnitRules) sqlFilter(now time.Time, argOffset int, negate bool) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	addCond := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, argOffset+len(args)))
+	}
+
+	if r.MaxItemAge > 0 {
+		addCond("item.created >= $%d", r.maxAgeLimit(now))
+	}
+	if len(r.ItemTypes) > 0 {
+		addCond("item.type = ANY($%d)", pq.StringArray(r.ItemTypes))
+	}
+	if len(r.Projects) > 0 {
+		addCond("item.api_ref->>'project_key' = ANY($%d)", pq.StringArray(r.Projects))
+	}
+	if len(r.ExcludedProjects) > 0 {
+		addCond("NOT (item.api_ref->>'project_key' = ANY($%d))", pq.StringArray(r.ExcludedProjects))
+	}
+
+	if len(conds) == 0 {
+		if negate {
+			return "false", nil
+		}
+		return "true", nil
+	}
+	filter := "(" + strings.Join(conds, " AND ") + ")"
+	if negate {
+		filter = "NOT " + filter
+	}
+	return filter, args
+}
+
+// Rules returns the rules of the unit with the given id, a unit without rules accepts all items.
+func (x *RunningStorageUnits) Rules(unitID string) StorageUnitRules {
+	for _, s := range x.Storages {
+		if s.ID() == unitID {
+			return s.Rules()
+		}
+	}
+	return StorageUnitRules{}
+}
+
+// FilterItemUnitsFromColdUnits removes item units stored on cold units if the item is available on another unit.
+func (x *RunningStorageUnits) FilterItemUnitsFromColdUnits(ius []sdk.CDNItemUnit) []sdk.CDNItemUnit {
+	res := make([]sdk.CDNItemUnit, 0, len(ius))
+	for _, iu := range ius {
+		if !x.Rules(iu.UnitID).Cold {
+			res = append(res, iu)
+		}
+	}
+	if len(res) == 0 {
+		return ius
+	}
+	return res
+}
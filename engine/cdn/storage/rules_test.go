@@ -0,0 +1,104 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/cdn/storage"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestStorageUnitRulesMatch(t *testing.T) {
+	now := time.Now()
+	stepLog := sdk.CDNItem{
+		Type:    sdk.CDNTypeItemStepLog,
+		Created: now.Add(-48 * time.Hour),
+		APIRef:  sdk.CDNLogAPIRef{ProjectKey: "PROJ1"},
+	}
+	artifact := sdk.CDNItem{
+		Type:    sdk.CDNTypeItemArtifact,
+		Created: now.Add(-time.Hour),
+		APIRef:  sdk.CDNLogAPIRef{ProjectKey: "PROJ2"},
+	}
+
+	tests := []struct {
+		name     string
+		rules    storage.StorageUnitRules
+		stepLog  bool
+		artifact bool
+	}{
+		{
+			name:     "no rules",
+			rules:    storage.StorageUnitRules{},
+			stepLog:  true,
+			artifact: true,
+		},
+		{
+			name:     "max item age",
+			rules:    storage.StorageUnitRules{MaxItemAge: 24},
+			stepLog:  false,
+			artifact: true,
+		},
+		{
+			name:     "item types",
+			rules:    storage.StorageUnitRules{ItemTypes: []string{string(sdk.CDNTypeItemStepLog)}},
+			stepLog:  true,
+			artifact: false,
+		},
+		{
+			name:     "projects",
+			rules:    storage.StorageUnitRules{Projects: []string{"PROJ2"}},
+			stepLog:  false,
+			artifact: true,
+		},
+		{
+			name:     "excluded projects",
+			rules:    storage.StorageUnitRules{ExcludedProjects: []string{"PROJ2"}},
+			stepLog:  true,
+			artifact: false,
+		},
+		{
+			name:     "cold unit",
+			rules:    storage.StorageUnitRules{Cold: true},
+			stepLog:  true,
+			artifact: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.stepLog, tt.rules.Match(stepLog, now))
+			require.Equal(t, tt.artifact, tt.rules.Match(artifact, now))
+		})
+	}
+}
+
+func TestFilterItemUnitsFromColdUnits(t *testing.T) {
+	hot := new(storage.AbstractUnit)
+	hot.Set(sdk.CDNUnit{ID: "hot"})
+	cold := new(storage.AbstractUnit)
+	cold.Set(sdk.CDNUnit{ID: "cold"})
+	cold.SetRules(storage.StorageUnitRules{Cold: true})
+
+	ius := []sdk.CDNItemUnit{{ID: "1", UnitID: "hot"}, {ID: "2", UnitID: "cold"}}
+
+	units := storage.RunningStorageUnits{Storages: []storage.StorageUnit{&testUnit{unit: hot}, &testUnit{unit: cold}}}
+	res := units.FilterItemUnitsFromColdUnits(ius)
+	require.Len(t, res, 1)
+	require.Equal(t, "1", res[0].ID)
+
+	// Cold units are used if the item is not available elsewhere
+	res = units.FilterItemUnitsFromColdUnits(ius[1:])
+	require.Len(t, res, 1)
+	require.Equal(t, "2", res[0].ID)
+}
+
+type testUnit struct {
+	storage.StorageUnit
+	unit *storage.AbstractUnit
+}
+
+func (u *testUnit) ID() string                      { return u.unit.ID() }
+func (u *testUnit) Rules() storage.StorageUnitRules { return u.unit.Rules() }
@@ -0,0 +1,180 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/cdn/storage"
+	"github.com/ovh/cds/engine/cdn/storage/encryption"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+type S3 struct {
+	storage.AbstractUnit
+	encryption.ConvergentEncryption
+	config storage.S3StorageConfiguration
+	sess   *session.Session
+	client *s3.S3
+}
+
+var (
+	_ storage.StorageUnit = new(S3)
+)
+
+func init() {
+	storage.RegisterDriver("s3", new(S3))
+}
+
+func (s *S3) Init(ctx context.Context, cfg interface{}) error {
+	config, is := cfg.(*storage.S3StorageConfiguration)
+	if !is {
+		return sdk.WithStack(fmt.Errorf("invalid configuration: %T", cfg))
+	}
+	s.config = *config
+	s.ConvergentEncryption = encryption.New(config.Encryption)
+
+	aConf := aws.NewConfig()
+	aConf.Region = aws.String(config.Region)
+	if config.AuthFromEnvironment {
+		aConf.Credentials = credentials.NewEnvCredentials()
+	} else if config.Profile != "" {
+		// if the shared creds file is empty the AWS SDK will check the defaults automatically
+		aConf.Credentials = credentials.NewSharedCredentials(config.SharedCredsFile, config.Profile)
+	} else {
+		aConf.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, config.SessionToken)
+	}
+
+	// If a custom endpoint is set, set up a new endPoint resolver (eg. minio)
+	if config.Endpoint != "" {
+		aConf.Endpoint = aws.String(config.Endpoint)
+		aConf.DisableSSL = aws.Bool(config.DisableSSL)
+		aConf.S3ForcePathStyle = aws.Bool(config.ForcePathStyle)
+	}
+
+	sess, err := session.NewSession(aConf)
+	if err != nil {
+		return sdk.WrapError(err, "unable to create an AWS session")
+	}
+	s.sess = sess
+	s.client = s3.New(sess)
+	return nil
+}
+
+func (s *S3) getObjectPath(i sdk.CDNItemUnit) string {
+	loc := i.Locator
+	return path.Join(s.config.Prefix, loc[:3], loc)
+}
+
+func (s *S3) ItemExists(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, i sdk.CDNItem) (bool, error) {
+	iu, err := s.ExistsInDatabase(ctx, m, db, i.ID)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if _, err := s.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.config.BucketName),
+		Key:    aws.String(s.getObjectPath(*iu)),
+	}); err != nil {
+		if aerr, ok := err.(awserr.RequestFailure); ok && aerr.StatusCode() == 404 {
+			return false, nil
+		}
+		return false, sdk.WrapError(err, "unable to get object %s", s.getObjectPath(*iu))
+	}
+	return true, nil
+}
+
+// writer streams the data to S3 through a pipe, Close waits for the upload to be done.
+// Close can be called several times as encryption pipes also close their writer.
+type writer struct {
+	pw       *io.PipeWriter
+	done     chan error
+	once     sync.Once
+	closeErr error
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	return w.pw.Write(p)
+}
+
+func (w *writer) Close() error {
+	w.once.Do(func() {
+		if err := w.pw.Close(); err != nil {
+			w.closeErr = sdk.WithStack(err)
+			return
+		}
+		w.closeErr = <-w.done
+	})
+	return w.closeErr
+}
+
+func (s *S3) NewWriter(ctx context.Context, i sdk.CDNItemUnit) (io.WriteCloser, error) {
+	key := s.getObjectPath(i)
+	uploader := s3manager.NewUploader(s.sess)
+
+	pr, pw := io.Pipe()
+	w := &writer{pw: pw, done: make(chan error, 1)}
+	gr := sdk.NewGoRoutines()
+	gr.Exec(ctx, "s3.newWriter", func(ctx context.Context) {
+		_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+			Bucket: aws.String(s.config.BucketName),
+			Key:    aws.String(key),
+			Body:   pr,
+		})
+		if err != nil {
+			err = sdk.WrapError(err, "unable to upload object %s", key)
+			_ = pr.CloseWithError(err)
+		}
+		w.done <- err
+	})
+	return w, nil
+}
+
+func (s *S3) NewReader(ctx context.Context, i sdk.CDNItemUnit) (io.ReadCloser, error) {
+	key := s.getObjectPath(i)
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.config.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to download object %s", key)
+	}
+	return out.Body, nil
+}
+
+// Status returns the status of the s3 bucket
+func (s *S3) Status(ctx context.Context) []sdk.MonitoringStatusLine {
+	if _, err := s.client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.config.BucketName)}); err != nil {
+		return []sdk.MonitoringStatusLine{{Component: "backend/" + s.Name(), Value: "S3 KO: " + err.Error(), Status: sdk.MonitoringStatusAlert}}
+	}
+	return []sdk.MonitoringStatusLine{{
+		Component: "backend/" + s.Name(),
+		Value:     fmt.Sprintf("S3 OK (bucket %s)", s.config.BucketName),
+		Status:    sdk.MonitoringStatusOK,
+	}}
+}
+
+func (s *S3) Remove(ctx context.Context, i sdk.CDNItemUnit) error {
+	key := s.getObjectPath(i)
+	if _, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.config.BucketName),
+		Key:    aws.String(key),
+	}); err != nil {
+		return sdk.WrapError(err, "unable to delete object %s", key)
+	}
+	return nil
+}
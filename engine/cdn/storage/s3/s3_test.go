@@ -0,0 +1,120 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ovh/symmecrypt/ciphers/aesgcm"
+	"github.com/ovh/symmecrypt/convergent"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/cdn/storage"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// fakeS3 is a minimal in memory S3 server with path style urls
+type fakeS3 struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		btes, _ := ioutil.ReadAll(r.Body)
+		f.objects[r.URL.Path] = btes
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead:
+		if r.URL.Path == "/mybucket" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if _, has := f.objects[r.URL.Path]; !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		btes, has := f.objects[r.URL.Path]
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(btes)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3(t *testing.T) {
+	log.SetLogger(t)
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	var driver = new(S3)
+	err := driver.Init(context.TODO(), &storage.S3StorageConfiguration{
+		BucketName:      "mybucket",
+		Region:          "us-east-1",
+		Prefix:          "cdn",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		Endpoint:        srv.URL,
+		DisableSSL:      true,
+		ForcePathStyle:  true,
+		Encryption: []convergent.ConvergentEncryptionConfig{
+			{
+				Cipher:      aesgcm.CipherName,
+				LocatorSalt: "secret_locator_salt",
+				SecretValue: "secret_value",
+			},
+		},
+	})
+	require.NoError(t, err, "unable to initialiaze s3 driver")
+
+	hash := "d7c5b1d0f2c5f0e4b5b5a3f0b4d3a0c6e2d1f9e8b7a6c5d4e3f2a1b0c9d8e7f6"
+	locator, err := driver.NewLocator(hash)
+	require.NoError(t, err)
+
+	itemUnit := sdk.CDNItemUnit{
+		ID:      "an_id",
+		Locator: locator,
+		Item:    &sdk.CDNItem{Hash: hash},
+	}
+	w, err := driver.NewWriter(context.TODO(), itemUnit)
+	require.NoError(t, err)
+	require.NotNil(t, w)
+	require.NoError(t, driver.Write(itemUnit, bytes.NewBufferString("something"), w))
+	require.NoError(t, w.Close())
+
+	require.Len(t, fake.objects, 1)
+	require.Contains(t, fake.objects, "/mybucket/cdn/"+locator[:3]+"/"+locator)
+	require.NotEqual(t, "something", string(fake.objects["/mybucket/cdn/"+locator[:3]+"/"+locator]), "content should be encrypted")
+
+	r, err := driver.NewReader(context.TODO(), itemUnit)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, driver.Read(itemUnit, r, buf))
+	require.NoError(t, r.Close())
+	require.Equal(t, "something", buf.String())
+
+	status := driver.Status(context.TODO())
+	require.Len(t, status, 1)
+	require.Equal(t, sdk.MonitoringStatusOK, status[0].Status)
+
+	require.NoError(t, driver.Remove(context.TODO(), itemUnit))
+	require.Len(t, fake.objects, 0)
+}
@@ -73,6 +73,7 @@ func (r RunningStorageUnits) GetSource(ctx context.Context, i *sdk.CDNItem) (Sou
 		log.Warning(ctx, "item %s can't be found. No unit knows it...", i.ID)
 		return nil, sdk.WithStack(sdk.ErrNotFound)
 	}
+	itemUnits = r.FilterItemUnitsFromColdUnits(itemUnits)
 
 	// Random pick a unit
 	idx := 0
@@ -95,8 +96,8 @@ func (r RunningStorageUnits) GetSource(ctx context.Context, i *sdk.CDNItem) (Sou
 }
 
 func (r RunningStorageUnits) GetItemUnitByLocatorByUnit(ctx context.Context, locator string, unitID string) ([]sdk.CDNItemUnit, error) {
-	// Load all the itemUnit for the unit
-	itemUnits, err := LoadItemUnitsByUnit(ctx, r.m, r.db, unitID, nil, gorpmapper.GetOptions.WithDecryption)
+	// Load the itemUnits of the unit with the same locator hash
+	itemUnits, err := LoadItemUnitsByUnitAndHashLocator(ctx, r.m, r.db, unitID, HashLocator(locator), gorpmapper.GetOptions.WithDecryption)
 	if err != nil {
 		return nil, err
 	}
@@ -4,12 +4,20 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ovh/cds/engine/cdn/item"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
 func (x *RunningStorageUnits) Purge(ctx context.Context, s Interface) error {
+	if err := x.markItemUnitsOutOfRulesToDelete(ctx, s); err != nil {
+		return err
+	}
+	if err := x.markItemsExpiredOnLastUnitToDelete(ctx, s); err != nil {
+		return err
+	}
+
 	unitItems, err := LoadAllItemUnitsToDeleteByUnit(ctx, x.m, x.db, s.ID(), gorpmapper.GetOptions.WithDecryption)
 	if err != nil {
 		return err
@@ -76,3 +84,60 @@ func (x *RunningStorageUnits) Purge(ctx context.Context, s Interface) error {
 
 	return nil
 }
+
+// markItemUnitsOutOfRulesToDelete marks to delete the item units that don't match the unit rules anymore (ie. too old items).
+func (x *RunningStorageUnits) markItemUnitsOutOfRulesToDelete(ctx context.Context, s Interface) error {
+	rules := s.Rules()
+	if rules.IsEmpty() {
+		return nil
+	}
+
+	ids, err := LoadAllItemUnitIDsOutOfRulesByUnit(x.db, s.ID(), x.Buffer.ID(), rules, 100)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := x.db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	n, err := MarkItemUnitToDelete(ctx, x.m, tx, ids)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	log.Info(ctx, "cdn:purge:%s: %d unit items out of the unit rules marked to delete", s.Name(), n)
+	return nil
+}
+
+// markItemsExpiredOnLastUnitToDelete marks to delete the items older than the max age of the unit rules when the unit has their last copy.
+// Without the expireLastCopy rule, these items are kept on the unit to not lose them.
+func (x *RunningStorageUnits) markItemsExpiredOnLastUnitToDelete(ctx context.Context, s Interface) error {
+	rules := s.Rules()
+	if !rules.expireLastCopy() {
+		return nil
+	}
+
+	ids, err := LoadAllItemIDsExpiredOnLastUnit(x.db, s.ID(), x.Buffer.ID(), rules, 100)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := item.MarkToDeleteByIDs(x.db, ids); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "cdn:purge:%s: %d expired items marked to delete", s.Name(), len(ids))
+	return nil
+}
@@ -2,6 +2,8 @@ package storage
 
 import (
 	"context"
+	"crypto/sha512"
+	"encoding/hex"
 	"io"
 	"time"
 
@@ -19,7 +21,7 @@ func (x *RunningStorageUnits) Run(ctx context.Context, s StorageUnit, nbItem int
 	}
 
 	// Load items to sync
-	itemIDs, err := LoadAllItemIDUnknownByUnitOrderByUnitID(x.db, s.ID(), x.Buffer.ID(), nbItem)
+	itemIDs, err := LoadAllItemIDUnknownByUnitOrderByUnitID(x.db, s.ID(), x.Buffer.ID(), s.Rules(), nbItem)
 	if err != nil {
 		return err
 	}
@@ -44,6 +46,11 @@ func (x *RunningStorageUnits) processItem(ctx context.Context, m *gorpmapper.Map
 		return nil
 	}
 
+	if !s.Rules().Match(*it, time.Now()) {
+		log.Debug("item %s doesn't match %s rules", it.ID, s.Name())
+		return nil
+	}
+
 	log.InfoWithFields(ctx, log.Fields{
 		"item_apiref":   it.APIRefHash,
 		"item_size_num": it.Size,
@@ -193,8 +200,18 @@ func (x *RunningStorageUnits) NewItemUnit(_ context.Context, su Interface, i *sd
 		UnitID:       su.ID(),
 		LastModified: time.Now(),
 		Locator:      loc,
+		HashLocator:  HashLocator(loc),
 		Item:         i,
 	}
 
 	return &iu, nil
 }
+
+// HashLocator returns the hash of a locator, stored in clear to find the item units by locator.
+func HashLocator(loc string) string {
+	if loc == "" {
+		return ""
+	}
+	h := sha512.Sum512([]byte(loc))
+	return hex.EncodeToString(h[:])
+}
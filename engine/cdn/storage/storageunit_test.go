@@ -169,7 +169,7 @@ func TestRun(t *testing.T) {
 	actual := btes.String()
 	require.Equal(t, "this is the first log\nthis is the second log\n", actual, "item %s content should match", i.ID)
 
-	itemIDs, err := storage.LoadAllItemIDUnknownByUnitOrderByUnitID(db, localUnitDriver.ID(), cdnUnits.Buffer.ID(), 100)
+	itemIDs, err := storage.LoadAllItemIDUnknownByUnitOrderByUnitID(db, localUnitDriver.ID(), cdnUnits.Buffer.ID(), storage.StorageUnitRules{}, 100)
 	require.NoError(t, err)
 	require.Len(t, itemIDs, 0)
 
@@ -187,7 +187,7 @@ func TestRun(t *testing.T) {
 	actual = btes.String()
 	require.Equal(t, "this is the first log\nthis is the second log\n", actual, "item %s content should match", i.ID)
 
-	itemIDs, err = storage.LoadAllItemIDUnknownByUnitOrderByUnitID(db, localUnitDriver2.ID(), cdnUnits.Buffer.ID(), 100)
+	itemIDs, err = storage.LoadAllItemIDUnknownByUnitOrderByUnitID(db, localUnitDriver2.ID(), cdnUnits.Buffer.ID(), storage.StorageUnitRules{}, 100)
 	require.NoError(t, err)
 	require.Len(t, itemIDs, 0)
 }
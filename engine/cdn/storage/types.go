@@ -44,6 +44,8 @@ type Interface interface {
 	Status(ctx context.Context) []sdk.MonitoringStatusLine
 	SyncBandwidth() float64
 	Remove(ctx context.Context, i sdk.CDNItemUnit) error
+	SetRules(r StorageUnitRules)
+	Rules() StorageUnitRules
 }
 
 type AbstractUnit struct {
@@ -51,6 +53,7 @@ type AbstractUnit struct {
 	u             sdk.CDNUnit
 	syncChan      chan string
 	syncBandwidth float64
+	rules         StorageUnitRules
 }
 
 func (a *AbstractUnit) ExistsInDatabase(ctx context.Context, m *gorpmapper.Mapper, db gorp.SqlExecutor, id string) (*sdk.CDNItemUnit, error) {
@@ -68,6 +71,10 @@ func (a *AbstractUnit) ID() string {
 
 func (a *AbstractUnit) Set(u sdk.CDNUnit) { a.u = u }
 
+func (a *AbstractUnit) SetRules(r StorageUnitRules) { a.rules = r }
+
+func (a *AbstractUnit) Rules() StorageUnitRules { return a.rules }
+
 func (a *AbstractUnit) New(gorts *sdk.GoRoutines, syncParrallel int64, syncBandwidth float64) {
 	a.GoRoutines = gorts
 	a.syncChan = make(chan string, syncParrallel)
@@ -123,7 +130,9 @@ type StorageConfiguration struct {
 	Local         *LocalStorageConfiguration  `toml:"local" json:"local,omitempty" mapstructure:"local"`
 	Swift         *SwiftStorageConfiguration  `toml:"swift" json:"swift,omitempty" mapstructure:"swift"`
 	Webdav        *WebdavStorageConfiguration `toml:"webdav" json:"webdav,omitempty" mapstructure:"webdav"`
+	S3            *S3StorageConfiguration     `toml:"s3" json:"s3,omitempty" mapstructure:"s3"`
 	CDS           *CDSStorageConfiguration    `toml:"cds" json:"cds,omitempty" mapstructure:"cds"`
+	Rules         StorageUnitRules            `toml:"rules" json:"rules" mapstructure:"rules"`
 }
 
 type LocalStorageConfiguration struct {
@@ -157,6 +166,22 @@ type WebdavStorageConfiguration struct {
 	Encryption []convergent.ConvergentEncryptionConfig `toml:"encryption" json:"-" mapstructure:"encryption"`
 }
 
+type S3StorageConfiguration struct {
+	BucketName          string                                  `toml:"bucketName" json:"bucketName" comment:"Name of the S3 bucket to use when storing items"`
+	Region              string                                  `toml:"region" json:"region" default:"us-east-1" comment:"The AWS region"`
+	Prefix              string                                  `toml:"prefix" json:"prefix" comment:"A subfolder of the bucket to store objects in, if left empty will store at the root of the bucket"`
+	AuthFromEnvironment bool                                    `toml:"authFromEnv" json:"authFromEnv" default:"false" comment:"Pull S3 auth information from env vars AWS_SECRET_ACCESS_KEY and AWS_SECRET_KEY_ID"`
+	SharedCredsFile     string                                  `toml:"sharedCredsFile" json:"sharedCredsFile" comment:"The path for the AWS credential file, used with profile"`
+	Profile             string                                  `toml:"profile" json:"profile" comment:"The profile within the AWS credentials file to use"`
+	AccessKeyID         string                                  `toml:"accessKeyId" json:"accessKeyId" comment:"A static AWS Secret Key ID"`
+	SecretAccessKey     string                                  `toml:"secretAccessKey" json:"-" comment:"A static AWS Secret Access Key"`
+	SessionToken        string                                  `toml:"sessionToken" json:"-" comment:"A static AWS session token"`
+	Endpoint            string                                  `toml:"endpoint" json:"endpoint" comment:"S3 API Endpoint (optional, eg. minio)" commented:"true"` //optional
+	DisableSSL          bool                                    `toml:"disableSSL" json:"disableSSL" commented:"true"`                                             //optional
+	ForcePathStyle      bool                                    `toml:"forcePathStyle" json:"forcePathStyle" commented:"true"`                                     //optional
+	Encryption          []convergent.ConvergentEncryptionConfig `toml:"encryption" json:"-" mapstructure:"encryption"`
+}
+
 type RedisBufferConfiguration struct {
 	Host     string `toml:"host" default:"localhost:6379" comment:"If your want to use a redis-sentinel based cluster, follow this syntax ! <clustername>@sentinel1:26379,sentinel2:26379sentinel3:26379" json:"host"`
 	Password string `toml:"password" json:"-"`
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/cdn"
+	"github.com/ovh/cds/engine/hatchery/docker"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -76,7 +77,7 @@ For advanced usage, Debug and Tracing section can be generated as:
 	$ engine config new debug tracing [µService(s)...]
 
 All options
-	$ engine config new [debug] [tracing] [api] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate]
+	$ engine config new [debug] [tracing] [api] [hatchery:docker] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate]
 
 `,
 
@@ -172,6 +173,14 @@ var configCheckCmd = &cobra.Command{
 			}
 		}
 
+		if conf.Hatchery != nil && conf.Hatchery.Docker != nil && conf.Hatchery.Docker.API.HTTP.URL != "" {
+			fmt.Printf("checking hatchery:docker configuration...\n")
+			if err := docker.New().CheckConfiguration(*conf.Hatchery.Docker); err != nil {
+				fmt.Printf("hatchery:docker Configuration: %v\n", err)
+				hasError = true
+			}
+		}
+
 		if conf.Hatchery != nil && conf.Hatchery.Swarm != nil && conf.Hatchery.Swarm.API.HTTP.URL != "" {
 			fmt.Printf("checking hatchery:swarm configuration...\n")
 			if err := swarm.New().CheckConfiguration(*conf.Hatchery.Swarm); err != nil {
@@ -15,6 +15,7 @@ import (
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/cdn"
 	"github.com/ovh/cds/engine/elasticsearch"
+	"github.com/ovh/cds/engine/hatchery/docker"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -91,7 +92,7 @@ This component operates CDS CDN to handle storage
 
 Start all of this with a single command:
 
-	$ engine start [api] [cdn] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate] [ui]
+	$ engine start [api] [cdn] [hatchery:docker] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate] [ui]
 
 All the services are using the same configuration file format.
 
@@ -184,6 +185,14 @@ See $ engine config command for more details.
 				names = append(names, conf.Hatchery.Openstack.Name)
 				types = append(types, sdk.TypeAPI)
 
+			case sdk.TypeHatchery + ":docker":
+				if conf.Hatchery.Docker == nil {
+					sdk.Exit("Unable to start: missing service %s configuration", a)
+				}
+				serviceConfs = append(serviceConfs, serviceConf{arg: a, service: docker.New(), cfg: *conf.Hatchery.Docker})
+				names = append(names, conf.Hatchery.Docker.Name)
+				types = append(types, sdk.TypeHatchery)
+
 			case sdk.TypeHatchery + ":swarm":
 				if conf.Hatchery.Swarm == nil {
 					sdk.Exit("Unable to start: missing service %s configuration", a)
@@ -22,6 +22,7 @@ import (
 	"github.com/ovh/cds/engine/database"
 	"github.com/ovh/cds/engine/elasticsearch"
 	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/hatchery/docker"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -50,7 +51,7 @@ func configBootstrap(args []string) Configuration {
 	if len(args) == 0 {
 		args = []string{
 			"api", "ui", "migrate", "hooks", "vcs", "repositories", "elasticsearch", "cdn",
-			"hatchery:local", "hatchery:kubernetes", "hatchery:marathon", "hatchery:openstack", "hatchery:swarm", "hatchery:vsphere",
+			"hatchery:docker", "hatchery:local", "hatchery:kubernetes", "hatchery:marathon", "hatchery:openstack", "hatchery:swarm", "hatchery:vsphere",
 		}
 	}
 
@@ -89,6 +90,16 @@ func configBootstrap(args []string) Configuration {
 			conf.DatabaseMigrate.Name = "cds-migrate-" + namesgenerator.GetRandomNameCDS(0)
 			conf.DatabaseMigrate.ServiceAPI.DB.Schema = "public"
 			conf.DatabaseMigrate.ServiceCDN.DB.Schema = "cdn"
+		case sdk.TypeHatchery + ":docker":
+			conf.Hatchery.Docker = &docker.HatcheryConfiguration{}
+			defaults.SetDefaults(conf.Hatchery.Docker)
+			var host docker.HostConfiguration
+			defaults.SetDefaults(&host)
+			host.Host = "unix:///var/run/docker.sock"
+			conf.Hatchery.Docker.Hosts = map[string]docker.HostConfiguration{
+				"sample-docker-host": host,
+			}
+			conf.Hatchery.Docker.Name = "cds-hatchery-docker-" + namesgenerator.GetRandomNameCDS(0)
 		case sdk.TypeHatchery + ":local":
 			conf.Hatchery.Local = &local.HatcheryConfiguration{}
 			defaults.SetDefaults(conf.Hatchery.Local)
@@ -135,12 +146,15 @@ func configBootstrap(args []string) Configuration {
 			defaults.SetDefaults(&gitlab)
 			var gerrit vcs.GerritServerConfiguration
 			defaults.SetDefaults(&gerrit)
+			var gitea vcs.GiteaServerConfiguration
+			defaults.SetDefaults(&gitea)
 			conf.VCS.Servers = map[string]vcs.ServerConfiguration{
 				"github":         {URL: "https://github.com", Github: &github},
 				"bitbucket":      {URL: "https://mybitbucket.com", Bitbucket: &bitbucket},
 				"bitbucketcloud": {BitbucketCloud: &bitbucketcloud},
 				"gitlab":         {URL: "https://gitlab.com", Gitlab: &gitlab},
 				"gerrit":         {URL: "http://localhost:8080", Gerrit: &gerrit},
+				"gitea":          {URL: "https://mygitea.com", Gitea: &gitea},
 			}
 			conf.VCS.Name = "cds-vcs-" + namesgenerator.GetRandomNameCDS(0)
 		case sdk.TypeRepositories:
@@ -423,6 +437,33 @@ func configSetStartupData(conf *Configuration) (string, error) {
 			privateKeyPEM, _ := jws.ExportPrivateKey(privateKey)
 			h.VSphere.RSAPrivateKey = string(privateKeyPEM)
 		}
+		if h.Docker != nil {
+			var cfg = api.StartupConfigService{
+				ID:          sdk.UUID(),
+				Name:        "hatchery:docker",
+				Description: "Autogenerated configuration for docker hatchery",
+				ServiceType: sdk.TypeHatchery,
+			}
+
+			var c = sdk.AuthConsumer{
+				ID:          cfg.ID,
+				Name:        cfg.Name,
+				Description: cfg.Description,
+				Type:        sdk.ConsumerBuiltin,
+				Data:        map[string]string{},
+				IssuedAt:    iat,
+			}
+
+			h.Docker.API.Token, err = builtin.NewSigninConsumerToken(&c)
+			if err != nil {
+				return "", err
+			}
+
+			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+			privateKey, _ := jws.NewRandomRSAKey()
+			privateKeyPEM, _ := jws.ExportPrivateKey(privateKey)
+			h.Docker.RSAPrivateKey = string(privateKeyPEM)
+		}
 		if h.Swarm != nil {
 			var cfg = api.StartupConfigService{
 				ID:          sdk.UUID(),
@@ -742,6 +783,24 @@ func getInitTokenFromExistingConfiguration(conf Configuration) (string, error) {
 			}
 			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
 		}
+		if h.Docker != nil {
+			consumerID, iat, err := builtin.CheckSigninConsumerToken(h.Docker.API.Token)
+			if err != nil {
+				return "", fmt.Errorf("cannot parse hatchery:docker signin token: %v", err)
+			}
+			if iat < globalIAT {
+				globalIAT = iat
+			}
+
+			var cfg = api.StartupConfigService{
+				ID:          consumerID,
+				Name:        "hatchery:docker",
+				Description: "Autogenerated configuration for docker hatchery",
+				ServiceType: sdk.TypeHatchery,
+			}
+
+			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+		}
 		if h.Swarm != nil {
 			consumerID, iat, err := builtin.CheckSigninConsumerToken(h.Swarm.API.Token)
 			if err != nil {
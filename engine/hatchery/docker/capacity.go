@@ -0,0 +1,245 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// resources is an amount of memory (in bytes) and cpus (in nano cpus).
+type resources struct {
+	memory   int64
+	nanoCPUs int64
+}
+
+func (r resources) add(o resources) resources {
+	return resources{memory: r.memory + o.memory, nanoCPUs: r.nanoCPUs + o.nanoCPUs}
+}
+
+// hostUsage is the current usage of a host by the hatchery.
+type hostUsage struct {
+	containers int
+	used       resources
+}
+
+// free returns the resources still available on the host for the hatchery.
+func (h *host) free(u hostUsage) resources {
+	return resources{
+		memory:   h.memTotal - h.config.ReservedMemory*1024*1024 - u.used.memory,
+		nanoCPUs: h.nanoCPUs - int64(h.config.ReservedCPUs)*1e9 - u.used.nanoCPUs,
+	}
+}
+
+// canRun returns true if the host can start the given containers count with the needed resources.
+func (h *host) canRun(u hostUsage, nbContainers int, needed resources) bool {
+	if h.config.MaxContainers > 0 && u.containers+nbContainers > h.config.MaxContainers {
+		return false
+	}
+	free := h.free(u)
+	if free.memory < needed.memory {
+		return false
+	}
+	// cpu is checked only if requested, containers without cpu limit share the host cpus
+	if needed.nanoCPUs > 0 && free.nanoCPUs < needed.nanoCPUs {
+		return false
+	}
+	return true
+}
+
+// usage computes the resources used by the running containers of the host. The containers started by a
+// docker hatchery are counted from their labels, the other ones from their memory limit or their memory usage.
+func (h *HatcheryDocker) usage(ctx context.Context, ho *host) (hostUsage, error) {
+	var u hostUsage
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cs, err := ho.ContainerList(ctx, types.ContainerListOptions{})
+	if err != nil {
+		return u, sdk.WrapError(err, "unable to list containers on %s", ho.name)
+	}
+	for _, c := range cs {
+		if c.State != "running" {
+			continue
+		}
+		if c.Labels[LabelHatchery] == h.Config.Name {
+			u.containers++
+		}
+		if _, ok := c.Labels[LabelMemory]; ok {
+			mem, _ := strconv.ParseInt(c.Labels[LabelMemory], 10, 64)
+			cpus, _ := strconv.ParseInt(c.Labels[LabelNanoCPUs], 10, 64)
+			u.used = u.used.add(resources{memory: mem, nanoCPUs: cpus})
+			continue
+		}
+		used, err := containerResources(ctx, ho, c.ID)
+		if err != nil {
+			return u, err
+		}
+		u.used = u.used.add(used)
+	}
+	return u, nil
+}
+
+// containerResources returns the resources of a container that was not started by a docker hatchery.
+// Its memory limit is used if it has one, else its current memory usage.
+func containerResources(ctx context.Context, ho *host, id string) (resources, error) {
+	c, err := ho.ContainerInspect(ctx, id)
+	if err != nil {
+		return resources{}, sdk.WrapError(err, "unable to inspect container %s on %s", id, ho.name)
+	}
+	res := resources{memory: c.HostConfig.Memory, nanoCPUs: c.HostConfig.NanoCPUs}
+	if res.memory > 0 {
+		return res, nil
+	}
+	stats, err := ho.ContainerStats(ctx, id, false)
+	if err != nil {
+		return res, sdk.WrapError(err, "unable to get stats of container %s on %s", id, ho.name)
+	}
+	defer stats.Body.Close() // nolint
+	var s types.StatsJSON
+	if err := json.NewDecoder(stats.Body).Decode(&s); err != nil {
+		return res, sdk.WrapError(err, "unable to read stats of container %s on %s", id, ho.name)
+	}
+	res.memory = int64(s.MemoryStats.Usage)
+	return res, nil
+}
+
+// getContainers returns all the containers created by the hatchery on the host.
+func (h *HatcheryDocker) getContainers(ctx context.Context, ho *host) ([]types.Container, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cs, err := ho.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", LabelHatchery+"="+h.Config.Name)),
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to list containers on %s", ho.name)
+	}
+	return cs, nil
+}
+
+// chooseHost returns the host with the more free memory that can run the containers.
+// The resources are reserved on the host until release is called.
+func (h *HatcheryDocker) chooseHost(ctx context.Context, nbContainers int, needed resources) (*host, func(), error) {
+	// Hosts are listed without lock, the pending reservations are added after
+	usages := make(map[string]hostUsage, len(h.hosts))
+	for _, ho := range h.hosts {
+		u, err := h.usage(ctx, ho)
+		if err != nil {
+			log.Warning(ctx, "hatchery> docker> chooseHost> %v", err)
+			continue
+		}
+		usages[ho.name] = u
+	}
+
+	h.pendingMutex.Lock()
+	defer h.pendingMutex.Unlock()
+
+	var res *host
+	var resFree int64
+	for _, ho := range h.hosts {
+		u, ok := usages[ho.name]
+		if !ok {
+			continue
+		}
+		pending := h.pending[ho.name]
+		u.containers += pending.containers
+		u.used = u.used.add(pending.used)
+		if !ho.canRun(u, nbContainers, needed) {
+			continue
+		}
+		if free := ho.free(u).memory; res == nil || free > resFree {
+			res = ho
+			resFree = free
+		}
+	}
+	if res == nil {
+		return nil, nil, sdk.WithStack(fmt.Errorf("no docker host with enough capacity (containers: %d, memory: %dMo, cpus: %.2f)", nbContainers, needed.memory/1024/1024, float64(needed.nanoCPUs)/1e9))
+	}
+
+	reservation := hostUsage{containers: nbContainers, used: needed}
+	h.reserve(res.name, reservation, 1)
+	release := func() {
+		h.pendingMutex.Lock()
+		defer h.pendingMutex.Unlock()
+		h.reserve(res.name, reservation, -1)
+	}
+	return res, release, nil
+}
+
+// reserve adds (sign=1) or removes (sign=-1) a reservation on a host, pendingMutex must be locked.
+func (h *HatcheryDocker) reserve(hostName string, u hostUsage, sign int) {
+	if h.pending == nil {
+		h.pending = make(map[string]hostUsage)
+	}
+	p := h.pending[hostName]
+	p.containers += sign * u.containers
+	p.used.memory += int64(sign) * u.used.memory
+	p.used.nanoCPUs += int64(sign) * u.used.nanoCPUs
+	h.pending[hostName] = p
+}
+
+// neededResources computes the count of containers and the resources needed by a worker and its services.
+func (h *HatcheryDocker) neededResources(model *sdk.Model, requirements []sdk.Requirement) (int, resources, error) {
+	memory := int64(h.Config.DefaultMemory)
+	if model != nil && model.ModelDocker.Memory != 0 {
+		memory = model.ModelDocker.Memory
+	}
+	nbContainers := 1
+	var servicesMemory, nanoCPUs int64
+	for _, r := range requirements {
+		switch r.Type {
+		case sdk.MemoryRequirement:
+			var err error
+			memory, err = strconv.ParseInt(r.Value, 10, 64)
+			if err != nil {
+				return 0, resources{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid memory requirement %s", r.Value)
+			}
+		case sdk.CPURequirement:
+			cpus, err := parseCPUs(r.Value)
+			if err != nil {
+				return 0, resources{}, err
+			}
+			nanoCPUs = cpus
+		case sdk.ServiceRequirement:
+			nbContainers++
+			servicesMemory += h.serviceMemory(r)
+		}
+	}
+	return nbContainers, resources{memory: (memory + servicesMemory) * 1024 * 1024, nanoCPUs: nanoCPUs}, nil
+}
+
+// serviceMemory returns the memory (in Mo) of a service requirement.
+func (h *HatcheryDocker) serviceMemory(r sdk.Requirement) int64 {
+	_, envm := hatchery.ParseRequirementModel(r.Value)
+	if sm, ok := envm["CDS_SERVICE_MEMORY"]; ok {
+		if i, err := strconv.ParseInt(sm, 10, 64); err == nil && i > 4 {
+			return i
+		}
+	}
+	return int64(h.Config.DefaultServiceMemory)
+}
+
+// parseCPUs parses a cpu requirement ("2", "0.5" or "500m") to nano cpus.
+func parseCPUs(v string) (int64, error) {
+	var cpus float64
+	var err error
+	if len(v) > 1 && v[len(v)-1] == 'm' {
+		var milli int64
+		milli, err = strconv.ParseInt(v[:len(v)-1], 10, 64)
+		cpus = float64(milli) / 1000
+	} else {
+		cpus, err = strconv.ParseFloat(v, 64)
+	}
+	if err != nil || cpus <= 0 {
+		return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid cpu requirement %s", v)
+	}
+	return int64(cpus * 1e9), nil
+}
@@ -0,0 +1,253 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type containerArgs struct {
+	name, image, network string
+	networkAliases       []string
+	cmd, env             []string
+	labels               map[string]string
+	memory               int64 // in Mo
+	nanoCPUs             int64
+}
+
+// createNetwork creates a bridge network dedicated to a worker and its services.
+func (h *HatcheryDocker) createNetwork(ctx context.Context, ho *host, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	_, err := ho.NetworkCreate(ctx, name, types.NetworkCreate{
+		Driver:         "bridge",
+		CheckDuplicate: true,
+		EnableIPv6:     h.Config.NetworkEnableIPv6,
+		Labels: map[string]string{
+			LabelHatchery: h.Config.Name,
+			LabelNetwork:  name,
+		},
+	})
+	return sdk.WrapError(err, "unable to create network %s on %s", name, ho.name)
+}
+
+// createAndStartContainer creates and starts a container, memory and cpus are enforced by the engine.
+func (h *HatcheryDocker) createAndStartContainer(ctx context.Context, ho *host, args containerArgs, spawnArgs hatchery.SpawnArguments) error {
+	if err := h.ensureImage(ctx, ho, args.image, *spawnArgs.Model, spawnArgs.JobID); err != nil {
+		return err
+	}
+
+	labels := make(map[string]string, len(args.labels)+3)
+	for k, v := range args.labels {
+		labels[k] = v
+	}
+	labels[LabelHatchery] = h.Config.Name
+	labels[LabelMemory] = fmt.Sprintf("%d", args.memory*1024*1024)
+	labels[LabelNanoCPUs] = fmt.Sprintf("%d", args.nanoCPUs)
+
+	config := &container.Config{
+		Image:  args.image,
+		Env:    args.env,
+		Cmd:    args.cmd,
+		Labels: labels,
+	}
+	hostConfig := &container.HostConfig{
+		Resources: container.Resources{
+			Memory:     args.memory * 1024 * 1024,
+			MemorySwap: -1,
+			NanoCPUs:   args.nanoCPUs,
+		},
+	}
+	networkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{},
+	}
+	if args.network != "" {
+		hostConfig.NetworkMode = container.NetworkMode(args.network)
+		networkingConfig.EndpointsConfig[args.network] = &network.EndpointSettings{
+			Aliases: append(args.networkAliases, args.name),
+		}
+	}
+
+	log.Info(ctx, "hatchery> docker> createAndStartContainer> create container %s on %s from %s (memory=%dMo)", args.name, ho.name, args.image, args.memory)
+	c, err := ho.ContainerCreate(ctx, config, hostConfig, networkingConfig, args.name)
+	if err != nil {
+		return sdk.WrapError(err, "unable to create container %s on %s", args.name, ho.name)
+	}
+	if err := ho.ContainerStart(ctx, c.ID, types.ContainerStartOptions{}); err != nil {
+		return sdk.WrapError(err, "unable to start container %s on %s", args.name, ho.name)
+	}
+	return nil
+}
+
+// removeContainer kills and removes a container.
+func (h *HatcheryDocker) removeContainer(ctx context.Context, ho *host, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	if err := ho.ContainerRemove(ctx, id, types.ContainerRemoveOptions{RemoveVolumes: true, Force: true}); err != nil {
+		if !strings.Contains(err.Error(), "No such container") && !strings.Contains(err.Error(), "is already in progress") {
+			return sdk.WrapError(err, "unable to remove container %s on %s", sdk.StringFirstN(id, 12), ho.name)
+		}
+	}
+	return nil
+}
+
+// removeWorker removes a worker, its services and its network.
+func (h *HatcheryDocker) removeWorker(ctx context.Context, ho *host, workerName string, containers []types.Container) {
+	for _, c := range containers {
+		if c.Labels[LabelWorker] != workerName && c.Labels[LabelServiceOf] != workerName {
+			continue
+		}
+		log.Debug("hatchery> docker> removeWorker> remove container %s on %s", containerName(c), ho.name)
+		if err := h.removeContainer(ctx, ho, c.ID); err != nil {
+			log.Error(ctx, "hatchery> docker> removeWorker> %v", err)
+		}
+	}
+
+	netName := networkNamePrefix + workerName
+	ctxNet, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := ho.NetworkRemove(ctxNet, netName); err != nil && !strings.Contains(err.Error(), "not found") {
+		log.Error(ctx, "hatchery> docker> removeWorker> unable to remove network %s on %s: %v", netName, ho.name, err)
+	}
+}
+
+func containerName(c types.Container) string {
+	if len(c.Names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(c.Names[0], "/") // docker returns names prefixed by a /
+}
+
+// killAwolWorkers removes the workers unknown by the API or disabled, and the services of removed workers.
+func (h *HatcheryDocker) killAwolWorkers(ctx context.Context) error {
+	ctxAPI, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	apiWorkers, err := h.CDSClient().WorkerList(ctxAPI)
+	if err != nil {
+		return err
+	}
+	mAPIWorkers := make(map[string]sdk.Worker, len(apiWorkers))
+	for _, w := range apiWorkers {
+		mAPIWorkers[w.Name] = w
+	}
+
+	for _, ho := range h.hosts {
+		containers, err := h.getContainers(ctx, ho)
+		if err != nil {
+			log.Warning(ctx, "hatchery> docker> killAwolWorkers> %v", err)
+			continue
+		}
+
+		workers := make(map[string]struct{})
+		for _, c := range containers {
+			workerName := c.Labels[LabelWorker]
+			if workerName == "" {
+				continue
+			}
+			workers[workerName] = struct{}{}
+
+			exited := c.State == "exited"
+			if !exited && time.Since(time.Unix(c.Created, 0)) < 3*time.Minute {
+				continue
+			}
+			w, known := mAPIWorkers[workerName]
+			if !exited && known && w.Status != sdk.StatusDisabled {
+				continue
+			}
+
+			if strings.HasPrefix(workerName, "register-") {
+				h.checkRegistration(ctx, ho, c)
+			}
+			log.Debug("hatchery> docker> killAwolWorkers> remove worker %s on %s", workerName, ho.name)
+			h.removeWorker(ctx, ho, workerName, containers)
+			delete(workers, workerName)
+		}
+
+		// Remove services whose worker does not exist anymore
+		for _, c := range containers {
+			workerName := c.Labels[LabelServiceOf]
+			if workerName == "" {
+				continue
+			}
+			if _, ok := workers[workerName]; ok || time.Since(time.Unix(c.Created, 0)) < 3*time.Minute {
+				continue
+			}
+			h.removeWorker(ctx, ho, workerName, containers)
+			workers[workerName] = struct{}{}
+		}
+	}
+
+	return h.killAwolNetworks(ctx)
+}
+
+// checkRegistration sends the logs of a register container to the API if the registration failed.
+func (h *HatcheryDocker) checkRegistration(ctx context.Context, ho *host, c types.Container) {
+	modelPath := c.Labels[LabelWorkerModel]
+	err := hatchery.CheckWorkerModelRegister(h, modelPath)
+	if err == nil {
+		return
+	}
+
+	spawnErr := sdk.SpawnErrorForm{Error: err.Error()}
+	ctxLogs, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	logsReader, err := ho.ContainerLogs(ctxLogs, c.ID, types.ContainerLogsOptions{ShowStderr: true, ShowStdout: true, Timestamps: true})
+	if err != nil {
+		spawnErr.Logs = []byte(fmt.Sprintf("unable to get container logs: %v", err))
+	} else {
+		defer logsReader.Close() // nolint
+		if logs, err := ioutil.ReadAll(logsReader); err == nil {
+			spawnErr.Logs = logs
+		}
+	}
+
+	tuple := strings.SplitN(modelPath, "/", 2)
+	if len(tuple) != 2 {
+		return
+	}
+	if err := h.CDSClient().WorkerModelSpawnError(tuple[0], tuple[1], spawnErr); err != nil {
+		log.Error(ctx, "hatchery> docker> checkRegistration> unable to send spawn error for model %s: %v", modelPath, err)
+	}
+}
+
+// killAwolNetworks removes the networks of the hatchery without any container.
+func (h *HatcheryDocker) killAwolNetworks(ctx context.Context) error {
+	for _, ho := range h.hosts {
+		ctxList, cancel := context.WithTimeout(ctx, 10*time.Second)
+		nets, err := ho.NetworkList(ctxList, types.NetworkListOptions{
+			Filters: filters.NewArgs(filters.Arg("label", LabelHatchery+"="+h.Config.Name)),
+		})
+		cancel()
+		if err != nil {
+			log.Warning(ctx, "hatchery> docker> killAwolNetworks> unable to list networks on %s: %v", ho.name, err)
+			continue
+		}
+
+		for _, n := range nets {
+			ctxInspect, cancel := context.WithTimeout(ctx, 10*time.Second)
+			net, err := ho.NetworkInspect(ctxInspect, n.ID, types.NetworkInspectOptions{})
+			cancel()
+			if err != nil || len(net.Containers) > 0 || time.Since(net.Created) < 10*time.Minute {
+				continue
+			}
+			log.Info(ctx, "hatchery> docker> killAwolNetworks> remove network %s on %s", net.Name, ho.name)
+			ctxRemove, cancel := context.WithTimeout(ctx, 10*time.Second)
+			if err := ho.NetworkRemove(ctxRemove, net.ID); err != nil {
+				log.Warning(ctx, "hatchery> docker> killAwolNetworks> unable to remove network %s on %s: %v", net.Name, ho.name, err)
+			}
+			cancel()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,484 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// New instanciates a new docker hatchery
+func New() *HatcheryDocker {
+	s := new(HatcheryDocker)
+	s.GoRoutines = sdk.NewGoRoutines()
+	s.Router = &api.Router{
+		Mux: mux.NewRouter(),
+	}
+	return s
+}
+
+var _ hatchery.InterfaceWithModels = new(HatcheryDocker)
+
+// Init cdsclient config.
+func (h *HatcheryDocker) Init(config interface{}) (cdsclient.ServiceConfig, error) {
+	var cfg cdsclient.ServiceConfig
+	sConfig, ok := config.(HatcheryConfiguration)
+	if !ok {
+		return cfg, sdk.WithStack(fmt.Errorf("invalid docker hatchery configuration"))
+	}
+
+	cfg.Host = sConfig.API.HTTP.URL
+	cfg.Token = sConfig.API.Token
+	cfg.InsecureSkipVerifyTLS = sConfig.API.HTTP.Insecure
+	cfg.RequestSecondsTimeout = sConfig.API.RequestTimeout
+	return cfg, nil
+}
+
+// ApplyConfiguration apply an object of type HatcheryConfiguration after checking it
+func (h *HatcheryDocker) ApplyConfiguration(cfg interface{}) error {
+	if err := h.CheckConfiguration(cfg); err != nil {
+		return err
+	}
+
+	var ok bool
+	h.Config, ok = cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	h.HTTPURL = h.Config.URL
+	h.MaxHeartbeatFailures = h.Config.API.MaxHeartbeatFailures
+	h.Common.Common.ServiceName = h.Config.Name
+	h.Common.Common.ServiceType = sdk.TypeHatchery
+	var err error
+	h.Common.Common.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(h.Config.RSAPrivateKey))
+	if err != nil {
+		return fmt.Errorf("unable to parse RSA private Key: %v", err)
+	}
+
+	return nil
+}
+
+// CheckConfiguration checks the validity of the configuration object
+func (h *HatcheryDocker) CheckConfiguration(cfg interface{}) error {
+	hconfig, ok := cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid hatchery docker configuration")
+	}
+
+	if err := hconfig.Check(); err != nil {
+		return fmt.Errorf("Invalid hatchery docker configuration: %v", err)
+	}
+
+	if hconfig.WorkerTTL <= 0 {
+		return fmt.Errorf("worker-ttl must be > 0")
+	}
+	if hconfig.DefaultMemory <= 1 {
+		return fmt.Errorf("worker-memory must be > 1")
+	}
+	if len(hconfig.Hosts) == 0 {
+		return fmt.Errorf("at least one docker host must be configured")
+	}
+	for name, host := range hconfig.Hosts {
+		if host.Host == "" {
+			return fmt.Errorf("invalid docker host %s: missing host address", name)
+		}
+	}
+
+	return nil
+}
+
+// InitHatchery connects the hatchery to the docker engines
+func (h *HatcheryDocker) InitHatchery(ctx context.Context) error {
+	h.hosts = make(map[string]*host, len(h.Config.Hosts))
+	for name, cfg := range h.Config.Hosts {
+		ho, err := newHost(ctx, name, cfg)
+		if err != nil {
+			log.Error(ctx, "hatchery> docker> %v", err)
+			continue
+		}
+		log.Info(ctx, "hatchery> docker> connected to %s (%s): %d cpus, %dMo", name, cfg.Host, ho.nanoCPUs/1e9, ho.memTotal/1024/1024)
+		h.hosts[name] = ho
+	}
+	if len(h.hosts) == 0 {
+		return sdk.WithStack(fmt.Errorf("no docker host available"))
+	}
+
+	if err := h.RefreshServiceLogger(ctx); err != nil {
+		log.Error(ctx, "hatchery> docker> cannot get cdn configuration : %v", err)
+	}
+	h.GoRoutines.Run(context.Background(), "hatchery docker routines", func(ctx context.Context) {
+		h.routines(ctx)
+	})
+	return nil
+}
+
+func newHost(ctx context.Context, name string, cfg HostConfiguration) (*host, error) {
+	opts := []func(*dockerclient.Client) error{dockerclient.WithHost(cfg.Host)}
+	if cfg.CertPath != "" {
+		opts = append(opts, dockerclient.WithTLSClientConfig(
+			filepath.Join(cfg.CertPath, "ca.pem"),
+			filepath.Join(cfg.CertPath, "cert.pem"),
+			filepath.Join(cfg.CertPath, "key.pem"),
+		))
+	}
+	if cfg.APIVersion != "" {
+		opts = append(opts, dockerclient.WithVersion(cfg.APIVersion))
+	}
+	c, err := dockerclient.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to create docker client for %s (%s)", name, cfg.Host)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// Podman and older docker engines don't support the latest api version
+	if cfg.APIVersion == "" {
+		c.NegotiateAPIVersion(ctx)
+	}
+	info, err := c.Info(ctx)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to get info from docker host %s (%s)", name, cfg.Host)
+	}
+
+	return &host{
+		Client:       *c,
+		name:         name,
+		config:       cfg,
+		memTotal:     info.MemTotal,
+		nanoCPUs:     int64(info.NCPU) * 1e9,
+		pulledImages: make(map[string]time.Time),
+		pendingPulls: make(map[string]*imagePull),
+	}, nil
+}
+
+// Status returns sdk.MonitoringStatus, implements interface service.Service
+func (h *HatcheryDocker) Status(ctx context.Context) *sdk.MonitoringStatus {
+	m := h.NewMonitoringStatus()
+	m.AddLine(sdk.MonitoringStatusLine{Component: "Workers", Value: fmt.Sprintf("%d/%d", len(h.WorkersStarted(ctx)), h.Config.Provision.MaxWorker), Status: sdk.MonitoringStatusOK})
+	for name, ho := range h.hosts {
+		u, err := h.usage(ctx, ho)
+		if err != nil {
+			m.AddLine(sdk.MonitoringStatusLine{Component: "Host-" + name, Value: err.Error(), Status: sdk.MonitoringStatusWarn})
+			continue
+		}
+		free := ho.free(u)
+		m.AddLine(sdk.MonitoringStatusLine{
+			Component: "Host-" + name,
+			Value:     fmt.Sprintf("containers: %d/%d, free memory: %dMo, free cpus: %.2f", u.containers, ho.config.MaxContainers, free.memory/1024/1024, float64(free.nanoCPUs)/1e9),
+			Status:    sdk.MonitoringStatusOK,
+		})
+	}
+	return m
+}
+
+// Serve start the hatchery server
+func (h *HatcheryDocker) Serve(ctx context.Context) error {
+	return h.CommonServe(ctx, h)
+}
+
+// Configuration returns Hatchery CommonConfiguration
+func (h *HatcheryDocker) Configuration() service.HatcheryCommonConfiguration {
+	return h.Config.HatcheryCommonConfiguration
+}
+
+// ModelType returns type of hatchery
+func (*HatcheryDocker) ModelType() string {
+	return sdk.Docker
+}
+
+// WorkerModelsEnabled returns Worker model enabled
+func (h *HatcheryDocker) WorkerModelsEnabled() ([]sdk.Model, error) {
+	return h.CDSClient().WorkerModelEnabledList()
+}
+
+// WorkerModelSecretList returns secret for given model.
+func (h *HatcheryDocker) WorkerModelSecretList(m sdk.Model) (sdk.WorkerModelSecrets, error) {
+	return h.CDSClient().WorkerModelSecretList(m.Group.Name, m.Name)
+}
+
+func (h *HatcheryDocker) GetLogger() *logrus.Logger {
+	return h.ServiceLogger
+}
+
+// NeedRegistration return true if worker model need regsitration
+func (h *HatcheryDocker) NeedRegistration(ctx context.Context, m *sdk.Model) bool {
+	return m.NeedRegistration || m.LastRegistration.Unix() < m.UserLastModified.Unix()
+}
+
+// CanSpawn checks if a host has enough capacity to run the worker and its services.
+// Hostname, volume and docker options requirements are not supported.
+func (h *HatcheryDocker) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
+	for _, r := range requirements {
+		switch r.Type {
+		case sdk.HostnameRequirement, sdk.VolumeRequirement:
+			log.Debug("CanSpawn> Job %d has a %s requirement, not supported by docker hatchery", jobID, r.Type)
+			return false
+		case sdk.ModelRequirement:
+			if len(strings.Fields(r.Value)) > 1 {
+				log.Debug("CanSpawn> Job %d has a model requirement with docker options, not supported by docker hatchery", jobID)
+				return false
+			}
+		}
+	}
+
+	nbContainers, needed, err := h.neededResources(model, requirements)
+	if err != nil {
+		log.Debug("CanSpawn> Job %d: %v", jobID, err)
+		return false
+	}
+	for _, ho := range h.hosts {
+		u, err := h.usage(ctx, ho)
+		if err != nil {
+			log.Warning(ctx, "hatchery> docker> CanSpawn> %v", err)
+			continue
+		}
+		if ho.canRun(u, nbContainers, needed) {
+			return true
+		}
+	}
+	log.Debug("CanSpawn> Job %d: no docker host with enough capacity", jobID)
+	return false
+}
+
+// SpawnWorker starts a worker container and its services on the host with the more free memory
+func (h *HatcheryDocker) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) error {
+	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly && !spawnArgs.Warm {
+		return sdk.WithStack(fmt.Errorf("no job ID and no register"))
+	}
+
+	var requirements []sdk.Requirement
+	if !spawnArgs.RegisterOnly {
+		requirements = spawnArgs.Requirements
+	}
+	nbContainers, needed, err := h.neededResources(spawnArgs.Model, requirements)
+	if err != nil {
+		return err
+	}
+	memory := needed.memory / 1024 / 1024
+	if spawnArgs.RegisterOnly {
+		memory = hatchery.MemoryRegisterContainer
+		needed.memory = memory * 1024 * 1024
+	}
+
+	ho, release, err := h.chooseHost(ctx, nbContainers, needed)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := h.spawnWorker(ctx, ho, spawnArgs, memory, needed.nanoCPUs); err != nil {
+		containers, errList := h.getContainers(ctx, ho)
+		if errList == nil {
+			h.removeWorker(ctx, ho, spawnArgs.WorkerName, containers)
+		}
+		return err
+	}
+	return nil
+}
+
+func (h *HatcheryDocker) spawnWorker(ctx context.Context, ho *host, spawnArgs hatchery.SpawnArguments, memory, nanoCPUs int64) error {
+	var services []sdk.Requirement
+	if !spawnArgs.RegisterOnly {
+		for _, r := range spawnArgs.Requirements {
+			if r.Type == sdk.ServiceRequirement {
+				services = append(services, r)
+			}
+		}
+	}
+
+	// Services are reachable by the worker only, on a network dedicated to the job
+	var netName string
+	if len(services) > 0 {
+		netName = networkNamePrefix + spawnArgs.WorkerName
+		if err := h.createNetwork(ctx, ho, netName); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range services {
+		//name= <alias> => the name of the host put in /etc/hosts of the worker
+		//value= "postgres:latest env_1=blabla env_2=blabla" => we can add env variables in requirement name
+		img, envm := hatchery.ParseRequirementModel(r.Value)
+		serviceMemory := h.serviceMemory(r)
+		var cmd []string
+		if sa, ok := envm["CDS_SERVICE_ARGS"]; ok {
+			cmd = hatchery.ParseArgs(sa)
+		}
+		delete(envm, "CDS_SERVICE_MEMORY")
+		delete(envm, "CDS_SERVICE_ARGS")
+		env := make([]string, 0, len(envm))
+		for k, v := range envm {
+			env = append(env, k+"="+v)
+		}
+
+		memory -= serviceMemory
+		args := containerArgs{
+			name:           r.Name + "-" + spawnArgs.WorkerName,
+			image:          img,
+			network:        netName,
+			networkAliases: []string{r.Name},
+			cmd:            cmd,
+			env:            env,
+			labels:         map[string]string{LabelServiceOf: spawnArgs.WorkerName},
+			memory:         serviceMemory,
+		}
+		if err := h.createAndStartContainer(ctx, ho, args, spawnArgs); err != nil {
+			return err
+		}
+	}
+
+	udataParam := sdk.WorkerArgs{
+		API:               h.Config.API.HTTP.URL,
+		Token:             spawnArgs.WorkerToken,
+		HTTPInsecure:      h.Config.API.HTTP.Insecure,
+		Name:              spawnArgs.WorkerName,
+		Model:             spawnArgs.ModelName(),
+		TTL:               h.Config.WorkerTTL,
+		HatcheryName:      h.Name(),
+		GraylogHost:       h.Config.Provision.WorkerLogsOptions.Graylog.Host,
+		GraylogPort:       h.Config.Provision.WorkerLogsOptions.Graylog.Port,
+		GraylogExtraKey:   h.Config.Provision.WorkerLogsOptions.Graylog.ExtraKey,
+		GraylogExtraValue: h.Config.Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		WorkflowJobID:     spawnArgs.JobID,
+	}
+
+	tmpl, err := template.New("cmd").Parse(spawnArgs.Model.ModelDocker.Cmd)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, udataParam); err != nil {
+		return sdk.WithStack(err)
+	}
+	cmd := buffer.String()
+	if spawnArgs.RegisterOnly {
+		cmd += " register"
+	}
+	cmds := append(strings.Fields(spawnArgs.Model.ModelDocker.Shell), cmd)
+
+	envsWm := map[string]string{}
+	envsWm["CDS_MODEL_MEMORY"] = fmt.Sprintf("%d", memory)
+	envsWm["CDS_API"] = udataParam.API
+	envsWm["CDS_TOKEN"] = udataParam.Token
+	envsWm["CDS_NAME"] = udataParam.Name
+	envsWm["CDS_MODEL_PATH"] = udataParam.Model
+	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
+	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
+	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	if spawnArgs.JobID > 0 {
+		envsWm["CDS_BOOKED_WORKFLOW_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
+	}
+
+	// copy envs to avoid data race
+	modelEnvs := make(map[string]string, len(spawnArgs.Model.ModelDocker.Envs))
+	for k, v := range spawnArgs.Model.ModelDocker.Envs {
+		modelEnvs[k] = v
+	}
+	envTemplated, err := sdk.TemplateEnvs(udataParam, modelEnvs)
+	if err != nil {
+		return err
+	}
+	for k, v := range envTemplated {
+		envsWm[k] = v
+	}
+	envs := make([]string, 0, len(envsWm))
+	for k, v := range envsWm {
+		envs = append(envs, k+"="+v)
+	}
+
+	args := containerArgs{
+		name:           spawnArgs.WorkerName,
+		image:          spawnArgs.Model.ModelDocker.Image,
+		network:        netName,
+		networkAliases: []string{"worker"},
+		cmd:            cmds,
+		env:            envs,
+		labels: map[string]string{
+			LabelWorker:      spawnArgs.WorkerName,
+			LabelWorkerModel: spawnArgs.ModelName(),
+		},
+		memory:   memory,
+		nanoCPUs: nanoCPUs,
+	}
+	return h.createAndStartContainer(ctx, ho, args, spawnArgs)
+}
+
+// WorkersStarted returns the names of the workers started but
+// not necessarily register on CDS yet
+func (h *HatcheryDocker) WorkersStarted(ctx context.Context) []string {
+	var res []string
+	for _, ho := range h.hosts {
+		containers, err := h.getContainers(ctx, ho)
+		if err != nil {
+			log.Error(ctx, "hatchery> docker> WorkersStarted> %v", err)
+			continue
+		}
+		for _, c := range containers {
+			if name := c.Labels[LabelWorker]; name != "" {
+				res = append(res, name)
+			}
+		}
+	}
+	return res
+}
+
+// WorkersStartedByModel returns the number of started workers for given model
+func (h *HatcheryDocker) WorkersStartedByModel(ctx context.Context, model *sdk.Model) int {
+	var nb int
+	for _, ho := range h.hosts {
+		containers, err := h.getContainers(ctx, ho)
+		if err != nil {
+			log.Error(ctx, "hatchery> docker> WorkersStartedByModel> %v", err)
+			continue
+		}
+		for _, c := range containers {
+			if c.Labels[LabelWorker] != "" && c.Labels[LabelWorkerModel] == model.Group.Name+"/"+model.Name {
+				nb++
+			}
+		}
+	}
+	return nb
+}
+
+func (h *HatcheryDocker) routines(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.GoRoutines.Exec(ctx, "killAwolWorkers", func(ctx context.Context) {
+				if err := h.killAwolWorkers(ctx); err != nil {
+					log.Error(ctx, "hatchery> docker> cannot kill awol workers: %v", err)
+				}
+			})
+
+			h.GoRoutines.Exec(ctx, "refreshCDNConfiguration", func(ctx context.Context) {
+				if err := h.RefreshServiceLogger(ctx); err != nil {
+					log.Error(ctx, "hatchery> docker> cannot get cdn configuration : %v", err)
+				}
+			})
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "hatchery> docker> exiting routines")
+			}
+			return
+		}
+	}
+}
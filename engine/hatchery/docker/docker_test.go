@@ -0,0 +1,220 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+func newTestHost(t *testing.T, name string, memTotal int64, cpus int64, cfg HostConfiguration) *host {
+	httpClient := cdsclient.NewHTTPClient(time.Minute, false)
+	c, err := dockerclient.NewClientWithOpts(
+		dockerclient.WithHTTPClient(httpClient),
+		dockerclient.WithHost("http://"+name),
+		dockerclient.WithVersion("1.39"),
+	)
+	require.NoError(t, err)
+	gock.InterceptClient(httpClient)
+	return &host{
+		Client:       *c,
+		name:         name,
+		config:       cfg,
+		memTotal:     memTotal * 1024 * 1024,
+		nanoCPUs:     cpus * 1e9,
+		pulledImages: make(map[string]time.Time),
+		pendingPulls: make(map[string]*imagePull),
+	}
+}
+
+func newTestHatchery(hosts ...*host) *HatcheryDocker {
+	h := New()
+	h.Config.Name = "my-hatchery"
+	h.Config.DefaultMemory = 1024
+	h.Config.DefaultServiceMemory = 512
+	h.Config.LatestImagePullInterval = 5
+	h.hosts = make(map[string]*host)
+	for _, ho := range hosts {
+		h.hosts[ho.name] = ho
+	}
+	return h
+}
+
+func TestParseCPUs(t *testing.T) {
+	for v, expected := range map[string]int64{"2": 2e9, "0.5": 5e8, "500m": 5e8} {
+		res, err := parseCPUs(v)
+		require.NoError(t, err)
+		require.Equal(t, expected, res, v)
+	}
+	for _, v := range []string{"", "two", "-1", "m"} {
+		_, err := parseCPUs(v)
+		require.Error(t, err, v)
+	}
+}
+
+func TestIsLatest(t *testing.T) {
+	require.True(t, isLatest("golang"))
+	require.True(t, isLatest("golang:latest"))
+	require.True(t, isLatest("my.registry:5000/golang"))
+	require.False(t, isLatest("golang:1.15"))
+	require.False(t, isLatest("my.registry:5000/golang:1.15"))
+}
+
+func TestNeededResources(t *testing.T) {
+	h := newTestHatchery()
+	nb, res, err := h.neededResources(&sdk.Model{}, []sdk.Requirement{
+		{Type: sdk.MemoryRequirement, Value: "2048"},
+		{Type: sdk.CPURequirement, Value: "2"},
+		{Name: "pg", Type: sdk.ServiceRequirement, Value: "postgres:9.6 CDS_SERVICE_MEMORY=256"},
+		{Name: "redis", Type: sdk.ServiceRequirement, Value: "redis"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, nb)
+	require.Equal(t, int64(2048+256+512)*1024*1024, res.memory)
+	require.Equal(t, int64(2e9), res.nanoCPUs)
+}
+
+func TestChooseHost(t *testing.T) {
+	defer gock.Off()
+
+	host1 := newTestHost(t, "host1", 8192, 4, HostConfiguration{MaxContainers: 10, ReservedMemory: 1024})
+	host2 := newTestHost(t, "host2", 8192, 4, HostConfiguration{MaxContainers: 10, ReservedMemory: 1024})
+	h := newTestHatchery(host1, host2)
+
+	// 5Go used on host1 with a container limited to 1Go not started by the hatchery,
+	// 1.5Go used on host2 with a container without limit using 512Mo
+	gock.New("https://host1").Get("/v1.39/containers/json").Persist().Reply(http.StatusOK).JSON([]types.Container{
+		{ID: "1", State: "running", Labels: map[string]string{LabelHatchery: "my-hatchery", LabelMemory: "4294967296", LabelNanoCPUs: "2000000000"}},
+		{ID: "4", State: "running"},
+	})
+	gock.New("https://host1").Get("/v1.39/containers/4/json").Persist().Reply(http.StatusOK).JSON(types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: "4", HostConfig: &container.HostConfig{Resources: container.Resources{Memory: 1073741824}}},
+	})
+	gock.New("https://host2").Get("/v1.39/containers/json").Persist().Reply(http.StatusOK).JSON([]types.Container{
+		{ID: "2", State: "running", Labels: map[string]string{LabelHatchery: "my-hatchery", LabelMemory: "1073741824"}},
+		{ID: "3", State: "exited", Labels: map[string]string{LabelHatchery: "my-hatchery", LabelMemory: "4294967296"}},
+		{ID: "5", State: "running"},
+	})
+	gock.New("https://host2").Get("/v1.39/containers/5/json").Persist().Reply(http.StatusOK).JSON(types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: "5", HostConfig: &container.HostConfig{}},
+	})
+	gock.New("https://host2").Get("/v1.39/containers/5/stats").Persist().Reply(http.StatusOK).JSON(types.StatsJSON{
+		Stats: types.Stats{MemoryStats: types.MemoryStats{Usage: 536870912}},
+	})
+
+	// Only the running containers of the hatchery count toward the max containers
+	u, err := h.usage(context.TODO(), host2)
+	require.NoError(t, err)
+	require.Equal(t, 1, u.containers)
+	require.Equal(t, int64(1536*1024*1024), u.used.memory)
+
+	ho, release, err := h.chooseHost(context.TODO(), 1, resources{memory: 1024 * 1024 * 1024})
+	require.NoError(t, err)
+	require.Equal(t, "host2", ho.name)
+
+	// host2 has 6Go free, but 1Go is now reserved by the pending spawn
+	ho2, release2, err := h.chooseHost(context.TODO(), 1, resources{memory: 5.5 * 1024 * 1024 * 1024})
+	require.Error(t, err)
+	require.Nil(t, ho2)
+	require.Nil(t, release2)
+
+	release()
+	ho, release, err = h.chooseHost(context.TODO(), 1, resources{memory: 5.5 * 1024 * 1024 * 1024})
+	require.NoError(t, err)
+	require.Equal(t, "host2", ho.name)
+	release()
+
+	// only 2 cpus are free on host1
+	require.True(t, h.CanSpawn(context.TODO(), &sdk.Model{}, 1, []sdk.Requirement{{Type: sdk.CPURequirement, Value: "4"}}))
+	require.False(t, h.CanSpawn(context.TODO(), &sdk.Model{}, 1, []sdk.Requirement{{Type: sdk.CPURequirement, Value: "5"}}))
+	require.False(t, h.CanSpawn(context.TODO(), &sdk.Model{}, 1, []sdk.Requirement{{Type: sdk.HostnameRequirement, Value: "myhost"}}))
+}
+
+func TestEnsureImage(t *testing.T) {
+	defer gock.Off()
+
+	ho := newTestHost(t, "host1", 8192, 4, HostConfiguration{})
+	h := newTestHatchery(ho)
+
+	gock.New("https://host1").Get("/v1.39/images/golang:1.15/json").Times(1).Reply(http.StatusNotFound).JSON(map[string]string{"message": "No such image"})
+	gock.New("https://host1").Post("/v1.39/images/create").MatchParam("fromImage", "golang").MatchParam("tag", "1.15").Times(1).Reply(http.StatusOK).BodyString(`{"status":"Downloaded"}`)
+
+	require.NoError(t, h.ensureImage(context.TODO(), ho, "golang:1.15", sdk.Model{}, 0))
+	require.True(t, gock.IsDone())
+
+	// Image is reused without any call to the docker engine
+	require.NoError(t, h.ensureImage(context.TODO(), ho, "golang:1.15", sdk.Model{}, 0))
+}
+
+func TestSpawnWorkerWithService(t *testing.T) {
+	defer gock.Off()
+	defer gock.Observe(nil)
+
+	ho := newTestHost(t, "host1", 8192, 4, HostConfiguration{MaxContainers: 10})
+	h := newTestHatchery(ho)
+
+	gock.New("https://host1").Get("/v1.39/containers/json").Reply(http.StatusOK).JSON([]types.Container{})
+	gock.New("https://host1").Post("/v1.39/networks/create").Reply(http.StatusCreated).JSON(types.NetworkCreateResponse{ID: "net"})
+	gock.New("https://host1").Get("/v1.39/images/postgres:9.6/json").Reply(http.StatusOK).JSON(types.ImageInspect{})
+	gock.New("https://host1").Post("/v1.39/containers/create").MatchParam("name", "pg-worker1").Reply(http.StatusCreated).JSON(map[string]string{"Id": "pg"})
+	gock.New("https://host1").Post("/v1.39/containers/pg/start").Reply(http.StatusNoContent)
+	gock.New("https://host1").Get("/v1.39/images/my-worker:1.0/json").Reply(http.StatusOK).JSON(types.ImageInspect{})
+	gock.New("https://host1").Post("/v1.39/containers/create").MatchParam("name", "worker1").Reply(http.StatusCreated).JSON(map[string]string{"Id": "worker"})
+	gock.New("https://host1").Post("/v1.39/containers/worker/start").Reply(http.StatusNoContent)
+
+	created := map[string]map[string]interface{}{}
+	gock.Observe(func(request *http.Request, mock gock.Mock) {
+		if request.Method != http.MethodPost || request.URL.Path != "/v1.39/containers/create" {
+			return
+		}
+		body, err := ioutil.ReadAll(request.Body)
+		require.NoError(t, err)
+		var c map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &c))
+		created[request.URL.Query().Get("name")] = c
+	})
+
+	err := h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		WorkerName: "worker1",
+		JobID:      666,
+		Model: &sdk.Model{
+			Name:        "my-worker",
+			Group:       &sdk.Group{Name: "group"},
+			ModelDocker: sdk.ModelDocker{Image: "my-worker:1.0", Shell: "sh -c", Cmd: "worker"},
+		},
+		Requirements: []sdk.Requirement{
+			{Type: sdk.MemoryRequirement, Value: "2048"},
+			{Type: sdk.CPURequirement, Value: "1"},
+			{Name: "pg", Type: sdk.ServiceRequirement, Value: "postgres:9.6 CDS_SERVICE_MEMORY=256 POSTGRES_PASSWORD=pwd"},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+
+	require.Len(t, created, 2)
+	pg := created["pg-worker1"]["HostConfig"].(map[string]interface{})
+	require.Equal(t, float64(256*1024*1024), pg["Memory"])
+	require.Equal(t, "cds-worker1", pg["NetworkMode"])
+	require.Equal(t, []interface{}{"POSTGRES_PASSWORD=pwd"}, created["pg-worker1"]["Env"])
+
+	worker := created["worker1"]["HostConfig"].(map[string]interface{})
+	require.Equal(t, float64(2048*1024*1024), worker["Memory"])
+	require.Equal(t, float64(1e9), worker["NanoCpus"])
+	require.Equal(t, "cds-worker1", worker["NetworkMode"])
+	labels := created["worker1"]["Labels"].(map[string]interface{})
+	require.Equal(t, "worker1", labels[LabelWorker])
+	require.Equal(t, "group/my-worker", labels[LabelWorkerModel])
+	require.Equal(t, "my-hatchery", labels[LabelHatchery])
+}
@@ -0,0 +1,140 @@
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const timeoutPullImage = 10 * time.Minute
+
+// ensureImage pulls the image on the host if needed.
+// Concurrent spawns needing the same image wait for a single pull.
+func (h *HatcheryDocker) ensureImage(ctx context.Context, ho *host, img string, model sdk.Model, jobID int64) error {
+	ho.imagesMutex.Lock()
+	if pulled, ok := ho.pulledImages[img]; ok && !h.needPull(img, pulled) {
+		ho.imagesMutex.Unlock()
+		return nil
+	}
+	if p, ok := ho.pendingPulls[img]; ok {
+		ho.imagesMutex.Unlock()
+		log.Debug("hatchery> docker> ensureImage> waiting for pull of image %s on %s", img, ho.name)
+		select {
+		case <-p.done:
+			return p.err
+		case <-ctx.Done():
+			return sdk.WithStack(ctx.Err())
+		}
+	}
+	p := &imagePull{done: make(chan struct{})}
+	ho.pendingPulls[img] = p
+	ho.imagesMutex.Unlock()
+
+	p.err = h.pullImageIfMissing(ctx, ho, img, model, jobID)
+
+	ho.imagesMutex.Lock()
+	delete(ho.pendingPulls, img)
+	if p.err == nil {
+		ho.pulledImages[img] = time.Now()
+	}
+	ho.imagesMutex.Unlock()
+	close(p.done)
+
+	return p.err
+}
+
+// needPull returns true if an image already pulled at given time should be pulled again.
+func (h *HatcheryDocker) needPull(img string, pulled time.Time) bool {
+	if !isLatest(img) {
+		return false
+	}
+	return time.Since(pulled) > time.Duration(h.Config.LatestImagePullInterval)*time.Minute
+}
+
+func isLatest(img string) bool {
+	// an image without tag is latest, the last ':' could be part of a registry host with port
+	i := strings.LastIndex(img, ":")
+	return i == -1 || strings.Contains(img[i:], "/") || strings.HasSuffix(img, ":latest")
+}
+
+func (h *HatcheryDocker) pullImageIfMissing(ctx context.Context, ho *host, img string, model sdk.Model, jobID int64) error {
+	if !isLatest(img) {
+		ctxInspect, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if _, _, err := ho.ImageInspectWithRaw(ctxInspect, img); err == nil {
+			return nil
+		}
+	}
+
+	hatchery.SendSpawnInfo(ctx, h, jobID, sdk.SpawnMsg{
+		ID:   sdk.MsgSpawnInfoHatcheryStartDockerPull.ID,
+		Args: []interface{}{h.Name(), img},
+	})
+	if err := h.pullImage(ctx, ho, img, model); err != nil {
+		hatchery.SendSpawnInfo(ctx, h, jobID, sdk.SpawnMsg{
+			ID:   sdk.MsgSpawnInfoHatcheryEndDockerPullErr.ID,
+			Args: []interface{}{h.Name(), img, sdk.ExtractHTTPError(err, "").Error()},
+		})
+		return err
+	}
+	hatchery.SendSpawnInfo(ctx, h, jobID, sdk.SpawnMsg{
+		ID:   sdk.MsgSpawnInfoHatcheryEndDockerPull.ID,
+		Args: []interface{}{h.Name(), img},
+	})
+	return nil
+}
+
+func (h *HatcheryDocker) pullImage(ctx context.Context, ho *host, img string, model sdk.Model) error {
+	t0 := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeoutPullImage)
+	defer cancel()
+
+	opts := types.ImageCreateOptions{}
+	if model.ModelDocker.Private {
+		registry := "index.docker.io"
+		if model.ModelDocker.Registry != "" {
+			urlParsed, err := url.Parse(model.ModelDocker.Registry)
+			if err != nil {
+				return sdk.WrapError(err, "cannot parse registry url %s", model.ModelDocker.Registry)
+			}
+			if urlParsed.Host == "" {
+				registry = urlParsed.Path
+			} else {
+				registry = urlParsed.Host
+			}
+		}
+		auth, err := json.Marshal(types.AuthConfig{
+			Username:      model.ModelDocker.Username,
+			Password:      model.ModelDocker.Password,
+			ServerAddress: registry,
+		})
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		opts.RegistryAuth = base64.URLEncoding.EncodeToString(auth)
+	}
+
+	res, err := ho.ImageCreate(ctx, img, opts)
+	if err != nil {
+		return sdk.WrapError(err, "unable to pull image %s on %s", img, ho.name)
+	}
+	defer res.Close() // nolint
+	// the pull is done when the progress stream is fully read
+	if _, err := io.Copy(ioutil.Discard, res); err != nil {
+		return sdk.WrapError(err, "unable to pull image %s on %s", img, ho.name)
+	}
+
+	log.Info(ctx, "hatchery> docker> pullImage> pulling image %s on %s - %.3f seconds elapsed", img, ho.name, time.Since(t0).Seconds())
+	return nil
+}
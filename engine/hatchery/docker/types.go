@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"sync"
+	"time"
+
+	dockerclient "github.com/docker/docker/client"
+
+	hatcheryCommon "github.com/ovh/cds/engine/hatchery"
+	"github.com/ovh/cds/engine/service"
+)
+
+// Labels set on containers and networks created by the hatchery
+const (
+	LabelHatchery     = "cds_hatchery"
+	LabelWorker       = "cds_worker"
+	LabelWorkerModel  = "cds_worker_model"
+	LabelServiceOf    = "cds_service_worker"
+	LabelNetwork      = "cds_worker_net"
+	LabelMemory       = "cds_memory"
+	LabelNanoCPUs     = "cds_nano_cpus"
+	networkNamePrefix = "cds-"
+)
+
+// HatcheryConfiguration is the configuration for docker hatchery
+type HatcheryConfiguration struct {
+	service.HatcheryCommonConfiguration `mapstructure:"commonConfiguration" toml:"commonConfiguration" json:"commonConfiguration"`
+	// WorkerTTL Worker TTL (minutes)
+	WorkerTTL int `mapstructure:"workerTTL" toml:"workerTTL" default:"10" commented:"false" comment:"Worker TTL (minutes)" json:"workerTTL"`
+	// DefaultMemory Worker default memory
+	DefaultMemory int `mapstructure:"defaultMemory" toml:"defaultMemory" default:"1024" commented:"false" comment:"Worker default memory in Mo" json:"defaultMemory"`
+	// DefaultServiceMemory Service default memory
+	DefaultServiceMemory int `mapstructure:"defaultServiceMemory" toml:"defaultServiceMemory" default:"1024" commented:"false" comment:"Service default memory in Mo, CDS_SERVICE_MEMORY can be set on the service requirement" json:"defaultServiceMemory"`
+	// LatestImagePullInterval Minimum interval between two pulls of an image tagged latest
+	LatestImagePullInterval int `mapstructure:"latestImagePullInterval" toml:"latestImagePullInterval" default:"5" commented:"false" comment:"Minimum interval (in minutes) between two pulls of an image tagged 'latest' on a host" json:"latestImagePullInterval"`
+	// NetworkEnableIPv6 if true: set ipv6 to true
+	NetworkEnableIPv6 bool `mapstructure:"networkEnableIPv6" toml:"networkEnableIPv6" default:"false" commented:"false" comment:"if true: hatchery creates private network between services with ipv6 enabled" json:"networkEnableIPv6"`
+	// Hosts Docker or Podman engines
+	Hosts map[string]HostConfiguration `mapstructure:"hosts" toml:"hosts" comment:"List of Docker or Podman engines" json:"hosts,omitempty"`
+}
+
+// HostConfiguration is the configuration to connect to a Docker or Podman engine
+type HostConfiguration struct {
+	Host           string `mapstructure:"host" toml:"host" comment:"Docker engine address, ie. unix:///var/run/docker.sock, unix:///run/podman/podman.sock or tcp://myhost:2375" json:"host"`
+	CertPath       string `mapstructure:"certPath" toml:"certPath" default:"" commented:"true" comment:"Directory containing ca.pem, cert.pem and key.pem for a tls connection" json:"-"`
+	APIVersion     string `mapstructure:"apiVersion" toml:"apiVersion" default:"" comment:"Docker API version, negotiated with the engine if empty" json:"apiVersion"`
+	MaxContainers  int    `mapstructure:"maxContainers" toml:"maxContainers" default:"10" commented:"false" comment:"Max containers on host managed by this hatchery" json:"maxContainers"`
+	ReservedMemory int64  `mapstructure:"reservedMemory" toml:"reservedMemory" default:"1024" commented:"false" comment:"Memory (in Mo) of the host that is never allocated to workers" json:"reservedMemory"`
+	ReservedCPUs   int    `mapstructure:"reservedCPUs" toml:"reservedCPUs" default:"0" commented:"false" comment:"Number of CPUs of the host that are never allocated to workers" json:"reservedCPUs"`
+}
+
+// HatcheryDocker spawns workers as containers on standalone Docker or Podman engines
+type HatcheryDocker struct {
+	hatcheryCommon.Common
+	Config HatcheryConfiguration
+	hosts  map[string]*host
+	// resources reserved by spawns in progress, by host name
+	pendingMutex sync.Mutex
+	pending      map[string]hostUsage
+}
+
+type host struct {
+	dockerclient.Client
+	name   string
+	config HostConfiguration
+	// total resources of the host, from docker info
+	memTotal int64
+	nanoCPUs int64
+
+	// pulled images and pending pulls, to reuse them between spawns
+	imagesMutex  sync.Mutex
+	pulledImages map[string]time.Time
+	pendingPulls map[string]*imagePull
+}
+
+type imagePull struct {
+	done chan struct{}
+	err  error
+}
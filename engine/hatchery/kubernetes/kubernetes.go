@@ -41,6 +41,7 @@ func New() *HatcheryKubernetes {
 }
 
 var _ hatchery.InterfaceWithModels = new(HatcheryKubernetes)
+var _ hatchery.InterfaceWithRegions = new(HatcheryKubernetes)
 
 // InitHatchery register local hatchery with its worker model
 func (h *HatcheryKubernetes) InitHatchery(ctx context.Context) error {
@@ -130,6 +131,10 @@ func (h *HatcheryKubernetes) ApplyConfiguration(cfg interface{}) error {
 
 	h.k8sClient = clientSet
 
+	if err := h.loadPodTemplates(); err != nil {
+		return err
+	}
+
 	if h.Config.Namespace != apiv1.NamespaceDefault {
 		if _, err := clientSet.CoreV1().Namespaces().Get(h.Config.Namespace, metav1.GetOptions{}); err != nil {
 			ns := apiv1.Namespace{}
@@ -217,6 +222,11 @@ func (*HatcheryKubernetes) ModelType() string {
 	return sdk.Docker
 }
 
+// Regions returns the regions accepted by the hatchery in addition to the provision region
+func (h *HatcheryKubernetes) Regions() []string {
+	return h.Config.Regions
+}
+
 // WorkerModelsEnabled returns Worker model enabled.
 func (h *HatcheryKubernetes) WorkerModelsEnabled() ([]sdk.Model, error) {
 	return h.CDSClient().WorkerModelEnabledList()
@@ -228,13 +238,27 @@ func (h *HatcheryKubernetes) WorkerModelSecretList(m sdk.Model) (sdk.WorkerModel
 }
 
 // CanSpawn return wether or not hatchery can spawn model.
-// requirements are not supported
+// hostname requirement is not supported
 func (h *HatcheryKubernetes) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
-	// Service and Hostname requirement are not supported
 	for _, r := range requirements {
-		if r.Type == sdk.HostnameRequirement {
+		switch r.Type {
+		case sdk.HostnameRequirement:
 			log.Debug("CanSpawn> Job %d has a hostname requirement. Kubernetes can't spawn a worker for this job", jobID)
 			return false
+		case sdk.CPURequirement:
+			if _, err := resource.ParseQuantity(r.Value); err != nil {
+				log.Debug("CanSpawn> Job %d has an invalid cpu requirement %s: %v", jobID, r.Value, err)
+				return false
+			}
+		case sdk.VolumeRequirement:
+			if h.Config.DisableVolumeRequirement {
+				log.Debug("CanSpawn> Job %d has a volume requirement. Volume requirements are disabled on this hatchery", jobID)
+				return false
+			}
+			if _, _, err := volumeFromRequirement("volume", r); err != nil {
+				log.Debug("CanSpawn> Job %d: %v", jobID, err)
+				return false
+			}
 		}
 	}
 	return true
@@ -242,7 +266,7 @@ func (h *HatcheryKubernetes) CanSpawn(ctx context.Context, model *sdk.Model, job
 
 // SpawnWorker starts a new worker process
 func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) error {
-	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly {
+	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly && !spawnArgs.Warm {
 		return sdk.WithStack(fmt.Errorf("no job ID and no register"))
 	}
 
@@ -257,14 +281,38 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	}
 
 	memory := int64(h.Config.DefaultMemory)
+	var cpu *resource.Quantity
+	var region string
+	var volumes []apiv1.Volume
+	var volumeMounts []apiv1.VolumeMount
 	for _, r := range spawnArgs.Requirements {
-		if r.Type == sdk.MemoryRequirement {
+		switch r.Type {
+		case sdk.MemoryRequirement:
 			var err error
 			memory, err = strconv.ParseInt(r.Value, 10, 64)
 			if err != nil {
 				log.Warning(ctx, "spawnKubernetesDockerWorker> %s unable to parse memory requirement %d: %v", logJob, memory, err)
 				return err
 			}
+		case sdk.CPURequirement:
+			q, err := resource.ParseQuantity(r.Value)
+			if err != nil {
+				log.Warning(ctx, "spawnKubernetesDockerWorker> %s unable to parse cpu requirement %s: %v", logJob, r.Value, err)
+				return sdk.WithStack(err)
+			}
+			cpu = &q
+		case sdk.RegionRequirement:
+			region = r.Value
+		case sdk.VolumeRequirement:
+			if h.Config.DisableVolumeRequirement {
+				return sdk.WithStack(fmt.Errorf("volume requirements are disabled on this hatchery"))
+			}
+			v, m, err := volumeFromRequirement(fmt.Sprintf("volume-%d", len(volumes)), r)
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			volumes = append(volumes, v)
+			volumeMounts = append(volumeMounts, m)
 		}
 	}
 
@@ -297,6 +345,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	if spawnArgs.RegisterOnly {
 		cmd += " register"
 		memory = hatchery.MemoryRegisterContainer
+		cpu = nil
 	}
 
 	if spawnArgs.Model.ModelDocker.Envs == nil {
@@ -365,11 +414,30 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 							apiv1.ResourceMemory: resource.MustParse(fmt.Sprintf("%d", memory)),
 						},
 					},
+					VolumeMounts: volumeMounts,
 				},
 			},
+			Volumes: volumes,
 		},
 	}
 
+	if cpu != nil {
+		podSchema.Spec.Containers[0].Resources.Requests[apiv1.ResourceCPU] = *cpu
+		podSchema.Spec.Containers[0].Resources.Limits = apiv1.ResourceList{apiv1.ResourceCPU: *cpu}
+	}
+
+	if region != "" && h.Config.RegionNodeSelectorKey != "" {
+		podSchema.Spec.NodeSelector = map[string]string{h.Config.RegionNodeSelectorKey: region}
+	}
+	if region != "" && h.Config.RegionTolerationKey != "" {
+		podSchema.Spec.Tolerations = []apiv1.Toleration{{
+			Key:      h.Config.RegionTolerationKey,
+			Operator: apiv1.TolerationOpEqual,
+			Value:    region,
+			Effect:   apiv1.TaintEffectNoSchedule,
+		}}
+	}
+
 	var services []sdk.Requirement
 	for _, req := range spawnArgs.Requirements {
 		if req.Type == sdk.ServiceRequirement {
@@ -442,6 +510,10 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		podSchema.Spec.HostAliases[0].Hostnames[i+1] = strings.ToLower(serv.Name)
 	}
 
+	if tmpl := h.podTemplateForModel(spawnArgs.Model); tmpl != nil {
+		applyPodTemplate(&podSchema, *tmpl)
+	}
+
 	_, err := h.k8sClient.CoreV1().Pods(h.Config.Namespace).Create(&podSchema)
 
 	log.Debug("hatchery> kubernetes> SpawnWorker> %s > Pod created", spawnArgs.WorkerName)
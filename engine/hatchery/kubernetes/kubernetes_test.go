@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -123,3 +125,106 @@ func TestHatcheryKubernetes_Status(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, gock.IsDone())
 }
+
+func TestHatcheryKubernetes_SpawnWorkerWithRequirementsAndTemplate(t *testing.T) {
+	defer gock.Off()
+	defer gock.Observe(nil)
+	h := NewHatcheryKubernetesTest(t)
+	h.Config.RegionNodeSelectorKey = "topology.kubernetes.io/region"
+	h.Config.RegionTolerationKey = "cds/region"
+
+	tmp, err := ioutil.TempDir("", "cds-kubernetes")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp) // nolint
+	tmplFile := filepath.Join(tmp, "pod.yml")
+	require.NoError(t, ioutil.WriteFile(tmplFile, []byte(`
+metadata:
+  labels:
+    team: cds
+spec:
+  serviceAccountName: cds-worker
+  nodeSelector:
+    node.kubernetes.io/lifecycle: spot
+  containers:
+  - name: worker
+    env:
+    - name: FROM_TEMPLATE
+      value: "true"
+  - name: proxy
+    image: envoyproxy/envoy
+`), 0644))
+	h.Config.WorkerModelsPodTemplates = map[string]string{"group/model1": tmplFile}
+	require.NoError(t, h.loadPodTemplates())
+
+	m := &sdk.Model{
+		Name: "model1",
+		Group: &sdk.Group{
+			Name: "group",
+		},
+	}
+
+	gock.New("http://lolcat.kube").Post("/api/v1/namespaces/hachibi/pods").Reply(http.StatusOK).JSON(v1.Pod{})
+
+	var checkRequest gock.ObserverFunc = func(request *http.Request, mock gock.Mock) {
+		if request.Body == nil {
+			return
+		}
+		bodyContent, err := ioutil.ReadAll(request.Body)
+		assert.NoError(t, err)
+		var podRequest v1.Pod
+		require.NoError(t, json.Unmarshal(bodyContent, &podRequest))
+
+		require.Equal(t, "cds", podRequest.Labels["team"])
+		require.Equal(t, "kyubi", podRequest.Labels["CDS_HATCHERY_NAME"])
+		require.Equal(t, "cds-worker", podRequest.Spec.ServiceAccountName)
+		require.Equal(t, v1.RestartPolicyNever, podRequest.Spec.RestartPolicy)
+		require.Equal(t, "spot", podRequest.Spec.NodeSelector["node.kubernetes.io/lifecycle"])
+		require.Equal(t, "eu", podRequest.Spec.NodeSelector["topology.kubernetes.io/region"])
+		require.Len(t, podRequest.Spec.Tolerations, 1)
+		require.Equal(t, "cds/region", podRequest.Spec.Tolerations[0].Key)
+		require.Equal(t, "eu", podRequest.Spec.Tolerations[0].Value)
+
+		require.Len(t, podRequest.Spec.Containers, 2)
+		worker := podRequest.Spec.Containers[0]
+		require.Equal(t, "k8s-toto", worker.Name)
+		require.Equal(t, "500m", worker.Resources.Requests.Cpu().String())
+		require.Equal(t, "500m", worker.Resources.Limits.Cpu().String())
+		require.Equal(t, "FROM_TEMPLATE", worker.Env[0].Name)
+		require.Len(t, worker.VolumeMounts, 1)
+		require.Equal(t, "/cache", worker.VolumeMounts[0].MountPath)
+		require.Equal(t, "proxy", podRequest.Spec.Containers[1].Name)
+
+		require.Len(t, podRequest.Spec.Volumes, 1)
+		require.NotNil(t, podRequest.Spec.Volumes[0].PersistentVolumeClaim)
+		require.Equal(t, "build-cache", podRequest.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	}
+	gock.Observe(checkRequest)
+
+	err = h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		JobID:      666,
+		NodeRunID:  999,
+		Model:      m,
+		WorkerName: "k8s-toto",
+		Requirements: []sdk.Requirement{
+			{Name: "cpu", Type: sdk.CPURequirement, Value: "500m"},
+			{Name: "region", Type: sdk.RegionRequirement, Value: "eu"},
+			{Name: "cache", Type: sdk.VolumeRequirement, Value: "type=pvc,source=build-cache,destination=/cache"},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
+func TestHatcheryKubernetes_CanSpawn(t *testing.T) {
+	h := NewHatcheryKubernetesTest(t)
+
+	require.True(t, h.CanSpawn(context.TODO(), nil, 1, []sdk.Requirement{{Type: sdk.CPURequirement, Value: "2"}}))
+	require.False(t, h.CanSpawn(context.TODO(), nil, 1, []sdk.Requirement{{Type: sdk.CPURequirement, Value: "two"}}))
+	require.False(t, h.CanSpawn(context.TODO(), nil, 1, []sdk.Requirement{{Type: sdk.HostnameRequirement, Value: "myhost"}}))
+
+	volume := sdk.Requirement{Type: sdk.VolumeRequirement, Value: "type=emptydir,destination=/tmp/data"}
+	require.True(t, h.CanSpawn(context.TODO(), nil, 1, []sdk.Requirement{volume}))
+	require.False(t, h.CanSpawn(context.TODO(), nil, 1, []sdk.Requirement{{Type: sdk.VolumeRequirement, Value: "type=bind,source=/,destination=/host"}}))
+	h.Config.DisableVolumeRequirement = true
+	require.False(t, h.CanSpawn(context.TODO(), nil, 1, []sdk.Requirement{volume}))
+}
@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"io/ioutil"
+
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// podTemplateWorkerContainer is the name of the container in a pod template that is merged with the worker container.
+const podTemplateWorkerContainer = "worker"
+
+func readPodTemplate(path string) (apiv1.Pod, error) {
+	var pod apiv1.Pod
+	btes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return pod, sdk.WrapError(err, "unable to read pod template %s", path)
+	}
+	if err := yaml.Unmarshal(btes, &pod); err != nil {
+		return pod, sdk.WrapError(err, "unable to parse pod template %s", path)
+	}
+	return pod, nil
+}
+
+// loadPodTemplates reads all the pod templates from the hatchery configuration.
+func (h *HatcheryKubernetes) loadPodTemplates() error {
+	h.podTemplate = nil
+	if h.Config.PodTemplate != "" {
+		pod, err := readPodTemplate(h.Config.PodTemplate)
+		if err != nil {
+			return err
+		}
+		h.podTemplate = &pod
+	}
+
+	h.modelPodTemplates = make(map[string]apiv1.Pod, len(h.Config.WorkerModelsPodTemplates))
+	for modelPath, path := range h.Config.WorkerModelsPodTemplates {
+		pod, err := readPodTemplate(path)
+		if err != nil {
+			return err
+		}
+		h.modelPodTemplates[modelPath] = pod
+	}
+	return nil
+}
+
+// podTemplateForModel returns the pod template to use for given worker model, nil if none.
+func (h *HatcheryKubernetes) podTemplateForModel(model *sdk.Model) *apiv1.Pod {
+	if model != nil && model.Group != nil {
+		if pod, ok := h.modelPodTemplates[model.Group.Name+"/"+model.Name]; ok {
+			return &pod
+		}
+	}
+	return h.podTemplate
+}
+
+// applyPodTemplate merges the generated worker pod into the template.
+// The template is used as a base, fields set by the hatchery always win.
+func applyPodTemplate(pod *apiv1.Pod, tmpl apiv1.Pod) {
+	for k, v := range tmpl.Labels {
+		if _, ok := pod.Labels[k]; !ok {
+			pod.Labels[k] = v
+		}
+	}
+	if len(tmpl.Annotations) > 0 && pod.Annotations == nil {
+		pod.Annotations = make(map[string]string, len(tmpl.Annotations))
+	}
+	for k, v := range tmpl.Annotations {
+		if _, ok := pod.Annotations[k]; !ok {
+			pod.Annotations[k] = v
+		}
+	}
+
+	spec := *tmpl.Spec.DeepCopy()
+	spec.RestartPolicy = pod.Spec.RestartPolicy
+	spec.TerminationGracePeriodSeconds = pod.Spec.TerminationGracePeriodSeconds
+	spec.HostAliases = append(spec.HostAliases, pod.Spec.HostAliases...)
+	spec.ImagePullSecrets = append(spec.ImagePullSecrets, pod.Spec.ImagePullSecrets...)
+	spec.Tolerations = append(spec.Tolerations, pod.Spec.Tolerations...)
+	spec.Volumes = append(spec.Volumes, pod.Spec.Volumes...)
+	if len(pod.Spec.NodeSelector) > 0 && spec.NodeSelector == nil {
+		spec.NodeSelector = make(map[string]string, len(pod.Spec.NodeSelector))
+	}
+	for k, v := range pod.Spec.NodeSelector {
+		spec.NodeSelector[k] = v
+	}
+
+	// The first container is the worker, others are services
+	var sidecars []apiv1.Container
+	workerContainer := pod.Spec.Containers[0]
+	for _, c := range spec.Containers {
+		if c.Name == podTemplateWorkerContainer {
+			workerContainer = mergeWorkerContainer(workerContainer, c)
+			continue
+		}
+		sidecars = append(sidecars, c)
+	}
+	spec.Containers = append([]apiv1.Container{workerContainer}, pod.Spec.Containers[1:]...)
+	spec.Containers = append(spec.Containers, sidecars...)
+
+	pod.Spec = spec
+}
+
+// mergeWorkerContainer adds to the worker container the settings from the template container.
+func mergeWorkerContainer(c apiv1.Container, tmpl apiv1.Container) apiv1.Container {
+	c.Env = append(tmpl.Env, c.Env...)
+	c.EnvFrom = append(tmpl.EnvFrom, c.EnvFrom...)
+	c.VolumeMounts = append(tmpl.VolumeMounts, c.VolumeMounts...)
+	if c.SecurityContext == nil {
+		c.SecurityContext = tmpl.SecurityContext
+	}
+	if c.WorkingDir == "" {
+		c.WorkingDir = tmpl.WorkingDir
+	}
+	c.Resources.Requests = mergeResourceList(c.Resources.Requests, tmpl.Resources.Requests)
+	c.Resources.Limits = mergeResourceList(c.Resources.Limits, tmpl.Resources.Limits)
+	return c
+}
+
+// mergeResourceList adds to the list the resources from the template that are not already set.
+func mergeResourceList(l, tmpl apiv1.ResourceList) apiv1.ResourceList {
+	if len(tmpl) > 0 && l == nil {
+		l = make(apiv1.ResourceList, len(tmpl))
+	}
+	for k, v := range tmpl {
+		if _, ok := l[k]; !ok {
+			l[k] = v
+		}
+	}
+	return l
+}
@@ -0,0 +1,49 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// volumeFromRequirement computes the pod volume and the worker volume mount from a volume requirement.
+// Only persistent volume claims and empty dirs are supported, example:
+// type=pvc,source=my-claim,destination=/dirInJob,readonly or type=emptydir,destination=/dirInJob
+func volumeFromRequirement(name string, r sdk.Requirement) (apiv1.Volume, apiv1.VolumeMount, error) {
+	var mtype, source, destination string
+	var readonly bool
+	for _, o := range strings.Split(r.Value, ",") {
+		if strings.HasPrefix(o, "type=") {
+			mtype = strings.TrimPrefix(o, "type=")
+		} else if strings.HasPrefix(o, "source=") {
+			source = strings.TrimPrefix(o, "source=")
+		} else if strings.HasPrefix(o, "destination=") {
+			destination = strings.TrimPrefix(o, "destination=")
+		} else if o == "readonly" {
+			readonly = true
+		}
+	}
+
+	volume := apiv1.Volume{Name: name}
+	mount := apiv1.VolumeMount{Name: name, MountPath: destination, ReadOnly: readonly}
+	if destination == "" {
+		return volume, mount, fmt.Errorf("invalid volume requirement - destination is empty. Example: type=pvc,source=my-claim,destination=/dirInJob current:%s", r.Value)
+	}
+
+	switch strings.ToLower(mtype) {
+	case "pvc":
+		if source == "" {
+			return volume, mount, fmt.Errorf("invalid volume requirement - source is empty. Example: type=pvc,source=my-claim,destination=/dirInJob current:%s", r.Value)
+		}
+		volume.PersistentVolumeClaim = &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: source, ReadOnly: readonly}
+	case "emptydir":
+		volume.EmptyDir = &apiv1.EmptyDirVolumeSource{}
+	default:
+		return volume, mount, fmt.Errorf("invalid volume requirement - type %q is not supported, use pvc or emptydir. current:%s", mtype, r.Value)
+	}
+
+	return volume, mount, nil
+}
@@ -11,6 +11,7 @@ import (
 	hatcheryCommon "github.com/ovh/cds/engine/hatchery"
 	"github.com/ovh/cds/sdk/cdsclient"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -48,6 +49,18 @@ type HatcheryConfiguration struct {
 	KubernetesClientCertData string `mapstructure:"clientCertData" toml:"clientCertData" default:"" commented:"true" comment:"Client certificate data (content, not path and not base64 encoded) for tls kubernetes (optional if no tls needed)" json:"-"`
 	// KubernetesKeyData Client certificate data for tls kubernetes (optional if no tls needed)
 	KubernetesClientKeyData string `mapstructure:"clientKeyData" toml:"clientKeyData" default:"" commented:"true" comment:"Client certificate data (content, not path and not base64 encoded) for tls kubernetes (optional if no tls needed)" json:"-"`
+	// PodTemplate Pod template file merged with the pod of each worker
+	PodTemplate string `mapstructure:"podTemplate" toml:"podTemplate" default:"" commented:"true" comment:"Path to a pod template file (yaml) merged with the pod of each worker. A container named 'worker' in the template is merged with the worker container, others are added as sidecars" json:"podTemplate"`
+	// WorkerModelsPodTemplates Pod template files by worker model
+	WorkerModelsPodTemplates map[string]string `mapstructure:"workerModelsPodTemplates" toml:"workerModelsPodTemplates" commented:"true" comment:"Path to pod template files (yaml) by worker model (ie. 'mygroup/mymodel'), overrides podTemplate for these models" json:"workerModelsPodTemplates"`
+	// Regions Regions accepted by the hatchery in addition to the provision region
+	Regions []string `mapstructure:"regions" toml:"regions" commented:"true" comment:"Regions of the cluster accepted in the region requirement of a job in addition to commonConfiguration.provision.region (ie. [\"eu\", \"us\"]). The pod is scheduled in the region with regionNodeSelectorKey and regionTolerationKey" json:"regions"`
+	// RegionNodeSelectorKey Node label matching the region requirement
+	RegionNodeSelectorKey string `mapstructure:"regionNodeSelectorKey" toml:"regionNodeSelectorKey" default:"" commented:"true" comment:"Node label that must match the value of the region requirement of a job (ie. 'topology.kubernetes.io/region')" json:"regionNodeSelectorKey"`
+	// RegionTolerationKey Node taint tolerated with the region requirement
+	RegionTolerationKey string `mapstructure:"regionTolerationKey" toml:"regionTolerationKey" default:"" commented:"true" comment:"Node taint key tolerated (effect NoSchedule) with the value of the region requirement of a job" json:"regionTolerationKey"`
+	// DisableVolumeRequirement Refuse jobs with a volume requirement
+	DisableVolumeRequirement bool `mapstructure:"disableVolumeRequirement" toml:"disableVolumeRequirement" default:"false" commented:"true" comment:"Refuse jobs with a volume requirement (ie. for a shared hatchery)" json:"disableVolumeRequirement"`
 }
 
 // HatcheryKubernetes implements HatcheryMode interface for local usage
@@ -60,6 +73,9 @@ type HatcheryKubernetes struct {
 	os        string
 	arch      string
 	k8sClient *kubernetes.Clientset
+	// pod templates loaded from configuration
+	podTemplate       *apiv1.Pod
+	modelPodTemplates map[string]apiv1.Pod
 }
 
 type workerCmd struct {
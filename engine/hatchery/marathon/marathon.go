@@ -170,7 +170,7 @@ func (h *HatcheryMarathon) WorkerModelSecretList(m sdk.Model) (sdk.WorkerModelSe
 // CanSpawn return wether or not hatchery can spawn model
 // requirements services are not supported
 func (h *HatcheryMarathon) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
-	// Service and Hostname requirement are not supported
+	// Service, Hostname and CPU requirement are not supported
 	for _, r := range requirements {
 		if r.Type == sdk.ServiceRequirement {
 			log.Debug("CanSpawn> Job %d has a service requirement. Marathon can't spawn a worker for this job", jobID)
@@ -178,6 +178,9 @@ func (h *HatcheryMarathon) CanSpawn(ctx context.Context, model *sdk.Model, jobID
 		} else if r.Type == sdk.HostnameRequirement {
 			log.Debug("CanSpawn> Job %d has a hostname requirement. Marathon can't spawn a worker for this job", jobID)
 			return false
+		} else if r.Type == sdk.CPURequirement {
+			log.Debug("CanSpawn> Job %d has a cpu requirement. Marathon can't spawn a worker for this job", jobID)
+			return false
 		}
 	}
 
@@ -222,7 +225,7 @@ func (h *HatcheryMarathon) SpawnWorker(ctx context.Context, spawnArgs hatchery.S
 		log.Debug("spawnWorker> spawning worker %s (%s)", spawnArgs.Model.Name, spawnArgs.Model.ModelDocker.Image)
 	}
 
-	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly {
+	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly && !spawnArgs.Warm {
 		return sdk.WithStack(fmt.Errorf("no job ID and no register"))
 	}
 
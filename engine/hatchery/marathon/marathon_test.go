@@ -398,3 +398,10 @@ func TestCanSpawnWithService(t *testing.T) {
 	canSpawn := h.CanSpawn(context.TODO(), m, int64(1), []sdk.Requirement{{Name: "pg", Type: sdk.ServiceRequirement, Value: "postgres:9.5.4"}})
 	assert.False(t, canSpawn)
 }
+
+func TestCanSpawnWithCPU(t *testing.T) {
+	h := InitMarathonMarathonTest(marathonJDD{})
+	m := &sdk.Model{Name: "fake"}
+	canSpawn := h.CanSpawn(context.TODO(), m, int64(1), []sdk.Requirement{{Name: "cpu", Type: sdk.CPURequirement, Value: "2"}})
+	assert.False(t, canSpawn)
+}
@@ -193,7 +193,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 	ctx, end := telemetry.Span(ctx, "swarm.SpawnWorker")
 	defer end()
 
-	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly {
+	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly && !spawnArgs.Warm {
 		return sdk.WithStack(fmt.Errorf("unable to spawn worker, no Job ID and no Register."))
 	}
 
@@ -469,11 +469,14 @@ const (
 // CanSpawn checks if the model can be spawned by this hatchery
 // it checks on every docker engine is one of the docker has availability
 func (h *HatcherySwarm) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
-	// Hostname requirement are not supported
+	// Hostname and CPU requirement are not supported
 	for _, r := range requirements {
 		if r.Type == sdk.HostnameRequirement {
 			log.Debug("CanSpawn> Job %d has a hostname requirement. Swarm can't spawn a worker for this job", jobID)
 			return false
+		} else if r.Type == sdk.CPURequirement {
+			log.Debug("CanSpawn> Job %d has a cpu requirement. Swarm can't spawn a worker for this job", jobID)
+			return false
 		}
 	}
 	for dockerName, dockerClient := range h.dockerClients {
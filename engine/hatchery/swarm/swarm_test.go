@@ -433,6 +433,23 @@ func TestHatcherySwarm_MaxContainerReached(t *testing.T) {
 	assert.True(t, gock.IsDone())
 }
 
+func TestHatcherySwarm_CanSpawnWithCPU(t *testing.T) {
+	defer gock.Off()
+	h := InitTestHatcherySwarm(t)
+	m := sdk.Model{
+		ID:   1,
+		Name: "my-model",
+		Group: &sdk.Group{
+			ID:   1,
+			Name: "mygroup",
+		},
+	}
+	jobID := int64(1)
+	b := h.CanSpawn(context.TODO(), &m, jobID, []sdk.Requirement{{Name: "cpu", Type: sdk.CPURequirement, Value: "2"}})
+	assert.False(t, b)
+	assert.True(t, gock.IsDone())
+}
+
 func TestHatcherySwarm_MaxContainerRatioService100(t *testing.T) {
 	defer gock.Off()
 	h := InitTestHatcherySwarm(t)
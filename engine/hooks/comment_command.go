@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// doCommentCommandExecution reads the pull request comments received by a comment command hook
+// and sends the commands they contain to CDS API. Comments without command are ignored.
+func (s *Service) doCommentCommandExecution(ctx context.Context, e *sdk.TaskExecution) error {
+	cmds, err := s.commentCommandsFromWebHook(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	confProj := e.Config[sdk.HookConfigProject]
+	confWorkflow := e.Config[sdk.HookConfigWorkflow]
+	for _, cmd := range cmds {
+		res, err := s.Client.WorkflowHookCommand(confProj.Value, confWorkflow.Value, e.UUID, cmd)
+		if err != nil {
+			return sdk.WrapError(err, "unable to execute command %q on workflow %s/%s", cmd.Name, confProj.Value, confWorkflow.Value)
+		}
+		if res.WorkflowRunNumber > 0 {
+			e.WorkflowRun = res.WorkflowRunNumber
+		}
+		log.Debug("Hooks> command %q from %s on workflow %s/%s: %s", cmd.Name, cmd.Author, confProj.Value, confWorkflow.Value, res.Message)
+	}
+	return nil
+}
+
+// commentCommandsFromWebHook returns the commands written in the pull request comments of a repository webhook delivery.
+func (s *Service) commentCommandsFromWebHook(ctx context.Context, e *sdk.TaskExecution) ([]sdk.HookCommentCommand, error) {
+	hs, err := s.executeRepositoryWebHook(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	vcsType := repositoryManagerType(e)
+	prefix := e.Config[sdk.HookConfigCommandPrefix].Value
+	cmds := make([]sdk.HookCommentCommand, 0, len(hs))
+	for _, h := range hs {
+		// Comments on issues or commits can't drive the workflow runs of a pull request
+		prID, _ := strconv.ParseInt(h.Payload[PR_ID], 10, 64)
+		if prID == 0 {
+			continue
+		}
+		cmd, ok := sdk.ParseHookCommentCommand(prefix, h.Payload[PR_COMMENT_TEXT])
+		if !ok {
+			continue
+		}
+		cmd.PullRequestID = prID
+		cmd.VCSType = vcsType
+		cmd.Author = h.Payload[PR_COMMENT_AUTHOR]
+		cmd.Payload = make(map[string]string)
+		for k, v := range h.Payload {
+			// The raw event is not needed to run the workflow
+			if k == PAYLOAD || strings.HasPrefix(k, "git.pr.comment") {
+				continue
+			}
+			cmd.Payload[k] = v
+		}
+		cmds = append(cmds, *cmd)
+	}
+	return cmds, nil
+}
+
+// repositoryManagerType returns the type of the repository manager that sent the webhook.
+func repositoryManagerType(e *sdk.TaskExecution) string {
+	headers := e.WebHook.RequestHeader
+	switch {
+	case len(headers[GiteaHeader]) > 0:
+		return "gitea"
+	case len(headers[GithubHeader]) > 0:
+		return "github"
+	case len(headers[GitlabHeader]) > 0:
+		return "gitlab"
+	case len(headers[BitbucketHeader]) > 0:
+		return "bitbucket"
+	}
+	return ""
+}
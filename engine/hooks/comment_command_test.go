@@ -0,0 +1,147 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_doCommentCommandExecutionGithub(t *testing.T) {
+	log.SetLogger(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mock_cdsclient.NewMockInterface(ctrl)
+	var s Service
+	s.Client = m
+
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeCommentCommand,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigProject:       {Value: "PROJ"},
+			sdk.HookConfigWorkflow:      {Value: "my-workflow"},
+			sdk.HookConfigEventFilter:   {Value: "issue_comment"},
+			sdk.HookConfigCommandPrefix: {Value: "/cds"},
+		},
+		WebHook: &sdk.WebHookExecution{
+			RequestBody:   []byte(githubIssueCommentEvent),
+			RequestHeader: map[string][]string{GithubHeader: {"issue_comment"}},
+		},
+	}
+
+	m.EXPECT().WorkflowHookCommand("PROJ", "my-workflow", task.UUID, gomock.Any()).DoAndReturn(
+		func(projectKey, workflowName, hookUUID string, cmd sdk.HookCommentCommand) (*sdk.HookCommentCommandResult, error) {
+			require.Equal(t, sdk.HookCommentCommandRetry, cmd.Name)
+			require.Equal(t, []string{"build"}, cmd.Args)
+			require.Equal(t, int64(42), cmd.PullRequestID)
+			require.Equal(t, "github", cmd.VCSType)
+			require.Equal(t, "reviewer", cmd.Author)
+			require.Equal(t, "ovh/cds", cmd.Payload["git.repository"])
+			require.Equal(t, "Fix the build", cmd.Payload["git.pr.title"])
+			require.NotContains(t, cmd.Payload, "payload")
+			require.NotContains(t, cmd.Payload, "git.pr.comment")
+			return &sdk.HookCommentCommandResult{WorkflowRunNumber: 12}, nil
+		})
+
+	require.NoError(t, s.doCommentCommandExecution(context.TODO(), task))
+	require.Equal(t, int64(12), task.WorkflowRun)
+
+	// Comments without command are ignored
+	task.Config[sdk.HookConfigCommandPrefix] = sdk.WorkflowNodeHookConfigValue{Value: "!ci"}
+	require.NoError(t, s.doCommentCommandExecution(context.TODO(), task))
+}
+
+func Test_commentCommandsFromWebHookGitlab(t *testing.T) {
+	log.SetLogger(t)
+	var s Service
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeCommentCommand,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigEventFilter: {Value: "Note Hook"},
+		},
+		WebHook: &sdk.WebHookExecution{
+			RequestBody:   []byte(gitlabNoteEvent),
+			RequestHeader: map[string][]string{GitlabHeader: {"Note Hook"}},
+		},
+	}
+
+	cmds, err := s.commentCommandsFromWebHook(context.TODO(), task)
+	require.NoError(t, err)
+	require.Len(t, cmds, 1)
+	require.Equal(t, sdk.HookCommentCommandStop, cmds[0].Name)
+	require.Equal(t, int64(7), cmds[0].PullRequestID)
+	require.Equal(t, "gitlab", cmds[0].VCSType)
+	require.Equal(t, "jdoe", cmds[0].Author)
+	require.Equal(t, "feature", cmds[0].Payload["git.branch"])
+	require.Equal(t, "da1560886d4f094c3e6c9ef40349f7d38b5d27d7", cmds[0].Payload["git.hash"])
+
+	// Comments on issues are ignored
+	task.WebHook.RequestBody = []byte(`{"object_kind":"note","user":{"username":"jdoe"},"object_attributes":{"note":"/cds run","noteable_type":"Issue"}}`)
+	cmds, err = s.commentCommandsFromWebHook(context.TODO(), task)
+	require.NoError(t, err)
+	require.Len(t, cmds, 0)
+}
+
+var githubIssueCommentEvent = `{
+  "action": "created",
+  "issue": {
+    "id": 1001,
+    "number": 42,
+    "title": "Fix the build",
+    "state": "open",
+    "html_url": "https://github.com/ovh/cds/pull/42",
+    "user": {"login": "author"},
+    "pull_request": {"url": "https://api.github.com/repos/ovh/cds/pulls/42"}
+  },
+  "comment": {
+    "id": 5001,
+    "html_url": "https://github.com/ovh/cds/pull/42#issuecomment-5001",
+    "body": "The integration tests are flaky\r\n/cds retry build",
+    "user": {"login": "reviewer"}
+  },
+  "repository": {
+    "id": 1,
+    "name": "cds",
+    "full_name": "ovh/cds"
+  },
+  "sender": {"login": "reviewer"}
+}`
+
+var gitlabNoteEvent = `{
+  "object_kind": "note",
+  "user": {
+    "name": "John Doe",
+    "username": "jdoe",
+    "email": "jdoe@example.com"
+  },
+  "project_id": 5,
+  "project": {
+    "id": 5,
+    "name": "cds",
+    "path_with_namespace": "ovh/cds"
+  },
+  "object_attributes": {
+    "id": 1244,
+    "note": "/cds stop",
+    "noteable_type": "MergeRequest",
+    "url": "http://example.com/ovh/cds/merge_requests/7#note_1244"
+  },
+  "merge_request": {
+    "id": 7001,
+    "iid": 7,
+    "title": "Add a feature",
+    "state": "opened",
+    "source_branch": "feature",
+    "target_branch": "master",
+    "last_commit": {
+      "id": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7"
+    }
+  }
+}`
@@ -0,0 +1,165 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (s *Service) generatePayloadFromGiteaRequest(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	projectKey := t.Config["project"].Value
+	workflowName := t.Config["workflow"].Value
+
+	payload := make(map[string]interface{})
+	payload[GIT_EVENT] = event
+
+	switch event {
+	case "delete":
+		var request GiteaDeleteEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+			return nil, sdk.WrapError(err, "unable ro read gitea request: %s", string(t.WebHook.RequestBody))
+		}
+		if request.RefType != "branch" {
+			return nil, nil
+		}
+		err := s.enqueueBranchDeletion(projectKey, workflowName, strings.TrimPrefix(request.Ref, "refs/heads/"))
+		return nil, sdk.WrapError(err, "cannot enqueue branch deletion")
+	case "pull_request":
+		var request GiteaPullRequestEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+			return nil, sdk.WrapError(err, "unable ro read gitea request: %s", string(t.WebHook.RequestBody))
+		}
+		getPayloadFromGiteaPullRequest(payload, request.PullRequest)
+		if request.Action != "" {
+			payload[PR_STATE] = request.Action
+		}
+		getPayloadFromGiteaSender(payload, request.Sender)
+		getPayloadStringVariable(ctx, payload, request)
+	case "issue_comment":
+		var request GiteaIssueCommentEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+			return nil, sdk.WrapError(err, "unable ro read gitea request: %s", string(t.WebHook.RequestBody))
+		}
+		if request.IsPull && request.Issue != nil {
+			payload[PR_ID] = request.Issue.Number
+			payload[PR_TITLE] = request.Issue.Title
+			payload[PR_STATE] = request.Issue.State
+		}
+		if request.Comment != nil {
+			payload[PR_COMMENT_TEXT] = request.Comment.Body
+			if request.Comment.User != nil {
+				payload[PR_COMMENT_AUTHOR] = request.Comment.User.Login
+				payload[PR_COMMENT_AUTHOR_EMAIL] = request.Comment.User.Email
+			}
+		}
+		getPayloadFromGiteaRepository(payload, request.Repository)
+		getPayloadFromGiteaSender(payload, request.Sender)
+		getPayloadStringVariable(ctx, payload, request)
+	default:
+		var request GiteaPushEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+			return nil, sdk.WrapError(err, "unable ro read gitea request: %s", string(t.WebHook.RequestBody))
+		}
+
+		// Branch deletion (gitea return 0000000000000000000000000000000000000000 as git hash)
+		if request.After == "0000000000000000000000000000000000000000" {
+			if strings.HasPrefix(request.Ref, "refs/tags/") {
+				return nil, nil
+			}
+			err := s.enqueueBranchDeletion(projectKey, workflowName, strings.TrimPrefix(request.Ref, "refs/heads/"))
+			return nil, sdk.WrapError(err, "cannot enqueue branch deletion")
+		}
+
+		if request.Ref != "" {
+			if !strings.HasPrefix(request.Ref, "refs/tags/") {
+				branch := strings.TrimPrefix(request.Ref, "refs/heads/")
+				payload[GIT_BRANCH] = branch
+				if err := s.stopBranchDeletionTask(ctx, branch); err != nil {
+					log.Error(ctx, "cannot stop branch deletion task for branch %s : %v", branch, err)
+				}
+			} else {
+				payload[GIT_TAG] = strings.TrimPrefix(request.Ref, "refs/tags/")
+			}
+		}
+		if request.Before != "" {
+			payload[GIT_HASH_BEFORE] = request.Before
+		}
+		if request.After != "" {
+			payload[GIT_HASH] = request.After
+			payload[GIT_HASH_SHORT] = sdk.StringFirstN(request.After, 7)
+		}
+
+		getPayloadFromGiteaRepository(payload, request.Repository)
+		getPayloadFromGiteaSender(payload, request.Pusher)
+		if request.HeadCommit != nil {
+			payload[GIT_MESSAGE] = request.HeadCommit.Message
+		} else if len(request.Commits) > 0 {
+			payload[GIT_MESSAGE] = request.Commits[0].Message
+		}
+
+		// The changed files are only known if the payload contains all the commits pushed on the branch
+		if !isEmptyHash(request.Before) && len(request.Commits) > 0 && len(request.Commits) == request.TotalCommits {
+			var files []string
+			for _, c := range request.Commits {
+				files = append(append(append(files, c.Added...), c.Removed...), c.Modified...)
+			}
+			payload[GIT_CHANGED_FILES] = joinChangedFiles(files)
+		}
+
+		for i := range request.Commits {
+			request.Commits[i].Added = nil
+			request.Commits[i].Removed = nil
+			request.Commits[i].Modified = nil
+		}
+		getPayloadStringVariable(ctx, payload, request)
+	}
+
+	return payload, nil
+}
+
+func getPayloadFromGiteaRepository(payload map[string]interface{}, repo *GiteaRepository) {
+	if repo == nil {
+		return
+	}
+	payload[GIT_REPOSITORY] = repo.FullName
+}
+
+func getPayloadFromGiteaSender(payload map[string]interface{}, user *GiteaUser) {
+	if user == nil {
+		return
+	}
+	login := user.Login
+	if login == "" {
+		login = user.Username
+	}
+	payload[GIT_AUTHOR] = login
+	payload[GIT_AUTHOR_EMAIL] = user.Email
+	payload[CDS_TRIGGERED_BY_USERNAME] = login
+	payload[CDS_TRIGGERED_BY_FULLNAME] = user.FullName
+	payload[CDS_TRIGGERED_BY_EMAIL] = user.Email
+}
+
+func getPayloadFromGiteaPullRequest(payload map[string]interface{}, pr *GiteaPullRequest) {
+	if pr == nil {
+		return
+	}
+	payload[PR_ID] = pr.Number
+	payload[PR_TITLE] = pr.Title
+	payload[PR_STATE] = pr.State
+	if pr.Head != nil {
+		payload[GIT_BRANCH] = pr.Head.Ref
+		payload[GIT_HASH] = pr.Head.Sha
+		payload[GIT_HASH_SHORT] = sdk.StringFirstN(pr.Head.Sha, 7)
+		getPayloadFromGiteaRepository(payload, pr.Head.Repo)
+	}
+	if pr.Base != nil {
+		payload[GIT_BRANCH_DEST] = pr.Base.Ref
+		payload[GIT_HASH_DEST] = pr.Base.Sha
+		if pr.Base.Repo != nil {
+			payload[GIT_REPOSITORY_DEST] = pr.Base.Repo.FullName
+		}
+	}
+}
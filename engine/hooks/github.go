@@ -10,6 +10,13 @@ import (
 )
 
 func (s *Service) generatePayloadFromGithubRequest(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	if event == "issue_comment" {
+		return generatePayloadFromGithubIssueComment(ctx, t, event)
+	}
+	if event == "pull_request" {
+		return generatePayloadFromGithubPullRequest(ctx, t, event)
+	}
+
 	projectKey := t.Config["project"].Value
 	workflowName := t.Config["workflow"].Value
 
@@ -52,6 +59,15 @@ func (s *Service) generatePayloadFromGithubRequest(ctx context.Context, t *sdk.T
 		payload[GIT_MESSAGE] = request.Commits[0].Message
 	}
 
+	// The changed files are only known if the payload contains all the commits pushed on the branch
+	if !request.Forced && !isEmptyHash(request.Before) && len(request.Commits) > 0 && len(request.Commits) < githubMaxPushCommits {
+		var files []string
+		for _, c := range request.Commits {
+			files = append(append(append(files, c.Added...), c.Removed...), c.Modified...)
+		}
+		payload[GIT_CHANGED_FILES] = joinChangedFiles(files)
+	}
+
 	for i := range request.Commits {
 		request.Commits[i].Added = nil
 		request.Commits[i].Removed = nil
@@ -62,6 +78,67 @@ func (s *Service) generatePayloadFromGithubRequest(ctx context.Context, t *sdk.T
 	return payload, nil
 }
 
+func generatePayloadFromGithubIssueComment(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	var request GithubIssueCommentEvent
+	if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+		return nil, sdk.WrapError(err, "unable ro read github request: %s", string(t.WebHook.RequestBody))
+	}
+
+	payload := make(map[string]interface{})
+	payload[GIT_EVENT] = event
+	// Issues and pull requests share the same comment event, only pull requests have a pull_request field
+	if request.Issue.PullRequest != nil {
+		payload[PR_ID] = request.Issue.Number
+		payload[PR_TITLE] = request.Issue.Title
+		payload[PR_STATE] = request.Issue.State
+	}
+	payload[PR_COMMENT_TEXT] = request.Comment.Body
+	payload[PR_COMMENT_AUTHOR] = request.Comment.User.Login
+	payload[GIT_AUTHOR] = request.Sender.Login
+	payload[CDS_TRIGGERED_BY_USERNAME] = request.Sender.Login
+	getPayloadFromRepository(payload, request.Repository)
+	getPayloadStringVariable(ctx, payload, request)
+
+	return payload, nil
+}
+
+func generatePayloadFromGithubPullRequest(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	var request GithubPullRequestEvent
+	if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+		return nil, sdk.WrapError(err, "unable ro read github request: %s", string(t.WebHook.RequestBody))
+	}
+
+	payload := make(map[string]interface{})
+	payload[GIT_EVENT] = event
+	payload[PR_ID] = request.PullRequest.Number
+	payload[PR_TITLE] = request.PullRequest.Title
+	payload[PR_STATE] = request.PullRequest.State
+	if request.Action != "" {
+		payload[PR_STATE] = request.Action
+	}
+	payload[GIT_BRANCH] = request.PullRequest.Head.Ref
+	payload[GIT_BRANCH_DEST] = request.PullRequest.Base.Ref
+	if request.PullRequest.Head.SHA != "" {
+		payload[GIT_HASH] = request.PullRequest.Head.SHA
+		payload[GIT_HASH_SHORT] = sdk.StringFirstN(request.PullRequest.Head.SHA, 7)
+	}
+	if request.PullRequest.Base.SHA != "" {
+		payload[GIT_HASH_DEST] = request.PullRequest.Base.SHA
+	}
+	payload[GIT_AUTHOR] = request.Sender.Login
+	payload[CDS_TRIGGERED_BY_USERNAME] = request.Sender.Login
+	getPayloadFromRepository(payload, request.PullRequest.Head.Repo)
+	if request.PullRequest.Base.Repo != nil {
+		payload[GIT_REPOSITORY_DEST] = request.PullRequest.Base.Repo.FullName
+	}
+	if _, has := payload[GIT_REPOSITORY]; !has {
+		getPayloadFromRepository(payload, request.Repository)
+	}
+	getPayloadStringVariable(ctx, payload, request)
+
+	return payload, nil
+}
+
 func getPayloadFromRepository(payload map[string]interface{}, repo *GithubRepository) {
 	if repo == nil {
 		return
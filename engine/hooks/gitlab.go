@@ -5,11 +5,20 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/xanzy/go-gitlab"
+
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
 func (s *Service) generatePayloadFromGitlabRequest(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	if event == string(gitlab.EventTypeNote) {
+		return generatePayloadFromGitlabNote(ctx, t, event)
+	}
+	if event == string(gitlab.EventTypeMergeRequest) {
+		return generatePayloadFromGitlabMergeRequest(ctx, t, event)
+	}
+
 	projectKey := t.Config["project"].Value
 	workflowName := t.Config["workflow"].Value
 
@@ -55,6 +64,93 @@ func (s *Service) generatePayloadFromGitlabRequest(ctx context.Context, t *sdk.T
 
 	getPayloadFromGitlabProject(payload, request.Project)
 	getPayloadFromGitlabCommit(payload, request.Commits)
+
+	// The changed files are only known if the payload contains all the commits pushed on the branch
+	if !isEmptyHash(request.Before) && len(request.Commits) > 0 && len(request.Commits) == request.TotalCommitsCount {
+		var files []string
+		for _, c := range request.Commits {
+			files = append(append(append(files, c.Added...), c.Removed...), c.Modified...)
+		}
+		payload[GIT_CHANGED_FILES] = joinChangedFiles(files)
+	}
+
+	for i := range request.Commits {
+		request.Commits[i].Added = nil
+		request.Commits[i].Removed = nil
+		request.Commits[i].Modified = nil
+	}
+	getPayloadStringVariable(ctx, payload, request)
+
+	return payload, nil
+}
+
+func generatePayloadFromGitlabNote(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	var request GitlabNoteEvent
+	if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+		return nil, sdk.WrapError(err, "unable ro read gitlab request: %s", string(t.WebHook.RequestBody))
+	}
+
+	payload := make(map[string]interface{})
+	payload[GIT_EVENT] = event
+	if request.MergeRequest != nil {
+		payload[PR_ID] = request.MergeRequest.IID
+		payload[PR_TITLE] = request.MergeRequest.Title
+		payload[PR_STATE] = request.MergeRequest.State
+		payload[GIT_BRANCH] = request.MergeRequest.SourceBranch
+		payload[GIT_BRANCH_DEST] = request.MergeRequest.TargetBranch
+		if request.MergeRequest.LastCommit.ID != "" {
+			payload[GIT_HASH] = request.MergeRequest.LastCommit.ID
+			payload[GIT_HASH_SHORT] = sdk.StringFirstN(request.MergeRequest.LastCommit.ID, 7)
+		}
+	}
+	payload[PR_COMMENT_TEXT] = request.ObjectAttributes.Note
+	payload[PR_COMMENT_AUTHOR] = request.User.Username
+	payload[PR_COMMENT_AUTHOR_EMAIL] = request.User.Email
+	payload[GIT_AUTHOR] = request.User.Username
+	payload[GIT_AUTHOR_EMAIL] = request.User.Email
+	payload[CDS_TRIGGERED_BY_USERNAME] = request.User.Username
+	payload[CDS_TRIGGERED_BY_FULLNAME] = request.User.Name
+	payload[CDS_TRIGGERED_BY_EMAIL] = request.User.Email
+	getPayloadFromGitlabProject(payload, request.Project)
+	getPayloadStringVariable(ctx, payload, request)
+
+	return payload, nil
+}
+
+func generatePayloadFromGitlabMergeRequest(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	var request GitlabMergeRequestEvent
+	if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+		return nil, sdk.WrapError(err, "unable ro read gitlab request: %s", string(t.WebHook.RequestBody))
+	}
+
+	mr := request.ObjectAttributes
+	payload := make(map[string]interface{})
+	payload[GIT_EVENT] = event
+	payload[PR_ID] = mr.IID
+	payload[PR_TITLE] = mr.Title
+	payload[PR_STATE] = mr.State
+	if mr.Action != "" {
+		payload[PR_STATE] = mr.Action
+	}
+	payload[GIT_BRANCH] = mr.SourceBranch
+	payload[GIT_BRANCH_DEST] = mr.TargetBranch
+	if mr.LastCommit.ID != "" {
+		payload[GIT_HASH] = mr.LastCommit.ID
+		payload[GIT_HASH_SHORT] = sdk.StringFirstN(mr.LastCommit.ID, 7)
+	}
+	payload[GIT_AUTHOR] = request.User.Username
+	payload[GIT_AUTHOR_EMAIL] = request.User.Email
+	payload[CDS_TRIGGERED_BY_USERNAME] = request.User.Username
+	payload[CDS_TRIGGERED_BY_FULLNAME] = request.User.Name
+	payload[CDS_TRIGGERED_BY_EMAIL] = request.User.Email
+	if mr.Source != nil {
+		getPayloadFromGitlabProject(payload, mr.Source)
+	} else {
+		getPayloadFromGitlabProject(payload, request.Project)
+	}
+	if mr.Target != nil {
+		payload[GIT_REPOSITORY_DEST] = mr.Target.PathWithNamespace
+	}
 	getPayloadStringVariable(ctx, payload, request)
 
 	return payload, nil
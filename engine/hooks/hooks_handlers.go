@@ -51,7 +51,7 @@ func (s *Service) webhookHandler() service.Handler {
 			Timestamp: time.Now().UnixNano(),
 			Type:      webHook.Type,
 			UUID:      webHook.UUID,
-			Config:    webHook.Config,
+			Config:    withoutWebHookSecret(webHook.Config),
 			Status:    TaskExecutionScheduled,
 			WebHook: &sdk.WebHookExecution{
 				RequestBody:   req,
@@ -60,6 +60,18 @@ func (s *Service) webhookHandler() service.Handler {
 			},
 		}
 
+		//Reject repository webhooks not signed by the repository manager, the rejection is kept in the executions history
+		if webHook.Type == TypeRepoManagerWebHook || webHook.Type == TypeCommentCommand {
+			if err := verifyRepositoryWebHook(ctx, webHook, exec.WebHook); err != nil {
+				exec.Status = TaskExecutionDone
+				exec.ProcessingTimestamp = time.Now().UnixNano()
+				exec.LastError = err.Error()
+				exec.NbErrors = s.Cfg.RetryError
+				s.Dao.SaveTaskExecution(exec)
+				return err
+			}
+		}
+
 		//Save the web hook execution
 		s.Dao.SaveTaskExecution(exec)
 
@@ -203,6 +215,7 @@ func (s *Service) getTasksHandler() service.Handler {
 			}
 			tasks[i].NbExecutionsTotal = len(m[t.UUID])
 			tasks[i].NbExecutionsTodo = nbTodo
+			tasks[i].Config = withoutWebHookSecret(t.Config)
 		}
 
 		for k, p := range sortParams {
@@ -274,6 +287,7 @@ func (s *Service) getTaskHandler() service.Handler {
 		}
 
 		t.Executions = execs
+		t.Config = withoutWebHookSecret(t.Config)
 
 		return service.WriteJSON(w, t, http.StatusOK)
 	}
@@ -322,6 +336,7 @@ func (s *Service) getTaskExecutionsHandler() service.Handler {
 		sort.Slice(t.Executions, func(i, j int) bool {
 			return t.Executions[i].Timestamp > t.Executions[j].Timestamp
 		})
+		t.Config = withoutWebHookSecret(t.Config)
 
 		return service.WriteJSON(w, t, http.StatusOK)
 	}
@@ -399,7 +414,7 @@ func (s *Service) postTaskBulkHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 
-		for _, hook := range hooks {
+		for k, hook := range hooks {
 			err := s.updateTask(ctx, &hook)
 			if err == errNoTask {
 				if err := s.addTask(ctx, &hook); err != nil {
@@ -408,6 +423,8 @@ func (s *Service) postTaskBulkHandler() service.Handler {
 			} else if err != nil {
 				return sdk.WithStack(err)
 			}
+			hook.Config = withoutWebHookSecret(hook.Config)
+			hooks[k] = hook
 		}
 		return service.WriteJSON(w, hooks, http.StatusOK)
 	}
@@ -467,6 +484,7 @@ func (s *Service) updateTask(ctx context.Context, h *sdk.NodeHook) error {
 	}
 
 	task.Config = t.Config
+	t.LastWorkflowRun = task.LastWorkflowRun
 	_ = s.stopTask(ctx, t)
 	execs, _ := s.Dao.FindAllTaskExecutions(ctx, t)
 	for _, e := range execs {
@@ -525,7 +543,7 @@ func (s *Service) Status(ctx context.Context) *sdk.MonitoringStatus {
 	}
 	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Balance", Value: fmt.Sprintf("%d/%d", in, out), Status: status})
 
-	var nbHooksKafkaTotal int64
+	var nbHooksKafkaTotal, nbHooksMessageBusTotal int64
 
 	tasks, err := s.Dao.FindAllTasks(ctx)
 	if err != nil {
@@ -536,6 +554,9 @@ func (s *Service) Status(ctx context.Context) *sdk.MonitoringStatus {
 		if t.Type == TypeKafka {
 			nbHooksKafkaTotal++
 		}
+		if _, ok := messageBusConsumerFactories[t.Type]; ok && !t.Stopped {
+			nbHooksMessageBusTotal++
+		}
 
 		if t.Stopped {
 			m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Task Stopped", Value: t.UUID, Status: sdk.MonitoringStatusWarn})
@@ -584,6 +605,12 @@ func (s *Service) Status(ctx context.Context) *sdk.MonitoringStatus {
 	}
 	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Hook Kafka Consumers", Value: fmt.Sprintf("%d", nbKafkaConsumers), Status: statusConsumer})
 
+	statusConsumer = sdk.MonitoringStatusOK
+	if nbMessageBusConsumers != nbHooksMessageBusTotal {
+		statusConsumer = sdk.MonitoringStatusWarn
+	}
+	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Hook Message Bus Consumers", Value: fmt.Sprintf("%d/%d", nbMessageBusConsumers, nbHooksMessageBusTotal), Status: statusConsumer})
+
 	return m
 }
 
@@ -2,13 +2,11 @@ package hooks
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync/atomic"
 	"time"
 
 	"github.com/Shopify/sarama"
-	"github.com/fsamin/go-dump"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -16,7 +14,7 @@ import (
 
 var nbKafkaConsumers int64
 
-func (s *Service) saveKafkaExecution(t *sdk.Task, error string, nbError int64) {
+func (s *Service) saveTaskExecutionError(t *sdk.Task, error string, nbError int64) {
 	exec := &sdk.TaskExecution{
 		Timestamp: time.Now().UnixNano(),
 		Type:      t.Type,
@@ -87,7 +85,7 @@ func (s *Service) startKafkaHook(ctx context.Context, t *sdk.Task) error {
 	// Track errors
 	go func() {
 		for err := range consumerGroup.Errors() {
-			s.saveKafkaExecution(t, err.Error(), 1)
+			s.saveTaskExecutionError(t, err.Error(), 1)
 		}
 	}()
 
@@ -145,40 +143,12 @@ func (h *handler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama
 func (s *Service) doKafkaTaskExecution(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
 	log.Debug("Hooks> Processing kafka %s %s", t.UUID, t.Type)
 
-	// Prepare a struct to send to CDS API
-	h := sdk.WorkflowNodeRunHookEvent{
-		WorkflowNodeHookUUID: t.UUID,
-		Payload:              map[string]string{},
-	}
-
-	var bodyJSON interface{}
-
-	//Try to parse the body as an array
-	bodyJSONArray := []interface{}{}
-	if err := json.Unmarshal(t.Kafka.Message, &bodyJSONArray); err != nil {
-		//Try to parse the body as a map
-		bodyJSONMap := map[string]interface{}{}
-		if err2 := json.Unmarshal(t.Kafka.Message, &bodyJSONMap); err2 == nil {
-			bodyJSON = bodyJSONMap
-		}
-	} else {
-		bodyJSON = bodyJSONArray
-	}
-
-	//Go Dump
-	e := dump.NewDefaultEncoder()
-	e.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
-	e.ExtraFields.DetailedMap = false
-	e.ExtraFields.DetailedStruct = false
-	e.ExtraFields.DeepJSON = true
-	e.ExtraFields.Len = false
-	e.ExtraFields.Type = false
-	m, err := e.ToStringMap(bodyJSON)
+	payload, err := messagePayload(t.Kafka.Message)
 	if err != nil {
-		return nil, sdk.WrapError(err, "Unable to dump body %s", t.WebHook.RequestBody)
+		return nil, err
 	}
-	h.Payload = m
-	h.Payload["payload"] = string(t.Kafka.Message)
-
-	return &h, nil
+	return &sdk.WorkflowNodeRunHookEvent{
+		WorkflowNodeHookUUID: t.UUID,
+		Payload:              payload,
+	}, nil
 }
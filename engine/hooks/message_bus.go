@@ -0,0 +1,164 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsamin/go-dump"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var (
+	nbMessageBusConsumers int64
+
+	// messageBusConsumers contains the cancel funcs of the running consumers, by task uuid
+	messageBusConsumers      = make(map[string]context.CancelFunc)
+	messageBusConsumersMutex sync.Mutex
+)
+
+// messageBusHandler is called by a consumer for each received message.
+// The message is acknowledged on the message bus only if the handler returns no error.
+type messageBusHandler func(subject string, msg []byte) error
+
+// messageBusConsumer consumes the messages of a message bus for a task.
+type messageBusConsumer interface {
+	// Consume blocks until the context is done, calling the handler for each received message.
+	Consume(ctx context.Context, handler messageBusHandler) error
+	Close() error
+}
+
+// messageBusConsumerFactory creates the consumer of a task from its hook configuration and the configuration of its project integration.
+type messageBusConsumerFactory func(s *Service, integration sdk.ProjectIntegration, t *sdk.Task) (messageBusConsumer, error)
+
+// messageBusConsumerFactories contains the message bus implementations, by task type.
+var messageBusConsumerFactories = map[string]messageBusConsumerFactory{
+	TypeNATS:        newNATSConsumer,
+	TypeRedisStream: newRedisStreamConsumer,
+}
+
+func (s *Service) startMessageBusHook(ctx context.Context, t *sdk.Task) error {
+	newConsumer, ok := messageBusConsumerFactories[t.Type]
+	if !ok {
+		return sdk.WithStack(fmt.Errorf("unsupported message bus task type %s", t.Type))
+	}
+
+	filter, err := newMessageFilter(t.Config)
+	if err != nil {
+		_ = s.stopTask(ctx, t)
+		return sdk.WrapError(err, "invalid message filter for task %s", t.UUID)
+	}
+
+	projectKey := t.Config[sdk.HookConfigProject].Value
+	integrationName := t.Config[sdk.HookModelIntegration].Value
+	pf, err := s.Client.ProjectIntegrationGet(projectKey, integrationName, true)
+	if err != nil {
+		_ = s.stopTask(ctx, t)
+		return sdk.WrapError(err, "cannot get %s configuration for %s/%s", t.Type, projectKey, integrationName)
+	}
+
+	consumer, err := newConsumer(s, pf, t)
+	if err != nil {
+		_ = s.stopTask(ctx, t)
+		return sdk.WrapError(err, "cannot create %s consumer for %s/%s", t.Type, projectKey, integrationName)
+	}
+
+	// A task is consumed only once by a hooks service, stop the previous consumer if the task is restarted
+	s.stopMessageBusHook(t)
+	consumeCtx, cancel := context.WithCancel(context.Background())
+	messageBusConsumersMutex.Lock()
+	messageBusConsumers[t.UUID] = cancel
+	messageBusConsumersMutex.Unlock()
+
+	handler := func(subject string, msg []byte) error {
+		if !filter.Match(msg) {
+			log.Debug("Hooks> message on %s ignored by the filter of task %s", subject, t.UUID)
+			return nil
+		}
+		exec := sdk.TaskExecution{
+			Status:     TaskExecutionScheduled,
+			Config:     t.Config,
+			Type:       t.Type,
+			UUID:       t.UUID,
+			Timestamp:  time.Now().UnixNano(),
+			MessageBus: &sdk.MessageBusTaskExecution{Subject: subject, Message: msg},
+		}
+		return s.Dao.SaveTaskExecution(&exec)
+	}
+
+	s.GoRoutines.Exec(consumeCtx, "message-bus-consume-"+t.UUID, func(ctx context.Context) {
+		atomic.AddInt64(&nbMessageBusConsumers, 1)
+		defer atomic.AddInt64(&nbMessageBusConsumers, -1)
+		if err := consumer.Consume(ctx, handler); err != nil {
+			log.Error(ctx, "Hooks> unable to consume %s messages for task %s: %v", t.Type, t.UUID, err)
+			s.saveTaskExecutionError(t, err.Error(), 1)
+		}
+		if err := consumer.Close(); err != nil {
+			log.Error(ctx, "Hooks> unable to close %s consumer for task %s: %v", t.Type, t.UUID, err)
+		}
+	})
+
+	return nil
+}
+
+func (s *Service) stopMessageBusHook(t *sdk.Task) {
+	messageBusConsumersMutex.Lock()
+	defer messageBusConsumersMutex.Unlock()
+	if cancel, ok := messageBusConsumers[t.UUID]; ok {
+		cancel()
+		delete(messageBusConsumers, t.UUID)
+	}
+}
+
+func (s *Service) doMessageBusTaskExecution(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
+	log.Debug("Hooks> Processing %s message %s %s", t.Type, t.UUID, t.MessageBus.Subject)
+
+	payload, err := messagePayload(t.MessageBus.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.WorkflowNodeRunHookEvent{
+		WorkflowNodeHookUUID: t.UUID,
+		Payload:              payload,
+	}, nil
+}
+
+// messagePayload computes the workflow run payload from a message consumed by a hook.
+// If the message is a JSON document, its content is dumped as payload variables.
+// The whole message is always given in the payload variable.
+func messagePayload(msg []byte) (map[string]string, error) {
+	var bodyJSON interface{}
+
+	//Try to parse the body as an array
+	bodyJSONArray := []interface{}{}
+	if err := json.Unmarshal(msg, &bodyJSONArray); err != nil {
+		//Try to parse the body as a map
+		bodyJSONMap := map[string]interface{}{}
+		if err2 := json.Unmarshal(msg, &bodyJSONMap); err2 == nil {
+			bodyJSON = bodyJSONMap
+		}
+	} else {
+		bodyJSON = bodyJSONArray
+	}
+
+	//Go Dump
+	e := dump.NewDefaultEncoder()
+	e.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
+	e.ExtraFields.DetailedMap = false
+	e.ExtraFields.DetailedStruct = false
+	e.ExtraFields.DeepJSON = true
+	e.ExtraFields.Len = false
+	e.ExtraFields.Type = false
+	m, err := e.ToStringMap(bodyJSON)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to dump body %s", msg)
+	}
+	m[sdk.Payload] = string(msg)
+
+	return m, nil
+}
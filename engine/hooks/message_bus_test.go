@@ -0,0 +1,25 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_doMessageBusTaskExecution(t *testing.T) {
+	var s Service
+	h, err := s.doMessageBusTaskExecution(&sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeNATS,
+		MessageBus: &sdk.MessageBusTaskExecution{
+			Subject: "deployments.prod",
+			Message: []byte(`{"event": {"type": "deployment"}, "version": "1.2.0"}`),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "deployment", h.Payload["event.type"])
+	require.Equal(t, "1.2.0", h.Payload["version"])
+	require.Equal(t, `{"event": {"type": "deployment"}, "version": "1.2.0"}`, h.Payload["payload"])
+}
@@ -0,0 +1,187 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// messageFilter selects the messages consumed by a message bus hook that should trigger the workflow.
+// The path is a JSONPath expression (ie: $.event.type or $.items[0].name) evaluated on the message,
+// the regex is matched on the selected value, or on the raw message if there is no path.
+type messageFilter struct {
+	path  []jsonPathStep
+	regex *regexp.Regexp
+}
+
+// jsonPathStep is a step of a JSONPath expression, it selects a key in an object or an index in an array.
+type jsonPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func newMessageFilter(config sdk.WorkflowNodeHookConfig) (*messageFilter, error) {
+	var f messageFilter
+	if p := strings.TrimSpace(config[sdk.HookConfigMessageFilterPath].Value); p != "" {
+		path, err := parseJSONPath(p)
+		if err != nil {
+			return nil, err
+		}
+		f.path = path
+	}
+	if r := config[sdk.HookConfigMessageFilterRegex].Value; r != "" {
+		regex, err := regexp.Compile(r)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid message filter regex %q: %v", r, err)
+		}
+		f.regex = regex
+	}
+	return &f, nil
+}
+
+// Match returns true if the message should trigger the workflow.
+func (f *messageFilter) Match(msg []byte) bool {
+	if f.path == nil {
+		return f.regex == nil || f.regex.Match(msg)
+	}
+
+	var body interface{}
+	d := json.NewDecoder(bytes.NewReader(msg))
+	d.UseNumber()
+	if err := d.Decode(&body); err != nil {
+		return false
+	}
+	value, found := evalJSONPath(body, f.path)
+	if !found {
+		return false
+	}
+	return f.regex == nil || f.regex.MatchString(jsonPathValueString(value))
+}
+
+// parseJSONPath parses the subset of JSONPath supported by message filters: dot notation (a.b),
+// bracket notation (['a']) and array indexes ([0], negative indexes select from the end of the array).
+// The leading $ is optional.
+func parseJSONPath(p string) ([]jsonPathStep, error) {
+	invalid := func(reason string) error {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid message filter path %q: %s", p, reason)
+	}
+
+	expr := strings.TrimPrefix(p, "$")
+	steps := []jsonPathStep{}
+	for i := 0; i < len(expr); {
+		switch expr[i] {
+		case '.':
+			i++
+			if i < len(expr) && expr[i] == '.' {
+				return nil, invalid("recursive descent is not supported")
+			}
+			if i < len(expr) && expr[i] == '[' {
+				continue
+			}
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, invalid("missing ]")
+			}
+			content := strings.TrimSpace(expr[i+1 : i+end])
+			i += end + 1
+			if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+				steps = append(steps, jsonPathStep{key: content[1 : len(content)-1]})
+				continue
+			}
+			index, err := strconv.Atoi(content)
+			if err != nil {
+				return nil, invalid("only quoted keys and array indexes are supported between brackets")
+			}
+			steps = append(steps, jsonPathStep{index: index, isIndex: true})
+			continue
+		}
+		if i == 0 && len(p) > len(expr) {
+			return nil, invalid("unexpected character after $")
+		}
+
+		// Read a key in dot notation
+		end := strings.IndexAny(expr[i:], ".[")
+		if end < 0 {
+			end = len(expr) - i
+		}
+		key := expr[i : i+end]
+		if key == "" {
+			return nil, invalid("empty key")
+		}
+		if key == "*" {
+			return nil, invalid("wildcards are not supported")
+		}
+		steps = append(steps, jsonPathStep{key: key})
+		i += end
+	}
+	if len(steps) == 0 {
+		return nil, invalid("the path should select a value in the message")
+	}
+	return steps, nil
+}
+
+// evalJSONPath returns the value selected by the path. Like the payload computed from the message,
+// string values that contain a JSON document are traversed.
+func evalJSONPath(body interface{}, path []jsonPathStep) (interface{}, bool) {
+	current := body
+	for _, step := range path {
+		if s, ok := current.(string); ok {
+			var deep interface{}
+			d := json.NewDecoder(strings.NewReader(s))
+			d.UseNumber()
+			if err := d.Decode(&deep); err != nil {
+				return nil, false
+			}
+			current = deep
+		}
+
+		switch v := current.(type) {
+		case map[string]interface{}:
+			if step.isIndex {
+				return nil, false
+			}
+			value, ok := v[step.key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			if !step.isIndex {
+				return nil, false
+			}
+			index := step.index
+			if index < 0 {
+				index += len(v)
+			}
+			if index < 0 || index >= len(v) {
+				return nil, false
+			}
+			current = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func jsonPathValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return "null"
+	default:
+		btes, _ := json.Marshal(v)
+		return string(btes)
+	}
+}
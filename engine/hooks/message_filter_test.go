@@ -0,0 +1,87 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_parseJSONPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []jsonPathStep
+		err      bool
+	}{
+		{path: "$.event.type", expected: []jsonPathStep{{key: "event"}, {key: "type"}}},
+		{path: "event.type", expected: []jsonPathStep{{key: "event"}, {key: "type"}}},
+		{path: "$['event'][\"my.type\"]", expected: []jsonPathStep{{key: "event"}, {key: "my.type"}}},
+		{path: "$.items[0].name", expected: []jsonPathStep{{key: "items"}, {index: 0, isIndex: true}, {key: "name"}}},
+		{path: "$[-1]", expected: []jsonPathStep{{index: -1, isIndex: true}}},
+		{path: "$", err: true},
+		{path: "$..name", err: true},
+		{path: "$.items[*]", err: true},
+		{path: "$.items.*", err: true},
+		{path: "$.items[0", err: true},
+		{path: "$foo", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			steps, err := parseJSONPath(tt.path)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, steps)
+		})
+	}
+}
+
+func Test_messageFilter(t *testing.T) {
+	msg := []byte(`{"event": {"type": "deployment", "version": 12, "success": true}, "items": [{"name": "api"}, {"name": "ui"}], "raw": "{\"env\": \"prod\"}"}`)
+
+	tests := []struct {
+		name  string
+		path  string
+		regex string
+		match bool
+	}{
+		{name: "no filter", match: true},
+		{name: "path exists", path: "$.event.type", match: true},
+		{name: "path does not exist", path: "$.event.name", match: false},
+		{name: "path and regex", path: "$.event.type", regex: "^deploy", match: true},
+		{name: "path and wrong regex", path: "$.event.type", regex: "^build$", match: false},
+		{name: "number value", path: "$.event.version", regex: "^12$", match: true},
+		{name: "boolean value", path: "$.event.success", regex: "^true$", match: true},
+		{name: "array index", path: "$.items[1].name", regex: "^ui$", match: true},
+		{name: "negative array index", path: "$.items[-2].name", regex: "^api$", match: true},
+		{name: "out of range index", path: "$.items[2].name", match: false},
+		{name: "object value", path: "$.items[0]", regex: `"name":"api"`, match: true},
+		{name: "deep json", path: "$.raw.env", regex: "^prod$", match: true},
+		{name: "regex on message", regex: `"type":\s*"deployment"`, match: true},
+		{name: "wrong regex on message", regex: `"type":\s*"build"`, match: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newMessageFilter(sdk.WorkflowNodeHookConfig{
+				sdk.HookConfigMessageFilterPath:  {Value: tt.path},
+				sdk.HookConfigMessageFilterRegex: {Value: tt.regex},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.match, f.Match(msg))
+		})
+	}
+
+	// Messages that are not JSON documents only match regex filters
+	f, err := newMessageFilter(sdk.WorkflowNodeHookConfig{sdk.HookConfigMessageFilterPath: {Value: "$.event"}})
+	require.NoError(t, err)
+	require.False(t, f.Match([]byte("deployment")))
+	f, err = newMessageFilter(sdk.WorkflowNodeHookConfig{sdk.HookConfigMessageFilterRegex: {Value: "^deploy"}})
+	require.NoError(t, err)
+	require.True(t, f.Match([]byte("deployment")))
+
+	_, err = newMessageFilter(sdk.WorkflowNodeHookConfig{sdk.HookConfigMessageFilterRegex: {Value: "(deploy"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// natsConsumer consumes the messages of a NATS JetStream subject with a durable consumer.
+type natsConsumer struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	subject string
+	durable string
+}
+
+func newNATSConsumer(s *Service, pf sdk.ProjectIntegration, t *sdk.Task) (messageBusConsumer, error) {
+	subject := t.Config[sdk.NATSHookModelSubject].Value
+	if subject == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing NATS subject")
+	}
+	durable := t.Config[sdk.NATSHookModelDurable].Value
+	if durable == "" {
+		durable = "cds-" + t.UUID
+	}
+
+	opts := []nats.Option{
+		nats.Name("cds-hooks-" + s.Cfg.Name),
+		nats.MaxReconnects(-1),
+	}
+	if token := pf.Config["token"].Value; token != "" {
+		opts = append(opts, nats.Token(token))
+	} else if username := pf.Config["username"].Value; username != "" {
+		opts = append(opts, nats.UserInfo(username, pf.Config["password"].Value))
+	}
+
+	conn, err := nats.Connect(pf.Config["url"].Value, opts...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot connect to NATS %s", pf.Config["url"].Value)
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, sdk.WrapError(err, "cannot get JetStream context")
+	}
+
+	return &natsConsumer{
+		conn:    conn,
+		js:      js,
+		subject: subject,
+		durable: durable,
+	}, nil
+}
+
+func (c *natsConsumer) Consume(ctx context.Context, handler messageBusHandler) error {
+	// Use the durable name as queue group so that the messages are shared between the hooks services instances.
+	// The JetStream consumer is created on first subscription and only receives the messages published after.
+	_, err := c.js.QueueSubscribe(c.subject, c.durable, func(m *nats.Msg) {
+		if err := handler(m.Subject, m.Data); err != nil {
+			_ = m.Nak()
+			return
+		}
+		_ = m.Ack()
+	}, nats.Durable(c.durable), nats.DeliverNew(), nats.ManualAck())
+	if err != nil {
+		return sdk.WrapError(err, "cannot subscribe to NATS subject %s", c.subject)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// Close drains the connection, this keeps the durable consumer so messages published while
+// the hook is stopped will be received when it restarts.
+func (c *natsConsumer) Close() error {
+	return sdk.WithStack(c.conn.Drain())
+}
@@ -3,14 +3,19 @@ package hooks
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	dump "github.com/fsamin/go-dump"
@@ -22,6 +27,16 @@ import (
 	"github.com/ovh/cds/sdk/log"
 )
 
+const (
+	OutgoingWebHookSignatureHeader = "X-Cds-Signature-256"
+	OutgoingWebHookDeliveryHeader  = "X-Cds-Delivery"
+
+	outgoingWebHookMaxRetryWait        = time.Minute
+	outgoingWebHookResponseExcerptSize = 1024
+)
+
+var outgoingWebHookClient = &http.Client{Timeout: 60 * time.Second}
+
 func (s *Service) nodeRunToTask(nr sdk.WorkflowNodeRun) (sdk.Task, error) {
 	if nr.OutgoingHook == nil {
 		return sdk.Task{}, fmt.Errorf("Unsupported node type: %d", nr.WorkflowNodeID)
@@ -85,6 +100,13 @@ func (s *Service) startOutgoingWebHookTask(t *sdk.Task) (*sdk.TaskExecution, err
 	payload := t.Config["payload"].Value
 	headers := http.Header{}
 	headers.Set("Content-Type", "application/json")
+	customHeaders, err := sdk.ParseOutgoingWebHookHeaders(t.Config[sdk.OutgoingWebHookModelHeaders].Value)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range customHeaders {
+		headers[k] = v
+	}
 
 	//Craft a new execution
 	exec := &sdk.TaskExecution{
@@ -327,42 +349,72 @@ func (s *Service) doOutgoingWebHookExecution(ctx context.Context, t *sdk.TaskExe
 		return sdk.WrapError(handleError(ctx, err), "Unable to interpolate body")
 	}
 
-	req, err := http.NewRequest(method, urls, bytes.NewBuffer([]byte(body)))
-	if err != nil {
-		return sdk.WrapError(handleError(ctx, err), "Unable to create request")
-	}
-
+	header := http.Header{}
 	for k, v := range t.WebHook.RequestHeader {
 		for _, val := range v {
 			val, err = interpolate.Do(val, mapParams)
 			if err != nil {
 				return sdk.WrapError(handleError(ctx, err), "Unable to interpolate request header")
 			}
-			req.Header.Add(k, val)
+			header.Add(k, val)
 		}
 	}
 
-	var logBuffer bytes.Buffer
-	logBuffer.WriteString("Request:\n")
-	dump, _ := httputil.DumpRequestOut(req, true)
-	logBuffer.Write(dump) // nolint
+	// Sign the body with a secret variable of the project
+	if secretName := t.Config[sdk.OutgoingWebHookModelSecret].Value; secretName != "" {
+		secret := mapParams["cds.proj."+strings.TrimPrefix(secretName, "cds.proj.")]
+		if secret == "" {
+			return handleError(ctx, fmt.Errorf("unable to find project secret variable %s to sign the payload", secretName))
+		}
+		header.Set(OutgoingWebHookSignatureHeader, signOutgoingWebHook(secret, []byte(body)))
+	}
+	header.Set(OutgoingWebHookDeliveryHeader, hookRunID)
 
-	http.DefaultClient.Timeout = 60 * time.Second
-	res, err := http.DefaultClient.Do(req)
+	retries, _ := strconv.Atoi(t.Config[sdk.OutgoingWebHookModelRetries].Value)
+	retryWait, err := time.ParseDuration(t.Config[sdk.OutgoingWebHookModelRetryWait].Value)
 	if err != nil {
+		retryWait = time.Second
+	}
+
+	attempt := len(t.WebHook.Deliveries) + 1
+	res, resBody, delivery, retry, err := deliverOutgoingWebHook(ctx, method, urls, header, []byte(body), attempt)
+	t.WebHook.Deliveries = append(t.WebHook.Deliveries, delivery)
+	callbackData.Deliveries = t.WebHook.Deliveries
+	if err != nil {
+		if retry && attempt <= retries {
+			wait := outgoingWebHookRetryWait(retryWait, attempt)
+			log.Info(ctx, "Hooks> outgoing webhook to %s failed (attempt %d): %v, retrying in %s", urls, attempt, err, wait)
+			if err := s.scheduleOutgoingWebHookRetry(t, wait); err != nil {
+				return err
+			}
+			// Send the deliveries without ending the hook run, so that pending retries can be listed
+			callbackData.Status = sdk.StatusBuilding
+			if _, err := s.Client.(cdsclient.Raw).PostJSON(context.Background(), callbackURL, callbackData, nil); err != nil {
+				log.Error(ctx, "unable to send outgoing hook deliveries: %v", err)
+			}
+			return nil
+		}
+		// Deliveries have already been retried, send the error callback now. Hooks created
+		// before the retries setting are still retried by the scheduler.
+		if _, ok := t.Config[sdk.OutgoingWebHookModelRetries]; ok {
+			t.NbErrors = s.Cfg.RetryError - 1
+		}
 		return sdk.WrapError(handleError(ctx, err), "Unable to send request")
 	}
 
+	var logBuffer bytes.Buffer
+	logBuffer.WriteString("Request:\n")
+	if req, err := newOutgoingWebHookRequest(method, urls, header, []byte(body)); err == nil {
+		dump, _ := httputil.DumpRequestOut(req, true)
+		logBuffer.Write(dump) // nolint
+	}
+
 	// Prepare the callback
 	logBuffer.WriteString("\n\nResponse:\n")
-	dump, _ = httputil.DumpResponse(res, true)
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+	dump, _ := httputil.DumpResponse(res, true)
 	logBuffer.Write(dump) // nolint
 
-	if res.StatusCode >= 400 {
-		err := fmt.Errorf("HTTP Status %d", res.StatusCode)
-		return handleError(ctx, err)
-	}
-
 	callbackData.Done = time.Now()
 	callbackData.Log = logBuffer.String()
 	callbackData.Status = sdk.StatusSuccess
@@ -374,3 +426,94 @@ func (s *Service) doOutgoingWebHookExecution(ctx context.Context, t *sdk.TaskExe
 
 	return nil
 }
+
+// signOutgoingWebHook returns the signature of an outgoing webhook body, computed
+// like the GitHub one so that receivers can reuse existing verification code.
+func signOutgoingWebHook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newOutgoingWebHookRequest(method, url string, header http.Header, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	return req, nil
+}
+
+// scheduleOutgoingWebHookRetry saves a new execution of the outgoing webhook task with the previous deliveries,
+// the scheduler will enqueue it after given wait so that the task executor is not blocked until the next attempt.
+func (s *Service) scheduleOutgoingWebHookRetry(t *sdk.TaskExecution, wait time.Duration) error {
+	webHook := *t.WebHook
+	webHook.Deliveries = append([]sdk.OutgoingHookDelivery{}, t.WebHook.Deliveries...)
+	exec := &sdk.TaskExecution{
+		Timestamp: time.Now().Add(wait).UnixNano(),
+		Status:    TaskExecutionScheduled,
+		Type:      t.Type,
+		UUID:      t.UUID,
+		Config:    t.Config,
+		WebHook:   &webHook,
+	}
+	return s.Dao.SaveTaskExecution(exec)
+}
+
+// outgoingWebHookRetryWait returns the exponential backoff before the attempt following the given one.
+func outgoingWebHookRetryWait(retryWait time.Duration, attempt int) time.Duration {
+	wait := retryWait << uint(attempt-1)
+	if wait <= 0 || wait > outgoingWebHookMaxRetryWait {
+		wait = outgoingWebHookMaxRetryWait
+	}
+	return wait
+}
+
+// deliverOutgoingWebHook sends the request once and returns the delivery of this attempt. Network errors,
+// timeouts and 5xx responses are returned as errors that can be retried.
+func deliverOutgoingWebHook(ctx context.Context, method, url string, header http.Header, body []byte, attempt int) (*http.Response, []byte, sdk.OutgoingHookDelivery, bool, error) {
+	delivery := sdk.OutgoingHookDelivery{
+		Attempt: attempt,
+		Date:    time.Now(),
+		Method:  method,
+		URL:     url,
+	}
+
+	req, err := newOutgoingWebHookRequest(method, url, header, body)
+	if err != nil {
+		delivery.Error = err.Error()
+		return nil, nil, delivery, false, err
+	}
+
+	res, err := outgoingWebHookClient.Do(req.WithContext(ctx))
+	var resBody []byte
+	if err == nil {
+		resBody, err = ioutil.ReadAll(res.Body)
+		res.Body.Close() // nolint
+	}
+	delivery.Latency = time.Since(delivery.Date).Milliseconds()
+
+	if err != nil {
+		delivery.Error = err.Error()
+		return nil, nil, delivery, true, sdk.WithStack(err)
+	}
+
+	delivery.StatusCode = res.StatusCode
+	delivery.Response = outgoingWebHookResponseExcerpt(resBody)
+	if res.StatusCode >= 400 {
+		err := fmt.Errorf("HTTP Status %d", res.StatusCode)
+		delivery.Error = err.Error()
+		return nil, nil, delivery, res.StatusCode >= 500, err
+	}
+
+	return res, resBody, delivery, false, nil
+}
+
+func outgoingWebHookResponseExcerpt(body []byte) string {
+	if len(body) > outgoingWebHookResponseExcerptSize {
+		return string(body[:outgoingWebHookResponseExcerptSize]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,81 @@
+package hooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_signOutgoingWebHook(t *testing.T) {
+	body := []byte(`{"status":"Success"}`)
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write(body) // nolint
+	require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), signOutgoingWebHook("s3cr3t", body))
+	require.NotEqual(t, signOutgoingWebHook("s3cr3t", body), signOutgoingWebHook("other", body))
+}
+
+func Test_deliverOutgoingWebHook(t *testing.T) {
+	log.SetLogger(t)
+
+	var calls int
+	statuses := []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		require.Equal(t, `{"foo":"bar"}`, string(body))
+		require.Equal(t, "sha256=abc", r.Header.Get(OutgoingWebHookSignatureHeader))
+		w.WriteHeader(statuses[calls])
+		calls++
+		w.Write([]byte("attempt")) // nolint
+	}))
+	defer srv.Close()
+
+	header := http.Header{}
+	header.Set(OutgoingWebHookSignatureHeader, "sha256=abc")
+
+	// 5xx responses can be retried
+	_, _, delivery, retry, err := deliverOutgoingWebHook(context.TODO(), http.MethodPost, srv.URL, header, []byte(`{"foo":"bar"}`), 1)
+	require.Error(t, err)
+	require.True(t, retry)
+	require.Equal(t, 1, delivery.Attempt)
+	require.Equal(t, http.StatusBadGateway, delivery.StatusCode)
+	require.Equal(t, "HTTP Status 502", delivery.Error)
+
+	_, _, delivery, retry, err = deliverOutgoingWebHook(context.TODO(), http.MethodPost, srv.URL, header, []byte(`{"foo":"bar"}`), 2)
+	require.Error(t, err)
+	require.True(t, retry)
+	require.Equal(t, 2, delivery.Attempt)
+	require.Equal(t, http.StatusServiceUnavailable, delivery.StatusCode)
+
+	res, body, delivery, _, err := deliverOutgoingWebHook(context.TODO(), http.MethodPost, srv.URL, header, []byte(`{"foo":"bar"}`), 3)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "attempt", string(body))
+	require.Equal(t, http.StatusOK, delivery.StatusCode)
+	require.Equal(t, "", delivery.Error)
+	require.Equal(t, "attempt", delivery.Response)
+
+	// Client errors are not retried
+	calls = 0
+	statuses = []int{http.StatusNotFound}
+	_, _, delivery, retry, err = deliverOutgoingWebHook(context.TODO(), http.MethodPost, srv.URL, header, []byte(`{"foo":"bar"}`), 1)
+	require.Error(t, err)
+	require.False(t, retry)
+	require.Equal(t, http.StatusNotFound, delivery.StatusCode)
+}
+
+func Test_outgoingWebHookRetryWait(t *testing.T) {
+	require.Equal(t, time.Second, outgoingWebHookRetryWait(time.Second, 1))
+	require.Equal(t, 4*time.Second, outgoingWebHookRetryWait(time.Second, 3))
+	require.Equal(t, outgoingWebHookMaxRetryWait, outgoingWebHookRetryWait(time.Second, 10))
+	require.Equal(t, outgoingWebHookMaxRetryWait, outgoingWebHookRetryWait(time.Second, 100))
+}
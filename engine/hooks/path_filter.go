@@ -0,0 +1,83 @@
+package hooks
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Github sends at most 20 commits in a push event
+const githubMaxPushCommits = 20
+
+func isEmptyHash(hash string) bool {
+	return hash == "" || strings.Trim(hash, "0") == ""
+}
+
+// joinChangedFiles returns the sorted list of files given to the payload, without duplicates.
+func joinChangedFiles(files []string) string {
+	seen := make(map[string]struct{}, len(files))
+	res := make([]string, 0, len(files))
+	for _, f := range files {
+		if _, ok := seen[f]; ok || f == "" {
+			continue
+		}
+		seen[f] = struct{}{}
+		res = append(res, f)
+	}
+	sort.Strings(res)
+	return strings.Join(res, ",")
+}
+
+func splitChangedFiles(files string) []string {
+	if files == "" {
+		return nil
+	}
+	return strings.Split(files, ",")
+}
+
+// filterEventsOnChangedFiles sets the files changed by the commits of each event and removes the events
+// that don't change any file matching the path filter of the hook. Events with unknown changes are kept.
+func (s *Service) filterEventsOnChangedFiles(ctx context.Context, t *sdk.TaskExecution, hs []sdk.WorkflowNodeRunHookEvent) []sdk.WorkflowNodeRunHookEvent {
+	filter := sdk.NewHookPathFilter(t.Config)
+	res := make([]sdk.WorkflowNodeRunHookEvent, 0, len(hs))
+	for _, h := range hs {
+		if _, ok := h.Payload[GIT_CHANGED_FILES]; !ok && !filter.IsEmpty() {
+			if files, ok := s.changedFilesBetweenRefs(ctx, t, h.Payload); ok {
+				h.Payload[GIT_CHANGED_FILES] = joinChangedFiles(files)
+			}
+		}
+		if files, ok := h.Payload[GIT_CHANGED_FILES]; ok && !filter.Match(splitChangedFiles(files)) {
+			log.Info(ctx, "hook %s: no changed file matches the path filter on %s, event skipped", t.UUID, h.Payload[GIT_HASH])
+			continue
+		}
+		res = append(res, h)
+	}
+	return res
+}
+
+// changedFilesBetweenRefs asks CDS API the files changed between the previous hash of the branch,
+// or the destination of the pull request, and the hash of the event.
+func (s *Service) changedFilesBetweenRefs(ctx context.Context, t *sdk.TaskExecution, payload map[string]string) ([]string, bool) {
+	head := payload[GIT_HASH]
+	base := payload[GIT_HASH_BEFORE]
+	if base == "" {
+		base = payload[GIT_HASH_DEST]
+	}
+	if isEmptyHash(base) || isEmptyHash(head) {
+		return nil, false
+	}
+	commits, err := s.Client.HookCommitsBetweenRefs(t.UUID, base, head)
+	if err != nil {
+		log.Warning(ctx, "hook %s: unable to get commits between %s and %s: %v", t.UUID, base, head, err)
+		return nil, false
+	}
+	// Some repository managers don't give the changes of the commits
+	files := sdk.VCSCommitsFiles(commits)
+	if len(files) == 0 {
+		return nil, false
+	}
+	return files, true
+}
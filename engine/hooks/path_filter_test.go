@@ -0,0 +1,58 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_filterEventsOnChangedFiles(t *testing.T) {
+	log.SetLogger(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mock_cdsclient.NewMockInterface(ctrl)
+	var s Service
+	s.Client = m
+
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigPathIncludes: {Value: "services/api/**"},
+			sdk.HookConfigPathExcludes: {Value: "**/*.md"},
+		},
+	}
+
+	// Files given by the payload
+	hs := s.filterEventsOnChangedFiles(context.TODO(), task, []sdk.WorkflowNodeRunHookEvent{
+		{Payload: map[string]string{GIT_HASH: "aaa", GIT_CHANGED_FILES: "README.md,services/api/main.go"}},
+		{Payload: map[string]string{GIT_HASH: "bbb", GIT_CHANGED_FILES: "services/api/README.md,services/ui/main.ts"}},
+	})
+	require.Len(t, hs, 1)
+	require.Equal(t, "aaa", hs[0].Payload[GIT_HASH])
+
+	// Truncated payload, files are asked to CDS API
+	m.EXPECT().HookCommitsBetweenRefs(task.UUID, "111", "222").Return([]sdk.VCSCommit{
+		{Hash: "aaa"},
+		{Hash: "222", Files: []string{"services/ui/main.ts", "docs/index.md"}},
+	}, nil)
+	hs = s.filterEventsOnChangedFiles(context.TODO(), task, []sdk.WorkflowNodeRunHookEvent{
+		{Payload: map[string]string{GIT_HASH_BEFORE: "111", GIT_HASH: "222"}},
+	})
+	require.Len(t, hs, 0)
+
+	// Unknown changes, the event is kept
+	m.EXPECT().HookCommitsBetweenRefs(task.UUID, "111", "333").Return([]sdk.VCSCommit{{Hash: "333"}}, nil)
+	hs = s.filterEventsOnChangedFiles(context.TODO(), task, []sdk.WorkflowNodeRunHookEvent{
+		{Payload: map[string]string{GIT_HASH_BEFORE: "111", GIT_HASH: "333"}},
+		{Payload: map[string]string{GIT_HASH_BEFORE: "0000000000000000000000000000000000000000", GIT_HASH: "444"}},
+	})
+	require.Len(t, hs, 2)
+	require.NotContains(t, hs[0].Payload, GIT_CHANGED_FILES)
+}
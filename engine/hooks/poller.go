@@ -25,6 +25,9 @@ func fillPayload(ctx context.Context, pushEvent sdk.VCSPushEvent) map[string]str
 	payload["cds.triggered_by.fullname"] = pushEvent.Commit.Author.Name
 	payload["cds.triggered_by.email"] = pushEvent.Commit.Author.Email
 	payload["git.message"] = pushEvent.Commit.Message
+	if len(pushEvent.ChangedFiles) > 0 {
+		payload[GIT_CHANGED_FILES] = joinChangedFiles(pushEvent.ChangedFiles)
+	}
 
 	payloadStr, err := json.Marshal(pushEvent)
 	if err != nil {
@@ -90,25 +93,23 @@ func (s *Service) doPollerTaskExecution(ctx context.Context, task *sdk.Task, tas
 
 	var hookEvents []sdk.WorkflowNodeRunHookEvent
 	if len(events.PushEvents) > 0 || len(events.PullRequestEvents) > 0 {
-		i := 0
-		hookEvents = make([]sdk.WorkflowNodeRunHookEvent, len(events.PushEvents)+len(events.PullRequestEvents))
+		hookEvents = make([]sdk.WorkflowNodeRunHookEvent, 0, len(events.PushEvents)+len(events.PullRequestEvents))
 		for _, pushEvent := range events.PushEvents {
 			payload := fillPayload(ctx, pushEvent)
-			hookEvents[i] = sdk.WorkflowNodeRunHookEvent{
+			hookEvents = append(hookEvents, sdk.WorkflowNodeRunHookEvent{
 				WorkflowNodeHookUUID: task.UUID,
 				Payload:              sdk.ParametersMapMerge(payloadValues, payload),
-			}
-			i++
+			})
 		}
 
 		for _, pullRequestEvent := range events.PullRequestEvents {
 			payload := fillPayload(ctx, pullRequestEvent.Head)
-			hookEvents[i] = sdk.WorkflowNodeRunHookEvent{
+			hookEvents = append(hookEvents, sdk.WorkflowNodeRunHookEvent{
 				WorkflowNodeHookUUID: task.UUID,
 				Payload:              sdk.ParametersMapMerge(payloadValues, payload),
-			}
-			i++
+			})
 		}
+		hookEvents = s.filterEventsOnChangedFiles(ctx, taskExec, hookEvents)
 	}
 
 	nextExec := fmt.Sprint(time.Now().Add(interval).Unix())
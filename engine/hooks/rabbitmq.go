@@ -2,14 +2,12 @@ package hooks
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/fsamin/go-dump"
 	"github.com/streadway/amqp"
 
 	"github.com/ovh/cds/sdk"
@@ -93,42 +91,14 @@ func (s *Service) startRabbitMQHook(ctx context.Context, t *sdk.Task) error {
 func (s *Service) doRabbitMQTaskExecution(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
 	log.Debug("Hooks> Processing rabbitMQ %s %s", t.UUID, t.Type)
 
-	// Prepare a struct to send to CDS API
-	h := sdk.WorkflowNodeRunHookEvent{
-		WorkflowNodeHookUUID: t.UUID,
-		Payload:              map[string]string{},
-	}
-
-	var bodyJSON interface{}
-
-	//Try to parse the body as an array
-	bodyJSONArray := []interface{}{}
-	if err := json.Unmarshal(t.RabbitMQ.Message, &bodyJSONArray); err != nil {
-		//Try to parse the body as a map
-		bodyJSONMap := map[string]interface{}{}
-		if err2 := json.Unmarshal(t.RabbitMQ.Message, &bodyJSONMap); err2 == nil {
-			bodyJSON = bodyJSONMap
-		}
-	} else {
-		bodyJSON = bodyJSONArray
-	}
-
-	//Go Dump
-	e := dump.NewDefaultEncoder()
-	e.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
-	e.ExtraFields.DetailedMap = false
-	e.ExtraFields.DetailedStruct = false
-	e.ExtraFields.DeepJSON = true
-	e.ExtraFields.Len = false
-	e.ExtraFields.Type = false
-	m, err := e.ToStringMap(bodyJSON)
+	payload, err := messagePayload(t.RabbitMQ.Message)
 	if err != nil {
-		return nil, sdk.WrapError(err, "Unable to dump body %s", t.WebHook.RequestBody)
+		return nil, err
 	}
-	h.Payload = m
-	h.Payload["payload"] = string(t.RabbitMQ.Message)
-
-	return &h, nil
+	return &sdk.WorkflowNodeRunHookEvent{
+		WorkflowNodeHookUUID: t.UUID,
+		Payload:              payload,
+	}, nil
 }
 
 func newConsumer(amqpURI, exchange, exchangeType, queueName, key, ctag string) (*rabbitMQConsumer, error) {
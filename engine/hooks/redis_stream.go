@@ -0,0 +1,124 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// redisStreamConsumer consumes the messages of a Redis Stream with a consumer group.
+type redisStreamConsumer struct {
+	client   *redis.Client
+	stream   string
+	group    string
+	consumer string
+}
+
+func newRedisStreamConsumer(s *Service, pf sdk.ProjectIntegration, t *sdk.Task) (messageBusConsumer, error) {
+	stream := t.Config[sdk.RedisStreamHookModelStream].Value
+	if stream == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing Redis stream")
+	}
+	group := t.Config[sdk.RedisStreamHookModelGroup].Value
+	if group == "" {
+		group = "cds-" + t.UUID
+	}
+	var db int
+	if v := pf.Config["db"].Value; v != "" {
+		var err error
+		db, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid Redis database %q", v)
+		}
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     pf.Config["host"].Value,
+		Password: pf.Config["password"].Value,
+		DB:       db,
+	})
+	if err := client.Ping().Err(); err != nil {
+		_ = client.Close()
+		return nil, sdk.WrapError(err, "cannot connect to Redis %s", pf.Config["host"].Value)
+	}
+
+	// The group only receives the messages added after its creation
+	if err := client.XGroupCreateMkStream(stream, group, "$").Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		_ = client.Close()
+		return nil, sdk.WrapError(err, "cannot create consumer group %s on Redis stream %s", group, stream)
+	}
+
+	consumer := s.Cfg.Name
+	if consumer == "" {
+		consumer = "cds-hooks"
+	}
+
+	return &redisStreamConsumer{
+		client:   client,
+		stream:   stream,
+		group:    group,
+		consumer: consumer,
+	}, nil
+}
+
+func (c *redisStreamConsumer) Consume(ctx context.Context, handler messageBusHandler) error {
+	// Start with the messages delivered to this consumer but never acknowledged, then read new messages
+	lastID := "0"
+	for ctx.Err() == nil {
+		streams, err := c.client.XReadGroup(&redis.XReadGroupArgs{
+			Group:    c.group,
+			Consumer: c.consumer,
+			Streams:  []string{c.stream, lastID},
+			Count:    10,
+			Block:    5 * time.Second,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			log.Error(ctx, "Hooks> unable to read Redis stream %s: %v", c.stream, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+
+		var nbMessages int
+		for _, st := range streams {
+			for _, m := range st.Messages {
+				nbMessages++
+				if lastID != ">" {
+					lastID = m.ID
+				}
+				// The message is the JSON object of the entry fields
+				msg, err := json.Marshal(m.Values)
+				if err != nil {
+					log.Error(ctx, "Hooks> unable to marshal Redis stream %s entry %s: %v", st.Stream, m.ID, err)
+					continue
+				}
+				if err := handler(st.Stream, msg); err != nil {
+					continue
+				}
+				if err := c.client.XAck(c.stream, c.group, m.ID).Err(); err != nil {
+					log.Error(ctx, "Hooks> unable to ack Redis stream %s entry %s: %v", st.Stream, m.ID, err)
+				}
+			}
+		}
+		if lastID != ">" && nbMessages == 0 {
+			lastID = ">"
+		}
+	}
+	return nil
+}
+
+func (c *redisStreamConsumer) Close() error {
+	return sdk.WithStack(c.client.Close())
+}
@@ -3,15 +3,30 @@ package hooks
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
 
 	dump "github.com/fsamin/go-dump"
+	"github.com/gorhill/cronexpr"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
-func (s *Service) doScheduledTaskExecution(ctx context.Context, t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
+func (s *Service) doScheduledTaskExecution(ctx context.Context, task *sdk.Task, t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
 	log.Debug("Hooks> Processing scheduled task %s", t.UUID)
 
+	skipReason, err := s.scheduledTaskSkipReason(ctx, task, t)
+	if err != nil {
+		return nil, err
+	}
+	if skipReason != "" {
+		log.Info(ctx, "Hooks> Scheduled task %s skipped: %s", t.UUID, skipReason)
+		t.ScheduledTask.SkipReason = skipReason
+		return nil, nil
+	}
+
 	// Prepare a struct to send to CDS API
 	h := sdk.WorkflowNodeRunHookEvent{
 		WorkflowNodeHookUUID: t.UUID,
@@ -44,7 +59,8 @@ func (s *Service) doScheduledTaskExecution(ctx context.Context, t *sdk.TaskExecu
 	}
 	for k, v := range t.Config {
 		switch k {
-		case sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.SchedulerModelCron, sdk.SchedulerModelTimezone, sdk.Payload:
+		case sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.SchedulerModelCron, sdk.SchedulerModelTimezone, sdk.Payload,
+			sdk.SchedulerModelJitter, sdk.SchedulerModelCalendars, sdk.SchedulerModelSkipIfBuilding:
 		default:
 			payloadValues[k] = v.Value
 		}
@@ -55,3 +71,68 @@ func (s *Service) doScheduledTaskExecution(ctx context.Context, t *sdk.TaskExecu
 
 	return &h, nil
 }
+
+// schedulerJitter returns a random delay to add to the next execution of a scheduled task.
+// The delay is lower than the configured jitter and than the interval between two executions.
+func schedulerJitter(ctx context.Context, t *sdk.Task, cronExpr *cronexpr.Expression, nextSchedule time.Time) time.Duration {
+	jitter, err := t.Config.SchedulerJitter()
+	if err != nil {
+		log.Warning(ctx, "Hooks> Scheduled task %s: %v", t.UUID, err)
+		return 0
+	}
+	if interval := cronExpr.Next(nextSchedule).Sub(nextSchedule); interval > 0 && jitter > interval {
+		jitter = interval
+	}
+	if jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(jitter)))
+}
+
+// scheduledTaskSkipReason returns why the execution of a scheduled task should be skipped, or an empty string.
+func (s *Service) scheduledTaskSkipReason(ctx context.Context, task *sdk.Task, t *sdk.TaskExecution) (string, error) {
+	projectKey := t.Config[sdk.HookConfigProject].Value
+	workflowName := t.Config[sdk.HookConfigWorkflow].Value
+
+	calendarNames := t.Config.SchedulerCalendars()
+	if len(calendarNames) > 0 {
+		calendars, err := s.Client.ProjectCalendarList(projectKey)
+		if err != nil {
+			return "", sdk.WrapError(err, "unable to get calendars of project %s", projectKey)
+		}
+		now := time.Now()
+		for _, name := range calendarNames {
+			var calendar *sdk.ProjectCalendar
+			for i := range calendars {
+				if calendars[i].Name == name {
+					calendar = &calendars[i]
+					break
+				}
+			}
+			if calendar == nil {
+				return "", sdk.NewErrorFrom(sdk.ErrNotFound, "calendar %s not found in project %s", name, projectKey)
+			}
+			if p := calendar.PeriodAt(now); p != nil {
+				if p.Name == "" {
+					return fmt.Sprintf("excluded by calendar %s", calendar.Name), nil
+				}
+				return fmt.Sprintf("excluded by calendar %s (%s)", calendar.Name, p.Name), nil
+			}
+		}
+	}
+
+	if skip, _ := strconv.ParseBool(t.Config[sdk.SchedulerModelSkipIfBuilding].Value); skip {
+		// The executions history is pruned, the last workflow run triggered is kept on the task
+		if task.LastWorkflowRun > 0 {
+			run, err := s.Client.WorkflowRunGet(projectKey, workflowName, task.LastWorkflowRun)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return "", sdk.WrapError(err, "unable to get workflow run %s/%s#%d", projectKey, workflowName, task.LastWorkflowRun)
+			}
+			if err == nil && !sdk.StatusIsTerminated(run.Status) {
+				return fmt.Sprintf("workflow run #%d is still %s", run.Number, run.Status), nil
+			}
+		}
+	}
+
+	return "", nil
+}
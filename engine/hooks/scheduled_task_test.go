@@ -0,0 +1,130 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorhill/cronexpr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_schedulerJitter(t *testing.T) {
+	log.SetLogger(t)
+	cronExpr := cronexpr.MustParse("*/10 * * * *")
+	next := cronExpr.Next(time.Now())
+
+	task := &sdk.Task{UUID: sdk.RandomString(10), Config: sdk.WorkflowNodeHookConfig{}}
+	require.Equal(t, time.Duration(0), schedulerJitter(context.TODO(), task, cronExpr, next))
+
+	task.Config[sdk.SchedulerModelJitter] = sdk.WorkflowNodeHookConfigValue{Value: "invalid"}
+	require.Equal(t, time.Duration(0), schedulerJitter(context.TODO(), task, cronExpr, next))
+
+	task.Config[sdk.SchedulerModelJitter] = sdk.WorkflowNodeHookConfigValue{Value: "5m"}
+	for i := 0; i < 100; i++ {
+		j := schedulerJitter(context.TODO(), task, cronExpr, next)
+		require.True(t, j >= 0 && j < 5*time.Minute, "invalid jitter %s", j)
+	}
+
+	// The jitter can't be greater than the interval between two executions
+	task.Config[sdk.SchedulerModelJitter] = sdk.WorkflowNodeHookConfigValue{Value: "2h"}
+	for i := 0; i < 100; i++ {
+		j := schedulerJitter(context.TODO(), task, cronExpr, next)
+		require.True(t, j >= 0 && j < 10*time.Minute, "invalid jitter %s", j)
+	}
+}
+
+func Test_scheduledTaskSkipReasonCalendars(t *testing.T) {
+	log.SetLogger(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mock_cdsclient.NewMockInterface(ctrl)
+	var s Service
+	s.Client = m
+
+	now := time.Now()
+	exec := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeScheduler,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigProject:       {Value: "PROJ"},
+			sdk.HookConfigWorkflow:      {Value: "my-workflow"},
+			sdk.SchedulerModelCalendars: {Value: "holidays;release-freeze"},
+		},
+		ScheduledTask: &sdk.ScheduledTaskExecution{},
+	}
+	task := &sdk.Task{UUID: exec.UUID, Type: exec.Type, Config: exec.Config}
+
+	calendars := []sdk.ProjectCalendar{
+		{Name: "holidays", Periods: sdk.CalendarPeriods{{Name: "past", Start: now.AddDate(0, -1, 0), End: now.AddDate(0, 0, -1)}}},
+		{Name: "release-freeze", Periods: sdk.CalendarPeriods{{Name: "next-week", Start: now.AddDate(0, 0, 7), End: now.AddDate(0, 0, 14)}}},
+	}
+	m.EXPECT().ProjectCalendarList("PROJ").DoAndReturn(func(string) ([]sdk.ProjectCalendar, error) { return calendars, nil }).Times(3)
+
+	reason, err := s.scheduledTaskSkipReason(context.TODO(), task, exec)
+	require.NoError(t, err)
+	require.Equal(t, "", reason)
+
+	calendars[1].Periods = append(calendars[1].Periods, sdk.CalendarPeriod{Name: "now", Start: now.Add(-time.Hour), End: now.Add(time.Hour)})
+	reason, err = s.scheduledTaskSkipReason(context.TODO(), task, exec)
+	require.NoError(t, err)
+	require.Equal(t, "excluded by calendar release-freeze (now)", reason)
+
+	// An unknown calendar is an error, the workflow is not triggered
+	exec.Config[sdk.SchedulerModelCalendars] = sdk.WorkflowNodeHookConfigValue{Value: "unknown"}
+	_, err = s.scheduledTaskSkipReason(context.TODO(), task, exec)
+	require.Error(t, err)
+
+	// Skipped executions don't trigger the workflow
+	exec.Config[sdk.SchedulerModelCalendars] = sdk.WorkflowNodeHookConfigValue{Value: "release-freeze"}
+	m.EXPECT().ProjectCalendarList("PROJ").Return(calendars, nil)
+	h, err := s.doScheduledTaskExecution(context.TODO(), task, exec)
+	require.NoError(t, err)
+	require.Nil(t, h)
+	require.Equal(t, "excluded by calendar release-freeze (now)", exec.ScheduledTask.SkipReason)
+}
+
+func Test_scheduledTaskSkipReasonSkipIfBuilding(t *testing.T) {
+	log.SetLogger(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mock_cdsclient.NewMockInterface(ctrl)
+	var s Service
+	s.Client = m
+
+	uuid := sdk.RandomString(10)
+	config := sdk.WorkflowNodeHookConfig{
+		sdk.HookConfigProject:            {Value: "PROJ"},
+		sdk.HookConfigWorkflow:           {Value: "my-workflow"},
+		sdk.SchedulerModelSkipIfBuilding: {Value: "true"},
+	}
+	exec := &sdk.TaskExecution{
+		UUID:          uuid,
+		Type:          TypeScheduler,
+		Timestamp:     time.Now().UnixNano(),
+		Config:        config,
+		ScheduledTask: &sdk.ScheduledTaskExecution{},
+	}
+	task := &sdk.Task{UUID: uuid, Type: TypeScheduler, Config: config}
+
+	// No workflow run has been triggered by the task yet
+	reason, err := s.scheduledTaskSkipReason(context.TODO(), task, exec)
+	require.NoError(t, err)
+	require.Equal(t, "", reason)
+
+	task.LastWorkflowRun = 12
+	m.EXPECT().WorkflowRunGet("PROJ", "my-workflow", int64(12)).Return(&sdk.WorkflowRun{Number: 12, Status: sdk.StatusBuilding}, nil)
+	reason, err = s.scheduledTaskSkipReason(context.TODO(), task, exec)
+	require.NoError(t, err)
+	require.Equal(t, "workflow run #12 is still Building", reason)
+
+	m.EXPECT().WorkflowRunGet("PROJ", "my-workflow", int64(12)).Return(&sdk.WorkflowRun{Number: 12, Status: sdk.StatusSuccess}, nil)
+	reason, err = s.scheduledTaskSkipReason(context.TODO(), task, exec)
+	require.NoError(t, err)
+	require.Equal(t, "", reason)
+}
@@ -17,6 +17,7 @@ import (
 //This are all the types
 const (
 	TypeRepoManagerWebHook = "RepoWebHook"
+	TypeCommentCommand     = "CommentCommand"
 	TypeWebHook            = "Webhook"
 	TypeScheduler          = "Scheduler"
 	TypeRepoPoller         = "RepoPoller"
@@ -24,6 +25,8 @@ const (
 	TypeKafka              = "Kafka"
 	TypeGerrit             = "Gerrit"
 	TypeRabbitMQ           = "RabbitMQ"
+	TypeNATS               = "NATS"
+	TypeRedisStream        = "RedisStream"
 	TypeWorkflowHook       = "Workflow"
 	TypeOutgoingWebHook    = "OutgoingWebhook"
 	TypeOutgoingWorkflow   = "OutgoingWorkflow"
@@ -32,6 +35,13 @@ const (
 	GitlabHeader         = "X-Gitlab-Event"
 	BitbucketHeader      = "X-Event-Key"
 	BitbucketCloudHeader = "X-Event-Key_Cloud" // Fake header, do not use to fetch header, just to return custom header
+	GiteaHeader          = "X-Gitea-Event"
+	GiteaEventTypeHeader = "X-Gitea-Event-Type"
+
+	GithubSignatureHeader    = "X-Hub-Signature-256"
+	GitlabTokenHeader        = "X-Gitlab-Token"
+	BitbucketSignatureHeader = "X-Hub-Signature"
+	GiteaSignatureHeader     = "X-Gitea-Signature"
 
 	ConfigNumber    = "Number"
 	ConfigSubNumber = "SubNumber"
@@ -182,6 +192,18 @@ func (s *Service) hookToTask(h *sdk.NodeHook) (*sdk.Task, error) {
 			Type:   TypeRabbitMQ,
 			Config: h.Config,
 		}, nil
+	case sdk.NATSHookModelName:
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypeNATS,
+			Config: h.Config,
+		}, nil
+	case sdk.RedisStreamHookModelName:
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypeRedisStream,
+			Config: h.Config,
+		}, nil
 	case sdk.WebHookModelName:
 		h.Config["webHookURL"] = sdk.WorkflowNodeHookConfigValue{
 			Value:        fmt.Sprintf("%s/webhook/%s", s.Cfg.URLPublic, h.UUID),
@@ -202,6 +224,16 @@ func (s *Service) hookToTask(h *sdk.NodeHook) (*sdk.Task, error) {
 			Type:   TypeRepoManagerWebHook,
 			Config: h.Config,
 		}, nil
+	case sdk.CommentCommandHookModelName:
+		h.Config["webHookURL"] = sdk.WorkflowNodeHookConfigValue{
+			Value:        fmt.Sprintf("%s/webhook/%s", s.Cfg.URLPublic, h.UUID),
+			Configurable: false,
+		}
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypeCommentCommand,
+			Config: h.Config,
+		}, nil
 	case sdk.SchedulerModelName:
 		return &sdk.Task{
 			UUID:   h.UUID,
@@ -272,7 +304,7 @@ func (s *Service) startTask(ctx context.Context, t *sdk.Task) (*sdk.TaskExecutio
 	}
 
 	switch t.Type {
-	case TypeWebHook, TypeRepoManagerWebHook, TypeWorkflowHook:
+	case TypeWebHook, TypeRepoManagerWebHook, TypeCommentCommand, TypeWorkflowHook:
 		return nil, nil
 	case TypeScheduler, TypeRepoPoller, TypeBranchDeletion:
 		return nil, s.prepareNextScheduledTaskExecution(ctx, t)
@@ -280,6 +312,8 @@ func (s *Service) startTask(ctx context.Context, t *sdk.Task) (*sdk.TaskExecutio
 		return nil, s.startKafkaHook(ctx, t)
 	case TypeRabbitMQ:
 		return nil, s.startRabbitMQHook(ctx, t)
+	case TypeNATS, TypeRedisStream:
+		return nil, s.startMessageBusHook(ctx, t)
 	case TypeOutgoingWebHook:
 		return s.startOutgoingWebHookTask(t)
 	case TypeOutgoingWorkflow:
@@ -329,6 +363,7 @@ func (s *Service) prepareNextScheduledTaskExecution(ctx context.Context, t *sdk.
 		//Compute a new date
 		t0 := time.Now().In(loc)
 		nextSchedule = cronExpr.Next(t0)
+		nextSchedule = nextSchedule.Add(schedulerJitter(ctx, t, cronExpr, nextSchedule))
 
 	case TypeRepoPoller:
 		// Default value of next scheduling
@@ -373,9 +408,13 @@ func (s *Service) stopTask(ctx context.Context, t *sdk.Task) error {
 	}
 
 	switch t.Type {
-	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypeRepoPoller, TypeKafka, TypeWorkflowHook:
+	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypeCommentCommand, TypeRepoPoller, TypeKafka, TypeWorkflowHook:
 		log.Debug("Hooks> Tasks %s has been stopped", t.UUID)
 		return nil
+	case TypeNATS, TypeRedisStream:
+		s.stopMessageBusHook(t)
+		log.Debug("Hooks> %s Task %s has been stopped", t.Type, t.UUID)
+		return nil
 	case TypeGerrit:
 		s.stopGerritHookTask(t)
 		log.Debug("Hooks> Gerrit Task %s has been stopped", t.UUID)
@@ -405,8 +444,10 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 		err = s.doOutgoingWorkflowExecution(ctx, e)
 	case e.WebHook != nil && (e.Type == TypeWebHook || e.Type == TypeRepoManagerWebHook):
 		hs, err = s.doWebHookExecution(ctx, e)
+	case e.WebHook != nil && e.Type == TypeCommentCommand:
+		err = s.doCommentCommandExecution(ctx, e)
 	case e.ScheduledTask != nil && e.Type == TypeScheduler:
-		h, err = s.doScheduledTaskExecution(ctx, e)
+		h, err = s.doScheduledTaskExecution(ctx, t, e)
 		doRestart = true
 	case e.ScheduledTask != nil && e.Type == TypeRepoPoller:
 		//Populate next execution
@@ -418,6 +459,8 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 		h, err = s.doKafkaTaskExecution(e)
 	case e.RabbitMQ != nil && e.Type == TypeRabbitMQ:
 		h, err = s.doRabbitMQTaskExecution(e)
+	case e.MessageBus != nil && (e.Type == TypeNATS || e.Type == TypeRedisStream):
+		h, err = s.doMessageBusTaskExecution(e)
 	default:
 		err = fmt.Errorf("Unsupported task type %s", e.Type)
 	}
@@ -445,6 +488,13 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 			//Save the run number
 			e.WorkflowRun = run.Number
 			log.Debug("Hooks> workflow %s/%s#%d has been triggered", confProj.Value, confWorkflow.Value, run.Number)
+			// Scheduled tasks can be skipped while the last workflow run they triggered is building
+			if e.Type == TypeScheduler {
+				t.LastWorkflowRun = run.Number
+				if err := s.Dao.SaveTask(t); err != nil {
+					log.Error(ctx, "Hooks> %s > unable to save the last workflow run: %v", t.UUID, err)
+				}
+			}
 		}
 	}
 
@@ -0,0 +1,218 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_doWebHookExecutionGitea(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(giteaPushEvent),
+			RequestHeader: map[string][]string{
+				GiteaHeader:          {"push"},
+				GiteaEventTypeHeader: {"push"},
+				GithubHeader:         {"push"},
+			},
+			RequestURL: "",
+		},
+	}
+	hs, err := s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+
+	require.Equal(t, 1, len(hs))
+	require.Equal(t, "develop", hs[0].Payload["git.branch"])
+	require.Equal(t, "gitea", hs[0].Payload["git.author"])
+	require.Equal(t, "gitea/webhooks", hs[0].Payload["git.repository"])
+	require.Equal(t, "Update README.md\n", hs[0].Payload["git.message"])
+	require.Equal(t, "bffeb74224043ba2feb48d137756c8a9331c449a", hs[0].Payload["git.hash"])
+	require.Equal(t, "28e1879d029cb852e4844d9c718537df08844e03", hs[0].Payload["git.hash.before"])
+}
+
+func Test_doWebHookExecutionGiteaPullRequest(t *testing.T) {
+	log.SetLogger(t)
+	var s Service
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigEventFilter: {Value: "pull_request_sync"},
+		},
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(giteaPullRequestEvent),
+			RequestHeader: map[string][]string{
+				GiteaHeader:          {"pull_request"},
+				GiteaEventTypeHeader: {"pull_request_sync"},
+				GithubHeader:         {"pull_request"},
+			},
+			RequestURL: "",
+		},
+	}
+	hs, err := s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+
+	require.Equal(t, 1, len(hs))
+	require.Equal(t, "pull_request", hs[0].Payload["git.hook"])
+	require.Equal(t, "1", hs[0].Payload["git.pr.id"])
+	require.Equal(t, "Add a feature", hs[0].Payload["git.pr.title"])
+	require.Equal(t, "synchronized", hs[0].Payload["git.pr.state"])
+	require.Equal(t, "feature", hs[0].Payload["git.branch"])
+	require.Equal(t, "7b9b1c3bd2d0e4c3b71be8d6bd3fae0fbb2dc8b7", hs[0].Payload["git.hash"])
+	require.Equal(t, "master", hs[0].Payload["git.branch.dest"])
+	require.Equal(t, "bffeb74224043ba2feb48d137756c8a9331c449a", hs[0].Payload["git.hash.dest"])
+	require.Equal(t, "jdoe/webhooks", hs[0].Payload["git.repository"])
+	require.Equal(t, "gitea/webhooks", hs[0].Payload["git.repository.dest"])
+	require.Equal(t, "jdoe", hs[0].Payload["cds.triggered_by.username"])
+
+	// The pull request event is filtered if it is not in the hook events
+	task.Config[sdk.HookConfigEventFilter] = sdk.WorkflowNodeHookConfigValue{Value: "push"}
+	_, err = s.doWebHookExecution(context.TODO(), task)
+	require.Error(t, err)
+}
+
+var giteaPushEvent = `{
+  "ref": "refs/heads/develop",
+  "before": "28e1879d029cb852e4844d9c718537df08844e03",
+  "after": "bffeb74224043ba2feb48d137756c8a9331c449a",
+  "compare_url": "http://localhost:3000/gitea/webhooks/compare/28e1879d029cb852e4844d9c718537df08844e03...bffeb74224043ba2feb48d137756c8a9331c449a",
+  "commits": [
+    {
+      "id": "bffeb74224043ba2feb48d137756c8a9331c449a",
+      "message": "Update README.md\n",
+      "url": "http://localhost:3000/gitea/webhooks/commit/bffeb74224043ba2feb48d137756c8a9331c449a",
+      "author": {
+        "name": "Gitea",
+        "email": "gitea@example.com",
+        "username": "gitea"
+      },
+      "committer": {
+        "name": "Gitea",
+        "email": "gitea@example.com",
+        "username": "gitea"
+      },
+      "timestamp": "2017-03-13T13:52:11-04:00",
+      "added": [],
+      "removed": [],
+      "modified": ["README.md"]
+    }
+  ],
+  "head_commit": {
+    "id": "bffeb74224043ba2feb48d137756c8a9331c449a",
+    "message": "Update README.md\n",
+    "url": "http://localhost:3000/gitea/webhooks/commit/bffeb74224043ba2feb48d137756c8a9331c449a",
+    "author": {
+      "name": "Gitea",
+      "email": "gitea@example.com",
+      "username": "gitea"
+    },
+    "committer": {
+      "name": "Gitea",
+      "email": "gitea@example.com",
+      "username": "gitea"
+    },
+    "timestamp": "2017-03-13T13:52:11-04:00"
+  },
+  "repository": {
+    "id": 140,
+    "owner": {
+      "id": 1,
+      "login": "gitea",
+      "full_name": "Gitea",
+      "email": "gitea@example.com",
+      "avatar_url": "https://localhost:3000/avatars/1",
+      "username": "gitea"
+    },
+    "name": "webhooks",
+    "full_name": "gitea/webhooks",
+    "private": false,
+    "fork": false,
+    "html_url": "http://localhost:3000/gitea/webhooks",
+    "ssh_url": "ssh://gitea@localhost:2222/gitea/webhooks.git",
+    "clone_url": "http://localhost:3000/gitea/webhooks.git",
+    "default_branch": "master"
+  },
+  "pusher": {
+    "id": 1,
+    "login": "gitea",
+    "full_name": "Gitea",
+    "email": "gitea@example.com",
+    "avatar_url": "https://localhost:3000/avatars/1",
+    "username": "gitea"
+  },
+  "sender": {
+    "id": 1,
+    "login": "gitea",
+    "full_name": "Gitea",
+    "email": "gitea@example.com",
+    "avatar_url": "https://localhost:3000/avatars/1",
+    "username": "gitea"
+  }
+}`
+
+var giteaPullRequestEvent = `{
+  "action": "synchronized",
+  "number": 1,
+  "pull_request": {
+    "id": 1,
+    "number": 1,
+    "user": {
+      "id": 2,
+      "login": "jdoe",
+      "full_name": "John Doe",
+      "email": "jdoe@example.com",
+      "username": "jdoe"
+    },
+    "title": "Add a feature",
+    "body": "",
+    "state": "open",
+    "html_url": "http://localhost:3000/gitea/webhooks/pulls/1",
+    "merged": false,
+    "head": {
+      "label": "feature",
+      "ref": "feature",
+      "sha": "7b9b1c3bd2d0e4c3b71be8d6bd3fae0fbb2dc8b7",
+      "repo": {
+        "id": 141,
+        "name": "webhooks",
+        "full_name": "jdoe/webhooks",
+        "fork": true,
+        "clone_url": "http://localhost:3000/jdoe/webhooks.git"
+      }
+    },
+    "base": {
+      "label": "master",
+      "ref": "master",
+      "sha": "bffeb74224043ba2feb48d137756c8a9331c449a",
+      "repo": {
+        "id": 140,
+        "name": "webhooks",
+        "full_name": "gitea/webhooks",
+        "clone_url": "http://localhost:3000/gitea/webhooks.git"
+      }
+    }
+  },
+  "repository": {
+    "id": 140,
+    "name": "webhooks",
+    "full_name": "gitea/webhooks",
+    "clone_url": "http://localhost:3000/gitea/webhooks.git"
+  },
+  "sender": {
+    "id": 2,
+    "login": "jdoe",
+    "full_name": "John Doe",
+    "email": "jdoe@example.com",
+    "username": "jdoe"
+  }
+}`
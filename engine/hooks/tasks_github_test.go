@@ -40,6 +40,30 @@ func Test_doWebHookExecutionGithub(t *testing.T) {
 	require.Equal(t, "baxterthehacker", hs[0].Payload["git.author"])
 	require.Equal(t, "Update README.md", hs[0].Payload["git.message"])
 	require.Equal(t, "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c", hs[0].Payload["git.hash"])
+	require.Equal(t, "README.md", hs[0].Payload["git.changed.files"])
+}
+
+func Test_doWebHookExecutionGithubPathFilter(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(githubPushEvent),
+			RequestHeader: map[string][]string{
+				GithubHeader: {"push"},
+			},
+			RequestURL: "",
+		},
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigPathExcludes: {Value: "*.md"},
+		},
+	}
+	hs, err := s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+	require.Equal(t, 0, len(hs))
 }
 
 func Test_doWebHookExecutionTagGithub(t *testing.T) {
@@ -82,6 +106,26 @@ func Test_prEvent(t *testing.T) {
 	require.Equal(t, "2019-05-15 15:20:32 +0000 UTC", datePushed.String())
 }
 
+func Test_generatePayloadFromGithubPullRequest(t *testing.T) {
+	task := &sdk.TaskExecution{
+		WebHook: &sdk.WebHookExecution{RequestBody: []byte(githubPREvent)},
+	}
+	payload, err := generatePayloadFromGithubPullRequest(context.TODO(), task, "pull_request")
+	require.NoError(t, err)
+
+	require.Equal(t, 2, payload[PR_ID])
+	require.Equal(t, "opened", payload[PR_STATE])
+	require.Equal(t, "Update the README with new information.", payload[PR_TITLE])
+	require.Equal(t, "changes", payload[GIT_BRANCH])
+	require.Equal(t, "master", payload[GIT_BRANCH_DEST])
+	require.Equal(t, "ec26c3e57ca3a959ca5aad62de7213c562f8c821", payload[GIT_HASH])
+	require.Equal(t, "ec26c3e", payload[GIT_HASH_SHORT])
+	require.Equal(t, "f95f852bd8fca8fcc58a9a2d6c842781e32a215e", payload[GIT_HASH_DEST])
+	require.Equal(t, "Codertocat/Hello-World", payload[GIT_REPOSITORY])
+	require.Equal(t, "Codertocat/Hello-World", payload[GIT_REPOSITORY_DEST])
+	require.Equal(t, "Codertocat", payload[GIT_AUTHOR])
+}
+
 var githubPushEvent = `
 	{
   "ref": "refs/heads/my-branch",
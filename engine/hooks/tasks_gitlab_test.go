@@ -111,3 +111,74 @@ var gitlabPushEvent = `
   "total_commits_count": 4
 }
 `
+
+func Test_generatePayloadFromGitlabMergeRequest(t *testing.T) {
+	task := &sdk.TaskExecution{
+		WebHook: &sdk.WebHookExecution{RequestBody: []byte(gitlabMergeRequestEvent)},
+	}
+	payload, err := generatePayloadFromGitlabMergeRequest(context.TODO(), task, string(gitlab.EventTypeMergeRequest))
+	test.NoError(t, err)
+
+	assert.Equal(t, 1, payload[PR_ID])
+	assert.Equal(t, "open", payload[PR_STATE])
+	assert.Equal(t, "MS-Viewport", payload[PR_TITLE])
+	assert.Equal(t, "ms-viewport", payload[GIT_BRANCH])
+	assert.Equal(t, "master", payload[GIT_BRANCH_DEST])
+	assert.Equal(t, "da1560886d4f094c3e6c9ef40349f7d38b5d27d7", payload[GIT_HASH])
+	assert.Equal(t, "da15608", payload[GIT_HASH_SHORT])
+	assert.Equal(t, "awesome_space/awesome_project", payload[GIT_REPOSITORY])
+	assert.Equal(t, "awesome_space/awesome_project", payload[GIT_REPOSITORY_DEST])
+	assert.Equal(t, "root", payload[GIT_AUTHOR])
+}
+
+var gitlabMergeRequestEvent = `
+{
+  "object_kind": "merge_request",
+  "user": {
+    "name": "Administrator",
+    "username": "root",
+    "email": "admin@example.com"
+  },
+  "project": {
+    "id": 1,
+    "name": "Gitlab Test",
+    "web_url": "http://example.com/gitlabhq/gitlab-test",
+    "git_ssh_url": "git@example.com:gitlabhq/gitlab-test.git",
+    "git_http_url": "http://example.com/gitlabhq/gitlab-test.git",
+    "namespace": "GitlabHQ",
+    "path_with_namespace": "gitlabhq/gitlab-test",
+    "default_branch": "master"
+  },
+  "object_attributes": {
+    "id": 99,
+    "iid": 1,
+    "target_branch": "master",
+    "source_branch": "ms-viewport",
+    "source_project_id": 14,
+    "title": "MS-Viewport",
+    "state": "opened",
+    "source": {
+      "name": "Awesome Project",
+      "web_url": "http://example.com/awesome_space/awesome_project",
+      "git_ssh_url": "git@example.com:awesome_space/awesome_project.git",
+      "git_http_url": "http://example.com/awesome_space/awesome_project.git",
+      "namespace": "Awesome Space",
+      "path_with_namespace": "awesome_space/awesome_project"
+    },
+    "target": {
+      "name": "Awesome Project",
+      "web_url": "http://example.com/awesome_space/awesome_project",
+      "git_ssh_url": "git@example.com:awesome_space/awesome_project.git",
+      "git_http_url": "http://example.com/awesome_space/awesome_project.git",
+      "namespace": "Awesome Space",
+      "path_with_namespace": "awesome_space/awesome_project"
+    },
+    "last_commit": {
+      "id": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+      "message": "fixed readme",
+      "url": "http://example.com/awesome_space/awesome_project/commits/da1560886d4f094c3e6c9ef40349f7d38b5d27d7"
+    },
+    "action": "open"
+  }
+}
+`
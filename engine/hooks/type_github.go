@@ -25,6 +25,63 @@ type GithubWebHookEvent struct {
 	Sender     GithubSender      `json:"sender"`
 }
 
+// GithubIssueCommentEvent represents payload send by github when an issue or a pull request is commented
+type GithubIssueCommentEvent struct {
+	Action     string            `json:"action"`
+	Issue      GithubIssue       `json:"issue"`
+	Comment    GithubComment     `json:"comment"`
+	Repository *GithubRepository `json:"repository"`
+	Sender     GithubSender      `json:"sender"`
+}
+
+// GithubPullRequestEvent represents payload send by github when a pull request is opened, updated or closed
+type GithubPullRequestEvent struct {
+	Action      string            `json:"action"`
+	Number      int               `json:"number"`
+	PullRequest GithubPullRequest `json:"pull_request"`
+	Repository  *GithubRepository `json:"repository"`
+	Sender      GithubSender      `json:"sender"`
+}
+
+type GithubPullRequest struct {
+	ID      int                  `json:"id"`
+	Number  int                  `json:"number"`
+	State   string               `json:"state"`
+	Title   string               `json:"title"`
+	HTMLURL string               `json:"html_url"`
+	Merged  bool                 `json:"merged"`
+	User    GithubSender         `json:"user"`
+	Head    GithubPullRequestRef `json:"head"`
+	Base    GithubPullRequestRef `json:"base"`
+}
+
+type GithubPullRequestRef struct {
+	Label string            `json:"label"`
+	Ref   string            `json:"ref"`
+	SHA   string            `json:"sha"`
+	User  GithubSender      `json:"user"`
+	Repo  *GithubRepository `json:"repo"`
+}
+
+type GithubIssue struct {
+	ID          int          `json:"id"`
+	Number      int          `json:"number"`
+	Title       string       `json:"title"`
+	State       string       `json:"state"`
+	HTMLURL     string       `json:"html_url"`
+	User        GithubSender `json:"user"`
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request"`
+}
+
+type GithubComment struct {
+	ID      int          `json:"id"`
+	HTMLURL string       `json:"html_url"`
+	Body    string       `json:"body"`
+	User    GithubSender `json:"user"`
+}
+
 type GithubSender struct {
 	Login             string `json:"login"`
 	ID                int    `json:"id"`
@@ -46,17 +103,17 @@ type GithubSender struct {
 }
 
 type GithubCommit struct {
-	ID        string        `json:"id"`
-	TreeID    string        `json:"tree_id"`
-	Distinct  bool          `json:"distinct"`
-	Message   string        `json:"message"`
-	Timestamp time.Time     `json:"timestamp"`
-	URL       string        `json:"url"`
-	Author    GithubAuthor  `json:"author"`
-	Committer GithubAuthor  `json:"committer"`
-	Added     []interface{} `json:"added"`
-	Removed   []interface{} `json:"removed"`
-	Modified  []string      `json:"modified"`
+	ID        string       `json:"id"`
+	TreeID    string       `json:"tree_id"`
+	Distinct  bool         `json:"distinct"`
+	Message   string       `json:"message"`
+	Timestamp time.Time    `json:"timestamp"`
+	URL       string       `json:"url"`
+	Author    GithubAuthor `json:"author"`
+	Committer GithubAuthor `json:"committer"`
+	Added     []string     `json:"added"`
+	Removed   []string     `json:"removed"`
+	Modified  []string     `json:"modified"`
 }
 
 type GithubAuthor struct {
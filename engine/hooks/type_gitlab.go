@@ -25,15 +25,65 @@ type GitlabEvent struct {
 	TotalCommitsCount int               `json:"total_commits_count"`
 }
 
+// GitlabNoteEvent represents payload send by gitlab when a merge request, an issue or a commit is commented
+type GitlabNoteEvent struct {
+	ObjectKind       string              `json:"object_kind"`
+	User             GitlabUser          `json:"user"`
+	ProjectID        int                 `json:"project_id"`
+	Project          *GitlabProject      `json:"project"`
+	ObjectAttributes GitlabNote          `json:"object_attributes"`
+	MergeRequest     *GitlabMergeRequest `json:"merge_request"`
+}
+
+// GitlabMergeRequestEvent represents payload send by gitlab when a merge request is opened, updated, merged or closed
+type GitlabMergeRequestEvent struct {
+	ObjectKind       string                       `json:"object_kind"`
+	User             GitlabUser                   `json:"user"`
+	Project          *GitlabProject               `json:"project"`
+	ObjectAttributes GitlabMergeRequestAttributes `json:"object_attributes"`
+}
+
+type GitlabMergeRequestAttributes struct {
+	GitlabMergeRequest
+	Action string         `json:"action"`
+	Source *GitlabProject `json:"source"`
+	Target *GitlabProject `json:"target"`
+}
+
+type GitlabUser struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type GitlabNote struct {
+	ID           int    `json:"id"`
+	Note         string `json:"note"`
+	NoteableType string `json:"noteable_type"`
+	URL          string `json:"url"`
+}
+
+type GitlabMergeRequest struct {
+	ID           int    `json:"id"`
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	State        string `json:"state"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	LastCommit   struct {
+		ID string `json:"id"`
+	} `json:"last_commit"`
+}
+
 type GitlabCommit struct {
-	ID        string        `json:"id"`
-	Message   string        `json:"message"`
-	Timestamp time.Time     `json:"timestamp"`
-	URL       string        `json:"url"`
-	Author    GitlabAuthor  `json:"author"`
-	Added     []string      `json:"added"`
-	Modified  []string      `json:"modified"`
-	Removed   []interface{} `json:"removed"`
+	ID        string       `json:"id"`
+	Message   string       `json:"message"`
+	Timestamp time.Time    `json:"timestamp"`
+	URL       string       `json:"url"`
+	Author    GitlabAuthor `json:"author"`
+	Added     []string     `json:"added"`
+	Modified  []string     `json:"modified"`
+	Removed   []string     `json:"removed"`
 }
 
 type GitlabAuthor struct {
@@ -32,6 +32,7 @@ const (
 	GIT_REPOSITORY_DEST = "git.repository.dest"
 	GIT_EVENT           = "git.hook"
 	GIT_MESSAGE         = "git.message"
+	GIT_CHANGED_FILES   = "git.changed.files"
 
 	CDS_TRIGGERED_BY_USERNAME = "cds.triggered_by.username"
 	CDS_TRIGGERED_BY_FULLNAME = "cds.triggered_by.fullname"
@@ -2,6 +2,10 @@ package hooks
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"mime"
@@ -30,7 +34,17 @@ func (s *Service) doWebHookExecution(ctx context.Context, e *sdk.TaskExecution)
 }
 
 func getRepositoryHeader(t *sdk.TaskExecution, events []string) string {
-	if v, ok := t.WebHook.RequestHeader[GithubHeader]; ok && ((len(events) == 0 && v[0] == "push") || sdk.IsInArray(v[0], events)) {
+	// Gitea also sends the GitHub event header, it has to be checked first
+	if v, ok := t.WebHook.RequestHeader[GiteaHeader]; ok {
+		var eventType string
+		if vt, ok := t.WebHook.RequestHeader[GiteaEventTypeHeader]; ok {
+			eventType = vt[0]
+		}
+		if (len(events) == 0 && v[0] == "push") || sdk.IsInArray(v[0], events) || (eventType != "" && sdk.IsInArray(eventType, events)) {
+			return GiteaHeader
+		}
+		return ""
+	} else if v, ok := t.WebHook.RequestHeader[GithubHeader]; ok && ((len(events) == 0 && v[0] == "push") || sdk.IsInArray(v[0], events)) {
 		return GithubHeader
 	} else if v, ok := t.WebHook.RequestHeader[GitlabHeader]; ok && ((len(events) == 0 && (v[0] == string(gitlab.EventTypePush) || v[0] == string(gitlab.EventTypeTagPush))) || sdk.IsInArray(v[0], events)) {
 		return GitlabHeader
@@ -48,6 +62,58 @@ func getRepositoryHeader(t *sdk.TaskExecution, events []string) string {
 	return ""
 }
 
+// verifyRepositoryWebHook checks that a repository webhook delivery was sent by the repository manager
+// with the secret given when the hook was created on the repository. Hooks created without secret are not checked
+// until the secret is set by the API migration.
+func verifyRepositoryWebHook(ctx context.Context, task *sdk.Task, e *sdk.WebHookExecution) error {
+	secret := task.Config[sdk.HookConfigWebHookSecret].Value
+	if secret == "" {
+		log.Warning(ctx, "Hooks> Repository webhook %s (%s/%s) has no secret, its deliveries are not verified", task.UUID,
+			task.Config[sdk.HookConfigProject].Value, task.Config[sdk.HookConfigWorkflow].Value)
+		return nil
+	}
+
+	headers := http.Header(e.RequestHeader)
+	var valid bool
+	switch {
+	case headers.Get(GiteaHeader) != "":
+		valid = checkWebHookSignature(secret, e.RequestBody, "sha256="+headers.Get(GiteaSignatureHeader))
+	case headers.Get(GithubHeader) != "":
+		valid = checkWebHookSignature(secret, e.RequestBody, headers.Get(GithubSignatureHeader))
+	case headers.Get(GitlabHeader) != "":
+		valid = subtle.ConstantTimeCompare([]byte(secret), []byte(headers.Get(GitlabTokenHeader))) == 1
+	case headers.Get(BitbucketHeader) != "":
+		// Bitbucket Server and Bitbucket Cloud use the same signature header
+		valid = checkWebHookSignature(secret, e.RequestBody, headers.Get(BitbucketSignatureHeader))
+	}
+	if !valid {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "invalid webhook signature")
+	}
+	return nil
+}
+
+// withoutWebHookSecret returns a copy of given task config without the secret of the repository webhook,
+// the secret should not be returned by the API nor kept in the executions.
+func withoutWebHookSecret(cfg sdk.WorkflowNodeHookConfig) sdk.WorkflowNodeHookConfig {
+	return cfg.Filter(func(k string, _ sdk.WorkflowNodeHookConfigValue) bool {
+		return k != sdk.HookConfigWebHookSecret
+	})
+}
+
+// checkWebHookSignature checks a signature header formatted as sha256=<hex encoded HMAC-SHA256 of the body>
+func checkWebHookSignature(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) // nolint
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
 func (s *Service) executeRepositoryWebHook(ctx context.Context, t *sdk.TaskExecution) ([]sdk.WorkflowNodeRunHookEvent, error) {
 	// Prepare a struct to send to CDS API
 	payloads := []map[string]interface{}{}
@@ -67,6 +133,15 @@ func (s *Service) executeRepositoryWebHook(ctx context.Context, t *sdk.TaskExecu
 		if payload != nil {
 			payloads = append(payloads, payload)
 		}
+	case GiteaHeader:
+		headerValue := t.WebHook.RequestHeader[GiteaHeader][0]
+		payload, err := s.generatePayloadFromGiteaRequest(ctx, t, headerValue)
+		if err != nil {
+			return nil, err
+		}
+		if payload != nil {
+			payloads = append(payloads, payload)
+		}
 	case GitlabHeader:
 		headerValue := t.WebHook.RequestHeader[GitlabHeader][0]
 		payload, err := s.generatePayloadFromGitlabRequest(ctx, t, headerValue)
@@ -114,6 +189,9 @@ func (s *Service) executeRepositoryWebHook(ctx context.Context, t *sdk.TaskExecu
 		hs = append(hs, h)
 	}
 
+	if t.Type == TypeRepoManagerWebHook {
+		hs = s.filterEventsOnChangedFiles(ctx, t, hs)
+	}
 	return hs, nil
 }
 
@@ -0,0 +1,70 @@
+package hooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_verifyRepositoryWebHook(t *testing.T) {
+	body := []byte(`{"ref":"refs/heads/master"}`)
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body) // nolint
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	task := func(secret string) *sdk.Task {
+		return &sdk.Task{
+			Type: TypeRepoManagerWebHook,
+			Config: sdk.WorkflowNodeHookConfig{
+				sdk.HookConfigWebHookSecret: {Value: secret},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		headers map[string][]string
+		valid   bool
+	}{
+		{"no secret", "", map[string][]string{GithubHeader: {"push"}}, true},
+		{"github", "s3cr3t", map[string][]string{GithubHeader: {"push"}, GithubSignatureHeader: {sign("s3cr3t")}}, true},
+		{"github wrong secret", "s3cr3t", map[string][]string{GithubHeader: {"push"}, GithubSignatureHeader: {sign("forged")}}, false},
+		{"github missing signature", "s3cr3t", map[string][]string{GithubHeader: {"push"}}, false},
+		{"gitlab", "s3cr3t", map[string][]string{GitlabHeader: {"Push Hook"}, GitlabTokenHeader: {"s3cr3t"}}, true},
+		{"gitlab wrong token", "s3cr3t", map[string][]string{GitlabHeader: {"Push Hook"}, GitlabTokenHeader: {"forged"}}, false},
+		{"bitbucket", "s3cr3t", map[string][]string{BitbucketHeader: {"repo:refs_changed"}, BitbucketSignatureHeader: {sign("s3cr3t")}}, true},
+		{"bitbucket invalid signature", "s3cr3t", map[string][]string{BitbucketHeader: {"repo:push"}, BitbucketSignatureHeader: {"sha256=zz"}}, false},
+		{"gitea", "s3cr3t", map[string][]string{GiteaHeader: {"push"}, GithubHeader: {"push"}, GiteaSignatureHeader: {strings.TrimPrefix(sign("s3cr3t"), "sha256=")}}, true},
+		{"gitea wrong secret", "s3cr3t", map[string][]string{GiteaHeader: {"push"}, GithubHeader: {"push"}, GiteaSignatureHeader: {strings.TrimPrefix(sign("forged"), "sha256=")}}, false},
+		{"unknown repository manager", "s3cr3t", map[string][]string{"X-Foo": {"bar"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyRepositoryWebHook(context.TODO(), task(tt.secret), &sdk.WebHookExecution{RequestBody: body, RequestHeader: tt.headers})
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.True(t, sdk.ErrorIs(err, sdk.ErrForbidden))
+			}
+		})
+	}
+}
+
+func Test_withoutWebHookSecret(t *testing.T) {
+	cfg := sdk.WorkflowNodeHookConfig{
+		sdk.HookConfigProject:       {Value: "PROJ"},
+		sdk.HookConfigWebHookSecret: {Value: "s3cr3t"},
+	}
+	res := withoutWebHookSecret(cfg)
+	require.Equal(t, sdk.WorkflowNodeHookConfig{sdk.HookConfigProject: {Value: "PROJ"}}, res)
+	require.Equal(t, "s3cr3t", cfg[sdk.HookConfigWebHookSecret].Value, "the task config should not be changed")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/fsamin/go-repo"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -74,8 +75,13 @@ func (s *Service) processPush(ctx context.Context, op *sdk.Operation) (globalErr
 		}
 	}()
 
+	// A repository that declares several workflows contains a workflows directory, the cds directory is not erased
+	// for migration because it contains the files of the other workflows
+	_, err = os.Stat(filepath.Join(path, ".cds", "workflows"))
+	multiWorkflows := err == nil
+
 	// Erase existing cds directory for migration, if update make sure that the cds directory exists
-	if !op.Setup.Push.Update {
+	if !op.Setup.Push.Update && !multiWorkflows {
 		if _, err := os.Stat(path + "/.cds"); err == nil {
 			if err := os.RemoveAll(path + "/.cds"); err != nil {
 				return sdk.WrapError(err, "error removing old .cds directory")
@@ -94,6 +100,9 @@ func (s *Service) processPush(ctx context.Context, op *sdk.Operation) (globalErr
 
 	for k, v := range op.LoadFiles.Results {
 		fname := filepath.Join(path, ".cds", k)
+		if multiWorkflows && !exportentities.IsWorkflowDependencyFile(filepath.Base(k)) {
+			fname = filepath.Join(path, ".cds", "workflows", k)
+		}
 		log.Debug("Creating %s", fname)
 		_ = os.Remove(fname)
 		fi, err := os.Create(fname)
@@ -43,13 +43,14 @@ type HatcheryCommonConfiguration struct {
 		MaxHeartbeatFailures int    `toml:"maxHeartbeatFailures" default:"10" comment:"Maximum allowed consecutives failures on heatbeat routine" json:"maxHeartbeatFailures"`
 	} `toml:"api" json:"api"`
 	Provision struct {
-		RatioService              *int   `toml:"ratioService" default:"50" commented:"true" comment:"Percent reserved for spawning worker with service requirement" json:"ratioService,omitempty" mapstructure:"ratioService"`
-		MaxWorker                 int    `toml:"maxWorker" default:"10" comment:"Maximum allowed simultaneous workers" json:"maxWorker"`
-		MaxConcurrentProvisioning int    `toml:"maxConcurrentProvisioning" default:"10" comment:"Maximum allowed simultaneous workers provisioning" json:"maxConcurrentProvisioning"`
-		MaxConcurrentRegistering  int    `toml:"maxConcurrentRegistering" default:"2" comment:"Maximum allowed simultaneous workers registering. -1 to disable registering on this hatchery" json:"maxConcurrentRegistering"`
-		RegisterFrequency         int    `toml:"registerFrequency" default:"60" comment:"Check if some worker model have to be registered each n Seconds" json:"registerFrequency"`
-		Region                    string `toml:"region" default:"" comment:"region of this hatchery - optional. With a free text as 'myregion', user can set a prerequisite 'region' with value 'myregion' on CDS Job" json:"region"`
-		IgnoreJobWithNoRegion     bool   `toml:"ignoreJobWithNoRegion" default:"false" comment:"Ignore job without a region prerequisite if ignoreJobWithNoRegion=true"`
+		RatioService              *int                             `toml:"ratioService" default:"50" commented:"true" comment:"Percent reserved for spawning worker with service requirement" json:"ratioService,omitempty" mapstructure:"ratioService"`
+		MaxWorker                 int                              `toml:"maxWorker" default:"10" comment:"Maximum allowed simultaneous workers" json:"maxWorker"`
+		MaxConcurrentProvisioning int                              `toml:"maxConcurrentProvisioning" default:"10" comment:"Maximum allowed simultaneous workers provisioning" json:"maxConcurrentProvisioning"`
+		MaxConcurrentRegistering  int                              `toml:"maxConcurrentRegistering" default:"2" comment:"Maximum allowed simultaneous workers registering. -1 to disable registering on this hatchery" json:"maxConcurrentRegistering"`
+		RegisterFrequency         int                              `toml:"registerFrequency" default:"60" comment:"Check if some worker model have to be registered each n Seconds" json:"registerFrequency"`
+		Region                    string                           `toml:"region" default:"" comment:"region of this hatchery - optional. With a free text as 'myregion', user can set a prerequisite 'region' with value 'myregion' on CDS Job" json:"region"`
+		IgnoreJobWithNoRegion     bool                             `toml:"ignoreJobWithNoRegion" default:"false" comment:"Ignore job without a region prerequisite if ignoreJobWithNoRegion=true"`
+		WarmPools                 map[string]WarmPoolConfiguration `toml:"warmPools" comment:"Idle workers kept ready to take jobs, by worker model path (ie. shared.infra/debian)" json:"warmPools,omitempty" mapstructure:"warmPools"`
 		WorkerLogsOptions         struct {
 			Graylog struct {
 				Host       string `toml:"host" comment:"Example: thot.ovh.com" json:"host"`
@@ -68,6 +69,13 @@ type HatcheryCommonConfiguration struct {
 	} `toml:"logOptions" comment:"Hatchery Log Configuration" json:"logOptions"`
 }
 
+// WarmPoolConfiguration is the configuration of the pool of idle workers of a worker model
+type WarmPoolConfiguration struct {
+	Min     int `toml:"min" default:"1" comment:"Minimum number of idle workers" json:"min" mapstructure:"min"`
+	Max     int `toml:"max" default:"2" comment:"Maximum number of idle workers, the pool grows with the number of jobs waiting for this model" json:"max" mapstructure:"max"`
+	IdleTTL int `toml:"idleTTL" default:"10" comment:"Idle workers above the minimum are removed after this delay (in minutes)" json:"idleTTL" mapstructure:"idleTTL"`
+}
+
 func (hcc HatcheryCommonConfiguration) Check() error {
 	if hcc.Provision.MaxConcurrentProvisioning > hcc.Provision.MaxWorker {
 		return fmt.Errorf("maxConcurrentProvisioning (value: %d) cannot be less than maxWorker (value: %d) ",
@@ -79,6 +87,15 @@ func (hcc HatcheryCommonConfiguration) Check() error {
 			hcc.Provision.MaxConcurrentRegistering, hcc.Provision.MaxWorker)
 	}
 
+	for model, pool := range hcc.Provision.WarmPools {
+		if pool.Min < 0 || pool.Max < pool.Min {
+			return fmt.Errorf("invalid warm pool for model %s: min (value: %d) must be positive and lower than max (value: %d)", model, pool.Min, pool.Max)
+		}
+		if pool.Max > hcc.Provision.MaxWorker {
+			return fmt.Errorf("invalid warm pool for model %s: max (value: %d) cannot be greater than maxWorker (value: %d)", model, pool.Max, hcc.Provision.MaxWorker)
+		}
+	}
+
 	if hcc.API.HTTP.URL == "" {
 		return fmt.Errorf("API HTTP(s) URL is mandatory")
 	}
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table "workflow" add column priority INT NOT NULL DEFAULT 0;
+alter table "workflow_node_run_job" add column priority INT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+alter table "workflow" drop column priority;
+alter table "workflow_node_run_job" drop column priority;
@@ -0,0 +1,5 @@
+-- +migrate Up
+alter table "pipeline_action" add column matrix JSONB;
+
+-- +migrate Down
+alter table "pipeline_action" drop column matrix;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table "pipeline_action" add column timeout BIGINT NOT NULL DEFAULT 0;
+alter table "action_edge" add column timeout BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+alter table "pipeline_action" drop column timeout;
+alter table "action_edge" drop column timeout;
@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_calendar" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    description TEXT,
+    periods JSONB,
+    created TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_CALENDAR_PROJECT', 'project_calendar', 'project', 'project_id', 'id');
+SELECT create_unique_index('project_calendar', 'IDX_PROJECT_CALENDAR_NAME', 'project_id,name');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "project_calendar";
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "workflow_template_instance" ADD COLUMN IF NOT EXISTS pinned_version BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "workflow_template_bulk" ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE "workflow_template_instance" DROP COLUMN IF EXISTS pinned_version;
+ALTER TABLE "workflow_template_bulk" DROP COLUMN IF EXISTS version;
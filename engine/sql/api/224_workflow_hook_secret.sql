@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "w_node_hook_secret" (
+    hook_uuid VARCHAR(255) PRIMARY KEY,
+    cipher_secret BYTEA,
+    sig BYTEA,
+    signer TEXT
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS "w_node_hook_secret";
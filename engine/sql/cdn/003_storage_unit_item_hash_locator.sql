@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "storage_unit_item" ADD COLUMN IF NOT EXISTS hash_locator VARCHAR(128) NOT NULL DEFAULT '';
+SELECT create_index('storage_unit_item', 'IDX_storage_unit_item_unit_id_hash_locator', 'unit_id,hash_locator');
+
+-- +migrate Down
+DROP INDEX IF EXISTS IDX_storage_unit_item_unit_id_hash_locator;
+ALTER TABLE "storage_unit_item" DROP COLUMN IF EXISTS hash_locator;
@@ -170,5 +170,49 @@ func (client *bitbucketcloudClient) CommitsBetweenRefs(ctx context.Context, repo
 		commitsResult = append(commitsResult, commit)
 	}
 
+	// Bitbucket only gives the changes between the two refs, they are reported on the last commit
+	if len(commitsResult) > 0 {
+		files, err := client.diffStat(ctx, repo, base, head)
+		if err != nil {
+			log.Warning(ctx, "bitbucketcloudClient.CommitsBetweenRefs> unable to get changes between %s and %s: %v", base, head, err)
+		}
+		commitsResult[len(commitsResult)-1].Files = files
+	}
+
 	return commitsResult, nil
 }
+
+func (client *bitbucketcloudClient) diffStat(ctx context.Context, repo, base, head string) ([]string, error) {
+	var files []string
+	params := url.Values{}
+	path := fmt.Sprintf("/repositories/%s/diffstat/%s..%s", repo, head, base)
+	nextPage := 1
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if nextPage != 1 {
+			params.Set("page", fmt.Sprintf("%d", nextPage))
+		}
+
+		var response DiffStats
+		if err := client.do(ctx, "GET", "core", path, params, nil, &response); err != nil {
+			return nil, sdk.WrapError(err, "Unable to get diffstat")
+		}
+		for _, v := range response.Values {
+			if v.New != nil {
+				files = append(files, v.New.Path)
+			}
+			if v.Old != nil && (v.New == nil || v.Old.Path != v.New.Path) {
+				files = append(files, v.Old.Path)
+			}
+		}
+
+		if response.Next == "" {
+			break
+		}
+		nextPage++
+	}
+	return files, nil
+}
@@ -19,6 +19,7 @@ type WebhookCreate struct {
 	URL         string   `json:"url"`
 	Active      bool     `json:"active"`
 	Events      []string `json:"events"`
+	Secret      string   `json:"secret,omitempty"`
 }
 
 type Webhook struct {
@@ -47,6 +48,7 @@ type Webhook struct {
 	Type   string   `json:"type"`
 	Events []string `json:"events"`
 	UUID   string   `json:"uuid"`
+	Secret string   `json:"secret,omitempty"`
 }
 
 type Webhooks struct {
@@ -354,6 +356,20 @@ type Commits struct {
 	Previous string   `json:"previous,omitempty"`
 }
 
+// DiffStats is the list of the files changed between two commits
+type DiffStats struct {
+	Values []struct {
+		Status string `json:"status"`
+		Old    *struct {
+			Path string `json:"path"`
+		} `json:"old"`
+		New *struct {
+			Path string `json:"path"`
+		} `json:"new"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
 type Commit struct {
 	Rendered struct {
 		Message struct {
@@ -184,5 +184,55 @@ func (b *bitbucketClient) CommitsBetweenRefs(ctx context.Context, repo, base, he
 		}
 		commits = append(commits, c)
 	}
+
+	// Bitbucket only gives the changes between the two refs, they are reported on the last commit
+	if len(commits) > 0 {
+		files, err := b.changesBetweenRefs(ctx, project, slug, base, head)
+		if err != nil {
+			log.Warning(ctx, "bitbucketClient.CommitsBetweenRefs> unable to get changes between %s and %s: %v", base, head, err)
+		}
+		commits[len(commits)-1].Files = files
+	}
 	return commits, nil
 }
+
+func (b *bitbucketClient) changesBetweenRefs(ctx context.Context, project, slug, base, head string) ([]string, error) {
+	var files []string
+	var changesKey = cache.Key("vcs", "bitbucket", b.consumer.URL, project, slug, "compare/changes", "from@"+base, "to@"+head)
+	find, err := b.consumer.cache.Get(changesKey, &files)
+	if err != nil {
+		log.Error(ctx, "cannot get from cache %s: %v", changesKey, err)
+	}
+	if find {
+		return files, nil
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/compare/changes", project, slug)
+	params := url.Values{}
+	params.Add("from", base)
+	params.Add("to", head)
+	response := ChangesResponse{}
+	for {
+		if response.NextPageStart != 0 {
+			params.Set("start", fmt.Sprintf("%d", response.NextPageStart))
+		}
+		if err := b.do(ctx, "GET", "core", path, params, nil, &response, nil); err != nil {
+			return nil, sdk.WrapError(err, "Unable to get changes %s", path)
+		}
+		for _, v := range response.Values {
+			files = append(files, v.Path.ToString)
+			if v.SrcPath != nil && v.SrcPath.ToString != "" {
+				files = append(files, v.SrcPath.ToString)
+			}
+		}
+		if response.IsLastPage {
+			break
+		}
+	}
+
+	//3 hours
+	if err := b.consumer.cache.SetWithTTL(changesKey, files, 3*60*60); err != nil {
+		log.Error(ctx, "cannot SetWithTTL: %s: %v", changesKey, err)
+	}
+	return files, nil
+}
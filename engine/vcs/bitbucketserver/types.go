@@ -66,6 +66,20 @@ type CommitsResponse struct {
 	IsLastPage    bool     `json:"isLastPage"`
 }
 
+// ChangesResponse is the list of the files changed between two refs
+type ChangesResponse struct {
+	Values []struct {
+		Path struct {
+			ToString string `json:"toString"`
+		} `json:"path"`
+		SrcPath *struct {
+			ToString string `json:"toString"`
+		} `json:"srcPath"`
+	} `json:"values"`
+	NextPageStart int  `json:"nextPageStart"`
+	IsLastPage    bool `json:"isLastPage"`
+}
+
 type Commit struct {
 	Hash      string `json:"id"`
 	Author    Author `json:"author"`
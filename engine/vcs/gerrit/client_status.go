@@ -35,6 +35,14 @@ func (c *gerritClient) SetStatus(ctx context.Context, event sdk.Event) error {
 		Notify:  "OWNER", // Send notification to the owner
 	}
 
+	if len(eventNR.GerritChange.Comments) > 0 {
+		comments, err := c.buildComments(eventNR)
+		if err != nil {
+			return err
+		}
+		ri.Comments = comments
+	}
+
 	// Check if we already send the message
 	changeDetail, _, err := c.client.Changes.GetChangeDetail(eventNR.GerritChange.ID, nil)
 	if err != nil {
@@ -80,6 +88,11 @@ func (c *gerritClient) buildMessage(eventNR sdk.EventRunWorkflowNode) string {
 }
 
 func (c *gerritClient) buildLabel(eventNR sdk.EventRunWorkflowNode) map[string]string {
+	// Labels configured on the workflow
+	if len(eventNR.GerritChange.Labels) > 0 {
+		return eventNR.GerritChange.Labels
+	}
+
 	labels := make(map[string]string)
 	switch eventNR.Status {
 	case sdk.StatusSuccess:
@@ -91,3 +104,33 @@ func (c *gerritClient) buildLabel(eventNR sdk.EventRunWorkflowNode) map[string]s
 	}
 	return labels
 }
+
+// buildComments keeps only the comments on files of the patchset, Gerrit rejects the review otherwise
+func (c *gerritClient) buildComments(eventNR sdk.EventRunWorkflowNode) (map[string][]gerrit.CommentInput, error) {
+	files, _, err := c.client.Changes.ListFiles(eventNR.GerritChange.ID, eventNR.GerritChange.Revision, nil)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to list files of gerrit change")
+	}
+	paths := make([]string, 0, len(files))
+	for f := range files {
+		// magic files of gerrit
+		if strings.HasPrefix(f, "/") {
+			continue
+		}
+		paths = append(paths, f)
+	}
+
+	comments := make(map[string][]gerrit.CommentInput)
+	for _, comment := range eventNR.GerritChange.Comments {
+		path, ok := sdk.MatchGerritFile(comment.Path, paths)
+		if !ok {
+			log.Debug("gerrit.buildComments> no file found for %s in change %s", comment.Path, eventNR.GerritChange.ID)
+			continue
+		}
+		comments[path] = append(comments[path], gerrit.CommentInput{
+			Line:    comment.Line,
+			Message: comment.Message,
+		})
+	}
+	return comments, nil
+}
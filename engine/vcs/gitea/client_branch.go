@@ -0,0 +1,65 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (b Branch) toVCSBranch(defaultBranch string) sdk.VCSBranch {
+	return sdk.VCSBranch{
+		ID:           b.Name,
+		DisplayID:    b.Name,
+		LatestCommit: b.Commit.ID,
+		Default:      b.Name == defaultBranch,
+	}
+}
+
+// Branches retrieves the branches
+func (c *giteaClient) Branches(ctx context.Context, fullname string) ([]sdk.VCSBranch, error) {
+	repo, err := c.repository(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+	path, err := repoPath(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []sdk.VCSBranch
+	err = c.getPages(ctx, path+"/branches", nil, func(body []byte) (int, error) {
+		var page []Branch
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		for _, b := range page {
+			branches = append(branches, b.toVCSBranch(repo.DefaultBranch))
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot list branches of %s", fullname)
+	}
+	return branches, nil
+}
+
+// Branch retrieves the branch
+func (c *giteaClient) Branch(ctx context.Context, fullname, branchName string) (*sdk.VCSBranch, error) {
+	repo, err := c.repository(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+	path, err := repoPath(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var b Branch
+	if err := c.get(ctx, path+"/branches/"+url.PathEscape(branchName), nil, &b); err != nil {
+		return nil, sdk.WrapError(err, "cannot get branch %s of %s", branchName, fullname)
+	}
+	branch := b.toVCSBranch(repo.DefaultBranch)
+	return &branch, nil
+}
@@ -0,0 +1,98 @@
+package gitea
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c Commit) toVCSCommit() sdk.VCSCommit {
+	commit := sdk.VCSCommit{
+		Hash:      c.SHA,
+		Timestamp: c.Commit.Author.Date.Unix() * 1000,
+		Message:   c.Commit.Message,
+		URL:       c.HTMLURL,
+		Author: sdk.VCSAuthor{
+			Name:        c.Commit.Author.Name,
+			DisplayName: c.Commit.Author.Name,
+			Email:       c.Commit.Author.Email,
+		},
+	}
+	if c.Author != nil {
+		commit.Author.Name = c.Author.Login
+		commit.Author.Avatar = c.Author.AvatarURL
+	}
+	return commit
+}
+
+// Commits returns the commits of a branch from until (or the head of the branch) back to since (excluded).
+// Only the first page of commits is returned if since is empty.
+func (c *giteaClient) Commits(ctx context.Context, repo, branch, since, until string) ([]sdk.VCSCommit, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := branch
+	if until != "" {
+		ref = until
+	}
+	params := url.Values{}
+	params.Set("sha", ref)
+	params.Set("stat", "false")
+	params.Set("limit", strconv.Itoa(pageSize))
+
+	var commits []sdk.VCSCommit
+	for page := 1; ctx.Err() == nil; page++ {
+		params.Set("page", strconv.Itoa(page))
+		var res []Commit
+		if err := c.get(ctx, path+"/commits", params, &res); err != nil {
+			return nil, sdk.WrapError(err, "cannot list commits of %s on %s", repo, ref)
+		}
+		for i := range res {
+			if since != "" && res[i].SHA == since {
+				return commits, nil
+			}
+			commits = append(commits, res[i].toVCSCommit())
+		}
+		if since == "" || len(res) < pageSize {
+			break
+		}
+	}
+	return commits, nil
+}
+
+// Commit retrieves a specific according to a hash
+func (c *giteaClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCommit, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return sdk.VCSCommit{}, err
+	}
+	var commit Commit
+	if err := c.get(ctx, path+"/git/commits/"+url.PathEscape(hash), nil, &commit); err != nil {
+		return sdk.VCSCommit{}, sdk.WrapError(err, "cannot get commit %s of %s", hash, repo)
+	}
+	return commit.toVCSCommit(), nil
+}
+
+// CommitsBetweenRefs returns the commits reachable from head and not from base
+func (c *giteaClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	var compare Compare
+	if err := c.get(ctx, path+"/compare/"+url.PathEscape(base)+"..."+url.PathEscape(head), nil, &compare); err != nil {
+		return nil, sdk.WrapError(err, "cannot compare %s and %s on %s", base, head, repo)
+	}
+	commits := make([]sdk.VCSCommit, len(compare.Commits))
+	for i := range compare.Commits {
+		commits[i] = compare.Commits[i].toVCSCommit()
+		for _, f := range compare.Commits[i].Files {
+			commits[i].Files = append(commits[i].Files, f.Filename)
+		}
+	}
+	return commits, nil
+}
@@ -0,0 +1,35 @@
+package gitea
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Gitea does not expose the activity of a repository through its API, only webhooks are supported
+
+// GetEvents is not implemented
+func (c *giteaClient) GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	return nil, 0, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// PushEvents is not implemented
+func (c *giteaClient) PushEvents(context.Context, string, []interface{}) ([]sdk.VCSPushEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// CreateEvents is not implemented
+func (c *giteaClient) CreateEvents(context.Context, string, []interface{}) ([]sdk.VCSCreateEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// DeleteEvents is not implemented
+func (c *giteaClient) DeleteEvents(context.Context, string, []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// PullRequestEvents is not implemented
+func (c *giteaClient) PullRequestEvents(context.Context, string, []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
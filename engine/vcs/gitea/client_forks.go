@@ -0,0 +1,32 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ListForks returns the forks of a repository
+func (c *giteaClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []sdk.VCSRepo
+	err = c.getPages(ctx, path+"/forks", nil, func(body []byte) (int, error) {
+		var page []Repository
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		for _, r := range page {
+			repos = append(repos, r.toVCSRepo())
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot list forks of %s", repo)
+	}
+	return repos, nil
+}
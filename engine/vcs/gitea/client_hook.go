@@ -0,0 +1,148 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (h Hook) toVCSHook() sdk.VCSHook {
+	return sdk.VCSHook{
+		ID:          strconv.FormatInt(h.ID, 10),
+		Name:        h.Type,
+		Events:      h.Events,
+		Method:      http.MethodPost,
+		URL:         h.Config["url"],
+		ContentType: h.Config["content_type"],
+		Disable:     !h.Active,
+	}
+}
+
+// proxyHookURL rewrites the hook url when the webhooks are sent through a proxy
+func (c *giteaClient) proxyHookURL(hook *sdk.VCSHook) {
+	if c.proxyURL == "" {
+		return
+	}
+	lastIndexSlash := strings.LastIndex(hook.URL, "/")
+	if c.proxyURL[len(c.proxyURL)-1] == '/' {
+		lastIndexSlash++
+	}
+	hook.URL = c.proxyURL + hook.URL[lastIndexSlash:]
+}
+
+// CreateHook creates a webhook on the repository, it is updated if a webhook already exists with the same url
+func (c *giteaClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	path, err := repoPath(repo)
+	if err != nil {
+		return err
+	}
+	c.proxyHookURL(hook)
+	if len(hook.Events) == 0 {
+		hook.Events = sdk.GiteaEventsDefault
+	}
+
+	existing, err := c.GetHook(ctx, repo, hook.URL)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		hook.ID = existing.ID
+		return c.UpdateHook(ctx, repo, hook)
+	}
+
+	opt := CreateHookOption{
+		Type: "gitea",
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+		Events: hook.Events,
+		Active: true,
+	}
+	if hook.Secret != "" {
+		opt.Config["secret"] = hook.Secret
+	}
+	var res Hook
+	if err := c.do(ctx, http.MethodPost, path+"/hooks", nil, opt, &res); err != nil {
+		return sdk.WrapError(err, "cannot create webhook on %s", repo)
+	}
+	hook.ID = strconv.FormatInt(res.ID, 10)
+	return nil
+}
+
+// UpdateHook updates the url, the events and the secret of a webhook
+func (c *giteaClient) UpdateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	path, err := repoPath(repo)
+	if err != nil {
+		return err
+	}
+	c.proxyHookURL(hook)
+	if len(hook.Events) == 0 {
+		hook.Events = sdk.GiteaEventsDefault
+	}
+
+	active := true
+	opt := EditHookOption{
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+		Events: hook.Events,
+		Active: &active,
+	}
+	if hook.Secret != "" {
+		opt.Config["secret"] = hook.Secret
+	}
+	if err := c.do(ctx, http.MethodPatch, path+"/hooks/"+url.PathEscape(hook.ID), nil, opt, nil); err != nil {
+		return sdk.WrapError(err, "cannot update webhook %s on %s", hook.ID, repo)
+	}
+	return nil
+}
+
+// GetHook returns the webhook of the repository matching the url
+func (c *giteaClient) GetHook(ctx context.Context, repo, webhookURL string) (sdk.VCSHook, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return sdk.VCSHook{}, err
+	}
+
+	var hooks []Hook
+	err = c.getPages(ctx, path+"/hooks", nil, func(body []byte) (int, error) {
+		var page []Hook
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		hooks = append(hooks, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return sdk.VCSHook{}, sdk.WrapError(err, "cannot list webhooks of %s", repo)
+	}
+
+	for _, h := range hooks {
+		if h.Config["url"] == webhookURL {
+			return h.toVCSHook(), nil
+		}
+	}
+	return sdk.VCSHook{}, sdk.WithStack(sdk.ErrNotFound)
+}
+
+// DeleteHook deletes a webhook, nothing is done if it does not exist anymore
+func (c *giteaClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	path, err := repoPath(repo)
+	if err != nil {
+		return err
+	}
+	if err := c.do(ctx, http.MethodDelete, path+"/hooks/"+url.PathEscape(hook.ID), nil, nil, nil); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return sdk.WrapError(err, "cannot delete webhook %s on %s", hook.ID, repo)
+	}
+	return nil
+}
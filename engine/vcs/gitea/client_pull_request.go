@@ -0,0 +1,160 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (b *PRBranchInfo) toVCSPushEvent(updated int64) sdk.VCSPushEvent {
+	if b == nil {
+		return sdk.VCSPushEvent{}
+	}
+	e := sdk.VCSPushEvent{
+		Branch: sdk.VCSBranch{
+			ID:           b.Ref,
+			DisplayID:    b.Ref,
+			LatestCommit: b.Sha,
+		},
+		Commit: sdk.VCSCommit{
+			Hash:      b.Sha,
+			Message:   b.Label,
+			Timestamp: updated,
+		},
+	}
+	if b.Repo != nil {
+		e.Repo = b.Repo.FullName
+		e.CloneURL = b.Repo.CloneURL
+		e.Commit.Author = sdk.VCSAuthor{
+			Avatar:      b.Repo.Owner.AvatarURL,
+			DisplayName: b.Repo.Owner.Login,
+			Name:        b.Repo.Owner.FullName,
+		}
+	}
+	return e
+}
+
+func (pr PullRequest) toVCSPullRequest() sdk.VCSPullRequest {
+	var updated int64
+	res := sdk.VCSPullRequest{
+		ID:     pr.Number,
+		URL:    pr.HTMLURL,
+		Title:  pr.Title,
+		Merged: pr.Merged,
+		Closed: pr.State == "closed",
+	}
+	if pr.Updated != nil {
+		res.Updated = *pr.Updated
+		updated = pr.Updated.Unix()
+	}
+	if pr.User != nil {
+		res.User = sdk.VCSAuthor{
+			Avatar:      pr.User.AvatarURL,
+			DisplayName: pr.User.Login,
+			Name:        pr.User.FullName,
+			Email:       pr.User.Email,
+		}
+	}
+	res.Base = pr.Base.toVCSPushEvent(updated)
+	res.Head = pr.Head.toVCSPushEvent(updated)
+	return res
+}
+
+// PullRequest returns a pull request from its number
+func (c *giteaClient) PullRequest(ctx context.Context, repo string, id string) (sdk.VCSPullRequest, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return sdk.VCSPullRequest{}, err
+	}
+	var pr PullRequest
+	if err := c.get(ctx, path+"/pulls/"+url.PathEscape(id), nil, &pr); err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "cannot get pull request %s of %s", id, repo)
+	}
+	return pr.toVCSPullRequest(), nil
+}
+
+// PullRequests fetch all the pull request for a repository
+func (c *giteaClient) PullRequests(ctx context.Context, repo string, opts sdk.VCSPullRequestOptions) ([]sdk.VCSPullRequest, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	switch opts.State {
+	case sdk.VCSPullRequestStateOpen:
+		params.Set("state", "open")
+	case sdk.VCSPullRequestStateClosed, sdk.VCSPullRequestStateMerged:
+		params.Set("state", "closed")
+	default:
+		params.Set("state", "all")
+	}
+
+	prs := []sdk.VCSPullRequest{}
+	err = c.getPages(ctx, path+"/pulls", params, func(body []byte) (int, error) {
+		var page []PullRequest
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		for _, pr := range page {
+			switch opts.State {
+			case sdk.VCSPullRequestStateMerged:
+				if !pr.Merged {
+					continue
+				}
+			case sdk.VCSPullRequestStateClosed:
+				if pr.Merged {
+					continue
+				}
+			}
+			prs = append(prs, pr.toVCSPullRequest())
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot list pull requests of %s", repo)
+	}
+	return prs, nil
+}
+
+// PullRequestComment push a new comment on a pull request
+func (c *giteaClient) PullRequestComment(ctx context.Context, repo string, prReq sdk.VCSPullRequestCommentRequest) error {
+	if c.disableStatus {
+		log.Warning(ctx, "gitea.PullRequestComment>  ⚠ Gitea statuses are disabled")
+		return nil
+	}
+	path, err := repoPath(repo)
+	if err != nil {
+		return err
+	}
+	payload := map[string]string{
+		"body": prReq.Message,
+	}
+	if err := c.do(ctx, http.MethodPost, path+"/issues/"+strconv.Itoa(prReq.ID)+"/comments", nil, payload, nil); err != nil {
+		return sdk.WrapError(err, "cannot comment pull request %d of %s", prReq.ID, repo)
+	}
+	return nil
+}
+
+// PullRequestCreate opens a pull request from the head branch to the base branch
+func (c *giteaClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return sdk.VCSPullRequest{}, err
+	}
+	opt := CreatePullRequestOption{
+		Head:  pr.Head.Branch.DisplayID,
+		Base:  pr.Base.Branch.DisplayID,
+		Title: pr.Title,
+	}
+	var res PullRequest
+	if err := c.do(ctx, http.MethodPost, path+"/pulls", nil, opt, &res); err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "cannot create pull request on %s", repo)
+	}
+	return res.toVCSPullRequest(), nil
+}
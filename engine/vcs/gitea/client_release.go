@@ -0,0 +1,66 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release creates a release on a tag
+func (c *giteaClient) Release(ctx context.Context, repo, tagName, title, releaseNote string) (*sdk.VCSRelease, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	opt := CreateReleaseOption{
+		TagName: tagName,
+		Title:   title,
+		Note:    releaseNote,
+	}
+	var res Release
+	if err := c.do(ctx, http.MethodPost, path+"/releases", nil, opt, &res); err != nil {
+		return nil, sdk.WrapError(err, "cannot create release %s on %s", tagName, repo)
+	}
+
+	// Old Gitea versions do not return the upload url of the release
+	uploadURL := res.UploadURL
+	if uploadURL == "" {
+		uploadURL = c.apiURL(path + "/releases/" + strconv.FormatInt(res.ID, 10) + "/assets")
+	}
+	return &sdk.VCSRelease{
+		ID:        res.ID,
+		UploadURL: uploadURL,
+	}, nil
+}
+
+// UploadReleaseFile attaches a file to the release
+func (c *giteaClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.ReadCloser) error {
+	defer r.Close()
+
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+	part, err := w.CreateFormFile("attachment", artifactName)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	params := url.Values{}
+	params.Set("name", artifactName)
+	if err := c.doRaw(ctx, http.MethodPost, strings.Split(uploadURL, "?")[0], params, w.FormDataContentType(), buf, nil); err != nil {
+		return sdk.WrapError(err, "cannot upload %s on release %s of %s", artifactName, releaseName, repo)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (r Repository) toVCSRepo() sdk.VCSRepo {
+	return sdk.VCSRepo{
+		ID:           strconv.FormatInt(r.ID, 10),
+		Name:         r.Name,
+		Slug:         r.Name,
+		Fullname:     r.FullName,
+		URL:          r.HTMLURL,
+		HTTPCloneURL: r.CloneURL,
+		SSHCloneURL:  r.SSHURL,
+	}
+}
+
+// Repos returns the list of accessible repositories
+func (c *giteaClient) Repos(ctx context.Context) ([]sdk.VCSRepo, error) {
+	var repos []sdk.VCSRepo
+	err := c.getPages(ctx, "/user/repos", nil, func(body []byte) (int, error) {
+		var page []Repository
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		for _, r := range page {
+			repos = append(repos, r.toVCSRepo())
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot list gitea repositories")
+	}
+	return repos, nil
+}
+
+// RepoByFullname returns the repo from its fullname
+func (c *giteaClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	repo, err := c.repository(ctx, fullname)
+	if err != nil {
+		return sdk.VCSRepo{}, err
+	}
+	return repo.toVCSRepo(), nil
+}
+
+func (c *giteaClient) repository(ctx context.Context, fullname string) (Repository, error) {
+	var repo Repository
+	path, err := repoPath(fullname)
+	if err != nil {
+		return repo, err
+	}
+	if err := c.get(ctx, path, nil, &repo); err != nil {
+		return repo, sdk.WrapError(err, "cannot get gitea repository %s", fullname)
+	}
+	return repo, nil
+}
+
+// GrantWritePermission is not needed on Gitea, CDS uses the token of the user
+func (c *giteaClient) GrantWritePermission(ctx context.Context, repo string) error {
+	return nil
+}
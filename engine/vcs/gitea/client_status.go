@@ -0,0 +1,153 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type statusData struct {
+	desc         string
+	status       string
+	repoFullName string
+	hash         string
+	urlPipeline  string
+	context      string
+}
+
+// SetStatus creates a commit status for the hash of a workflow node run
+func (c *giteaClient) SetStatus(ctx context.Context, event sdk.Event) error {
+	if c.disableStatus {
+		log.Warning(ctx, "gitea.SetStatus>  ⚠ Gitea statuses are disabled")
+		return nil
+	}
+
+	var data statusData
+	var err error
+	switch event.EventType {
+	case fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}):
+		data, err = processEventWorkflowNodeRun(event, c.uiURL, c.disableStatusDetail)
+	default:
+		log.Error(ctx, "gitea.SetStatus> Unknown event %v", event)
+		return nil
+	}
+	if err != nil {
+		return sdk.WrapError(err, "cannot process event")
+	}
+
+	if data.status == "" {
+		log.Debug("gitea.SetStatus> Do not process event for current status: %v", event)
+		return nil
+	}
+
+	path, err := repoPath(data.repoFullName)
+	if err != nil {
+		return err
+	}
+	opt := CreateStatusOption{
+		State:       data.status,
+		TargetURL:   data.urlPipeline,
+		Description: data.desc,
+		Context:     data.context,
+	}
+	if err := c.do(ctx, http.MethodPost, path+"/statuses/"+url.PathEscape(data.hash), nil, opt, nil); err != nil {
+		return sdk.WrapError(err, "cannot set status on %s for %s", data.repoFullName, data.hash)
+	}
+	return nil
+}
+
+// ListStatuses returns the CDS statuses of a ref
+func (c *giteaClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []CommitStatus
+	err = c.getPages(ctx, path+"/commits/"+url.PathEscape(ref)+"/statuses", nil, func(body []byte) (int, error) {
+		var page []CommitStatus
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		statuses = append(statuses, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot list statuses of %s on %s", ref, repo)
+	}
+
+	vcsStatuses := []sdk.VCSCommitStatus{}
+	for _, s := range statuses {
+		if !strings.HasPrefix(s.Context, "CDS/") {
+			continue
+		}
+		vcsStatuses = append(vcsStatuses, sdk.VCSCommitStatus{
+			CreatedAt:  s.Created,
+			Decription: s.Context,
+			Ref:        ref,
+			State:      processGiteaState(s),
+		})
+	}
+	return vcsStatuses, nil
+}
+
+func processGiteaState(s CommitStatus) string {
+	switch s.State {
+	case "success":
+		return sdk.StatusSuccess
+	case "error", "failure":
+		return sdk.StatusFail
+	default:
+		return sdk.StatusDisabled
+	}
+}
+
+func processEventWorkflowNodeRun(event sdk.Event, cdsUIURL string, disabledStatusDetail bool) (statusData, error) {
+	data := statusData{}
+	var eventNR sdk.EventRunWorkflowNode
+	if err := json.Unmarshal(event.Payload, &eventNR); err != nil {
+		return data, sdk.WrapError(err, "cannot unmarshal payload")
+	}
+	//We only manage status Success and Failure
+	if eventNR.Status == sdk.StatusChecking ||
+		eventNR.Status == sdk.StatusDisabled ||
+		eventNR.Status == sdk.StatusNeverBuilt ||
+		eventNR.Status == sdk.StatusSkipped ||
+		eventNR.Status == sdk.StatusUnknown ||
+		eventNR.Status == sdk.StatusWaiting {
+		return data, nil
+	}
+
+	switch eventNR.Status {
+	case sdk.StatusFail:
+		data.status = "failure"
+	case sdk.StatusSuccess:
+		data.status = "success"
+	case sdk.StatusStopped:
+		data.status = "error"
+	default:
+		data.status = "pending"
+	}
+	data.hash = eventNR.Hash
+	data.repoFullName = eventNR.RepositoryFullName
+
+	//CDS can avoid sending the target url in status, if it's disable
+	if !disabledStatusDetail {
+		data.urlPipeline = fmt.Sprintf("%s/project/%s/workflow/%s/run/%d",
+			cdsUIURL,
+			event.ProjectKey,
+			event.WorkflowName,
+			eventNR.Number,
+		)
+	}
+
+	data.context = sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR)
+	data.desc = eventNR.NodeName + ": " + eventNR.Status
+	return data, nil
+}
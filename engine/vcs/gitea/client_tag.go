@@ -0,0 +1,40 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags retrieves the tags
+func (c *giteaClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	path, err := repoPath(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []sdk.VCSTag
+	err = c.getPages(ctx, path+"/tags", nil, func(body []byte) (int, error) {
+		var page []Tag
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		for _, t := range page {
+			tag := sdk.VCSTag{
+				Tag:     t.Name,
+				Sha:     t.ID,
+				Message: t.Message,
+			}
+			if t.Commit != nil {
+				tag.Hash = t.Commit.SHA
+			}
+			tags = append(tags, tag)
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot list tags of %s", fullname)
+	}
+	return tags, nil
+}
@@ -0,0 +1,56 @@
+package gitea
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	_ sdk.VCSAuthorizedClient = &giteaClient{}
+	_ sdk.VCSServer           = &giteaConsumer{}
+)
+
+// giteaClient implements VCSAuthorizedClient interface
+type giteaClient struct {
+	URL                 string
+	OAuthToken          string
+	RefreshToken        string
+	expiration          time.Time
+	uiURL               string
+	proxyURL            string
+	disableStatus       bool
+	disableStatusDetail bool
+}
+
+// giteaConsumer implements vcs.Server and it's used to instantiate a giteaClient
+type giteaConsumer struct {
+	URL                      string `json:"url"`
+	ClientID                 string `json:"client-id"`
+	ClientSecret             string `json:"-"`
+	AuthorizationCallbackURL string
+	uiURL                    string
+	proxyURL                 string
+	disableStatus            bool
+	disableStatusDetail      bool
+}
+
+// New instantiate a new gitea consumer, it also works with Forgejo instances
+func New(clientID, clientSecret, URL, callbackURL, uiURL, proxyURL string, disableStatus, disableStatusDetail bool) sdk.VCSServer {
+	return &giteaConsumer{
+		URL:                      strings.TrimSuffix(URL, "/"),
+		ClientID:                 clientID,
+		ClientSecret:             clientSecret,
+		AuthorizationCallbackURL: callbackURL,
+		uiURL:                    uiURL,
+		proxyURL:                 proxyURL,
+		disableStatus:            disableStatus,
+		disableStatusDetail:      disableStatusDetail,
+	}
+}
+
+func (c *giteaClient) GetAccessToken(_ context.Context) string {
+	return c.OAuthToken
+}
@@ -0,0 +1,312 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// stubServer is a recorded Gitea API, it answers with the registered JSON bodies and keeps the received requests
+type stubServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	routes   map[string]string
+	requests map[string][]byte
+}
+
+func newStubServer(t *testing.T) *stubServer {
+	s := &stubServer{
+		routes:   map[string]string{},
+		requests: map[string][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/login/oauth/access_token" && r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		key := r.Method + " " + r.URL.Path
+		if page := r.URL.Query().Get("page"); page != "" && page != "1" {
+			key += "?page=" + page
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.requests[key] = body
+		res, ok := s.routes[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		fmt.Fprint(w, res)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *stubServer) on(key, body string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.routes[key] = body
+}
+
+func (s *stubServer) received(key string) ([]byte, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, ok := s.requests[key]
+	return b, ok
+}
+
+func newTestClient(t *testing.T, s *stubServer) sdk.VCSAuthorizedClient {
+	log.SetLogger(t)
+	consumer := New("client-id", "client-secret", s.URL+"/", "http://localhost:8080/callback", "http://localhost:4200", "", false, false)
+	client, err := consumer.GetAuthorizedClient(context.TODO(), "my-token", "", time.Now().Unix())
+	require.NoError(t, err)
+	return client
+}
+
+func TestAuthorize(t *testing.T) {
+	s := newStubServer(t)
+	s.on("POST /login/oauth/access_token", `{"access_token":"my-token","token_type":"bearer","expires_in":3600,"refresh_token":"my-refresh-token"}`)
+	consumer := New("client-id", "client-secret", s.URL, "http://localhost:8080/callback", "", "", false, false)
+
+	requestToken, u, err := consumer.AuthorizeRedirect(context.TODO())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(u, s.URL+"/login/oauth/authorize?"))
+	require.Contains(t, u, "state="+requestToken)
+
+	accessToken, refreshToken, err := consumer.AuthorizeToken(context.TODO(), requestToken, "my-code")
+	require.NoError(t, err)
+	require.Equal(t, "my-token", accessToken)
+	require.Equal(t, "my-refresh-token", refreshToken)
+
+	body, _ := s.received("POST /login/oauth/access_token")
+	require.Contains(t, string(body), "code=my-code")
+	require.Contains(t, string(body), "grant_type=authorization_code")
+}
+
+func TestGetAuthorizedClientRefresh(t *testing.T) {
+	s := newStubServer(t)
+	s.on("POST /login/oauth/access_token", `{"access_token":"my-new-token","token_type":"bearer","expires_in":3600,"refresh_token":"my-new-refresh-token"}`)
+	consumer := New("client-id", "client-secret", s.URL, "", "", "", false, false)
+
+	// The token was created two hours ago, it has to be refreshed
+	client, err := consumer.GetAuthorizedClient(context.TODO(), "my-expired-token", "my-refresh-token", time.Now().Add(-2*time.Hour).Unix())
+	require.NoError(t, err)
+	require.Equal(t, "my-new-token", client.GetAccessToken(context.TODO()))
+
+	body, _ := s.received("POST /login/oauth/access_token")
+	require.Contains(t, string(body), "refresh_token=my-refresh-token")
+}
+
+func TestRepos(t *testing.T) {
+	s := newStubServer(t)
+	var page []Repository
+	for i := 0; i < pageSize; i++ {
+		page = append(page, Repository{ID: int64(i), Name: "repo" + strconv.Itoa(i), FullName: "gitea/repo" + strconv.Itoa(i)})
+	}
+	b, _ := json.Marshal(page)
+	s.on("GET /api/v1/user/repos", string(b))
+	s.on("GET /api/v1/user/repos?page=2", `[{"id":100,"name":"last","full_name":"gitea/last","clone_url":"http://localhost/gitea/last.git","ssh_url":"git@localhost:gitea/last.git","html_url":"http://localhost/gitea/last"}]`)
+	client := newTestClient(t, s)
+
+	repos, err := client.Repos(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, repos, pageSize+1)
+	require.Equal(t, sdk.VCSRepo{
+		ID:           "100",
+		Name:         "last",
+		Slug:         "last",
+		Fullname:     "gitea/last",
+		URL:          "http://localhost/gitea/last",
+		HTTPCloneURL: "http://localhost/gitea/last.git",
+		SSHCloneURL:  "git@localhost:gitea/last.git",
+	}, repos[pageSize])
+
+	_, err = client.RepoByFullname(context.TODO(), "gitea/unknown")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	_, err = client.RepoByFullname(context.TODO(), "invalid")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+}
+
+func TestBranches(t *testing.T) {
+	s := newStubServer(t)
+	s.on("GET /api/v1/repos/gitea/webhooks", `{"id":1,"full_name":"gitea/webhooks","default_branch":"master"}`)
+	s.on("GET /api/v1/repos/gitea/webhooks/branches", `[{"name":"master","commit":{"id":"aaa"}},{"name":"feat/one","commit":{"id":"bbb"}}]`)
+	s.on("GET /api/v1/repos/gitea/webhooks/branches/feat/one", `{"name":"feat/one","commit":{"id":"bbb"}}`)
+	client := newTestClient(t, s)
+
+	branches, err := client.Branches(context.TODO(), "gitea/webhooks")
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	require.Equal(t, "master", sdk.GetDefaultBranch(branches).DisplayID)
+
+	branch, err := client.Branch(context.TODO(), "gitea/webhooks", "feat/one")
+	require.NoError(t, err)
+	require.Equal(t, "bbb", branch.LatestCommit)
+	require.False(t, branch.Default)
+}
+
+func TestCommits(t *testing.T) {
+	s := newStubServer(t)
+	s.on("GET /api/v1/repos/gitea/webhooks/commits", `[
+		{"sha":"ccc","html_url":"http://localhost/c","commit":{"message":"third","author":{"name":"John Doe","email":"jdoe@example.com","date":"2020-05-01T10:00:00Z"}},"author":{"login":"jdoe","avatar_url":"http://localhost/avatar"}},
+		{"sha":"bbb","commit":{"message":"second","author":{"name":"John Doe","email":"jdoe@example.com","date":"2020-04-30T10:00:00Z"}}},
+		{"sha":"aaa","commit":{"message":"first","author":{"name":"John Doe","email":"jdoe@example.com","date":"2020-04-29T10:00:00Z"}}}
+	]`)
+	s.on("GET /api/v1/repos/gitea/webhooks/compare/aaa...ccc", `{"total_commits":1,"commits":[{"sha":"ccc","commit":{"message":"third"}}]}`)
+	client := newTestClient(t, s)
+
+	commits, err := client.Commits(context.TODO(), "gitea/webhooks", "master", "aaa", "")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, "ccc", commits[0].Hash)
+	require.Equal(t, "jdoe", commits[0].Author.Name)
+	require.Equal(t, "John Doe", commits[0].Author.DisplayName)
+	require.Equal(t, "http://localhost/avatar", commits[0].Author.Avatar)
+	require.Equal(t, time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC).Unix()*1000, commits[0].Timestamp)
+
+	commits, err = client.CommitsBetweenRefs(context.TODO(), "gitea/webhooks", "aaa", "ccc")
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, "third", commits[0].Message)
+}
+
+func TestPullRequests(t *testing.T) {
+	s := newStubServer(t)
+	s.on("GET /api/v1/repos/gitea/webhooks/pulls", `[
+		{"number":1,"state":"closed","merged":true,"title":"merged","head":{"ref":"feat","sha":"bbb","repo":{"full_name":"gitea/webhooks"}},"base":{"ref":"master","sha":"aaa","repo":{"full_name":"gitea/webhooks"}}},
+		{"number":2,"state":"closed","merged":false,"title":"declined"}
+	]`)
+	s.on("POST /api/v1/repos/gitea/webhooks/issues/1/comments", `{"id":1}`)
+	client := newTestClient(t, s)
+
+	prs, err := client.PullRequests(context.TODO(), "gitea/webhooks", sdk.VCSPullRequestOptions{State: sdk.VCSPullRequestStateMerged})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	require.Equal(t, 1, prs[0].ID)
+	require.Equal(t, "feat", prs[0].Head.Branch.DisplayID)
+	require.Equal(t, "aaa", prs[0].Base.Commit.Hash)
+	require.True(t, prs[0].Closed)
+
+	prs, err = client.PullRequests(context.TODO(), "gitea/webhooks", sdk.VCSPullRequestOptions{State: sdk.VCSPullRequestStateClosed})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	require.Equal(t, 2, prs[0].ID)
+
+	err = client.PullRequestComment(context.TODO(), "gitea/webhooks", sdk.VCSPullRequestCommentRequest{VCSPullRequest: prs[0], Message: "hello"})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+	require.NoError(t, client.PullRequestComment(context.TODO(), "gitea/webhooks", sdk.VCSPullRequestCommentRequest{VCSPullRequest: sdk.VCSPullRequest{ID: 1}, Message: "hello"}))
+	body, _ := s.received("POST /api/v1/repos/gitea/webhooks/issues/1/comments")
+	require.JSONEq(t, `{"body":"hello"}`, string(body))
+}
+
+func TestHooks(t *testing.T) {
+	s := newStubServer(t)
+	s.on("GET /api/v1/repos/gitea/webhooks/hooks", `[{"id":12,"type":"gitea","config":{"url":"http://localhost:8083/webhook/uuid","content_type":"json"},"events":["push"],"active":true}]`)
+	s.on("POST /api/v1/repos/gitea/webhooks/hooks", `{"id":13}`)
+	s.on("PATCH /api/v1/repos/gitea/webhooks/hooks/12", `{"id":12}`)
+	client := newTestClient(t, s)
+
+	// A new hook is created with the secret
+	hook := sdk.VCSHook{URL: "http://localhost:8083/webhook/other", Secret: "s3cr3t"}
+	require.NoError(t, client.CreateHook(context.TODO(), "gitea/webhooks", &hook))
+	require.Equal(t, "13", hook.ID)
+	var created CreateHookOption
+	body, _ := s.received("POST /api/v1/repos/gitea/webhooks/hooks")
+	require.NoError(t, json.Unmarshal(body, &created))
+	require.Equal(t, "gitea", created.Type)
+	require.Equal(t, "s3cr3t", created.Config["secret"])
+	require.Equal(t, sdk.GiteaEventsDefault, created.Events)
+
+	// An existing hook with the same url is updated
+	hook = sdk.VCSHook{URL: "http://localhost:8083/webhook/uuid", Events: []string{"push", "pull_request"}}
+	require.NoError(t, client.CreateHook(context.TODO(), "gitea/webhooks", &hook))
+	require.Equal(t, "12", hook.ID)
+	var updated EditHookOption
+	body, _ = s.received("PATCH /api/v1/repos/gitea/webhooks/hooks/12")
+	require.NoError(t, json.Unmarshal(body, &updated))
+	require.Equal(t, []string{"push", "pull_request"}, updated.Events)
+
+	found, err := client.GetHook(context.TODO(), "gitea/webhooks", "http://localhost:8083/webhook/uuid")
+	require.NoError(t, err)
+	require.Equal(t, "12", found.ID)
+	_, err = client.GetHook(context.TODO(), "gitea/webhooks", "http://localhost:8083/webhook/unknown")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	// Deleting a hook that does not exist anymore is not an error
+	require.NoError(t, client.DeleteHook(context.TODO(), "gitea/webhooks", found))
+}
+
+func TestSetStatus(t *testing.T) {
+	s := newStubServer(t)
+	s.on("POST /api/v1/repos/gitea/webhooks/statuses/aaa", `{"id":1}`)
+	s.on("GET /api/v1/repos/gitea/webhooks/commits/aaa/statuses", `[
+		{"status":"failure","context":"CDS/KEY-my-workflow-build"},
+		{"status":"success","context":"ci/other"}
+	]`)
+	client := newTestClient(t, s)
+
+	payload, _ := json.Marshal(sdk.EventRunWorkflowNode{
+		Number:             3,
+		NodeName:           "build",
+		Status:             sdk.StatusFail,
+		Hash:               "aaa",
+		RepositoryFullName: "gitea/webhooks",
+	})
+	require.NoError(t, client.SetStatus(context.TODO(), sdk.Event{
+		EventType:    fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}),
+		ProjectKey:   "KEY",
+		WorkflowName: "my-workflow",
+		Payload:      payload,
+	}))
+	var status CreateStatusOption
+	body, _ := s.received("POST /api/v1/repos/gitea/webhooks/statuses/aaa")
+	require.NoError(t, json.Unmarshal(body, &status))
+	require.Equal(t, CreateStatusOption{
+		State:       "failure",
+		TargetURL:   "http://localhost:4200/project/KEY/workflow/my-workflow/run/3",
+		Description: "build: " + sdk.StatusFail,
+		Context:     "CDS/KEY-my-workflow-build",
+	}, status)
+
+	statuses, err := client.ListStatuses(context.TODO(), "gitea/webhooks", "aaa")
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, sdk.StatusFail, statuses[0].State)
+}
+
+func TestRelease(t *testing.T) {
+	s := newStubServer(t)
+	s.on("POST /api/v1/repos/gitea/webhooks/releases", `{"id":7,"tag_name":"v1.0.0"}`)
+	s.on("POST /api/v1/repos/gitea/webhooks/releases/7/assets", `{"id":1,"name":"app.tar.gz"}`)
+	client := newTestClient(t, s)
+
+	release, err := client.Release(context.TODO(), "gitea/webhooks", "v1.0.0", "Version 1.0.0", "First release")
+	require.NoError(t, err)
+	require.Equal(t, s.URL+"/api/v1/repos/gitea/webhooks/releases/7/assets", release.UploadURL)
+
+	require.NoError(t, client.UploadReleaseFile(context.TODO(), "gitea/webhooks", "v1.0.0", release.UploadURL, "app.tar.gz", ioutil.NopCloser(strings.NewReader("content"))))
+	body, _ := s.received("POST /api/v1/repos/gitea/webhooks/releases/7/assets")
+	require.Contains(t, string(body), `name="attachment"; filename="app.tar.gz"`)
+	require.Contains(t, string(body), "content")
+}
@@ -0,0 +1,145 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// pageSize is the number of items requested by page, it is the default max of a Gitea instance
+const pageSize = 50
+
+var httpClient = cdsclient.NewHTTPClient(time.Second*30, false)
+
+func (c *giteaClient) apiURL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return c.URL + "/api/v1" + path
+}
+
+// do sends a request to the Gitea API, in is marshaled as JSON body and the response is unmarshaled in out
+func (c *giteaClient) do(ctx context.Context, method, path string, params url.Values, in, out interface{}) error {
+	var body io.Reader
+	contentType := ""
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		body = bytes.NewReader(b)
+		contentType = "application/json"
+	}
+	return c.doRaw(ctx, method, path, params, contentType, body, out)
+}
+
+func (c *giteaClient) doRaw(ctx context.Context, method, path string, params url.Values, contentType string, body io.Reader, out interface{}) error {
+	u, err := url.Parse(c.apiURL(path))
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if len(params) > 0 {
+		u.RawQuery = params.Encode()
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.OAuthToken)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	log.Debug("Gitea API>> Request %s %s", method, u.String())
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return sdk.WrapError(err, "cannot do request %s %s", method, path)
+	}
+	defer res.Body.Close()
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	if res.StatusCode >= 400 {
+		var giteaErr Error
+		_ = json.Unmarshal(resBody, &giteaErr)
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return sdk.WithStack(sdk.ErrNotFound)
+		case http.StatusForbidden:
+			return sdk.WithStack(sdk.ErrForbidden)
+		case http.StatusUnauthorized:
+			return sdk.WithStack(sdk.ErrUnauthorized)
+		case http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity:
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "%s", giteaErr.Error())
+		}
+		return sdk.WithStack(fmt.Errorf("gitea error on %s %s (%d): %s", method, path, res.StatusCode, string(resBody)))
+	}
+
+	if out == nil || len(resBody) == 0 {
+		return nil
+	}
+	return sdk.WithStack(json.Unmarshal(resBody, out))
+}
+
+func (c *giteaClient) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, params, nil, out)
+}
+
+// getPages calls a list endpoint page by page, the page is given to add which returns the number of items read
+func (c *giteaClient) getPages(ctx context.Context, path string, params url.Values, add func(body []byte) (int, error)) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("limit", strconv.Itoa(pageSize))
+	for page := 1; ctx.Err() == nil; page++ {
+		params.Set("page", strconv.Itoa(page))
+		var raw json.RawMessage
+		if err := c.get(ctx, path, params, &raw); err != nil {
+			return err
+		}
+		n, err := add(raw)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		if n < pageSize {
+			break
+		}
+	}
+	return nil
+}
+
+// splitRepo returns the owner and the name of a repository from its fullname
+func splitRepo(fullname string) (string, string, error) {
+	t := strings.Split(fullname, "/")
+	if len(t) != 2 || t[0] == "" || t[1] == "" {
+		return "", "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid repository fullname %q", fullname)
+	}
+	return t[0], t[1], nil
+}
+
+// repoPath returns the API path of a repository
+func repoPath(fullname string) (string, error) {
+	owner, name, err := splitRepo(fullname)
+	if err != nil {
+		return "", err
+	}
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name), nil
+}
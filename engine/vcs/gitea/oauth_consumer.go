@@ -0,0 +1,147 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// accessTokenLifetime is the default lifetime of a Gitea OAuth2 access token
+const accessTokenLifetime = time.Hour
+
+// AuthorizeRedirect returns the request token, the Authorize URL
+func (g *giteaConsumer) AuthorizeRedirect(ctx context.Context) (string, string, error) {
+	requestToken, err := sdk.GenerateHash()
+	if err != nil {
+		return "", "", err
+	}
+
+	val := url.Values{}
+	val.Add("client_id", g.ClientID)
+	val.Add("redirect_uri", g.AuthorizationCallbackURL)
+	val.Add("response_type", "code")
+	val.Add("state", requestToken)
+
+	return requestToken, fmt.Sprintf("%s/login/oauth/authorize?%s", g.URL, val.Encode()), nil
+}
+
+// AuthorizeToken returns the authorized token (and its refresh token)
+// from the request token and the code got on authorize url
+func (g *giteaConsumer) AuthorizeToken(ctx context.Context, _, code string) (string, string, error) {
+	log.Debug("GiteaDriver.AuthorizeToken: code:%s", code)
+
+	params := url.Values{}
+	params.Add("code", code)
+	params.Add("grant_type", "authorization_code")
+	params.Add("redirect_uri", g.AuthorizationCallbackURL)
+
+	token, err := g.accessToken(params)
+	if err != nil {
+		return "", "", err
+	}
+	return token.AccessToken, token.RefreshToken, nil
+}
+
+// RefreshToken returns a new access token from the refresh token
+func (g *giteaConsumer) RefreshToken(ctx context.Context, refreshToken string) (AccessToken, error) {
+	params := url.Values{}
+	params.Add("refresh_token", refreshToken)
+	params.Add("grant_type", "refresh_token")
+	return g.accessToken(params)
+}
+
+func (g *giteaConsumer) accessToken(params url.Values) (AccessToken, error) {
+	var token AccessToken
+	params.Add("client_id", g.ClientID)
+	params.Add("client_secret", g.ClientSecret)
+
+	req, err := http.NewRequest(http.MethodPost, g.URL+"/login/oauth/access_token", strings.NewReader(params.Encode()))
+	if err != nil {
+		return token, sdk.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return token, sdk.WrapError(err, "cannot get gitea access token")
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return token, sdk.WithStack(err)
+	}
+
+	if res.StatusCode >= 400 {
+		var giteaErr Error
+		if err := json.Unmarshal(body, &giteaErr); err == nil && giteaErr.Error() != "" {
+			return token, sdk.WithStack(giteaErr)
+		}
+		return token, sdk.WithStack(fmt.Errorf("gitea error (%d) %s", res.StatusCode, string(body)))
+	}
+
+	if err := json.Unmarshal(body, &token); err != nil {
+		return token, sdk.WrapError(err, "unable to parse gitea response (%d) %s", res.StatusCode, string(body))
+	}
+	return token, nil
+}
+
+// keep client in memory
+var (
+	instancesAuthorizedClient      = map[string]*giteaClient{}
+	instancesAuthorizedClientMutex sync.Mutex
+)
+
+// GetAuthorizedClient returns an authorized client, the access token is refreshed when expired
+func (g *giteaConsumer) GetAuthorizedClient(ctx context.Context, accessToken, refreshToken string, created int64) (sdk.VCSAuthorizedClient, error) {
+	instancesAuthorizedClientMutex.Lock()
+	defer instancesAuthorizedClientMutex.Unlock()
+
+	// Several Gitea servers may be configured, the token is only unique on its server
+	key := g.URL + "/" + accessToken
+	c, ok := instancesAuthorizedClient[key]
+	if !ok {
+		c = &giteaClient{
+			URL:                 g.URL,
+			OAuthToken:          accessToken,
+			RefreshToken:        refreshToken,
+			expiration:          time.Unix(created, 0).Add(accessTokenLifetime),
+			uiURL:               g.uiURL,
+			proxyURL:            g.proxyURL,
+			disableStatus:       g.disableStatus,
+			disableStatusDetail: g.disableStatusDetail,
+		}
+		instancesAuthorizedClient[key] = c
+	}
+
+	// Refresh a bit before the expiration to not use a token that expires during the calls
+	if c.RefreshToken != "" && c.expiration.Before(time.Now().Add(time.Minute)) {
+		token, err := g.RefreshToken(ctx, c.RefreshToken)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot refresh token")
+		}
+		// The previous client may still be in use, so the refreshed one is a copy
+		refreshed := *c
+		refreshed.OAuthToken = token.AccessToken
+		if token.RefreshToken != "" {
+			refreshed.RefreshToken = token.RefreshToken
+		}
+		refreshed.expiration = time.Now().Add(accessTokenLifetime)
+		if token.ExpiresIn > 0 {
+			refreshed.expiration = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		}
+		c = &refreshed
+		instancesAuthorizedClient[key] = c
+	}
+
+	return c, nil
+}
@@ -0,0 +1,213 @@
+package gitea
+
+import "time"
+
+// AccessToken is the response of the Gitea OAuth2 token endpoint
+type AccessToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Error is the error returned by the Gitea API
+type Error struct {
+	Message          string `json:"message"`
+	URL              string `json:"url"`
+	ErrorCode        string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (e Error) Error() string {
+	if e.ErrorCode != "" {
+		return e.ErrorCode + ": " + e.ErrorDescription
+	}
+	return e.Message
+}
+
+// User represents a Gitea user
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// Repository represents a Gitea repository
+type Repository struct {
+	ID            int64       `json:"id"`
+	Owner         User        `json:"owner"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent,omitempty"`
+	HTMLURL       string      `json:"html_url"`
+	CloneURL      string      `json:"clone_url"`
+	SSHURL        string      `json:"ssh_url"`
+	DefaultBranch string      `json:"default_branch"`
+}
+
+// PayloadUser represents the author or the committer of a commit
+type PayloadUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	UserName string `json:"username"`
+}
+
+// PayloadCommit represents the commit of a branch
+type PayloadCommit struct {
+	ID        string       `json:"id"`
+	Message   string       `json:"message"`
+	URL       string       `json:"url"`
+	Author    *PayloadUser `json:"author"`
+	Committer *PayloadUser `json:"committer"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// Branch represents a Gitea branch
+type Branch struct {
+	Name      string        `json:"name"`
+	Commit    PayloadCommit `json:"commit"`
+	Protected bool          `json:"protected"`
+}
+
+// CommitUser represents the git author or committer of a commit
+type CommitUser struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// RepoCommit contains the git data of a commit
+type RepoCommit struct {
+	Message   string     `json:"message"`
+	Author    CommitUser `json:"author"`
+	Committer CommitUser `json:"committer"`
+}
+
+// CommitMeta is a reference to a commit
+type CommitMeta struct {
+	SHA     string    `json:"sha"`
+	URL     string    `json:"url"`
+	Created time.Time `json:"created"`
+}
+
+// Commit represents a Gitea commit
+type Commit struct {
+	SHA     string       `json:"sha"`
+	URL     string       `json:"url"`
+	HTMLURL string       `json:"html_url"`
+	Commit  RepoCommit   `json:"commit"`
+	Author  *User        `json:"author"`
+	Parents []CommitMeta `json:"parents"`
+	Files   []CommitFile `json:"files"`
+}
+
+// CommitFile is a file changed by a commit
+type CommitFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+}
+
+// Compare is the result of the comparison of two refs
+type Compare struct {
+	TotalCommits int      `json:"total_commits"`
+	Commits      []Commit `json:"commits"`
+}
+
+// Tag represents a Gitea tag
+type Tag struct {
+	Name    string      `json:"name"`
+	ID      string      `json:"id"`
+	Message string      `json:"message"`
+	Commit  *CommitMeta `json:"commit"`
+}
+
+// PRBranchInfo represents the head or the base of a pull request
+type PRBranchInfo struct {
+	Label string      `json:"label"`
+	Ref   string      `json:"ref"`
+	Sha   string      `json:"sha"`
+	Repo  *Repository `json:"repo"`
+}
+
+// PullRequest represents a Gitea pull request
+type PullRequest struct {
+	ID        int64         `json:"id"`
+	Number    int           `json:"number"`
+	User      *User         `json:"user"`
+	Title     string        `json:"title"`
+	Body      string        `json:"body"`
+	State     string        `json:"state"`
+	HTMLURL   string        `json:"html_url"`
+	Merged    bool          `json:"merged"`
+	Head      *PRBranchInfo `json:"head"`
+	Base      *PRBranchInfo `json:"base"`
+	Updated   *time.Time    `json:"updated_at"`
+	MergeBase string        `json:"merge_base"`
+}
+
+// CreatePullRequestOption is the body used to create a pull request
+type CreatePullRequestOption struct {
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+// Hook represents a repository webhook
+type Hook struct {
+	ID     int64             `json:"id"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// CreateHookOption is the body used to create a webhook
+type CreateHookOption struct {
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// EditHookOption is the body used to update a webhook, only given config keys are updated
+type EditHookOption struct {
+	Config map[string]string `json:"config,omitempty"`
+	Events []string          `json:"events,omitempty"`
+	Active *bool             `json:"active,omitempty"`
+}
+
+// CommitStatus represents a status set on a commit
+type CommitStatus struct {
+	ID          int64     `json:"id"`
+	State       string    `json:"status"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	Context     string    `json:"context"`
+	Created     time.Time `json:"created_at"`
+}
+
+// CreateStatusOption is the body used to set a status on a commit
+type CreateStatusOption struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+// CreateReleaseOption is the body used to create a release
+type CreateReleaseOption struct {
+	TagName string `json:"tag_name"`
+	Title   string `json:"name"`
+	Note    string `json:"body"`
+}
+
+// Release represents a Gitea release
+type Release struct {
+	ID        int64  `json:"id"`
+	TagName   string `json:"tag_name"`
+	UploadURL string `json:"upload_url"`
+}
@@ -314,6 +314,16 @@ func (g *githubClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 				URL: commit.HTMLURL,
 			}
 		}
+		// Github only gives the files changed between the two refs, they are reported on the last commit
+		if len(commits) > 0 {
+			last := &commits[len(commits)-1]
+			for _, f := range diff.Files {
+				last.Files = append(last.Files, f.Filename)
+				if f.PreviousFilename != "" {
+					last.Files = append(last.Files, f.PreviousFilename)
+				}
+			}
+		}
 		//Put the body on cache for one hour and one minute
 		k := cache.Key("vcs", "github", "commitdiff", g.OAuthToken, url)
 		if err := g.Cache.SetWithTTL(k, &commits, 61*60); err != nil {
@@ -63,6 +63,7 @@ type Webhook struct {
 	Config  struct {
 		URL         string `json:"url"`
 		ContentType string `json:"content_type"`
+		Secret      string `json:"secret,omitempty"`
 	} `json:"config"`
 	UpdatedAt time.Time `json:"updated_at"`
 	CreatedAt time.Time `json:"created_at"`
@@ -72,6 +73,7 @@ type Webhook struct {
 type WebHookConfig struct {
 	URL         string `json:"url"`
 	ContentType string `json:"content_type"`
+	Secret      string `json:"secret,omitempty"`
 }
 
 // User represents a GitHub user.
@@ -676,16 +678,17 @@ type DiffCommits struct {
 	TotalCommits int      `json:"total_commits"`
 	Commits      []Commit `json:"commits"`
 	Files        []struct {
-		Sha         string `json:"sha"`
-		Filename    string `json:"filename"`
-		Status      string `json:"status"`
-		Additions   int    `json:"additions"`
-		Deletions   int    `json:"deletions"`
-		Changes     int    `json:"changes"`
-		BlobURL     string `json:"blob_url"`
-		RawURL      string `json:"raw_url"`
-		ContentsURL string `json:"contents_url"`
-		Patch       string `json:"patch"`
+		Sha              string `json:"sha"`
+		Filename         string `json:"filename"`
+		PreviousFilename string `json:"previous_filename"`
+		Status           string `json:"status"`
+		Additions        int    `json:"additions"`
+		Deletions        int    `json:"deletions"`
+		Changes          int    `json:"changes"`
+		BlobURL          string `json:"blob_url"`
+		RawURL           string `json:"raw_url"`
+		ContentsURL      string `json:"contents_url"`
+		Patch            string `json:"patch"`
 	} `json:"files"`
 }
 
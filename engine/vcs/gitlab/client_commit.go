@@ -103,5 +103,16 @@ func (c *gitlabClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 		}
 	}
 
+	// Gitlab only gives the diffs between the two refs, they are reported on the last commit
+	if len(vcscommits) > 0 {
+		last := &vcscommits[len(vcscommits)-1]
+		for _, d := range compare.Diffs {
+			last.Files = append(last.Files, d.NewPath)
+			if d.RenamedFile {
+				last.Files = append(last.Files, d.OldPath)
+			}
+		}
+	}
+
 	return vcscommits, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ovh/cds/engine/vcs/bitbucketcloud"
 	"github.com/ovh/cds/engine/vcs/bitbucketserver"
 	"github.com/ovh/cds/engine/vcs/gerrit"
+	"github.com/ovh/cds/engine/vcs/gitea"
 	"github.com/ovh/cds/engine/vcs/github"
 	"github.com/ovh/cds/engine/vcs/gitlab"
 	"github.com/ovh/cds/sdk"
@@ -139,6 +140,17 @@ func (s *Service) getConsumer(name string) (sdk.VCSServer, error) {
 			serverCfg.Gitlab.Status.ShowDetail,
 		), nil
 	}
+	if serverCfg.Gitea != nil {
+		return gitea.New(serverCfg.Gitea.ClientID,
+			serverCfg.Gitea.ClientSecret,
+			serverCfg.URL,
+			serverCfg.Gitea.CallbackURL,
+			s.Cfg.UI.HTTP.URL,
+			serverCfg.Gitea.ProxyWebhook,
+			serverCfg.Gitea.Status.Disable,
+			!serverCfg.Gitea.Status.ShowDetail,
+		), nil
+	}
 	if serverCfg.Gerrit != nil {
 		return gerrit.New(
 			serverCfg.URL,
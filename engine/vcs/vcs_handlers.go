@@ -44,6 +44,8 @@ func (s *Service) getAllVCSServersHandler() service.Handler {
 				vcsType = "github"
 			} else if v.Gitlab != nil {
 				vcsType = "gitlab"
+			} else if v.Gitea != nil {
+				vcsType = "gitea"
 			}
 
 			servers[k] = sdk.VCSConfiguration{
@@ -76,6 +78,8 @@ func (s *Service) getVCSServersHandler() service.Handler {
 			s.Type = "github"
 		} else if cfg.Gitlab != nil {
 			s.Type = "gitlab"
+		} else if cfg.Gitea != nil {
+			s.Type = "gitea"
 		}
 		return service.WriteJSON(w, s, http.StatusOK)
 	}
@@ -94,6 +98,7 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 			WebhooksIcon       string   `json:"webhooks_icon"`
 			GerritHookDisabled bool     `json:"gerrithook_disabled"`
 			Events             []string `json:"events"`
+			CommentEvents      []string `json:"comment_events"`
 		}{}
 
 		switch {
@@ -115,6 +120,8 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 			res.WebhooksIcon = sdk.GitHubIcon
 			// https://developer.github.com/v3/activity/events/types/
 			res.Events = sdk.GitHubEvents
+			// Comment commands need a CDS user linked to the comment author, only available for GitHub and GitLab
+			res.CommentEvents = []string{"issue_comment"}
 		case cfg.Gitlab != nil:
 			res.WebhooksSupported = true
 			res.WebhooksDisabled = cfg.Gitlab.DisableWebHooks
@@ -130,6 +137,13 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 				string(gitlab.EventTypePipeline),
 				"Job Hook", // TODO update gitlab sdk
 			}
+			res.CommentEvents = []string{string(gitlab.EventTypeNote)}
+		case cfg.Gitea != nil:
+			res.WebhooksSupported = true
+			res.WebhooksDisabled = cfg.Gitea.DisableWebHooks
+			res.WebhooksIcon = sdk.GiteaIcon
+			// https://docs.gitea.com/usage/webhooks
+			res.Events = sdk.GiteaEvents
 		case cfg.Gerrit != nil:
 			res.WebhooksSupported = false
 			res.GerritHookDisabled = cfg.Gerrit.DisableGerritEvent
@@ -166,6 +180,8 @@ func (s *Service) getVCSServersPollingHandler() service.Handler {
 		case cfg.Gitlab != nil:
 			res.PollingSupported = false
 			res.PollingDisabled = cfg.Gitlab.DisablePolling
+		case cfg.Gitea != nil:
+			res.PollingSupported = false
 		}
 
 		return service.WriteJSON(w, res, http.StatusOK)
@@ -61,11 +61,6 @@ func RunArtifactDownload(ctx context.Context, wk workerruntime.Runtime, a sdk.Ac
 		return res, fmt.Errorf("cds.run.number variable is not valid. aborting")
 	}
 
-	artifacts, err := wk.Client().WorkflowRunArtifacts(project, workflow, n)
-	if err != nil {
-		return res, err
-	}
-
 	regexp, err := regexp.Compile(pattern)
 	if err != nil {
 		res.Status = sdk.StatusFail
@@ -74,6 +69,21 @@ func RunArtifactDownload(ctx context.Context, wk workerruntime.Runtime, a sdk.Ac
 		return res, err
 	}
 
+	if wk.CDNHttpURL() != "" {
+		// Artifacts of the current workflow run
+		if err := DownloadCDNArtifacts(ctx, wk, 0, regexp, tag, destPath); err != nil {
+			res.Status = sdk.StatusFail
+			res.Reason = err.Error()
+			return res, err
+		}
+		return res, nil
+	}
+
+	artifacts, err := wk.Client().WorkflowRunArtifacts(project, workflow, n)
+	if err != nil {
+		return res, err
+	}
+
 	wg := new(sync.WaitGroup)
 	wg.Add(len(artifacts))
 
@@ -129,3 +139,46 @@ func RunArtifactDownload(ctx context.Context, wk workerruntime.Runtime, a sdk.Ac
 	wg.Wait()
 	return res, nil
 }
+
+// DownloadCDNArtifacts downloads the artifacts of a workflow run stored in CDN into destPath.
+// If runID is not set, artifacts from the run of the current job are downloaded.
+func DownloadCDNArtifacts(ctx context.Context, wk workerruntime.Runtime, runID int64, pattern *regexp.Regexp, tag, destPath string) error {
+	cdnAddr := wk.CDNHttpURL()
+	signature, err := wk.CDNSignature(ctx)
+	if err != nil {
+		return err
+	}
+
+	items, err := wk.Client().CDNArtifactList(ctx, cdnAddr, signature, runID)
+	if err != nil {
+		return err
+	}
+
+	wkDirFS := afero.NewOsFs()
+	for _, it := range items {
+		name := it.APIRef.ArtifactName
+		if pattern != nil && !pattern.MatchString(name) {
+			wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%s does not match pattern %s - skipped", name, pattern))
+			continue
+		}
+		if tag != "" && it.APIRef.ArtifactTag != tag {
+			wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%s does not match tag %s - skipped", name, tag))
+			continue
+		}
+
+		destFile := path.Join(destPath, name)
+		f, err := wkDirFS.OpenFile(destFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(it.APIRef.ArtifactPerm))
+		if err != nil {
+			return sdk.WrapError(err, "cannot download artifact %s", destFile)
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Downloading artifact %s from CDS CDN...", destFile))
+		if err := wk.Client().CDNArtifactDownload(ctx, cdnAddr, signature, it.APIRefHash, f); err != nil {
+			_ = f.Close()
+			return sdk.WrapError(err, "cannot download artifact %s", destFile)
+		}
+		if err := f.Close(); err != nil {
+			return sdk.WrapError(err, "cannot download artifact %s", destFile)
+		}
+	}
+	return nil
+}
@@ -80,6 +80,17 @@ func RunArtifactUpload(ctx context.Context, wk workerruntime.Runtime, a sdk.Acti
 		go func(path string) {
 			log.Debug("worker.RunArtifactUpload> Uploading %s projectKey:%v integrationName:%v job:%d", path, projectKey, integrationName, jobID)
 			defer wg.Done()
+			if cdnAddr := wk.CDNHttpURL(); cdnAddr != "" {
+				duration, err := uploadArtifactToCDN(ctx, wk, cdnAddr, tag.Value, path)
+				if err != nil {
+					log.Warning(ctx, "worker.RunArtifactUpload> CDNArtifactUpload(%s, %s) failed: %v", tag.Value, path, err)
+					chanError <- sdk.WrapError(err, "Error while uploading artifact %s", path)
+					wgErrors.Add(1)
+					return
+				}
+				wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("File '%s' uploaded in %.2fs to CDS CDN", path, duration.Seconds()))
+				return
+			}
 			throughTempURL, duration, err := wk.Client().QueueArtifactUpload(ctx, projectKey, integrationName, jobID, tag.Value, path)
 			if err != nil {
 				log.Warning(ctx, "worker.RunArtifactUpload> QueueArtifactUpload(%s, %s, %d, %s, %s) failed: %v", projectKey, integrationName, jobID, tag.Value, path, err)
@@ -109,3 +120,11 @@ func RunArtifactUpload(ctx context.Context, wk workerruntime.Runtime, a sdk.Acti
 
 	return res, nil
 }
+
+func uploadArtifactToCDN(ctx context.Context, wk workerruntime.Runtime, cdnAddr, tag, path string) (time.Duration, error) {
+	signature, err := wk.CDNSignature(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return wk.Client().CDNArtifactUpload(ctx, cdnAddr, signature, tag, path)
+}
@@ -202,6 +202,16 @@ func gitClone(ctx context.Context, w workerruntime.Runtime, params []sdk.Paramet
 		w.SendLog(ctx, workerruntime.LevelWarn, err.Error())
 	}
 
+	// build the merge result of the pull request instead of its head commit if asked by the application vcs strategy
+	if (gitURLSSH == url || gitURLHTTP == url) && sdk.ParameterValue(params, "git.merge_result") == "true" &&
+		(clone.Tag == "" || clone.Tag == sdk.DefaultGitCloneParameterTagValue) {
+		mergeVars, err := gitCheckoutMergeResult(ctx, w, params, url, basedir, dir, auth, clone)
+		if err != nil {
+			return sdk.Result{}, err
+		}
+		vars = append(vars, mergeVars...)
+	}
+
 	stdTaglistErr := new(bytes.Buffer)
 	stdTagListOut := new(bytes.Buffer)
 	outputGitTag := &git.OutputOpts{
@@ -222,6 +232,59 @@ func gitClone(ctx context.Context, w workerruntime.Runtime, params []sdk.Paramet
 	return sdk.Result{Status: sdk.StatusSuccess, NewVariables: vars}, nil
 }
 
+func gitCheckoutMergeResult(ctx context.Context, w workerruntime.Runtime, params []sdk.Parameter, url, basedir, dir string, auth *git.AuthOpts, clone *git.CloneOpts) ([]sdk.Variable, error) {
+	prID := sdk.ParameterValue(params, "git.pr.id")
+	baseBranch := sdk.ParameterValue(params, "git.branch.dest")
+	if prID == "" || baseBranch == "" {
+		w.SendLog(ctx, workerruntime.LevelInfo, "not a pull request, the merge result is not built")
+		return nil, nil
+	}
+
+	repoDir := dir
+	if repoDir == "" {
+		t := strings.Split(url, "/")
+		repoDir = filepath.Join(basedir, strings.TrimSuffix(t[len(t)-1], ".git"))
+	} else if !sdk.PathIsAbs(repoDir) {
+		repoDir = filepath.Join(basedir, repoDir)
+	}
+
+	stdErr := new(bytes.Buffer)
+	stdOut := new(bytes.Buffer)
+	output := &git.OutputOpts{
+		Stderr: stdErr,
+		Stdout: stdOut,
+	}
+	res, err := git.CheckoutMergeResult(url, repoDir, auth, &git.MergeOpts{
+		PullRequestID: prID,
+		BaseBranch:    baseBranch,
+		HeadHash:      clone.CheckoutCommit,
+	}, output)
+	if len(stdOut.Bytes()) > 0 {
+		w.SendLog(ctx, workerruntime.LevelInfo, stdOut.String())
+	}
+	if len(stdErr.Bytes()) > 0 {
+		w.SendLog(ctx, workerruntime.LevelWarn, stdErr.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to build the merge result of pull request %s: %v", prID, err)
+	}
+
+	if res.Ref != "" {
+		w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("merge result checked out from %s", res.Ref))
+	} else {
+		w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("merge result of %s in %s computed locally", res.HeadHash, baseBranch))
+	}
+	w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("git.hash.base: %s", res.BaseHash))
+	w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("git.hash.head: %s", res.HeadHash))
+	w.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("git.hash.merge: %s", res.MergeHash))
+
+	return []sdk.Variable{
+		{Name: "git.hash.base", Type: sdk.StringVariable, Value: res.BaseHash},
+		{Name: "git.hash.head", Type: sdk.StringVariable, Value: res.HeadHash},
+		{Name: "git.hash.merge", Type: sdk.StringVariable, Value: res.MergeHash},
+	}, nil
+}
+
 func extractInfo(ctx context.Context, w workerruntime.Runtime, basedir, dir string, params []sdk.Parameter, tag, branch, commit string, opts *git.CloneOpts) ([]sdk.Variable, error) {
 	var res []sdk.Variable
 	author := sdk.ParameterValue(params, "git.author")
@@ -1,12 +1,19 @@
 package action
 
 import (
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ovh/cds/engine/test"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs"
+	"github.com/ovh/cds/sdk/vcs/git"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRunGitCloneInSSHWithoutVCSStrategyShouldRaiseError(t *testing.T) {
@@ -286,3 +293,64 @@ func TestRunGitCloneWithSecret(t *testing.T) {
 
 	assert.DirExists(t, filepath.Join(wk.workingDirectory.File.Name(), ".git"))
 }
+
+func TestGitCheckoutMergeResult(t *testing.T) {
+	wk, ctx := SetupTest(t)
+
+	tmp, err := ioutil.TempDir("", "cds-worker-merge")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp) // nolint
+
+	run := func(dir string, args ...string) string {
+		c := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@localhost"}, args...)...)
+		c.Dir = dir
+		out, err := c.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(dir, file string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, file), []byte(file), os.FileMode(0644)))
+		run(dir, "add", ".")
+		run(dir, "commit", "--quiet", "-m", file)
+	}
+
+	// Create a repository with a pull request from feature to master, then clone the feature branch
+	origin := filepath.Join(tmp, "origin")
+	require.NoError(t, os.MkdirAll(origin, os.FileMode(0755)))
+	run(origin, "init", "--quiet")
+	run(origin, "checkout", "--quiet", "-b", "master")
+	commit(origin, "README.md")
+	run(origin, "checkout", "--quiet", "-b", "feature")
+	commit(origin, "feature.txt")
+	head := run(origin, "rev-parse", "HEAD")
+	run(origin, "checkout", "--quiet", "master")
+	commit(origin, "master.txt")
+	base := run(origin, "rev-parse", "HEAD")
+	run(tmp, "clone", "--quiet", "--branch", "feature", origin, filepath.Join(tmp, "repo"))
+
+	auth := &git.AuthOpts{PrivateKey: vcs.SSHKey{Filename: filepath.Join(tmp, "key")}}
+
+	// Without pull request id or base branch, the head commit is kept
+	vars, err := gitCheckoutMergeResult(ctx, wk, []sdk.Parameter{{Name: "git.branch", Value: "feature"}}, origin, tmp, "repo", auth, &git.CloneOpts{})
+	require.NoError(t, err)
+	require.Len(t, vars, 0)
+	require.Equal(t, head, run(filepath.Join(tmp, "repo"), "rev-parse", "HEAD"))
+
+	// With the pull request variables sent by the repository manager webhooks, the merge result is checked out
+	params := []sdk.Parameter{
+		{Name: "git.branch", Value: "feature"},
+		{Name: "git.pr.id", Value: "1"},
+		{Name: "git.branch.dest", Value: "master"},
+	}
+	vars, err = gitCheckoutMergeResult(ctx, wk, params, origin, tmp, "repo", auth, &git.CloneOpts{CheckoutCommit: head})
+	require.NoError(t, err)
+	require.Len(t, vars, 3)
+	require.Equal(t, "git.hash.base", vars[0].Name)
+	require.Equal(t, base, vars[0].Value)
+	require.Equal(t, "git.hash.head", vars[1].Name)
+	require.Equal(t, head, vars[1].Value)
+	require.Equal(t, "git.hash.merge", vars[2].Name)
+	require.Equal(t, run(filepath.Join(tmp, "repo"), "rev-parse", "HEAD"), vars[2].Value)
+	require.FileExists(t, filepath.Join(tmp, "repo", "feature.txt"))
+	require.FileExists(t, filepath.Join(tmp, "repo", "master.txt"))
+}
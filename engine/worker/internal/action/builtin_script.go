@@ -202,7 +202,8 @@ func RunScriptAction(ctx context.Context, wk workerruntime.Runtime, a sdk.Action
 		}
 
 		log.Info(ctx, "runScriptAction> Running command %s %s in %s", script.shell, strings.Trim(fmt.Sprint(script.opts), "[]"), script.dir)
-		cmd := exec.CommandContext(ctx, script.shell, script.opts...)
+		cmd := exec.Command(script.shell, script.opts...)
+		sdk.SetProcessGroup(cmd)
 		res.Status = sdk.StatusUnknown
 
 		cmd.Dir = script.dir
@@ -280,9 +281,24 @@ func RunScriptAction(ctx context.Context, wk workerruntime.Runtime, a sdk.Action
 			return
 		}
 
+		// Kill the script with all its children when the step is canceled or reaches its timeout,
+		// background processes would otherwise keep the outputs open.
+		cmdDone := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				if err := sdk.KillProcessTree(cmd); err != nil {
+					log.Warning(ctx, "runScriptAction> unable to kill process %d: %v", cmd.Process.Pid, err)
+				}
+			case <-cmdDone:
+			}
+		}()
+
 		<-outchan
 		<-errchan
-		if err := cmd.Wait(); err != nil {
+		err = cmd.Wait()
+		close(cmdDone)
+		if err != nil {
 			chanErr <- fmt.Errorf("command failure: %v", err)
 		}
 
@@ -2,7 +2,12 @@ package action
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -109,6 +114,35 @@ func TestRunScriptAction(t *testing.T) {
 	assert.Equal(t, sdk.StatusSuccess, res.Status)
 }
 
+func TestRunScriptActionTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.SkipNow()
+	}
+	wk, ctx := SetupTest(t)
+	tmp, err := ioutil.TempDir("", "cds-script-timeout")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp) // nolint
+	marker := filepath.Join(tmp, "marker")
+
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	_, err = RunScriptAction(ctx, wk,
+		sdk.Action{
+			Parameters: []sdk.Parameter{
+				{
+					Name:  "script",
+					Value: "(sleep 2; touch " + marker + ") &\nsleep 30",
+				},
+			},
+		}, nil)
+	require.Error(t, err)
+
+	// The background process must have been killed with the script
+	time.Sleep(3 * time.Second)
+	_, err = os.Stat(marker)
+	assert.True(t, os.IsNotExist(err))
+}
+
 func Test_writeScriptContent_windows(t *testing.T) {
 	sdk.GOOS = "windows"
 	defer func() {
@@ -122,6 +122,14 @@ func (w *TestWorker) InstallKeyTo(key sdk.Variable, destinationPath string) (*wo
 	}, nil
 }
 
+func (_ *TestWorker) CDNHttpURL() string {
+	return ""
+}
+
+func (_ *TestWorker) CDNSignature(ctx context.Context) (string, error) {
+	return "", nil
+}
+
 var _ workerruntime.Runtime = new(TestWorker)
 
 func SetupTest(t *testing.T) (*TestWorker, context.Context) {
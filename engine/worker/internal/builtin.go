@@ -3,6 +3,7 @@ package internal
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ovh/cds/engine/worker/internal/action"
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
@@ -12,6 +13,9 @@ import (
 
 var mapBuiltinActions = map[string]BuiltInAction{}
 
+// builtinStopGracePeriod is the delay given to a builtin action to return once its step is canceled.
+var builtinStopGracePeriod = 10 * time.Second
+
 func init() {
 	mapBuiltinActions[sdk.ArtifactUpload] = action.RunArtifactUpload
 	mapBuiltinActions[sdk.ArtifactDownload] = action.RunArtifactDownload
@@ -40,14 +44,36 @@ func (w *CurrentWorker) runBuiltin(ctx context.Context, a sdk.Action, secrets []
 	}
 
 	log.Debug("running builin action %s %s", a.StepName, a.Name)
-	res, err := f(ctx, w, a, secrets)
-	if err != nil {
-		res.Status = sdk.StatusFail
-		res.Reason = err.Error()
-		log.Error(ctx, "worker.runBuiltin> %v", err)
-		w.SendLog(ctx, workerruntime.LevelError, res.Reason)
+	chanRes := make(chan sdk.Result, 1)
+	go func() {
+		res, err := f(ctx, w, a, secrets)
+		if err != nil {
+			res.Status = sdk.StatusFail
+			res.Reason = err.Error()
+			log.Error(ctx, "worker.runBuiltin> %v", err)
+			w.SendLog(ctx, workerruntime.LevelError, res.Reason)
+		}
+		chanRes <- res
+	}()
+
+	select {
+	case res := <-chanRes:
+		return res
+	case <-ctx.Done():
+	}
+
+	// Some builtin actions don't stop with the step context (ie. git commands), don't wait for them
+	// after a grace period so the step timeout is enforced.
+	select {
+	case res := <-chanRes:
+		return res
+	case <-time.After(builtinStopGracePeriod):
+		log.Error(ctx, "worker.runBuiltin> action %s did not stop after %s: %v", a.Name, builtinStopGracePeriod, ctx.Err())
+		return sdk.Result{
+			Status: sdk.StatusFail,
+			Reason: fmt.Sprintf("builtin step %s was stopped: %v", a.Name, ctx.Err()),
+		}
 	}
-	return res
 }
 
 func (w *CurrentWorker) runGRPCPlugin(ctx context.Context, a sdk.Action) sdk.Result {
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ovh/cds/engine/worker/internal/action"
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/ovh/cds/sdk"
 )
@@ -66,12 +67,6 @@ func downloadHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 		}
 
 		projectKey := sdk.ParameterValue(wk.currentJob.params, "cds.project")
-		artifacts, err := wk.client.WorkflowRunArtifacts(projectKey, reqArgs.Workflow, reqArgs.Number)
-		if err != nil {
-			newError := sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Cannot download artifacts with worker download: %s", err))
-			writeError(w, r, newError)
-			return
-		}
 
 		regexp, errp := regexp.Compile(reqArgs.Pattern)
 		if errp != nil {
@@ -79,6 +74,27 @@ func downloadHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			writeError(w, r, newError)
 			return
 		}
+
+		if wk.CDNHttpURL() != "" {
+			run, err := wk.client.WorkflowRunGet(projectKey, reqArgs.Workflow, reqArgs.Number)
+			if err != nil {
+				writeError(w, r, sdk.WrapError(err, "cannot get run %d for project %s and workflow: %s", reqArgs.Number, projectKey, reqArgs.Workflow))
+				return
+			}
+			wk.SendLog(ctx, workerruntime.LevelInfo, "Downloading artifacts from CDN into current directory")
+			if err := action.DownloadCDNArtifacts(ctx, wk, run.ID, regexp, reqArgs.Tag, reqArgs.Destination); err != nil {
+				wk.SendLog(ctx, workerruntime.LevelError, err.Error())
+				writeError(w, r, sdk.NewError(sdk.ErrUnknownError, fmt.Errorf("Error while downloading artifacts - see previous logs")))
+			}
+			return
+		}
+
+		artifacts, err := wk.client.WorkflowRunArtifacts(projectKey, reqArgs.Workflow, reqArgs.Number)
+		if err != nil {
+			newError := sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Cannot download artifacts with worker download: %s", err))
+			writeError(w, r, newError)
+			return
+		}
 		wg := new(sync.WaitGroup)
 		wg.Add(len(artifacts))
 
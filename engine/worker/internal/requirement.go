@@ -23,6 +23,7 @@ var requirementCheckFuncs = map[string]func(w *CurrentWorker, r sdk.Requirement)
 	sdk.PluginRequirement:   checkPluginRequirement,
 	sdk.ServiceRequirement:  checkServiceRequirement,
 	sdk.MemoryRequirement:   checkMemoryRequirement,
+	sdk.CPURequirement:      checkCPURequirement,
 	sdk.VolumeRequirement:   checkVolumeRequirement,
 	sdk.OSArchRequirement:   checkOSArchRequirement,
 	sdk.RegionRequirement:   checkRegionRequirement,
@@ -178,6 +179,11 @@ func checkMemoryRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
 	return totalMemory >= (neededMemory*1024*1024)*90/100, nil
 }
 
+// cpu is set by hatchery only
+func checkCPURequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
+	return true, nil
+}
+
 func checkVolumeRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
 	// volume are supported only for Model Docker
 	if w.model.Type != sdk.Docker {
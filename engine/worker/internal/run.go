@@ -117,7 +117,10 @@ func (w *CurrentWorker) replaceVariablesPlaceholder(a *sdk.Action, params []sdk.
 	return nil
 }
 
-func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64, secrets []sdk.Variable) sdk.Result {
+// alwaysExecutedStepsTimeout is the delay given to the steps always executed once the job timeout is reached.
+var alwaysExecutedStepsTimeout = 5 * time.Minute
+
+func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64, secrets []sdk.Variable, timeout time.Duration) sdk.Result {
 	log.Info(ctx, "runJob> start job %s (%d)", a.Name, jobID)
 	var jobResult = sdk.Result{
 		Status:  sdk.StatusSuccess,
@@ -129,7 +132,17 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 		log.Info(ctx, "runJob> job %s (%d)", a.Name, jobID)
 	}()
 
-	var nDisabled, nCriticalFailed int
+	// Steps are run with the job context that expires with the job timeout,
+	// results are still sent to the API with the parent context.
+	jobCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		jobCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var nDisabled, nCriticalFailed, nCriticalTimedOut int
+	var jobTimedOut bool
 	for jobStepIndex, step := range a.Actions {
 		// Reset step log line to 0
 		w.stepLogLine = 0
@@ -150,8 +163,19 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 			Status:  sdk.StatusNeverBuilt,
 			BuildID: jobID,
 		}
-		if nCriticalFailed == 0 || step.AlwaysExecuted {
-			stepResult = w.runAction(ctx, step, jobID, secrets, step.Name)
+		if (nCriticalFailed == 0 && !jobTimedOut) || step.AlwaysExecuted {
+			stepResult = w.runStepWithTimeout(ctx, jobCtx, step, jobID, secrets)
+			if stepResult.Status == sdk.StatusTimeout && jobCtx.Err() != nil && !jobTimedOut {
+				jobTimedOut = true
+				jobResult.Reason = fmt.Sprintf("job %s exceeded its timeout of %s", a.Name, timeout)
+				w.sendSpawnInfo(ctx, jobID, sdk.MsgSpawnInfoJobTimeout, timeout.String())
+				// The following steps always executed get their own time limit, as the job context has expired
+				var cancel context.CancelFunc
+				jobCtx, cancel = context.WithTimeout(ctx, alwaysExecutedStepsTimeout)
+				defer cancel()
+			} else if stepResult.Status == sdk.StatusTimeout && !step.Optional {
+				jobResult.Reason = stepResult.Reason
+			}
 
 			// Check if all newVariables are in currentJob.params
 			// variable can be add in w.currentJob.newVariables by worker command export
@@ -178,6 +202,11 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 				if !step.Optional {
 					nCriticalFailed++
 				}
+			case sdk.StatusTimeout:
+				if !step.Optional {
+					nCriticalFailed++
+					nCriticalTimedOut++
+				}
 			}
 		}
 		if err := w.updateStepStatus(ctx, jobID, jobStepIndex, stepResult.Status); err != nil {
@@ -198,9 +227,54 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 	if nCriticalFailed > 0 {
 		jobResult.Status = sdk.StatusFail
 	}
+	if nCriticalTimedOut > 0 || jobTimedOut {
+		jobResult.Status = sdk.StatusTimeout
+	}
 	return jobResult
 }
 
+// runStepWithTimeout runs a step of the job, the step is stopped with the timeout status if it reaches its timeout or the job one.
+func (w *CurrentWorker) runStepWithTimeout(ctx, jobCtx context.Context, step sdk.Action, jobID int64, secrets []sdk.Variable) sdk.Result {
+	stepOrder, _ := workerruntime.StepOrder(ctx)
+	stepName, _ := workerruntime.StepName(ctx)
+	stepCtx := workerruntime.SetStepName(workerruntime.SetStepOrder(jobCtx, stepOrder), stepName)
+
+	timeout := time.Duration(step.Timeout) * time.Second
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		stepCtx, cancel = context.WithTimeout(stepCtx, timeout)
+		defer cancel()
+	}
+
+	res := w.runAction(stepCtx, step, jobID, secrets, step.Name)
+	if stepCtx.Err() != context.DeadlineExceeded {
+		return res
+	}
+
+	res.Status = sdk.StatusTimeout
+	if jobCtx.Err() != nil {
+		res.Reason = fmt.Sprintf("step %s was stopped by the job timeout", stepName)
+	} else {
+		res.Reason = fmt.Sprintf("step %s exceeded its timeout of %s", stepName, timeout)
+		w.sendSpawnInfo(ctx, jobID, sdk.MsgSpawnInfoStepTimeout, stepName, timeout.String())
+	}
+	w.SendLog(ctx, workerruntime.LevelError, res.Reason)
+	return res
+}
+
+// sendSpawnInfo adds an information message on the job.
+func (w *CurrentWorker) sendSpawnInfo(ctx context.Context, jobID int64, msg *sdk.Message, args ...interface{}) {
+	sp := sdk.SpawnMsg{ID: msg.ID, Args: args}
+	infos := []sdk.SpawnInfo{{
+		RemoteTime:  time.Now(),
+		Message:     sp,
+		UserMessage: sp.DefaultUserMessage(),
+	}}
+	if err := w.Client().QueueJobSendSpawnInfo(ctx, jobID, infos); err != nil {
+		log.Error(ctx, "unable to send spawn info: %v", err)
+	}
+}
+
 func (w *CurrentWorker) runAction(ctx context.Context, a sdk.Action, jobID int64, secrets []sdk.Variable, actionName string) sdk.Result {
 	log.Info(ctx, "runAction> start action %s %s %d", a.StepName, actionName, jobID)
 	defer func() { log.Info(ctx, "runAction> end action %s %s run %d", a.StepName, actionName, jobID) }()
@@ -607,7 +681,8 @@ func (w *CurrentWorker) ProcessJob(jobInfo sdk.WorkflowNodeJobRunData) (res sdk.
 
 	w.currentJob.params = jobParameters
 
-	res = w.runJob(ctx, &jobInfo.NodeJobRun.Job.Action, jobInfo.NodeJobRun.ID, jobInfo.Secrets)
+	timeout := time.Duration(jobInfo.NodeJobRun.Job.Timeout) * time.Second
+	res = w.runJob(ctx, &jobInfo.NodeJobRun.Job.Action, jobInfo.NodeJobRun.ID, jobInfo.Secrets, timeout)
 
 	if len(res.NewVariables) > 0 {
 		log.Debug("processJob> new variables: %v", res.NewVariables)
@@ -1,12 +1,21 @@
 package internal
 
 import (
+	"context"
 	"encoding/json"
+	"sync"
 	"testing"
+	"time"
 
-	"github.com/ovh/cds/sdk"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+	loghook "github.com/ovh/cds/sdk/log/hook"
 )
 
 var (
@@ -31,3 +40,137 @@ func Test_processVariablesAndParameters(t *testing.T) {
 	assert.Equal(t, expectedJobParameters, string(actualJobParameters))
 
 }
+
+func Test_runJobWithTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_cdsclient.NewMockWorkerInterface(ctrl)
+	w := &CurrentWorker{client: client}
+	w.currentJob.context = context.TODO()
+	h, err := loghook.NewHook(context.TODO(), &loghook.Config{Addr: "localhost:12202", Protocol: "udp"}, nil)
+	require.NoError(t, err)
+	w.SetGelfLogger(h, logrus.New())
+
+	mapBuiltinActions["test-wait-cancel"] = func(ctx context.Context, _ workerruntime.Runtime, _ sdk.Action, _ []sdk.Variable) (sdk.Result, error) {
+		<-ctx.Done()
+		return sdk.Result{}, ctx.Err()
+	}
+	mapBuiltinActions["test-ignore-cancel"] = func(ctx context.Context, _ workerruntime.Runtime, _ sdk.Action, _ []sdk.Variable) (sdk.Result, error) {
+		time.Sleep(5 * time.Second)
+		return sdk.Result{Status: sdk.StatusSuccess}, nil
+	}
+	mapBuiltinActions["test-success"] = func(ctx context.Context, _ workerruntime.Runtime, _ sdk.Action, _ []sdk.Variable) (sdk.Result, error) {
+		return sdk.Result{Status: sdk.StatusSuccess}, nil
+	}
+	defer func() {
+		delete(mapBuiltinActions, "test-wait-cancel")
+		delete(mapBuiltinActions, "test-ignore-cancel")
+		delete(mapBuiltinActions, "test-success")
+	}()
+	previousGracePeriod := builtinStopGracePeriod
+	builtinStopGracePeriod = 100 * time.Millisecond
+	defer func() { builtinStopGracePeriod = previousGracePeriod }()
+	previousAlwaysExecutedTimeout := alwaysExecutedStepsTimeout
+	alwaysExecutedStepsTimeout = 500 * time.Millisecond
+	defer func() { alwaysExecutedStepsTimeout = previousAlwaysExecutedTimeout }()
+
+	var mutex sync.Mutex
+	var stepStatus map[int]string
+	var spawnInfos []string
+	client.EXPECT().QueueSendStepResult(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int64, res sdk.StepStatus) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			stepStatus[res.StepOrder] = res.Status
+			return nil
+		},
+	).AnyTimes()
+	client.EXPECT().QueueJobSendSpawnInfo(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int64, infos []sdk.SpawnInfo) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, i := range infos {
+				spawnInfos = append(spawnInfos, i.Message.ID)
+			}
+			return nil
+		},
+	).AnyTimes()
+
+	step := func(name string, timeout int64) sdk.Action {
+		return sdk.Action{Name: name, Type: sdk.BuiltinAction, Enabled: true, Timeout: timeout}
+	}
+
+	tests := []struct {
+		name               string
+		steps              []sdk.Action
+		jobTimeout         time.Duration
+		expectedStatus     string
+		expectedStepStatus map[int]string
+		expectedSpawnInfos []string
+	}{
+		{
+			name:               "step timeout",
+			steps:              []sdk.Action{step("test-wait-cancel", 1), step("test-success", 0)},
+			expectedStatus:     sdk.StatusTimeout,
+			expectedStepStatus: map[int]string{0: sdk.StatusTimeout, 1: sdk.StatusNeverBuilt},
+			expectedSpawnInfos: []string{sdk.MsgSpawnInfoStepTimeout.ID},
+		},
+		{
+			name: "optional step timeout",
+			steps: []sdk.Action{
+				func() sdk.Action { s := step("test-wait-cancel", 1); s.Optional = true; return s }(),
+				step("test-success", 0),
+			},
+			expectedStatus:     sdk.StatusSuccess,
+			expectedStepStatus: map[int]string{0: sdk.StatusTimeout, 1: sdk.StatusSuccess},
+			expectedSpawnInfos: []string{sdk.MsgSpawnInfoStepTimeout.ID},
+		},
+		{
+			name:               "step that ignores the timeout",
+			steps:              []sdk.Action{step("test-ignore-cancel", 1)},
+			expectedStatus:     sdk.StatusTimeout,
+			expectedStepStatus: map[int]string{0: sdk.StatusTimeout},
+			expectedSpawnInfos: []string{sdk.MsgSpawnInfoStepTimeout.ID},
+		},
+		{
+			name: "job timeout",
+			steps: []sdk.Action{
+				step("test-wait-cancel", 0),
+				func() sdk.Action { s := step("test-success", 0); s.AlwaysExecuted = true; return s }(),
+			},
+			jobTimeout:         time.Second,
+			expectedStatus:     sdk.StatusTimeout,
+			expectedStepStatus: map[int]string{0: sdk.StatusTimeout, 1: sdk.StatusSuccess},
+			expectedSpawnInfos: []string{sdk.MsgSpawnInfoJobTimeout.ID},
+		},
+		{
+			name: "job timeout skips steps not always executed",
+			steps: []sdk.Action{
+				func() sdk.Action { s := step("test-wait-cancel", 0); s.Optional = true; return s }(),
+				step("test-success", 0),
+				func() sdk.Action { s := step("test-wait-cancel", 0); s.AlwaysExecuted = true; return s }(),
+			},
+			jobTimeout:         time.Second,
+			expectedStatus:     sdk.StatusTimeout,
+			expectedStepStatus: map[int]string{0: sdk.StatusTimeout, 1: sdk.StatusNeverBuilt, 2: sdk.StatusTimeout},
+			expectedSpawnInfos: []string{sdk.MsgSpawnInfoJobTimeout.ID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stepStatus = make(map[int]string)
+			spawnInfos = nil
+
+			start := time.Now()
+			res := w.runJob(context.TODO(), &sdk.Action{Name: "job", Actions: tt.steps}, 1, nil, tt.jobTimeout)
+			require.True(t, time.Since(start) < 3*time.Second, "the job should have been stopped by the timeout")
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			assert.Equal(t, tt.expectedStatus, res.Status)
+			assert.Equal(t, tt.expectedStepStatus, stepStatus)
+			assert.Equal(t, tt.expectedSpawnInfos, spawnInfos)
+		})
+	}
+}
@@ -11,10 +11,10 @@ import (
 )
 
 func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (mainError error) {
-	log.Info(ctx, "Starting worker %s on job %d", w.Name(), bookedJobID)
-
 	if bookedJobID == 0 {
-		return fmt.Errorf("startWorker: bookedJobID is mandatory. val:%d", bookedJobID)
+		log.Info(ctx, "Starting worker %s, waiting for a job", w.Name())
+	} else {
+		log.Info(ctx, "Starting worker %s on job %d", w.Name(), bookedJobID)
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -55,23 +55,6 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 		}
 	}
 
-	if err := processBookedWJob(ctx, w, jobsChan, bookedJobID); err != nil {
-		// Unbook job
-		if errR := w.Client().QueueJobRelease(ctx, bookedJobID); errR != nil {
-			log.Error(ctx, "runCmd> QueueJobRelease> Cannot release job")
-		}
-		// this worker was spawned for a job
-		// this job can't be process (err != nil)
-		// so, call endFunc() now, this worker don't have to work
-		// on another job
-		endFunc()
-		return sdk.WrapError(err, "unable to process booked job")
-	}
-
-	if err := w.Client().WorkerSetStatus(ctx, sdk.StatusWaiting); err != nil {
-		log.Error(ctx, "WorkerSetStatus> error on WorkerSetStatus(ctx, sdk.StatusWaiting): %s", err)
-	}
-
 	// Errors check loops
 	go func() {
 		for err := range errsChan {
@@ -103,6 +86,34 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 		}
 	}()
 
+	// A worker started without a job is an idle worker of the hatchery, it waits for a job to be assigned
+	if bookedJobID == 0 {
+		var err error
+		bookedJobID, err = waitForAssignment(ctx, w)
+		if err != nil {
+			endFunc()
+			return err
+		}
+		log.Info(ctx, "Job %d assigned to worker %s", bookedJobID, w.Name())
+	}
+
+	if err := processBookedWJob(ctx, w, jobsChan, bookedJobID); err != nil {
+		// Unbook job
+		if errR := w.Client().QueueJobRelease(ctx, bookedJobID); errR != nil {
+			log.Error(ctx, "runCmd> QueueJobRelease> Cannot release job")
+		}
+		// this worker was spawned for a job
+		// this job can't be process (err != nil)
+		// so, call endFunc() now, this worker don't have to work
+		// on another job
+		endFunc()
+		return sdk.WrapError(err, "unable to process booked job")
+	}
+
+	if err := w.Client().WorkerSetStatus(ctx, sdk.StatusWaiting); err != nil {
+		log.Error(ctx, "WorkerSetStatus> error on WorkerSetStatus(ctx, sdk.StatusWaiting): %s", err)
+	}
+
 	// main loop
 	for {
 		if ctx.Err() != nil {
@@ -139,6 +150,30 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 	}
 }
 
+// waitForAssignment waits until the hatchery assigns a job to the worker
+func waitForAssignment(ctx context.Context, w *CurrentWorker) (int64, error) {
+	tick := time.NewTicker(2 * time.Second)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-tick.C:
+			wk, err := w.Client().WorkerAssignment(ctx)
+			if err != nil {
+				log.Warning(ctx, "waitForAssignment> unable to get assignment: %v", err)
+				continue
+			}
+			if wk.Status == sdk.StatusDisabled {
+				return 0, sdk.WithStack(fmt.Errorf("worker %s disabled while waiting for a job", w.Name()))
+			}
+			if wk.JobRunID != nil && *wk.JobRunID > 0 {
+				return *wk.JobRunID, nil
+			}
+		}
+	}
+}
+
 func processBookedWJob(ctx context.Context, w *CurrentWorker, wjobs chan<- sdk.WorkflowNodeJobRun, bookedWJobID int64) error {
 	log.Debug("Try to take the workflow node job %d", bookedWJobID)
 	wjob, err := w.Client().QueueJobInfo(ctx, bookedWJobID)
@@ -37,6 +37,7 @@ func (w *CurrentWorker) Take(ctx context.Context, job sdk.WorkflowNodeJobRun) er
 	w.currentJob.workflowID = info.WorkflowID
 	w.currentJob.runID = info.RunID
 	w.currentJob.nodeRunName = info.NodeRunName
+	w.currentJob.cdnHttpAddr = info.CDNHttpAddr
 
 	// Reset build variables
 	w.currentJob.newVariables = nil
@@ -129,8 +130,8 @@ func (w *CurrentWorker) Take(ctx context.Context, job sdk.WorkflowNodeJobRun) er
 	//Wait until the logchannel is empty
 	res.BuildID = job.ID
 
-	// Send the reason as a spawninfo
-	if res.Status != sdk.StatusSuccess && res.Reason != "" {
+	// Send the reason as a spawninfo, timeouts were already sent when they occurred
+	if res.Status != sdk.StatusSuccess && res.Status != sdk.StatusTimeout && res.Reason != "" {
 		sp := sdk.SpawnMsg{ID: sdk.MsgWorkflowError.ID, Args: []interface{}{res.Reason}}
 		infos := []sdk.SpawnInfo{{
 			RemoteTime:  time.Now(),
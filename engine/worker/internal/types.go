@@ -58,6 +58,7 @@ type CurrentWorker struct {
 		workflowID   int64
 		runID        int64
 		nodeRunName  string
+		cdnHttpAddr  string
 	}
 	status struct {
 		Name   string `json:"name"`
@@ -136,10 +137,22 @@ func (wk *CurrentWorker) prepareLog(ctx context.Context, level workerruntime.Lev
 		res.Level = logrus.ErrorLevel
 	}
 
+	res.Signature = wk.newSignature(ctx)
+	res.Value = s
+
+	signature, err := jws.Sign(wk.currentJob.signer, res.Signature)
+	if err != nil {
+		return res, "", sdk.WrapError(err, "cannot sign log message")
+	}
+
+	return res, signature, nil
+}
+
+func (wk *CurrentWorker) newSignature(ctx context.Context) log.Signature {
 	stepOrder, _ := workerruntime.StepOrder(ctx)
 	stepName, _ := workerruntime.StepName(ctx)
 
-	res.Signature = log.Signature{
+	return log.Signature{
 		Worker: &log.SignatureWorker{
 			WorkerID:   wk.id,
 			WorkerName: wk.Name(),
@@ -156,15 +169,23 @@ func (wk *CurrentWorker) prepareLog(ctx context.Context, level workerruntime.Lev
 		RunID:        wk.currentJob.runID,
 		JobName:      wk.currentJob.wJob.Job.Action.Name,
 	}
+}
 
-	res.Value = s
-
-	signature, err := jws.Sign(wk.currentJob.signer, res.Signature)
+// CDNSignature returns a signature that allows the worker to call the CDN HTTP api for its current job.
+func (wk *CurrentWorker) CDNSignature(ctx context.Context) (string, error) {
+	if wk.currentJob.wJob == nil {
+		return "", sdk.WithStack(fmt.Errorf("job is nill"))
+	}
+	signature, err := jws.Sign(wk.currentJob.signer, wk.newSignature(ctx))
 	if err != nil {
-		return res, "", sdk.WrapError(err, "cannot sign log message")
+		return "", sdk.WrapError(err, "cannot sign cdn request")
 	}
+	return signature, nil
+}
 
-	return res, signature, nil
+// CDNHttpURL returns the CDN public HTTP address if artifacts should be stored in CDN for the current job.
+func (wk *CurrentWorker) CDNHttpURL() string {
+	return wk.currentJob.cdnHttpAddr
 }
 
 func (wk *CurrentWorker) Name() string {
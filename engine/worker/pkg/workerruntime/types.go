@@ -82,6 +82,8 @@ type Runtime interface {
 	Blur(interface{}) error
 	HTTPPort() int32
 	Parameters() []sdk.Parameter
+	CDNHttpURL() string
+	CDNSignature(ctx context.Context) (string, error)
 }
 
 func JobID(ctx context.Context) (int64, error) {
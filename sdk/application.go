@@ -85,6 +85,7 @@ type RepositoryStrategy struct {
 	Branch         string `json:"branch,omitempty"`
 	DefaultBranch  string `json:"default_branch,omitempty"`
 	PGPKey         string `json:"pgp_key"`
+	MergeResult    bool   `json:"merge_result,omitempty"`
 }
 
 // ApplicationVariableAudit represents an audit on an application variable
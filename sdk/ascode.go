@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -42,3 +43,63 @@ func (d AsCodeEventData) Value() (driver.Value, error) {
 	j, err := json.Marshal(d)
 	return j, WrapError(err, "cannot marshal AsCodeEventData")
 }
+
+// Types of as code lint errors.
+const (
+	AsCodeLintErrorSyntax     = "syntax"
+	AsCodeLintErrorPermission = "permission"
+	AsCodeLintErrorImport     = "import"
+)
+
+// AsCodeLint is the result of the validation of as code files.
+type AsCodeLint struct {
+	Valid     bool                 `json:"valid"`
+	Errors    []AsCodeLintError    `json:"errors,omitempty"`
+	Workflows []AsCodeLintWorkflow `json:"workflows,omitempty"`
+}
+
+// AsCodeLintError is an error found in as code files, file and line are set when known.
+type AsCodeLintError struct {
+	Type     string        `json:"type"`
+	File     string        `json:"file,omitempty"`
+	Line     int           `json:"line,omitempty"`
+	Workflow string        `json:"workflow,omitempty"`
+	Entity   *AsCodeEntity `json:"entity,omitempty"`
+	Message  string        `json:"message"`
+}
+
+func (e AsCodeLintError) String() string {
+	var location string
+	switch {
+	case e.File != "" && e.Line > 0:
+		location = fmt.Sprintf("%s:%d: ", e.File, e.Line)
+	case e.File != "":
+		location = e.File + ": "
+	case e.Workflow != "":
+		location = "workflow " + e.Workflow + ": "
+	}
+	return fmt.Sprintf("%s%s (%s)", location, e.Message, e.Type)
+}
+
+// AsCodeEntity identifies an application, environment, pipeline or workflow imported from as code files.
+// It's given as data of the error returned when the import of the entity fails.
+type AsCodeEntity struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// Types of as code entities.
+const (
+	AsCodeEntityApplication = "application"
+	AsCodeEntityEnvironment = "environment"
+	AsCodeEntityPipeline    = "pipeline"
+	AsCodeEntityWorkflow    = "workflow"
+)
+
+// AsCodeLintWorkflow contains the changes that as code files will apply on a workflow.
+type AsCodeLintWorkflow struct {
+	Name     string   `json:"name"`
+	Exists   bool     `json:"exists"`
+	Diff     string   `json:"diff,omitempty"`
+	Messages []string `json:"messages,omitempty"`
+}
@@ -130,6 +130,7 @@ const (
 	StatusUnknown           = "Unknown"
 	StatusSkipped           = "Skipped"
 	StatusStopped           = "Stopped"
+	StatusTimeout           = "Timeout" // a job or a step stopped because it exceeded its timeout
 	StatusWorkerPending     = "Pending"
 	StatusWorkerRegistering = "Registering"
 )
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	json "encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,9 @@ import (
 	"github.com/mitchellh/hashstructure"
 )
 
+// CDNWorkerSignatureHeader contains the signature of a worker that calls the CDN HTTP api.
+const CDNWorkerSignatureHeader = "X-CDS-WORKER-SIGNATURE"
+
 type CDNItem struct {
 	ID           string       `json:"id" db:"id"`
 	Created      time.Time    `json:"created" db:"created"`
@@ -31,6 +35,7 @@ type CDNItemUnit struct {
 	UnitID       string    `json:"unit_id" db:"unit_id"`
 	LastModified time.Time `json:"last_modified" db:"last_modified"`
 	Locator      string    `json:"locator" db:"cipher_locator" gorpmapping:"encrypted,UnitID,ItemID"`
+	HashLocator  string    `json:"-" db:"hash_locator"`
 	Item         *CDNItem  `json:"-" db:"-"`
 	ToDelete     bool      `json:"to_delete" db:"to_delete"`
 }
@@ -69,6 +74,11 @@ type CDNLogAPIRef struct {
 	// for hatcheries
 	RequirementServiceID   int64  `json:"service_id,omitempty"`
 	RequirementServiceName string `json:"service_name,omitempty"`
+
+	// for artifacts, ignored by hashstructure to keep log hashes unchanged, see ToHash
+	ArtifactName string `json:"artifact_name,omitempty" hash:"ignore"`
+	ArtifactTag  string `json:"artifact_tag,omitempty" hash:"ignore"`
+	ArtifactPerm uint32 `json:"artifact_perm,omitempty" hash:"ignore"`
 }
 
 type CDNItemResume struct {
@@ -77,6 +87,10 @@ type CDNItemResume struct {
 }
 
 func (a CDNLogAPIRef) ToFilename() string {
+	if a.ArtifactName != "" {
+		return a.ArtifactName
+	}
+
 	jobName := strings.Replace(a.NodeRunJobName, " ", "", -1)
 
 	isService := a.RequirementServiceID > 0 && a.RequirementServiceName != ""
@@ -97,7 +111,15 @@ func (a CDNLogAPIRef) ToFilename() string {
 }
 
 func (a CDNLogAPIRef) ToHash() (string, error) {
-	hashRefU, err := hashstructure.Hash(a, nil)
+	var toHash interface{} = a
+	if a.ArtifactName != "" {
+		toHash = struct {
+			APIRef       CDNLogAPIRef
+			ArtifactName string
+			ArtifactTag  string
+		}{a, a.ArtifactName, a.ArtifactTag}
+	}
+	hashRefU, err := hashstructure.Hash(toHash, nil)
 	if err != nil {
 		return "", WithStack(err)
 	}
@@ -126,7 +148,7 @@ type CDNItemType string
 
 func (t CDNItemType) Validate() error {
 	switch t {
-	case CDNTypeItemStepLog, CDNTypeItemServiceLog:
+	case CDNTypeItemStepLog, CDNTypeItemServiceLog, CDNTypeItemArtifact:
 		return nil
 	}
 	return NewErrorFrom(ErrWrongRequest, "invalid item type")
@@ -143,6 +165,7 @@ func (t CDNItemType) IsLog() bool {
 const (
 	CDNTypeItemStepLog     CDNItemType = "step-log"
 	CDNTypeItemServiceLog  CDNItemType = "service-log"
+	CDNTypeItemArtifact    CDNItemType = "artifact"
 	CDNStatusItemIncoming              = "Incoming"
 	CDNStatusItemCompleted             = "Completed"
 )
@@ -154,6 +177,46 @@ const (
 	CDNReaderFormatText CDNReaderFormat = "text"
 )
 
+// CDNLogSearchOptions filters the logs to search in.
+type CDNLogSearchOptions struct {
+	ProjectKey   string `json:"project_key"`
+	WorkflowName string `json:"workflow_name"`
+	FromRunID    int64  `json:"from_run_id"`
+	ToRunID      int64  `json:"to_run_id"`
+	Pattern      string `json:"pattern"`
+	Limit        int64  `json:"limit"`
+}
+
+func (o CDNLogSearchOptions) Validate() error {
+	if o.ProjectKey == "" || o.WorkflowName == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing project key or workflow name")
+	}
+	if o.Pattern == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing search pattern")
+	}
+	if _, err := regexp.Compile(o.Pattern); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid search pattern: %v", err)
+	}
+	if o.ToRunID > 0 && o.FromRunID > o.ToRunID {
+		return NewErrorFrom(ErrWrongRequest, "invalid run range")
+	}
+	return nil
+}
+
+// CDNLogSearchResult is a log line matching a search.
+type CDNLogSearchResult struct {
+	APIRef         string      `json:"api_ref" cli:"-"`
+	ItemType       CDNItemType `json:"item_type" cli:"type"`
+	RunID          int64       `json:"run_id" cli:"run_id"`
+	NodeRunName    string      `json:"node_run_name" cli:"pipeline"`
+	NodeRunJobName string      `json:"node_run_job_name" cli:"job"`
+	StepOrder      int64       `json:"step_order" cli:"step_order"`
+	StepName       string      `json:"step_name,omitempty" cli:"step"`
+	ServiceName    string      `json:"service_name,omitempty" cli:"service"`
+	LineNumber     int64       `json:"line_number" cli:"line"`
+	Line           string      `json:"line" cli:"value"`
+}
+
 type CDNWSEvent struct {
 	ItemType CDNItemType `json:"item_type"`
 	APIRef   string      `json:"api_ref"`
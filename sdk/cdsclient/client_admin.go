@@ -193,3 +193,12 @@ func (c *client) AdminWorkflowUpdateMaxRuns(projectKey string, workflowName stri
 	}
 	return nil
 }
+
+func (c *client) AdminWorkflowUpdatePriority(projectKey string, workflowName string, priority int64) error {
+	request := sdk.UpdateWorkflowPriorityRequest{Priority: priority}
+	url := fmt.Sprintf("/project/%s/workflows/%s/queue/priority", projectKey, workflowName)
+	if _, err := c.PostJSON(context.Background(), url, &request, nil); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) CDNArtifactUpload(ctx context.Context, cdnAddr string, signature string, tag string, filePath string) (time.Duration, error) {
+	t0 := time.Now()
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	defer f.Close() // nolint
+
+	stat, err := f.Stat()
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+
+	params := url.Values{}
+	params.Set("name", filepath.Base(filePath))
+	params.Set("tag", tag)
+	params.Set("perm", fmt.Sprintf("%d", stat.Mode().Perm()))
+	uploadURL := fmt.Sprintf("%s/artifact/upload?%s", cdnAddr, params.Encode())
+
+	reader, _, code, err := c.Stream(ctx, http.MethodPost, uploadURL, f, true, SetHeader(sdk.CDNWorkerSignatureHeader, signature), SetHeader("Content-Type", "application/octet-stream"))
+	if err != nil {
+		return time.Since(t0), sdk.WrapError(err, "unable to upload artifact %s to %s", filePath, cdnAddr)
+	}
+	defer reader.Close() // nolint
+
+	if code >= 400 {
+		return time.Since(t0), decodeCDNError(reader, code)
+	}
+	return time.Since(t0), nil
+}
+
+func (c *client) CDNArtifactList(ctx context.Context, cdnAddr string, signature string, runID int64) ([]sdk.CDNItem, error) {
+	var its []sdk.CDNItem
+	listURL := fmt.Sprintf("%s/artifact?runID=%d", cdnAddr, runID)
+	if _, err := c.GetJSON(ctx, listURL, &its, SetHeader(sdk.CDNWorkerSignatureHeader, signature)); err != nil {
+		return nil, err
+	}
+	return its, nil
+}
+
+func (c *client) CDNArtifactDownload(ctx context.Context, cdnAddr string, signature string, apiRef string, w io.Writer) error {
+	downloadURL := fmt.Sprintf("%s/artifact/%s/download", cdnAddr, apiRef)
+	reader, _, code, err := c.Stream(ctx, http.MethodGet, downloadURL, nil, true, SetHeader(sdk.CDNWorkerSignatureHeader, signature))
+	if err != nil {
+		return err
+	}
+	defer reader.Close() // nolint
+
+	if code >= 400 {
+		return decodeCDNError(reader, code)
+	}
+
+	_, err = io.Copy(w, reader)
+	return sdk.WithStack(err)
+}
+
+func decodeCDNError(r io.Reader, code int) error {
+	body, _ := ioutil.ReadAll(r)
+	if err := sdk.DecodeError(body); err != nil {
+		return err
+	}
+	return sdk.WithStack(fmt.Errorf("HTTP %d", code))
+}
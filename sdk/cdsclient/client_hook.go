@@ -1,7 +1,9 @@
 package cdsclient
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -25,3 +27,14 @@ func (c *client) PollVCSEvents(uuid string, workflowID int64, vcsServer string,
 
 	return events, interval, nil
 }
+
+func (c *client) HookCommitsBetweenRefs(uuid, base, head string) ([]sdk.VCSCommit, error) {
+	var commits []sdk.VCSCommit
+	params := url.Values{}
+	params.Set("base", base)
+	params.Set("head", head)
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/hook/%s/commits?%s", uuid, params.Encode()), &commits); err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
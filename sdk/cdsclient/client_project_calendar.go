@@ -0,0 +1,39 @@
+package cdsclient
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectCalendarList(projectKey string) ([]sdk.ProjectCalendar, error) {
+	calendars := []sdk.ProjectCalendar{}
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/calendar", &calendars); err != nil {
+		return nil, err
+	}
+	return calendars, nil
+}
+
+func (c *client) ProjectCalendarGet(projectKey string, name string) (*sdk.ProjectCalendar, error) {
+	var calendar sdk.ProjectCalendar
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/calendar/"+url.QueryEscape(name), &calendar); err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+func (c *client) ProjectCalendarCreate(projectKey string, calendar *sdk.ProjectCalendar) error {
+	_, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/calendar", calendar, calendar)
+	return err
+}
+
+func (c *client) ProjectCalendarUpdate(projectKey string, calendar *sdk.ProjectCalendar) error {
+	_, err := c.PutJSON(context.Background(), "/project/"+projectKey+"/calendar/"+url.QueryEscape(calendar.Name), calendar, calendar)
+	return err
+}
+
+func (c *client) ProjectCalendarDelete(projectKey string, name string) error {
+	_, err := c.DeleteJSON(context.Background(), "/project/"+projectKey+"/calendar/"+url.QueryEscape(name), nil)
+	return err
+}
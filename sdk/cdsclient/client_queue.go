@@ -52,6 +52,10 @@ func shrinkQueue(queue *sdk.WorkflowQueue, nbJobsToKeep int) time.Time {
 	return t0
 }
 
+// queuePollingWebsocketDelay is the delay between a job received from the websocket and the polling of the queue,
+// jobs that arrive together are ranked by the same polling.
+var queuePollingWebsocketDelay = time.Second
+
 func (c *client) QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, delay time.Duration, modelType string, ratioService *int) error {
 	jobsTicker := time.NewTicker(delay)
 
@@ -65,6 +69,49 @@ func (c *client) QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, j
 		Type: sdk.WebsocketFilterTypeQueue,
 	}}
 
+	// Jobs received from the websocket are not pushed directly, they are ranked with the
+	// other jobs of the queue by the next polling that is triggered after a short delay
+	wsJobIDs := make(map[int64]struct{})
+	var wsPolling <-chan time.Time
+
+	pollQueue := func() {
+		urlValues := url.Values{}
+		if ratioService != nil {
+			urlValues.Set("ratioService", strconv.Itoa(*ratioService))
+		}
+
+		if modelType != "" {
+			urlValues.Set("modelType", modelType)
+		}
+
+		ctxt, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		queue := sdk.WorkflowQueue{}
+		var urlSuffix = urlValues.Encode()
+		if urlSuffix != "" {
+			urlSuffix = "?" + urlSuffix
+		}
+		if _, err := c.GetJSON(ctxt, "/queue/workflows"+urlSuffix, &queue, nil); err != nil && !sdk.ErrorIs(err, sdk.ErrUnauthorized) {
+			errs <- sdk.WrapError(err, "Unable to load jobs")
+			return
+		} else if sdk.ErrorIs(err, sdk.ErrUnauthorized) {
+			return
+		}
+
+		if c.config.Verbose {
+			fmt.Println("Jobs Queue size: ", len(queue))
+		}
+
+		shrinkQueue(&queue, cap(jobs))
+		for _, j := range queue {
+			if _, ok := wsJobIDs[j.ID]; ok {
+				j.Header["WS"] = "true"
+			}
+			jobs <- j
+		}
+		wsJobIDs = make(map[int64]struct{})
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -83,22 +130,17 @@ func (c *client) QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, j
 					errs <- fmt.Errorf("unable to unmarshal job %v: %v", wsEvent.Event.Payload, err)
 					continue
 				}
-				job, err := c.QueueJobInfo(ctx, jobEvent.ID)
-				// Do not log the error if the job does not exist
-				if sdk.ErrorIs(err, sdk.ErrWorkflowNodeRunJobNotFound) {
-					continue
-				}
-
-				if err != nil {
-					errs <- fmt.Errorf("unable to get job %v info: %v", jobEvent.ID, err)
-					continue
-				}
-				// push the job in the channel
-				if job.Status == sdk.StatusWaiting && job.BookedBy.Name == "" {
-					job.Header["WS"] = "true"
-					jobs <- *job
+				wsJobIDs[jobEvent.ID] = struct{}{}
+				if wsPolling == nil {
+					wsPolling = time.After(queuePollingWebsocketDelay)
 				}
 			}
+		case <-wsPolling:
+			wsPolling = nil
+			if jobs == nil {
+				continue
+			}
+			pollQueue()
 		case <-jobsTicker.C:
 			if c.config.Verbose {
 				fmt.Println("jobsTicker")
@@ -107,41 +149,7 @@ func (c *client) QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, j
 			if jobs == nil {
 				continue
 			}
-
-			urlValues := url.Values{}
-			if ratioService != nil {
-				urlValues.Set("ratioService", strconv.Itoa(*ratioService))
-			}
-
-			if modelType != "" {
-				urlValues.Set("modelType", modelType)
-			}
-
-			ctxt, cancel := context.WithTimeout(ctx, 10*time.Second)
-			queue := sdk.WorkflowQueue{}
-			var urlSuffix = urlValues.Encode()
-			if urlSuffix != "" {
-				urlSuffix = "?" + urlSuffix
-			}
-			if _, err := c.GetJSON(ctxt, "/queue/workflows"+urlSuffix, &queue, nil); err != nil && !sdk.ErrorIs(err, sdk.ErrUnauthorized) {
-				errs <- sdk.WrapError(err, "Unable to load jobs")
-				cancel()
-				continue
-			} else if sdk.ErrorIs(err, sdk.ErrUnauthorized) {
-				cancel()
-				continue
-			}
-
-			cancel()
-
-			if c.config.Verbose {
-				fmt.Println("Jobs Queue size: ", len(queue))
-			}
-
-			shrinkQueue(&queue, cap(jobs))
-			for _, j := range queue {
-				jobs <- j
-			}
+			pollQueue()
 		}
 	}
 }
@@ -232,6 +240,13 @@ func (c *client) QueueJobRelease(ctx context.Context, id int64) error {
 	return err
 }
 
+// QueueJobAssign assigns a job booked by the hatchery to one of its idle workers
+func (c *client) QueueJobAssign(ctx context.Context, id int64, workerID string) error {
+	path := fmt.Sprintf("/queue/workflows/%d/assign", id)
+	_, err := c.PostJSON(ctx, path, sdk.WorkerAssignForm{WorkerID: workerID}, nil)
+	return err
+}
+
 func (c *client) QueueSendResult(ctx context.Context, id int64, res sdk.Result) error {
 	path := fmt.Sprintf("/queue/workflows/%d/result", id)
 	_, err := c.PostJSON(ctx, path, res, nil)
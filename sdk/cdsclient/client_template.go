@@ -151,3 +151,25 @@ func (c *client) TemplateDeleteInstance(groupName, templateSlug string, id int64
 
 	return nil
 }
+
+func (c *client) TemplatePinInstance(groupName, templateSlug string, id int64, version int64) (*sdk.WorkflowTemplateInstance, error) {
+	url := fmt.Sprintf("/template/%s/%s/instance/%d/pin", groupName, templateSlug, id)
+
+	var wti sdk.WorkflowTemplateInstance
+	if _, err := c.PostJSON(context.Background(), url, sdk.WorkflowTemplateInstancePin{Version: version}, &wti); err != nil {
+		return nil, err
+	}
+
+	return &wti, nil
+}
+
+func (c *client) TemplateGetUpgrades(groupName, templateSlug string, version int64) ([]sdk.WorkflowTemplateInstanceUpgrade, error) {
+	url := fmt.Sprintf("/template/%s/%s/upgrade?version=%d", groupName, templateSlug, version)
+
+	var upgrades []sdk.WorkflowTemplateInstanceUpgrade
+	if _, err := c.GetJSON(context.Background(), url, &upgrades); err != nil {
+		return nil, err
+	}
+
+	return upgrades, nil
+}
@@ -29,6 +29,17 @@ func (c *client) WorkerGet(ctx context.Context, name string, mods ...RequestModi
 	return &wrk, nil
 }
 
+// WorkerAssignment returns the current worker, with the job assigned by its hatchery if any
+func (c *client) WorkerAssignment(ctx context.Context) (*sdk.Worker, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var wrk sdk.Worker
+	if _, err := c.GetJSON(ctx, "/worker/assignment", &wrk); err != nil {
+		return nil, err
+	}
+	return &wrk, nil
+}
+
 func (c *client) WorkerUnregister(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -10,6 +10,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/ovh/cds/sdk"
@@ -98,6 +99,15 @@ func (c *client) WorkflowRunGet(projectKey string, workflowName string, number i
 	return &run, nil
 }
 
+func (c *client) WorkflowRunOutgoingHookDeliveries(projectKey string, workflowName string, number int64) ([]sdk.OutgoingHookDelivery, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/hooks/deliveries", projectKey, workflowName, number)
+	var deliveries []sdk.OutgoingHookDelivery
+	if _, err := c.GetJSON(context.Background(), url, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
 func (c *client) WorkflowRunsDeleteByBranch(projectKey string, workflowName string, branch string) error {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/branch/%s", projectKey, workflowName, url.PathEscape(branch))
 	if _, err := c.DeleteJSON(context.Background(), url, nil); err != nil {
@@ -260,6 +270,34 @@ func (c *client) WorkflowLogDownload(ctx context.Context, link sdk.CDNLogLink) (
 	return data, nil
 }
 
+func (c *client) WorkflowLogSearch(ctx context.Context, opts sdk.CDNLogSearchOptions) ([]sdk.CDNLogSearchResult, error) {
+	cdnConfig, err := c.ConfigCDN()
+	if err != nil {
+		return nil, err
+	}
+	if cdnConfig.HTTPURL == "" {
+		return nil, sdk.WithStack(fmt.Errorf("unable to find CDN http address"))
+	}
+
+	params := url.Values{}
+	params.Set("project", opts.ProjectKey)
+	params.Set("workflow", opts.WorkflowName)
+	params.Set("fromRunID", strconv.FormatInt(opts.FromRunID, 10))
+	params.Set("toRunID", strconv.FormatInt(opts.ToRunID, 10))
+	params.Set("pattern", opts.Pattern)
+	params.Set("limit", strconv.FormatInt(opts.Limit, 10))
+	searchURL := fmt.Sprintf("%s/search/logs?%s", cdnConfig.HTTPURL, params.Encode())
+
+	var res []sdk.CDNLogSearchResult
+	if _, err := c.GetJSON(ctx, searchURL, &res, func(req *http.Request) {
+		auth := "Bearer " + c.config.SessionToken
+		req.Header.Add("Authorization", auth)
+	}); err != nil {
+		return nil, sdk.WrapError(err, "can't search logs from: %s", cdnConfig.HTTPURL)
+	}
+	return res, nil
+}
+
 func (c *client) WorkflowNodeRunArtifactDownload(projectKey string, workflowName string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error {
 	var url = fmt.Sprintf("/project/%s/workflows/%s/artifact/%d", projectKey, workflowName, a.ID)
 	var reader io.ReadCloser
@@ -323,6 +361,15 @@ func (c *client) WorkflowRunFromHook(projectKey string, workflowName string, hoo
 	return run, nil
 }
 
+func (c *client) WorkflowHookCommand(projectKey string, workflowName string, hookUUID string, cmd sdk.HookCommentCommand) (*sdk.HookCommentCommandResult, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/hooks/%s/command", projectKey, workflowName, hookUUID)
+	var res sdk.HookCommentCommandResult
+	if _, err := c.PostJSON(context.Background(), url, &cmd, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func (c *client) WorkflowRunFromManual(projectKey string, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error) {
 	if c.config.Verbose {
 		log.Println("Payload: ", manual.Payload)
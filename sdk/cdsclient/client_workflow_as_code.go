@@ -2,7 +2,9 @@ package cdsclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/ovh/cds/sdk"
@@ -53,3 +55,27 @@ func (c *client) WorkflowAsCodePerform(projectKey string, operationID string) ([
 	}
 	return messages, nil
 }
+
+func (c *client) WorkflowAsCodeLint(projectKey, branch string, tarContent io.Reader, mods ...RequestModifier) (*sdk.AsCodeLint, error) {
+	path := fmt.Sprintf("/project/%s/lint/workflows", projectKey)
+	mods = append(mods, func(r *http.Request) {
+		r.Header.Set("Content-Type", "application/tar")
+		q := r.URL.Query()
+		q.Set("branch", branch)
+		r.URL.RawQuery = q.Encode()
+	})
+
+	btes, _, code, err := c.Request(context.Background(), "POST", path, tarContent, mods...)
+	if err != nil {
+		return nil, err
+	}
+	if code >= 400 {
+		return nil, fmt.Errorf("HTTP Status code %d", code)
+	}
+
+	var res sdk.AsCodeLint
+	if err := json.Unmarshal(btes, &res); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return &res, nil
+}
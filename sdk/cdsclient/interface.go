@@ -32,6 +32,8 @@ type TemplateClient interface {
 	TemplateDelete(groupName, templateSlug string) error
 	TemplateGetInstances(groupName, templateSlug string) ([]sdk.WorkflowTemplateInstance, error)
 	TemplateDeleteInstance(groupName, templateSlug string, id int64) error
+	TemplatePinInstance(groupName, templateSlug string, id int64, version int64) (*sdk.WorkflowTemplateInstance, error)
+	TemplateGetUpgrades(groupName, templateSlug string, version int64) ([]sdk.WorkflowTemplateInstanceUpgrade, error)
 }
 
 // Admin expose all function to CDS administration
@@ -49,6 +51,7 @@ type Admin interface {
 	AdminCDSMigrationCancel(id int64) error
 	AdminCDSMigrationReset(id int64) error
 	AdminWorkflowUpdateMaxRuns(projectKey string, workflowName string, maxRuns int64) error
+	AdminWorkflowUpdatePriority(projectKey string, workflowName string, priority int64) error
 	Features() ([]sdk.Feature, error)
 	FeatureCreate(f sdk.Feature) error
 	FeatureDelete(name string) error
@@ -85,6 +88,7 @@ type WorkflowAsCodeInterface interface {
 	WorkflowAsCodeStart(projectKey string, repoURL string, repoStrategy sdk.RepositoryStrategy) (*sdk.Operation, error)
 	WorkflowAsCodeInfo(projectKey string, operationID string) (*sdk.Operation, error)
 	WorkflowAsCodePerform(projectKey string, operationID string) ([]string, error)
+	WorkflowAsCodeLint(projectKey, branch string, tarContent io.Reader, mods ...RequestModifier) (*sdk.AsCodeLint, error)
 }
 
 // RepositoriesManagerInterface exposes all repostories manager functions
@@ -217,6 +221,7 @@ type ProjectClient interface {
 	ProjectList(withApplications, withWorkflow bool, filters ...Filter) ([]sdk.Project, error)
 	ProjectKeysClient
 	ProjectVariablesClient
+	ProjectCalendarsClient
 	ProjectGroupsImport(projectKey string, content io.Reader, mods ...RequestModifier) (sdk.Project, error)
 	ProjectIntegrationImport(projectKey string, content io.Reader, mods ...RequestModifier) (sdk.ProjectIntegration, error)
 	ProjectIntegrationGet(projectKey string, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error)
@@ -233,6 +238,15 @@ type ProjectKeysClient interface {
 	ProjectKeysDelete(projectKey string, keyProjectName string) error
 }
 
+// ProjectCalendarsClient exposes project calendars related functions
+type ProjectCalendarsClient interface {
+	ProjectCalendarList(projectKey string) ([]sdk.ProjectCalendar, error)
+	ProjectCalendarGet(projectKey string, name string) (*sdk.ProjectCalendar, error)
+	ProjectCalendarCreate(projectKey string, calendar *sdk.ProjectCalendar) error
+	ProjectCalendarUpdate(projectKey string, calendar *sdk.ProjectCalendar) error
+	ProjectCalendarDelete(projectKey string, name string) error
+}
+
 // ProjectVariablesClient exposes project variables related functions
 type ProjectVariablesClient interface {
 	ProjectVariablesList(key string) ([]sdk.Variable, error)
@@ -251,6 +265,7 @@ type QueueClient interface {
 	QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error)
 	QueueJobBook(ctx context.Context, id int64) (sdk.WorkflowNodeJobRunBooked, error)
 	QueueJobRelease(ctx context.Context, id int64) error
+	QueueJobAssign(ctx context.Context, id int64, workerID string) error
 	QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error)
 	QueueJobSendSpawnInfo(ctx context.Context, id int64, in []sdk.SpawnInfo) error
 	QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error
@@ -279,6 +294,7 @@ type UserClient interface {
 // WorkerClient exposes workers functions
 type WorkerClient interface {
 	WorkerGet(ctx context.Context, name string, mods ...RequestModifier) (*sdk.Worker, error)
+	WorkerAssignment(ctx context.Context) (*sdk.Worker, error)
 	WorkerModelBook(groupName, name string) error
 	WorkerList(ctx context.Context) ([]sdk.Worker, error)
 	WorkerRefresh(ctx context.Context) error
@@ -298,6 +314,7 @@ type WorkerClient interface {
 // HookClient exposes functions used for hooks services
 type HookClient interface {
 	PollVCSEvents(uuid string, workflowID int64, vcsServer string, timestamp int64) (events sdk.RepositoryEvents, interval time.Duration, err error)
+	HookCommitsBetweenRefs(uuid, base, head string) ([]sdk.VCSCommit, error)
 	VCSConfiguration() (map[string]sdk.VCSConfiguration, error)
 }
 
@@ -324,7 +341,9 @@ type WorkflowClient interface {
 	WorkflowRunSearch(projectKey string, offset, limit int64, filter ...Filter) ([]sdk.WorkflowRun, error)
 	WorkflowRunList(projectKey string, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error)
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
+	WorkflowRunOutgoingHookDeliveries(projectKey string, workflowName string, number int64) ([]sdk.OutgoingHookDelivery, error)
 	WorkflowRunFromHook(projectKey string, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error)
+	WorkflowHookCommand(projectKey string, workflowName string, hookUUID string, cmd sdk.HookCommentCommand) (*sdk.HookCommentCommandResult, error)
 	WorkflowRunFromManual(projectKey string, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error)
 	WorkflowRunNumberGet(projectKey string, workflowName string) (*sdk.WorkflowRunNumber, error)
 	WorkflowRunNumberSet(projectKey string, workflowName string, number int64) error
@@ -338,6 +357,7 @@ type WorkflowClient interface {
 	WorkflowNodeRunJobServiceLog(ctx context.Context, projectKey string, workflowName string, nodeRunID, job int64, serviceName string) (*sdk.ServiceLog, error)
 	WorkflowLogAccess(ctx context.Context, projectKey, workflowName, sessionID string) error
 	WorkflowLogDownload(ctx context.Context, link sdk.CDNLogLink) ([]byte, error)
+	WorkflowLogSearch(ctx context.Context, opts sdk.CDNLogSearchOptions) ([]sdk.CDNLogSearchResult, error)
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
 	WorkflowAllHooksList() ([]sdk.NodeHook, error)
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
@@ -403,6 +423,7 @@ type Interface interface {
 }
 
 type WorkerInterface interface {
+	CDNClient
 	GRPCPluginsClient
 	ProjectIntegrationGet(projectKey string, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error)
 	QueueClient
@@ -412,6 +433,7 @@ type WorkerInterface interface {
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
 	WorkflowCachePull(projectKey, integrationName, ref string) (io.Reader, error)
+	WorkflowRunGet(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowRunList(projectKey string, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error)
 	WorkflowNodeRunArtifactDownload(projectKey string, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
@@ -430,6 +452,13 @@ type Raw interface {
 	HTTPWebsocketClient() *websocket.Dialer
 }
 
+// CDNClient exposes CDN functions used by workers
+type CDNClient interface {
+	CDNArtifactUpload(ctx context.Context, cdnAddr string, signature string, tag string, filePath string) (time.Duration, error)
+	CDNArtifactList(ctx context.Context, cdnAddr string, signature string, runID int64) ([]sdk.CDNItem, error)
+	CDNArtifactDownload(ctx context.Context, cdnAddr string, signature string, apiRef string, w io.Writer) error
+}
+
 // GRPCPluginsClient exposes plugins API
 type GRPCPluginsClient interface {
 	PluginsList() ([]sdk.GRPCPlugin, error)
@@ -192,6 +192,36 @@ func (mr *MockTemplateClientMockRecorder) TemplateDeleteInstance(groupName, temp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateDeleteInstance", reflect.TypeOf((*MockTemplateClient)(nil).TemplateDeleteInstance), groupName, templateSlug, id)
 }
 
+// TemplatePinInstance mocks base method
+func (m *MockTemplateClient) TemplatePinInstance(groupName, templateSlug string, id, version int64) (*sdk.WorkflowTemplateInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplatePinInstance", groupName, templateSlug, id, version)
+	ret0, _ := ret[0].(*sdk.WorkflowTemplateInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplatePinInstance indicates an expected call of TemplatePinInstance
+func (mr *MockTemplateClientMockRecorder) TemplatePinInstance(groupName, templateSlug, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplatePinInstance", reflect.TypeOf((*MockTemplateClient)(nil).TemplatePinInstance), groupName, templateSlug, id, version)
+}
+
+// TemplateGetUpgrades mocks base method
+func (m *MockTemplateClient) TemplateGetUpgrades(groupName, templateSlug string, version int64) ([]sdk.WorkflowTemplateInstanceUpgrade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateGetUpgrades", groupName, templateSlug, version)
+	ret0, _ := ret[0].([]sdk.WorkflowTemplateInstanceUpgrade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateGetUpgrades indicates an expected call of TemplateGetUpgrades
+func (mr *MockTemplateClientMockRecorder) TemplateGetUpgrades(groupName, templateSlug, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateGetUpgrades", reflect.TypeOf((*MockTemplateClient)(nil).TemplateGetUpgrades), groupName, templateSlug, version)
+}
+
 // MockAdmin is a mock of Admin interface
 type MockAdmin struct {
 	ctrl     *gomock.Controller
@@ -401,6 +431,20 @@ func (mr *MockAdminMockRecorder) AdminWorkflowUpdateMaxRuns(projectKey, workflow
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminWorkflowUpdateMaxRuns", reflect.TypeOf((*MockAdmin)(nil).AdminWorkflowUpdateMaxRuns), projectKey, workflowName, maxRuns)
 }
 
+// AdminWorkflowUpdatePriority mocks base method
+func (m *MockAdmin) AdminWorkflowUpdatePriority(projectKey, workflowName string, priority int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminWorkflowUpdatePriority", projectKey, workflowName, priority)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminWorkflowUpdatePriority indicates an expected call of AdminWorkflowUpdatePriority
+func (mr *MockAdminMockRecorder) AdminWorkflowUpdatePriority(projectKey, workflowName, priority interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminWorkflowUpdatePriority", reflect.TypeOf((*MockAdmin)(nil).AdminWorkflowUpdatePriority), projectKey, workflowName, priority)
+}
+
 // Features mocks base method
 func (m *MockAdmin) Features() ([]sdk.Feature, error) {
 	m.ctrl.T.Helper()
@@ -875,6 +919,26 @@ func (mr *MockExportImportInterfaceMockRecorder) WorkflowAsCodePerform(projectKe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodePerform", reflect.TypeOf((*MockExportImportInterface)(nil).WorkflowAsCodePerform), projectKey, operationID)
 }
 
+// WorkflowAsCodeLint mocks base method
+func (m *MockExportImportInterface) WorkflowAsCodeLint(projectKey, branch string, tarContent io.Reader, mods ...cdsclient.RequestModifier) (*sdk.AsCodeLint, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, branch, tarContent}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowAsCodeLint", varargs...)
+	ret0, _ := ret[0].(*sdk.AsCodeLint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowAsCodeLint indicates an expected call of WorkflowAsCodeLint
+func (mr *MockExportImportInterfaceMockRecorder) WorkflowAsCodeLint(projectKey, branch, tarContent interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, branch, tarContent}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeLint", reflect.TypeOf((*MockExportImportInterface)(nil).WorkflowAsCodeLint), varargs...)
+}
+
 // MockWorkflowAsCodeInterface is a mock of WorkflowAsCodeInterface interface
 type MockWorkflowAsCodeInterface struct {
 	ctrl     *gomock.Controller
@@ -943,6 +1007,26 @@ func (mr *MockWorkflowAsCodeInterfaceMockRecorder) WorkflowAsCodePerform(project
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodePerform", reflect.TypeOf((*MockWorkflowAsCodeInterface)(nil).WorkflowAsCodePerform), projectKey, operationID)
 }
 
+// WorkflowAsCodeLint mocks base method
+func (m *MockWorkflowAsCodeInterface) WorkflowAsCodeLint(projectKey, branch string, tarContent io.Reader, mods ...cdsclient.RequestModifier) (*sdk.AsCodeLint, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, branch, tarContent}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowAsCodeLint", varargs...)
+	ret0, _ := ret[0].(*sdk.AsCodeLint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowAsCodeLint indicates an expected call of WorkflowAsCodeLint
+func (mr *MockWorkflowAsCodeInterfaceMockRecorder) WorkflowAsCodeLint(projectKey, branch, tarContent interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, branch, tarContent}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeLint", reflect.TypeOf((*MockWorkflowAsCodeInterface)(nil).WorkflowAsCodeLint), varargs...)
+}
+
 // MockRepositoriesManagerInterface is a mock of RepositoriesManagerInterface interface
 type MockRepositoriesManagerInterface struct {
 	ctrl     *gomock.Controller
@@ -2652,6 +2736,78 @@ func (mr *MockProjectClientMockRecorder) VariableEncrypt(projectKey, varName, co
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariableEncrypt", reflect.TypeOf((*MockProjectClient)(nil).VariableEncrypt), projectKey, varName, content)
 }
 
+// ProjectCalendarList mocks base method
+func (m *MockProjectClient) ProjectCalendarList(projectKey string) ([]sdk.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCalendarList indicates an expected call of ProjectCalendarList
+func (mr *MockProjectClientMockRecorder) ProjectCalendarList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarList", reflect.TypeOf((*MockProjectClient)(nil).ProjectCalendarList), projectKey)
+}
+
+// ProjectCalendarGet mocks base method
+func (m *MockProjectClient) ProjectCalendarGet(projectKey, name string) (*sdk.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarGet", projectKey, name)
+	ret0, _ := ret[0].(*sdk.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCalendarGet indicates an expected call of ProjectCalendarGet
+func (mr *MockProjectClientMockRecorder) ProjectCalendarGet(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarGet", reflect.TypeOf((*MockProjectClient)(nil).ProjectCalendarGet), projectKey, name)
+}
+
+// ProjectCalendarCreate mocks base method
+func (m *MockProjectClient) ProjectCalendarCreate(projectKey string, calendar *sdk.ProjectCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarCreate", projectKey, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarCreate indicates an expected call of ProjectCalendarCreate
+func (mr *MockProjectClientMockRecorder) ProjectCalendarCreate(projectKey, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarCreate", reflect.TypeOf((*MockProjectClient)(nil).ProjectCalendarCreate), projectKey, calendar)
+}
+
+// ProjectCalendarUpdate mocks base method
+func (m *MockProjectClient) ProjectCalendarUpdate(projectKey string, calendar *sdk.ProjectCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarUpdate", projectKey, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarUpdate indicates an expected call of ProjectCalendarUpdate
+func (mr *MockProjectClientMockRecorder) ProjectCalendarUpdate(projectKey, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarUpdate", reflect.TypeOf((*MockProjectClient)(nil).ProjectCalendarUpdate), projectKey, calendar)
+}
+
+// ProjectCalendarDelete mocks base method
+func (m *MockProjectClient) ProjectCalendarDelete(projectKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarDelete", projectKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarDelete indicates an expected call of ProjectCalendarDelete
+func (mr *MockProjectClientMockRecorder) ProjectCalendarDelete(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectCalendarDelete), projectKey, name)
+}
+
 // ProjectGroupsImport mocks base method
 func (m *MockProjectClient) ProjectGroupsImport(projectKey string, content io.Reader, mods ...cdsclient.RequestModifier) (sdk.Project, error) {
 	m.ctrl.T.Helper()
@@ -2831,6 +2987,101 @@ func (mr *MockProjectKeysClientMockRecorder) ProjectKeysDelete(projectKey, keyPr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysDelete), projectKey, keyProjectName)
 }
 
+// MockProjectCalendarsClient is a mock of ProjectCalendarsClient interface
+type MockProjectCalendarsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectCalendarsClientMockRecorder
+}
+
+// MockProjectCalendarsClientMockRecorder is the mock recorder for MockProjectCalendarsClient
+type MockProjectCalendarsClientMockRecorder struct {
+	mock *MockProjectCalendarsClient
+}
+
+// NewMockProjectCalendarsClient creates a new mock instance
+func NewMockProjectCalendarsClient(ctrl *gomock.Controller) *MockProjectCalendarsClient {
+	mock := &MockProjectCalendarsClient{ctrl: ctrl}
+	mock.recorder = &MockProjectCalendarsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProjectCalendarsClient) EXPECT() *MockProjectCalendarsClientMockRecorder {
+	return m.recorder
+}
+
+// ProjectCalendarList mocks base method
+func (m *MockProjectCalendarsClient) ProjectCalendarList(projectKey string) ([]sdk.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCalendarList indicates an expected call of ProjectCalendarList
+func (mr *MockProjectCalendarsClientMockRecorder) ProjectCalendarList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarList", reflect.TypeOf((*MockProjectCalendarsClient)(nil).ProjectCalendarList), projectKey)
+}
+
+// ProjectCalendarGet mocks base method
+func (m *MockProjectCalendarsClient) ProjectCalendarGet(projectKey, name string) (*sdk.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarGet", projectKey, name)
+	ret0, _ := ret[0].(*sdk.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCalendarGet indicates an expected call of ProjectCalendarGet
+func (mr *MockProjectCalendarsClientMockRecorder) ProjectCalendarGet(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarGet", reflect.TypeOf((*MockProjectCalendarsClient)(nil).ProjectCalendarGet), projectKey, name)
+}
+
+// ProjectCalendarCreate mocks base method
+func (m *MockProjectCalendarsClient) ProjectCalendarCreate(projectKey string, calendar *sdk.ProjectCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarCreate", projectKey, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarCreate indicates an expected call of ProjectCalendarCreate
+func (mr *MockProjectCalendarsClientMockRecorder) ProjectCalendarCreate(projectKey, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarCreate", reflect.TypeOf((*MockProjectCalendarsClient)(nil).ProjectCalendarCreate), projectKey, calendar)
+}
+
+// ProjectCalendarUpdate mocks base method
+func (m *MockProjectCalendarsClient) ProjectCalendarUpdate(projectKey string, calendar *sdk.ProjectCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarUpdate", projectKey, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarUpdate indicates an expected call of ProjectCalendarUpdate
+func (mr *MockProjectCalendarsClientMockRecorder) ProjectCalendarUpdate(projectKey, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarUpdate", reflect.TypeOf((*MockProjectCalendarsClient)(nil).ProjectCalendarUpdate), projectKey, calendar)
+}
+
+// ProjectCalendarDelete mocks base method
+func (m *MockProjectCalendarsClient) ProjectCalendarDelete(projectKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarDelete", projectKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarDelete indicates an expected call of ProjectCalendarDelete
+func (mr *MockProjectCalendarsClientMockRecorder) ProjectCalendarDelete(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarDelete", reflect.TypeOf((*MockProjectCalendarsClient)(nil).ProjectCalendarDelete), projectKey, name)
+}
+
 // MockProjectVariablesClient is a mock of ProjectVariablesClient interface
 type MockProjectVariablesClient struct {
 	ctrl     *gomock.Controller
@@ -3056,6 +3307,20 @@ func (mr *MockQueueClientMockRecorder) QueueJobRelease(ctx, id interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRelease", reflect.TypeOf((*MockQueueClient)(nil).QueueJobRelease), ctx, id)
 }
 
+// QueueJobAssign mocks base method
+func (m *MockQueueClient) QueueJobAssign(ctx context.Context, id int64, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobAssign", ctx, id, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobAssign indicates an expected call of QueueJobAssign
+func (mr *MockQueueClientMockRecorder) QueueJobAssign(ctx, id, workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobAssign", reflect.TypeOf((*MockQueueClient)(nil).QueueJobAssign), ctx, id, workerID)
+}
+
 // QueueJobInfo mocks base method
 func (m *MockQueueClient) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -3400,6 +3665,21 @@ func (mr *MockWorkerClientMockRecorder) WorkerGet(ctx, name interface{}, mods ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerGet", reflect.TypeOf((*MockWorkerClient)(nil).WorkerGet), varargs...)
 }
 
+// WorkerAssignment mocks base method
+func (m *MockWorkerClient) WorkerAssignment(ctx context.Context) (*sdk.Worker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerAssignment", ctx)
+	ret0, _ := ret[0].(*sdk.Worker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerAssignment indicates an expected call of WorkerAssignment
+func (mr *MockWorkerClientMockRecorder) WorkerAssignment(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerAssignment", reflect.TypeOf((*MockWorkerClient)(nil).WorkerAssignment), ctx)
+}
+
 // WorkerModelBook mocks base method
 func (m *MockWorkerClient) WorkerModelBook(groupName, name string) error {
 	m.ctrl.T.Helper()
@@ -3643,6 +3923,21 @@ func (mr *MockHookClientMockRecorder) PollVCSEvents(uuid, workflowID, vcsServer,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PollVCSEvents", reflect.TypeOf((*MockHookClient)(nil).PollVCSEvents), uuid, workflowID, vcsServer, timestamp)
 }
 
+// HookCommitsBetweenRefs mocks base method
+func (m *MockHookClient) HookCommitsBetweenRefs(uuid, base, head string) ([]sdk.VCSCommit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HookCommitsBetweenRefs", uuid, base, head)
+	ret0, _ := ret[0].([]sdk.VCSCommit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HookCommitsBetweenRefs indicates an expected call of HookCommitsBetweenRefs
+func (mr *MockHookClientMockRecorder) HookCommitsBetweenRefs(uuid, base, head interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookCommitsBetweenRefs", reflect.TypeOf((*MockHookClient)(nil).HookCommitsBetweenRefs), uuid, base, head)
+}
+
 // VCSConfiguration mocks base method
 func (m *MockHookClient) VCSConfiguration() (map[string]sdk.VCSConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -3971,6 +4266,21 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowRunArtifacts(projectKey, name,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunArtifacts), projectKey, name, number)
 }
 
+// WorkflowRunOutgoingHookDeliveries mocks base method
+func (m *MockWorkflowClient) WorkflowRunOutgoingHookDeliveries(projectKey, workflowName string, number int64) ([]sdk.OutgoingHookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunOutgoingHookDeliveries", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.OutgoingHookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunOutgoingHookDeliveries indicates an expected call of WorkflowRunOutgoingHookDeliveries
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunOutgoingHookDeliveries(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunOutgoingHookDeliveries", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunOutgoingHookDeliveries), projectKey, workflowName, number)
+}
+
 // WorkflowRunFromHook mocks base method
 func (m *MockWorkflowClient) WorkflowRunFromHook(projectKey, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -3986,6 +4296,21 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowRunFromHook(projectKey, workfl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromHook", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunFromHook), projectKey, workflowName, hook)
 }
 
+// WorkflowHookCommand mocks base method
+func (m *MockWorkflowClient) WorkflowHookCommand(projectKey, workflowName, hookUUID string, cmd sdk.HookCommentCommand) (*sdk.HookCommentCommandResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowHookCommand", projectKey, workflowName, hookUUID, cmd)
+	ret0, _ := ret[0].(*sdk.HookCommentCommandResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowHookCommand indicates an expected call of WorkflowHookCommand
+func (mr *MockWorkflowClientMockRecorder) WorkflowHookCommand(projectKey, workflowName, hookUUID, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowHookCommand", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowHookCommand), projectKey, workflowName, hookUUID, cmd)
+}
+
 // WorkflowRunFromManual mocks base method
 func (m *MockWorkflowClient) WorkflowRunFromManual(projectKey, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -4178,6 +4503,21 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowLogDownload(ctx, link interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLogDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowLogDownload), ctx, link)
 }
 
+// WorkflowLogSearch mocks base method
+func (m *MockWorkflowClient) WorkflowLogSearch(ctx context.Context, opts sdk.CDNLogSearchOptions) ([]sdk.CDNLogSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowLogSearch", ctx, opts)
+	ret0, _ := ret[0].([]sdk.CDNLogSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowLogSearch indicates an expected call of WorkflowLogSearch
+func (mr *MockWorkflowClientMockRecorder) WorkflowLogSearch(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLogSearch", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowLogSearch), ctx, opts)
+}
+
 // WorkflowNodeRunRelease mocks base method
 func (m *MockWorkflowClient) WorkflowNodeRunRelease(projectKey, workflowName string, runNumber, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	m.ctrl.T.Helper()
@@ -5167,6 +5507,20 @@ func (mr *MockInterfaceMockRecorder) AdminWorkflowUpdateMaxRuns(projectKey, work
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminWorkflowUpdateMaxRuns", reflect.TypeOf((*MockInterface)(nil).AdminWorkflowUpdateMaxRuns), projectKey, workflowName, maxRuns)
 }
 
+// AdminWorkflowUpdatePriority mocks base method
+func (m *MockInterface) AdminWorkflowUpdatePriority(projectKey, workflowName string, priority int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminWorkflowUpdatePriority", projectKey, workflowName, priority)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminWorkflowUpdatePriority indicates an expected call of AdminWorkflowUpdatePriority
+func (mr *MockInterfaceMockRecorder) AdminWorkflowUpdatePriority(projectKey, workflowName, priority interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminWorkflowUpdatePriority", reflect.TypeOf((*MockInterface)(nil).AdminWorkflowUpdatePriority), projectKey, workflowName, priority)
+}
+
 // Features mocks base method
 func (m *MockInterface) Features() ([]sdk.Feature, error) {
 	m.ctrl.T.Helper()
@@ -6142,6 +6496,26 @@ func (mr *MockInterfaceMockRecorder) WorkflowAsCodePerform(projectKey, operation
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodePerform", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodePerform), projectKey, operationID)
 }
 
+// WorkflowAsCodeLint mocks base method
+func (m *MockInterface) WorkflowAsCodeLint(projectKey, branch string, tarContent io.Reader, mods ...cdsclient.RequestModifier) (*sdk.AsCodeLint, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, branch, tarContent}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowAsCodeLint", varargs...)
+	ret0, _ := ret[0].(*sdk.AsCodeLint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowAsCodeLint indicates an expected call of WorkflowAsCodeLint
+func (mr *MockInterfaceMockRecorder) WorkflowAsCodeLint(projectKey, branch, tarContent interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, branch, tarContent}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeLint", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodeLint), varargs...)
+}
+
 // FeatureEnabled mocks base method
 func (m *MockInterface) FeatureEnabled(name string, params map[string]string) (sdk.FeatureEnabledResponse, error) {
 	m.ctrl.T.Helper()
@@ -6854,6 +7228,78 @@ func (mr *MockInterfaceMockRecorder) VariableEncrypt(projectKey, varName, conten
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariableEncrypt", reflect.TypeOf((*MockInterface)(nil).VariableEncrypt), projectKey, varName, content)
 }
 
+// ProjectCalendarList mocks base method
+func (m *MockInterface) ProjectCalendarList(projectKey string) ([]sdk.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCalendarList indicates an expected call of ProjectCalendarList
+func (mr *MockInterfaceMockRecorder) ProjectCalendarList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarList", reflect.TypeOf((*MockInterface)(nil).ProjectCalendarList), projectKey)
+}
+
+// ProjectCalendarGet mocks base method
+func (m *MockInterface) ProjectCalendarGet(projectKey, name string) (*sdk.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarGet", projectKey, name)
+	ret0, _ := ret[0].(*sdk.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCalendarGet indicates an expected call of ProjectCalendarGet
+func (mr *MockInterfaceMockRecorder) ProjectCalendarGet(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarGet", reflect.TypeOf((*MockInterface)(nil).ProjectCalendarGet), projectKey, name)
+}
+
+// ProjectCalendarCreate mocks base method
+func (m *MockInterface) ProjectCalendarCreate(projectKey string, calendar *sdk.ProjectCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarCreate", projectKey, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarCreate indicates an expected call of ProjectCalendarCreate
+func (mr *MockInterfaceMockRecorder) ProjectCalendarCreate(projectKey, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarCreate", reflect.TypeOf((*MockInterface)(nil).ProjectCalendarCreate), projectKey, calendar)
+}
+
+// ProjectCalendarUpdate mocks base method
+func (m *MockInterface) ProjectCalendarUpdate(projectKey string, calendar *sdk.ProjectCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarUpdate", projectKey, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarUpdate indicates an expected call of ProjectCalendarUpdate
+func (mr *MockInterfaceMockRecorder) ProjectCalendarUpdate(projectKey, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectCalendarUpdate), projectKey, calendar)
+}
+
+// ProjectCalendarDelete mocks base method
+func (m *MockInterface) ProjectCalendarDelete(projectKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCalendarDelete", projectKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectCalendarDelete indicates an expected call of ProjectCalendarDelete
+func (mr *MockInterfaceMockRecorder) ProjectCalendarDelete(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCalendarDelete", reflect.TypeOf((*MockInterface)(nil).ProjectCalendarDelete), projectKey, name)
+}
+
 // ProjectGroupsImport mocks base method
 func (m *MockInterface) ProjectGroupsImport(projectKey string, content io.Reader, mods ...cdsclient.RequestModifier) (sdk.Project, error) {
 	m.ctrl.T.Helper()
@@ -7059,6 +7505,20 @@ func (mr *MockInterfaceMockRecorder) QueueJobRelease(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRelease", reflect.TypeOf((*MockInterface)(nil).QueueJobRelease), ctx, id)
 }
 
+// QueueJobAssign mocks base method
+func (m *MockInterface) QueueJobAssign(ctx context.Context, id int64, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobAssign", ctx, id, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobAssign indicates an expected call of QueueJobAssign
+func (mr *MockInterfaceMockRecorder) QueueJobAssign(ctx, id, workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobAssign", reflect.TypeOf((*MockInterface)(nil).QueueJobAssign), ctx, id, workerID)
+}
+
 // QueueJobInfo mocks base method
 func (m *MockInterface) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -7446,6 +7906,21 @@ func (mr *MockInterfaceMockRecorder) WorkerGet(ctx, name interface{}, mods ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerGet", reflect.TypeOf((*MockInterface)(nil).WorkerGet), varargs...)
 }
 
+// WorkerAssignment mocks base method
+func (m *MockInterface) WorkerAssignment(ctx context.Context) (*sdk.Worker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerAssignment", ctx)
+	ret0, _ := ret[0].(*sdk.Worker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerAssignment indicates an expected call of WorkerAssignment
+func (mr *MockInterfaceMockRecorder) WorkerAssignment(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerAssignment", reflect.TypeOf((*MockInterface)(nil).WorkerAssignment), ctx)
+}
+
 // WorkerModelBook mocks base method
 func (m *MockInterface) WorkerModelBook(groupName, name string) error {
 	m.ctrl.T.Helper()
@@ -7902,6 +8377,21 @@ func (mr *MockInterfaceMockRecorder) WorkflowRunArtifacts(projectKey, name, numb
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockInterface)(nil).WorkflowRunArtifacts), projectKey, name, number)
 }
 
+// WorkflowRunOutgoingHookDeliveries mocks base method
+func (m *MockInterface) WorkflowRunOutgoingHookDeliveries(projectKey, workflowName string, number int64) ([]sdk.OutgoingHookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunOutgoingHookDeliveries", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.OutgoingHookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunOutgoingHookDeliveries indicates an expected call of WorkflowRunOutgoingHookDeliveries
+func (mr *MockInterfaceMockRecorder) WorkflowRunOutgoingHookDeliveries(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunOutgoingHookDeliveries", reflect.TypeOf((*MockInterface)(nil).WorkflowRunOutgoingHookDeliveries), projectKey, workflowName, number)
+}
+
 // WorkflowRunFromHook mocks base method
 func (m *MockInterface) WorkflowRunFromHook(projectKey, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -7917,6 +8407,21 @@ func (mr *MockInterfaceMockRecorder) WorkflowRunFromHook(projectKey, workflowNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromHook", reflect.TypeOf((*MockInterface)(nil).WorkflowRunFromHook), projectKey, workflowName, hook)
 }
 
+// WorkflowHookCommand mocks base method
+func (m *MockInterface) WorkflowHookCommand(projectKey, workflowName, hookUUID string, cmd sdk.HookCommentCommand) (*sdk.HookCommentCommandResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowHookCommand", projectKey, workflowName, hookUUID, cmd)
+	ret0, _ := ret[0].(*sdk.HookCommentCommandResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowHookCommand indicates an expected call of WorkflowHookCommand
+func (mr *MockInterfaceMockRecorder) WorkflowHookCommand(projectKey, workflowName, hookUUID, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowHookCommand", reflect.TypeOf((*MockInterface)(nil).WorkflowHookCommand), projectKey, workflowName, hookUUID, cmd)
+}
+
 // WorkflowRunFromManual mocks base method
 func (m *MockInterface) WorkflowRunFromManual(projectKey, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -8109,6 +8614,21 @@ func (mr *MockInterfaceMockRecorder) WorkflowLogDownload(ctx, link interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLogDownload", reflect.TypeOf((*MockInterface)(nil).WorkflowLogDownload), ctx, link)
 }
 
+// WorkflowLogSearch mocks base method
+func (m *MockInterface) WorkflowLogSearch(ctx context.Context, opts sdk.CDNLogSearchOptions) ([]sdk.CDNLogSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowLogSearch", ctx, opts)
+	ret0, _ := ret[0].([]sdk.CDNLogSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowLogSearch indicates an expected call of WorkflowLogSearch
+func (mr *MockInterfaceMockRecorder) WorkflowLogSearch(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowLogSearch", reflect.TypeOf((*MockInterface)(nil).WorkflowLogSearch), ctx, opts)
+}
+
 // WorkflowNodeRunRelease mocks base method
 func (m *MockInterface) WorkflowNodeRunRelease(projectKey, workflowName string, runNumber, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	m.ctrl.T.Helper()
@@ -8258,6 +8778,21 @@ func (mr *MockInterfaceMockRecorder) PollVCSEvents(uuid, workflowID, vcsServer,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PollVCSEvents", reflect.TypeOf((*MockInterface)(nil).PollVCSEvents), uuid, workflowID, vcsServer, timestamp)
 }
 
+// HookCommitsBetweenRefs mocks base method
+func (m *MockInterface) HookCommitsBetweenRefs(uuid, base, head string) ([]sdk.VCSCommit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HookCommitsBetweenRefs", uuid, base, head)
+	ret0, _ := ret[0].([]sdk.VCSCommit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HookCommitsBetweenRefs indicates an expected call of HookCommitsBetweenRefs
+func (mr *MockInterfaceMockRecorder) HookCommitsBetweenRefs(uuid, base, head interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookCommitsBetweenRefs", reflect.TypeOf((*MockInterface)(nil).HookCommitsBetweenRefs), uuid, base, head)
+}
+
 // VCSConfiguration mocks base method
 func (m *MockInterface) VCSConfiguration() (map[string]sdk.VCSConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -8437,6 +8972,36 @@ func (mr *MockInterfaceMockRecorder) TemplateDeleteInstance(groupName, templateS
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateDeleteInstance", reflect.TypeOf((*MockInterface)(nil).TemplateDeleteInstance), groupName, templateSlug, id)
 }
 
+// TemplatePinInstance mocks base method
+func (m *MockInterface) TemplatePinInstance(groupName, templateSlug string, id, version int64) (*sdk.WorkflowTemplateInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplatePinInstance", groupName, templateSlug, id, version)
+	ret0, _ := ret[0].(*sdk.WorkflowTemplateInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplatePinInstance indicates an expected call of TemplatePinInstance
+func (mr *MockInterfaceMockRecorder) TemplatePinInstance(groupName, templateSlug, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplatePinInstance", reflect.TypeOf((*MockInterface)(nil).TemplatePinInstance), groupName, templateSlug, id, version)
+}
+
+// TemplateGetUpgrades mocks base method
+func (m *MockInterface) TemplateGetUpgrades(groupName, templateSlug string, version int64) ([]sdk.WorkflowTemplateInstanceUpgrade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateGetUpgrades", groupName, templateSlug, version)
+	ret0, _ := ret[0].([]sdk.WorkflowTemplateInstanceUpgrade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateGetUpgrades indicates an expected call of TemplateGetUpgrades
+func (mr *MockInterfaceMockRecorder) TemplateGetUpgrades(groupName, templateSlug, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateGetUpgrades", reflect.TypeOf((*MockInterface)(nil).TemplateGetUpgrades), groupName, templateSlug, version)
+}
+
 // RequestWebsocket mocks base method
 func (m *MockInterface) RequestWebsocket(ctx context.Context, goRoutines *sdk.GoRoutines, path string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
 	m.ctrl.T.Helper()
@@ -8474,6 +9039,50 @@ func (m *MockWorkerInterface) EXPECT() *MockWorkerInterfaceMockRecorder {
 	return m.recorder
 }
 
+// CDNArtifactUpload mocks base method
+func (m *MockWorkerInterface) CDNArtifactUpload(ctx context.Context, cdnAddr, signature, tag, filePath string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CDNArtifactUpload", ctx, cdnAddr, signature, tag, filePath)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CDNArtifactUpload indicates an expected call of CDNArtifactUpload
+func (mr *MockWorkerInterfaceMockRecorder) CDNArtifactUpload(ctx, cdnAddr, signature, tag, filePath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNArtifactUpload", reflect.TypeOf((*MockWorkerInterface)(nil).CDNArtifactUpload), ctx, cdnAddr, signature, tag, filePath)
+}
+
+// CDNArtifactList mocks base method
+func (m *MockWorkerInterface) CDNArtifactList(ctx context.Context, cdnAddr, signature string, runID int64) ([]sdk.CDNItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CDNArtifactList", ctx, cdnAddr, signature, runID)
+	ret0, _ := ret[0].([]sdk.CDNItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CDNArtifactList indicates an expected call of CDNArtifactList
+func (mr *MockWorkerInterfaceMockRecorder) CDNArtifactList(ctx, cdnAddr, signature, runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNArtifactList", reflect.TypeOf((*MockWorkerInterface)(nil).CDNArtifactList), ctx, cdnAddr, signature, runID)
+}
+
+// CDNArtifactDownload mocks base method
+func (m *MockWorkerInterface) CDNArtifactDownload(ctx context.Context, cdnAddr, signature, apiRef string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CDNArtifactDownload", ctx, cdnAddr, signature, apiRef, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CDNArtifactDownload indicates an expected call of CDNArtifactDownload
+func (mr *MockWorkerInterfaceMockRecorder) CDNArtifactDownload(ctx, cdnAddr, signature, apiRef, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNArtifactDownload", reflect.TypeOf((*MockWorkerInterface)(nil).CDNArtifactDownload), ctx, cdnAddr, signature, apiRef, w)
+}
+
 // PluginsList mocks base method
 func (m *MockWorkerInterface) PluginsList() ([]sdk.GRPCPlugin, error) {
 	m.ctrl.T.Helper()
@@ -8710,6 +9319,20 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueJobRelease(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRelease", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobRelease), ctx, id)
 }
 
+// QueueJobAssign mocks base method
+func (m *MockWorkerInterface) QueueJobAssign(ctx context.Context, id int64, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobAssign", ctx, id, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueJobAssign indicates an expected call of QueueJobAssign
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobAssign(ctx, id, workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobAssign", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobAssign), ctx, id, workerID)
+}
+
 // QueueJobInfo mocks base method
 func (m *MockWorkerInterface) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -8948,6 +9571,21 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkerGet(ctx, name interface{}, mods
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerGet", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerGet), varargs...)
 }
 
+// WorkerAssignment mocks base method
+func (m *MockWorkerInterface) WorkerAssignment(ctx context.Context) (*sdk.Worker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerAssignment", ctx)
+	ret0, _ := ret[0].(*sdk.Worker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerAssignment indicates an expected call of WorkerAssignment
+func (mr *MockWorkerInterfaceMockRecorder) WorkerAssignment(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerAssignment", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerAssignment), ctx)
+}
+
 // WorkerModelBook mocks base method
 func (m *MockWorkerInterface) WorkerModelBook(groupName, name string) error {
 	m.ctrl.T.Helper()
@@ -9196,6 +9834,21 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkflowCachePull(projectKey, integra
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCachePull", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowCachePull), projectKey, integrationName, ref)
 }
 
+// WorkflowRunGet mocks base method
+func (m *MockWorkerInterface) WorkflowRunGet(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunGet", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunGet indicates an expected call of WorkflowRunGet
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowRunGet(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunGet", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowRunGet), projectKey, workflowName, number)
+}
+
 // WorkflowRunList mocks base method
 func (m *MockWorkerInterface) WorkflowRunList(projectKey, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -9428,6 +10081,73 @@ func (mr *MockRawMockRecorder) HTTPWebsocketClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTTPWebsocketClient", reflect.TypeOf((*MockRaw)(nil).HTTPWebsocketClient))
 }
 
+// MockCDNClient is a mock of CDNClient interface
+type MockCDNClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCDNClientMockRecorder
+}
+
+// MockCDNClientMockRecorder is the mock recorder for MockCDNClient
+type MockCDNClientMockRecorder struct {
+	mock *MockCDNClient
+}
+
+// NewMockCDNClient creates a new mock instance
+func NewMockCDNClient(ctrl *gomock.Controller) *MockCDNClient {
+	mock := &MockCDNClient{ctrl: ctrl}
+	mock.recorder = &MockCDNClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCDNClient) EXPECT() *MockCDNClientMockRecorder {
+	return m.recorder
+}
+
+// CDNArtifactUpload mocks base method
+func (m *MockCDNClient) CDNArtifactUpload(ctx context.Context, cdnAddr, signature, tag, filePath string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CDNArtifactUpload", ctx, cdnAddr, signature, tag, filePath)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CDNArtifactUpload indicates an expected call of CDNArtifactUpload
+func (mr *MockCDNClientMockRecorder) CDNArtifactUpload(ctx, cdnAddr, signature, tag, filePath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNArtifactUpload", reflect.TypeOf((*MockCDNClient)(nil).CDNArtifactUpload), ctx, cdnAddr, signature, tag, filePath)
+}
+
+// CDNArtifactList mocks base method
+func (m *MockCDNClient) CDNArtifactList(ctx context.Context, cdnAddr, signature string, runID int64) ([]sdk.CDNItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CDNArtifactList", ctx, cdnAddr, signature, runID)
+	ret0, _ := ret[0].([]sdk.CDNItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CDNArtifactList indicates an expected call of CDNArtifactList
+func (mr *MockCDNClientMockRecorder) CDNArtifactList(ctx, cdnAddr, signature, runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNArtifactList", reflect.TypeOf((*MockCDNClient)(nil).CDNArtifactList), ctx, cdnAddr, signature, runID)
+}
+
+// CDNArtifactDownload mocks base method
+func (m *MockCDNClient) CDNArtifactDownload(ctx context.Context, cdnAddr, signature, apiRef string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CDNArtifactDownload", ctx, cdnAddr, signature, apiRef, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CDNArtifactDownload indicates an expected call of CDNArtifactDownload
+func (mr *MockCDNClientMockRecorder) CDNArtifactDownload(ctx, cdnAddr, signature, apiRef, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNArtifactDownload", reflect.TypeOf((*MockCDNClient)(nil).CDNArtifactDownload), ctx, cdnAddr, signature, apiRef, w)
+}
+
 // MockGRPCPluginsClient is a mock of GRPCPluginsClient interface
 type MockGRPCPluginsClient struct {
 	ctrl     *gomock.Controller
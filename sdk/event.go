@@ -90,6 +90,9 @@ type GerritChangeEvent struct {
 	Revision   string `json:"revision,omitempty"`
 	Report     string `json:"report,omitempty"`
 	URL        string `json:"url,omitempty"`
+	// Labels and Comments are the review configured on the workflow, default labels are used if empty
+	Labels   map[string]string `json:"labels,omitempty"`
+	Comments []GerritComment   `json:"comments,omitempty"`
 }
 
 // EventRunWorkflowOutgoingHook contains event data for a workflow outgoing hook run
@@ -18,6 +18,7 @@ type Application struct {
 	VCSUser              string                              `json:"vcs_user,omitempty" yaml:"vcs_user,omitempty"`
 	VCSPassword          string                              `json:"vcs_password,omitempty" yaml:"vcs_password,omitempty"`
 	VCSPGPKey            string                              `json:"vcs_pgp_key,omitempty" yaml:"vcs_pgp_key,omitempty" jsonschema_description:"Name of the pgp key, ex: proj-my-pgp-key. Will be used to tag for example."`
+	VCSMergeResult       bool                                `json:"vcs_merge_result,omitempty" yaml:"vcs_merge_result,omitempty" jsonschema_description:"Build the result of the merge of pull requests in their target branch instead of their head."`
 	DeploymentStrategies map[string]map[string]VariableValue `json:"deployments,omitempty" yaml:"deployments,omitempty"`
 }
 
@@ -83,6 +84,7 @@ func NewApplication(app sdk.Application, keys []EncryptedKey) (a Application, er
 		a.VCSConnectionType = app.RepositoryStrategy.ConnectionType
 	}
 	a.VCSPGPKey = app.RepositoryStrategy.PGPKey
+	a.VCSMergeResult = app.RepositoryStrategy.MergeResult
 
 	a.DeploymentStrategies = make(map[string]map[string]VariableValue, len(app.DeploymentStrategies))
 	for name, config := range app.DeploymentStrategies {
@@ -141,3 +141,30 @@ func OpenPath(path string) (io.ReadCloser, Format, error) {
 
 	return contentFile, format, nil
 }
+
+// newTimeout returns the given timeout in seconds as a duration string, ie: "1h30m".
+func newTimeout(seconds int64) string {
+	if seconds <= 0 {
+		return ""
+	}
+	s := (time.Duration(seconds) * time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// computeTimeout returns the given duration string in seconds, 0 if not set.
+func computeTimeout(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Second {
+		return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid timeout %q, should be a duration like 30s, 10m or 1h30m", s)
+	}
+	return int64(d.Round(time.Second) / time.Second), nil
+}
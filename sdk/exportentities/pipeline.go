@@ -47,6 +47,15 @@ type Job struct {
 	Requirements   []Requirement `json:"requirements,omitempty" yaml:"requirements,omitempty" jsonschema_description:"The list of requirements for the jobs."`
 	Optional       *bool         `json:"optional,omitempty" yaml:"optional,omitempty" jsonschema_description:"Set this option to ignore job's errors."`
 	AlwaysExecuted *bool         `json:"always_executed,omitempty" yaml:"always_executed,omitempty" jsonschema_description:"Set this option to execute the job even if a previous step failed."`
+	Matrix         *Matrix       `json:"matrix,omitempty" yaml:"matrix,omitempty" jsonschema_description:"Run the job once for each combination of the matrix axes values."`
+	Timeout        string        `json:"timeout,omitempty" yaml:"timeout,omitempty" jsonschema_description:"The maximum duration of the job, ie: 30m or 1h30m. The job fails when it is exceeded."`
+}
+
+// Matrix represents exported sdk.JobMatrix
+type Matrix struct {
+	Axes    map[string][]string `json:"axes,omitempty" yaml:"axes,omitempty" jsonschema_description:"The list of values by axis name, available in the job as {{.cds.matrix.<axis>}}."`
+	Exclude []map[string]string `json:"exclude,omitempty" yaml:"exclude,omitempty" jsonschema_description:"The combinations of axes values to exclude."`
+	Include []map[string]string `json:"include,omitempty" yaml:"include,omitempty" jsonschema_description:"The values to add to the matching combinations, or the combinations to add."`
 }
 
 // Requirement represents an exported sdk.Requirement
@@ -57,6 +66,7 @@ type Requirement struct {
 	Plugin            string             `json:"plugin,omitempty" yaml:"plugin,omitempty"`
 	Service           ServiceRequirement `json:"service,omitempty" yaml:"service,omitempty"`
 	Memory            string             `json:"memory,omitempty" yaml:"memory,omitempty"`
+	CPU               string             `json:"cpu,omitempty" yaml:"cpu,omitempty"`
 	OSArchRequirement string             `json:"os-architecture,omitempty" yaml:"os-architecture,omitempty"`
 	RegionRequirement string             `json:"region,omitempty" yaml:"region,omitempty"`
 }
@@ -152,6 +162,8 @@ func newRequirements(req []sdk.Requirement) []Requirement {
 			res = append(res, Requirement{RegionRequirement: r.Value})
 		case sdk.MemoryRequirement:
 			res = append(res, Requirement{Memory: r.Value})
+		case sdk.CPURequirement:
+			res = append(res, Requirement{CPU: r.Value})
 		}
 	}
 	return res
@@ -165,6 +177,16 @@ func newJob(j sdk.Job) Job {
 	jo.Steps = newSteps(j.Action)
 	jo.Description = j.Action.Description
 	jo.Requirements = newRequirements(j.Action.Requirements)
+	if j.Matrix != nil {
+		jo.Matrix = &Matrix{Axes: j.Matrix.Axes}
+		for _, e := range j.Matrix.Exclude {
+			jo.Matrix.Exclude = append(jo.Matrix.Exclude, e)
+		}
+		for _, i := range j.Matrix.Include {
+			jo.Matrix.Include = append(jo.Matrix.Include, i)
+		}
+	}
+	jo.Timeout = newTimeout(j.Timeout)
 	return jo
 }
 
@@ -209,6 +231,10 @@ func computeJobRequirements(req []Requirement) []sdk.Requirement {
 			name = "memory"
 			val = r.Memory
 			tpe = sdk.MemoryRequirement
+		} else if r.CPU != "" {
+			name = "cpu"
+			val = r.CPU
+			tpe = sdk.CPURequirement
 		} else if r.Model != "" {
 			name = "model"
 			val = r.Model
@@ -262,6 +288,24 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 	}
 	job.Action.Actions = children
 
+	job.Timeout, err = computeTimeout(j.Timeout)
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid job %s: %s", name, sdk.ExtractHTTPError(err, "").From)
+	}
+
+	if j.Matrix != nil {
+		job.Matrix = &sdk.JobMatrix{Axes: j.Matrix.Axes}
+		for _, e := range j.Matrix.Exclude {
+			job.Matrix.Exclude = append(job.Matrix.Exclude, e)
+		}
+		for _, i := range j.Matrix.Include {
+			job.Matrix.Include = append(job.Matrix.Include, i)
+		}
+		if err := job.Matrix.IsValid(); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid matrix for job %s: %s", name, sdk.ExtractHTTPError(err, "").From)
+		}
+	}
+
 	return &job, nil
 }
 
@@ -777,3 +777,90 @@ func TestExportAndImportPipelineV1_YAML(t *testing.T) {
 		}
 	}
 }
+
+func Test_ImportPipelineWithMatrix(t *testing.T) {
+	in := `version: v1.0
+name: build
+jobs:
+- job: Test
+  matrix:
+    axes:
+      go: ["1.14", "1.15"]
+      os: [linux, darwin]
+    exclude:
+    - go: "1.14"
+      os: darwin
+    include:
+    - go: "1.16"
+      os: linux
+  requirements:
+  - os-architecture: '{{.cds.matrix.os}}/amd64'
+  steps:
+  - script:
+    - go test ./...
+`
+
+	payload := &exportentities.PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+	assert.Len(t, p.Stages, 1)
+	assert.Len(t, p.Stages[0].Jobs, 1)
+	job := p.Stages[0].Jobs[0]
+	assert.Equal(t, &sdk.JobMatrix{
+		Axes:    map[string][]string{"go": {"1.14", "1.15"}, "os": {"linux", "darwin"}},
+		Exclude: []sdk.JobMatrixValues{{"go": "1.14", "os": "darwin"}},
+		Include: []sdk.JobMatrixValues{{"go": "1.16", "os": "linux"}},
+	}, job.Matrix)
+
+	// Export again the pipeline
+	exported := exportentities.NewPipelineV1(*p)
+	btes, err := yaml.Marshal(exported)
+	test.NoError(t, err)
+	assert.Contains(t, string(btes), "matrix:")
+	assert.Equal(t, payload.Jobs[0].Matrix, exported.Jobs[0].Matrix)
+
+	// Invalid matrix
+	payload.Jobs[0].Matrix.Exclude = append(payload.Jobs[0].Matrix.Exclude, map[string]string{"arch": "arm"})
+	_, err = payload.Pipeline()
+	assert.Error(t, err)
+	assert.Contains(t, sdk.ExtractHTTPError(err, "").Error(), `unknown axis "arch"`)
+}
+
+func Test_ImportPipelineWithTimeouts(t *testing.T) {
+	in := `version: v1.0
+name: build
+jobs:
+- job: Test
+  timeout: 1h30m
+  steps:
+  - script:
+    - make test
+    timeout: 10m
+  - script:
+    - make lint
+`
+
+	payload := &exportentities.PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+	job := p.Stages[0].Jobs[0]
+	assert.Equal(t, int64(5400), job.Timeout)
+	assert.Equal(t, int64(600), job.Action.Actions[0].Timeout)
+	assert.Equal(t, int64(0), job.Action.Actions[1].Timeout)
+
+	// Export again the pipeline
+	exported := exportentities.NewPipelineV1(*p)
+	assert.Equal(t, "1h30m", exported.Jobs[0].Timeout)
+	assert.Equal(t, "10m", exported.Jobs[0].Steps[0].Timeout)
+	assert.Equal(t, "", exported.Jobs[0].Steps[1].Timeout)
+
+	// Invalid timeout
+	payload.Jobs[0].Steps[1].Timeout = "ten minutes"
+	_, err = payload.Pipeline()
+	assert.Error(t, err)
+	assert.Contains(t, sdk.ExtractHTTPError(err, "").Error(), `invalid timeout "ten minutes"`)
+}
@@ -26,6 +26,7 @@ func newStep(act sdk.Action) Step {
 	if act.AlwaysExecuted {
 		s.AlwaysExecuted = &sdk.True
 	}
+	s.Timeout = newTimeout(act.Timeout)
 
 	switch act.Type {
 	case sdk.BuiltinAction:
@@ -326,6 +327,7 @@ type Step struct {
 	Enabled        *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
 	Optional       *bool  `json:"optional,omitempty" yaml:"optional,omitempty"`
 	AlwaysExecuted *bool  `json:"always_executed,omitempty" yaml:"always_executed,omitempty"`
+	Timeout        string `json:"timeout,omitempty" yaml:"timeout,omitempty" jsonschema_description:"The maximum duration of the step, ie: 10m. The step fails when it is exceeded."`
 	// step specific data, only one option should be set
 	StepCustom       `json:"-" yaml:",inline"`
 	Script           interface{}           `json:"script,omitempty" yaml:"script,omitempty" jsonschema:"oneof_type=string;array,oneof_required=actionScript" jsonschema_description:"Script.\nhttps://ovh.github.io/cds/docs/actions/builtin-script"`
@@ -506,6 +508,10 @@ func (s Step) toAction() (*sdk.Action, error) {
 	a.Enabled = s.Enabled == nil || *s.Enabled == sdk.True // enabled is true by default
 	a.Optional = s.Optional != nil && *s.Optional == sdk.True
 	a.AlwaysExecuted = s.AlwaysExecuted != nil && *s.AlwaysExecuted == sdk.True
+	a.Timeout, err = computeTimeout(s.Timeout)
+	if err != nil {
+		return nil, err
+	}
 
 	return &a, nil
 }
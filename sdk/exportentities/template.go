@@ -30,9 +30,13 @@ type Template struct {
 
 // TemplateParameter is the "as code" representation of a sdk.TemplateParameter.
 type TemplateParameter struct {
-	Key      string `json:"key" yaml:"key"`
-	Type     string `json:"type" yaml:"type"`
-	Required bool   `json:"required" yaml:"required"`
+	Key      string   `json:"key" yaml:"key"`
+	Type     string   `json:"type" yaml:"type"`
+	Required bool     `json:"required" yaml:"required"`
+	Values   []string `json:"values,omitempty" yaml:"values,omitempty"`
+	Min      *float64 `json:"min,omitempty" yaml:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty" yaml:"max,omitempty"`
+	Pattern  string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 }
 
 // Name pattern for template files.
@@ -61,6 +65,10 @@ func NewTemplate(wt sdk.WorkflowTemplate) (Template, error) {
 		exportedTemplate.Parameters[i].Key = p.Key
 		exportedTemplate.Parameters[i].Type = string(p.Type)
 		exportedTemplate.Parameters[i].Required = p.Required
+		exportedTemplate.Parameters[i].Values = p.Values
+		exportedTemplate.Parameters[i].Min = p.Min
+		exportedTemplate.Parameters[i].Max = p.Max
+		exportedTemplate.Parameters[i].Pattern = p.Pattern
 	}
 
 	for i := range wt.Pipelines {
@@ -96,6 +104,10 @@ func (w Template) GetTemplate(wkf []byte, pips, apps, envs [][]byte) sdk.Workflo
 			Key:      p.Key,
 			Type:     sdk.TemplateParameterType(p.Type),
 			Required: p.Required,
+			Values:   p.Values,
+			Min:      p.Min,
+			Max:      p.Max,
+			Pattern:  p.Pattern,
 		})
 	}
 
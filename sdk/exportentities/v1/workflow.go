@@ -361,7 +361,7 @@ func (w *Workflow) processHooks(n *sdk.Node, wf *sdk.Workflow) {
 			for k, v := range h.Config {
 				var hType string
 				switch h.Model {
-				case sdk.KafkaHookModelName, sdk.RabbitMQHookModelName:
+				case sdk.KafkaHookModelName, sdk.RabbitMQHookModelName, sdk.NATSHookModelName, sdk.RedisStreamHookModelName:
 					if k == sdk.HookModelIntegration {
 						hType = sdk.HookConfigTypeIntegration
 					} else {
@@ -83,6 +83,7 @@ func (h HookEntry) IsDefault(model sdk.WorkflowHookModel) bool {
 					v != strings.Join(sdk.BitbucketCloudEventsDefault, ";") &&
 					v != strings.Join(sdk.BitbucketEventsDefault, ";") &&
 					v != strings.Join(sdk.GitHubEventsDefault, ";") &&
+					v != strings.Join(sdk.GiteaEventsDefault, ";") &&
 					v != strings.Join(sdk.GitlabEventsDefault, ";") &&
 					v != strings.Join(sdk.GerritEventsDefault, ";") {
 					return false
@@ -721,7 +722,7 @@ func (w *Workflow) processHooks(n *sdk.Node, wf *sdk.Workflow) {
 			for k, v := range h.Config {
 				var hType string
 				switch h.Model {
-				case sdk.KafkaHookModelName, sdk.RabbitMQHookModelName:
+				case sdk.KafkaHookModelName, sdk.RabbitMQHookModelName, sdk.NATSHookModelName, sdk.RedisStreamHookModelName:
 					if k == sdk.HookModelIntegration {
 						hType = sdk.HookConfigTypeIntegration
 					} else {
@@ -1,7 +1,14 @@
 package exportentities
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
 
 	"github.com/ovh/cds/sdk"
 	v1 "github.com/ovh/cds/sdk/exportentities/v1"
@@ -71,6 +78,76 @@ func UnmarshalWorkflow(body []byte, format Format) (Workflow, error) {
 	return nil, sdk.WrapError(sdk.ErrWrongRequest, "invalid workflow version: %s", workflowVersion.Version)
 }
 
+var yamlErrorLineRegexp = regexp.MustCompile(`line ([0-9]+): (.*)`)
+
+// CheckWorkflowFile checks the syntax of an as code file given its name, template instance files are not checked.
+// The line of the error is returned if it's known.
+func CheckWorkflowFile(name string, b []byte) (int, error) {
+	format, err := GetFormatFromPath(name)
+	if err != nil {
+		return 0, err
+	}
+
+	var i interface{}
+	switch {
+	case strings.Contains(name, ".app."):
+		i = &Application{}
+	case strings.Contains(name, ".pip."):
+		i = &PipelineV1{}
+	case strings.Contains(name, ".env."):
+		i = &Environment{}
+	default:
+		var tmp TemplateInstance
+		if UnmarshalStrict(b, format, &tmp) == nil && tmp.From != "" {
+			return 0, nil
+		}
+		var workflowVersion WorkflowVersion
+		if err := decodeWorkflowFile(b, format, &workflowVersion); err != nil {
+			return syntaxError(b, err)
+		}
+		switch workflowVersion.Version {
+		case WorkflowVersion1:
+			i = &v1.Workflow{}
+		case WorkflowVersion2:
+			i = &v2.Workflow{}
+		default:
+			return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid workflow version: %s", workflowVersion.Version)
+		}
+	}
+
+	if err := decodeWorkflowFile(b, format, i); err != nil {
+		return syntaxError(b, err)
+	}
+	return 0, nil
+}
+
+func decodeWorkflowFile(b []byte, format Format, i interface{}) error {
+	if format == FormatJSON {
+		return json.Unmarshal(b, i)
+	}
+	return yaml.Unmarshal(b, i)
+}
+
+// syntaxError returns the line and the message of a yaml or json decoding error.
+func syntaxError(b []byte, err error) (int, error) {
+	var offset int64 = -1
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *json.UnmarshalTypeError:
+		offset = e.Offset
+	}
+	if offset >= 0 && offset <= int64(len(b)) {
+		return bytes.Count(b[:offset], []byte("\n")) + 1, sdk.NewErrorFrom(sdk.ErrWrongRequest, "%s", err.Error())
+	}
+
+	if m := yamlErrorLineRegexp.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line, sdk.NewErrorFrom(sdk.ErrWrongRequest, "%s", m[2])
+	}
+	return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "%s", strings.TrimPrefix(err.Error(), "yaml: "))
+}
+
 func ParseWorkflow(exportWorkflow Workflow) (*sdk.Workflow, error) {
 	switch exportWorkflow.GetVersion() {
 	case WorkflowVersion2:
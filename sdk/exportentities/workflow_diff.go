@@ -0,0 +1,94 @@
+package exportentities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Files returns the yaml files of the workflow components indexed by file name, as they are stored in a tar.
+func (w WorkflowComponents) Files() (map[string]string, error) {
+	files := make(map[string]string, 1+len(w.Applications)+len(w.Pipelines)+len(w.Environments))
+	add := func(name string, v interface{}) error {
+		bs, err := yaml.Marshal(v)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		files[name] = string(bs)
+		return nil
+	}
+
+	if w.Workflow != nil {
+		if err := add(fmt.Sprintf(PullWorkflowName, w.Workflow.GetName()), w.Workflow); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range w.Applications {
+		if err := add(fmt.Sprintf(PullApplicationName, a.Name), a); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range w.Environments {
+		if err := add(fmt.Sprintf(PullEnvironmentName, e.Name), e); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range w.Pipelines {
+		if err := add(fmt.Sprintf(PullPipelineName, p.Name), p); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// DiffWorkflowComponents returns the unified diff between the files of two workflow components,
+// an empty string is returned if there is no difference.
+func DiffWorkflowComponents(from, to WorkflowComponents) (string, error) {
+	fromFiles, err := from.Files()
+	if err != nil {
+		return "", err
+	}
+	toFiles, err := to.Files()
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(fromFiles)+len(toFiles))
+	for name := range fromFiles {
+		names = append(names, name)
+	}
+	for name := range toFiles {
+		if _, ok := fromFiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diff strings.Builder
+	for _, name := range names {
+		fromName, toName := "a/"+name, "b/"+name
+		if _, ok := fromFiles[name]; !ok {
+			fromName = "/dev/null"
+		}
+		if _, ok := toFiles[name]; !ok {
+			toName = "/dev/null"
+		}
+		d, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(fromFiles[name]),
+			B:        difflib.SplitLines(toFiles[name]),
+			FromFile: fromName,
+			ToFile:   toName,
+			Context:  3,
+		})
+		if err != nil {
+			return "", sdk.WithStack(err)
+		}
+		diff.WriteString(d)
+	}
+	return diff.String(), nil
+}
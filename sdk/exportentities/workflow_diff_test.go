@@ -0,0 +1,35 @@
+package exportentities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestDiffWorkflowComponents(t *testing.T) {
+	from := exportentities.WorkflowComponents{
+		Pipelines: []exportentities.PipelineV1{
+			{Name: "build", Stages: []string{"Compile"}},
+			{Name: "deploy"},
+		},
+	}
+	to := exportentities.WorkflowComponents{
+		Pipelines: []exportentities.PipelineV1{
+			{Name: "build", Stages: []string{"Compile", "Test"}},
+		},
+		Environments: []exportentities.Environment{{Name: "prod"}},
+	}
+
+	diff, err := exportentities.DiffWorkflowComponents(from, from)
+	require.NoError(t, err)
+	require.Equal(t, "", diff)
+
+	diff, err = exportentities.DiffWorkflowComponents(from, to)
+	require.NoError(t, err)
+	require.Contains(t, diff, "--- a/build.pip.yml\n+++ b/build.pip.yml\n")
+	require.Contains(t, diff, "+- Test\n")
+	require.Contains(t, diff, "--- a/deploy.pip.yml\n+++ /dev/null\n")
+	require.Contains(t, diff, "--- /dev/null\n+++ b/prod.env.yml\n")
+}
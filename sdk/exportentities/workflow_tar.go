@@ -169,6 +169,11 @@ func TarWorkflowComponents(ctx context.Context, w WorkflowComponents, writer io.
 	return nil
 }
 
+// IsWorkflowDependencyFile returns true for application, pipeline and environment files.
+func IsWorkflowDependencyFile(fname string) bool {
+	return strings.Contains(fname, ".app.") || strings.Contains(fname, ".pip.") || strings.Contains(fname, ".env.")
+}
+
 func UntarWorkflowComponents(ctx context.Context, tr *tar.Reader) (WorkflowComponents, error) {
 	var res WorkflowComponents
 	mError := new(sdk.MultiError)
@@ -0,0 +1,77 @@
+package exportentities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestCheckWorkflowFile(t *testing.T) {
+	cases := []struct {
+		Name    string
+		File    string
+		Content string
+		Line    int
+		Error   string
+	}{
+		{
+			Name:    "valid workflow",
+			File:    ".cds/workflows/my-workflow.yml",
+			Content: "name: my-workflow\nversion: v2.0\npipeline: build",
+		},
+		{
+			Name:    "valid template instance",
+			File:    ".cds/my-workflow.yml",
+			Content: "name: my-workflow\nfrom: shared.infra/my-template\nparameters:\n  withDeploy: \"true\"",
+		},
+		{
+			Name:    "invalid yaml",
+			File:    ".cds/build.pip.yml",
+			Content: "version: v1.0\nname: build\n  stages:\n- Compile",
+			Line:    3,
+			Error:   "mapping values are not allowed in this context",
+		},
+		{
+			Name:    "invalid type",
+			File:    ".cds/build.pip.yml",
+			Content: "version: v1.0\nname: build\nstages: Compile",
+			Line:    3,
+			Error:   "cannot unmarshal !!str `Compile` into []string",
+		},
+		{
+			Name:    "invalid json",
+			File:    ".cds/my-app.app.json",
+			Content: "{\n\"version\": \"v1.0\",\n\"name\": my-app\n}",
+			Line:    3,
+			Error:   "invalid character 'm' looking for beginning of value",
+		},
+		{
+			Name:    "unknown workflow version",
+			File:    ".cds/my-workflow.yml",
+			Content: "name: my-workflow\nversion: v3.0",
+			Error:   "invalid workflow version: v3.0",
+		},
+		{
+			Name:  "unknown format",
+			File:  ".cds/README.md",
+			Error: "format is not supported",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			line, err := exportentities.CheckWorkflowFile(c.File, []byte(c.Content))
+			if c.Error == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, c.Line, line)
+			assert.Equal(t, c.Error, sdk.Cause(err).Error())
+		})
+	}
+}
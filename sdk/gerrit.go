@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ovh/venom"
+)
+
+// GerritMaxInlineComments is the maximum number of inline comments posted on a Gerrit change for a node run.
+const GerritMaxInlineComments = 50
+
+// GerritReviewSettings configures the review posted by CDS on Gerrit changes, it's part of the vcs notification.
+type GerritReviewSettings struct {
+	// Labels are the votes cast on the patchset, default is Verified +1 on success and -1 on failure
+	Labels []GerritLabelVote `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// InlineComments enables inline comments built from failed tests
+	InlineComments bool `json:"inline_comments,omitempty" yaml:"inline_comments,omitempty"`
+}
+
+// GerritLabelVote is the vote on a Gerrit label for a node run result.
+type GerritLabelVote struct {
+	Label     string `json:"label" yaml:"label"`
+	OnSuccess int    `json:"on_success,omitempty" yaml:"on_success,omitempty"`
+	OnFailure int    `json:"on_failure,omitempty" yaml:"on_failure,omitempty"`
+}
+
+var gerritLabelRegexp = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+// IsValid checks the Gerrit review settings.
+func (s GerritReviewSettings) IsValid() error {
+	labels := make(map[string]struct{}, len(s.Labels))
+	for _, l := range s.Labels {
+		if !gerritLabelRegexp.MatchString(l.Label) {
+			return NewErrorFrom(ErrWrongRequest, "invalid gerrit label %q", l.Label)
+		}
+		if _, has := labels[l.Label]; has {
+			return NewErrorFrom(ErrWrongRequest, "gerrit label %s is defined twice", l.Label)
+		}
+		labels[l.Label] = struct{}{}
+		if l.OnSuccess < -2 || l.OnSuccess > 2 || l.OnFailure < -2 || l.OnFailure > 2 {
+			return NewErrorFrom(ErrWrongRequest, "invalid votes on gerrit label %s, values must be between -2 and +2", l.Label)
+		}
+	}
+	return nil
+}
+
+// Votes returns the votes for given node run status, nil if no vote should be cast.
+func (s GerritReviewSettings) Votes(status string) map[string]string {
+	if len(s.Labels) == 0 {
+		return nil
+	}
+	votes := make(map[string]string, len(s.Labels))
+	for _, l := range s.Labels {
+		switch status {
+		case StatusSuccess:
+			votes[l.Label] = strconv.Itoa(l.OnSuccess)
+		case StatusFail, StatusStopped:
+			votes[l.Label] = strconv.Itoa(l.OnFailure)
+		default:
+			return nil
+		}
+	}
+	return votes
+}
+
+// GerritComment is an inline comment to post on a file of a Gerrit change.
+type GerritComment struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// gerritLocationRegexp matches file locations in test failures, ex: pkg/foo_test.go:42 or (Foo.java:12)
+var gerritLocationRegexp = regexp.MustCompile(`((?:[\w.-]+/)*[\w-][\w.-]*\.[A-Za-z][A-Za-z0-9]*):(\d+)`)
+
+// GerritCommentsFromTests builds inline comments from the failures of tests, at the locations found in
+// failure messages. Comments are not bound to the files of the change, see MatchGerritFile.
+func GerritCommentsFromTests(tests *venom.Tests) []GerritComment {
+	if tests == nil {
+		return nil
+	}
+	var comments []GerritComment
+	seen := make(map[string]struct{})
+	for _, ts := range tests.TestSuites {
+		for _, tc := range ts.TestCases {
+			failures := append(append([]venom.Failure{}, tc.Failures...), tc.Errors...)
+			for _, f := range failures {
+				text := strings.TrimSpace(f.Message + "\n" + f.Value)
+				message := fmt.Sprintf("Test %s failed", tc.Name)
+				if ts.Name != "" {
+					message = fmt.Sprintf("Test %s / %s failed", ts.Name, tc.Name)
+				}
+				if text != "" {
+					message += ":\n" + gerritExcerpt(text, 1000)
+				}
+				for _, m := range gerritLocationRegexp.FindAllStringSubmatch(text, -1) {
+					line, err := strconv.Atoi(m[2])
+					if err != nil || line <= 0 {
+						continue
+					}
+					path := strings.TrimPrefix(m[1], "./")
+					key := fmt.Sprintf("%s:%d:%s", path, line, tc.Name)
+					if _, has := seen[key]; has {
+						continue
+					}
+					seen[key] = struct{}{}
+					comments = append(comments, GerritComment{Path: path, Line: line, Message: message})
+					if len(comments) == GerritMaxInlineComments {
+						return comments
+					}
+				}
+			}
+		}
+	}
+	return comments
+}
+
+// MatchGerritFile returns the file of the change that matches the path found in a test failure.
+// The path can be absolute (workspace of the worker) or relative to a sub directory of the repository,
+// it should match only one file of the change.
+func MatchGerritFile(path string, files []string) (string, bool) {
+	var candidates []string
+	for _, f := range files {
+		if path == f {
+			return f, true
+		}
+		if strings.HasSuffix(path, "/"+f) || strings.HasSuffix(f, "/"+path) {
+			candidates = append(candidates, f)
+		}
+	}
+	if len(candidates) != 1 {
+		return "", false
+	}
+	return candidates[0], true
+}
+
+func gerritExcerpt(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	// Do not split a multi-byte character
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+	return s[:size] + "..."
+}
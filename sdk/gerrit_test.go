@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ovh/venom"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGerritReviewSettings(t *testing.T) {
+	s := GerritReviewSettings{Labels: []GerritLabelVote{
+		{Label: "Verified", OnSuccess: 1, OnFailure: -1},
+		{Label: "Code-Style", OnSuccess: 0, OnFailure: -2},
+	}}
+	require.NoError(t, s.IsValid())
+	require.Equal(t, map[string]string{"Verified": "1", "Code-Style": "0"}, s.Votes(StatusSuccess))
+	require.Equal(t, map[string]string{"Verified": "-1", "Code-Style": "-2"}, s.Votes(StatusFail))
+	require.Nil(t, s.Votes(StatusBuilding))
+	require.Nil(t, GerritReviewSettings{}.Votes(StatusSuccess))
+
+	s.Labels = append(s.Labels, GerritLabelVote{Label: "Verified"})
+	require.Error(t, s.IsValid())
+	require.Error(t, GerritReviewSettings{Labels: []GerritLabelVote{{Label: "my label"}}}.IsValid())
+	require.Error(t, GerritReviewSettings{Labels: []GerritLabelVote{{Label: "Verified", OnSuccess: 3}}}.IsValid())
+	require.Error(t, GerritReviewSettings{Labels: []GerritLabelVote{{Label: "Verified", OnFailure: -3}}}.IsValid())
+}
+
+func TestGerritExcerpt(t *testing.T) {
+	require.Equal(t, "abc", gerritExcerpt("abc", 3))
+	require.Equal(t, "ab...", gerritExcerpt("abc", 2))
+	// "é" is two bytes long, it is not split
+	require.Equal(t, "a...", gerritExcerpt("aéb", 2))
+	require.True(t, utf8.ValidString(gerritExcerpt("ééé", 3)))
+}
+
+func TestGerritCommentsFromTests(t *testing.T) {
+	tests := &venom.Tests{
+		TestSuites: []venom.TestSuite{
+			{
+				Name: "github.com/ovh/cds/sdk",
+				TestCases: []venom.TestCase{
+					{Name: "TestOK"},
+					{Name: "TestKO", Failures: []venom.Failure{{Value: "    foo_test.go:42: expected 1, got 2\n    foo_test.go:42: again"}}},
+					{Name: "TestPanic", Errors: []venom.Failure{{Message: "panic", Value: "at com.ovh.Foo.bar(Foo.java:12)\n/tmp/run/repo/sdk/bar.go:7 +0x1d"}}},
+				},
+			},
+		},
+	}
+	comments := GerritCommentsFromTests(tests)
+	require.Len(t, comments, 3)
+	require.Equal(t, "foo_test.go", comments[0].Path)
+	require.Equal(t, 42, comments[0].Line)
+	require.Contains(t, comments[0].Message, "Test github.com/ovh/cds/sdk / TestKO failed")
+	require.Equal(t, "Foo.java", comments[1].Path)
+	require.Equal(t, 12, comments[1].Line)
+	require.Equal(t, "tmp/run/repo/sdk/bar.go", comments[2].Path)
+	require.Equal(t, 7, comments[2].Line)
+
+	require.Nil(t, GerritCommentsFromTests(nil))
+}
+
+func TestMatchGerritFile(t *testing.T) {
+	files := []string{"sdk/foo_test.go", "engine/foo_test.go", "sdk/bar.go", "src/main/java/com/ovh/Foo.java"}
+
+	f, ok := MatchGerritFile("sdk/bar.go", files)
+	require.True(t, ok)
+	require.Equal(t, "sdk/bar.go", f)
+
+	f, ok = MatchGerritFile("tmp/run/repo/sdk/bar.go", files)
+	require.True(t, ok)
+	require.Equal(t, "sdk/bar.go", f)
+
+	f, ok = MatchGerritFile("Foo.java", files)
+	require.True(t, ok)
+	require.Equal(t, "src/main/java/com/ovh/Foo.java", f)
+
+	// Ambiguous
+	_, ok = MatchGerritFile("foo_test.go", files)
+	require.False(t, ok)
+
+	_, ok = MatchGerritFile("unknown.go", files)
+	require.False(t, ok)
+}
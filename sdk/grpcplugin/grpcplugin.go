@@ -35,7 +35,8 @@ type Plugin interface {
 
 // StartPlugin starts a plugin, returns stdoutPipe, stderrPipe and socketName
 func StartPlugin(ctx context.Context, pluginName string, workdir, cmd string, args []string, env []string) (io.Reader, string, error) {
-	c := exec.CommandContext(ctx, cmd, args...)
+	c := exec.Command(cmd, args...)
+	sdk.SetProcessGroup(c)
 	c.Dir = workdir
 	c.Env = env
 	stdoutPipe, err := c.StdoutPipe()
@@ -55,10 +56,23 @@ func StartPlugin(ctx context.Context, pluginName string, workdir, cmd string, ar
 		return nil, "", err
 	}
 
+	// Kill the plugin with all its children when the step is canceled or reaches its timeout
+	cmdDone := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := sdk.KillProcessTree(c); err != nil {
+				log.Warning(ctx, "GRPC Plugin %s unable to kill process %d: %v", cmd, c.Process.Pid, err)
+			}
+		case <-cmdDone:
+		}
+	}()
+
 	go func() {
 		if err := c.Wait(); err != nil {
 			log.Info(ctx, "GRPC Plugin %s wait failed:%+v", cmd, err)
 		}
+		close(cmdDone)
 		log.Info(ctx, "GRPC Plugin %s end", cmd)
 	}()
 
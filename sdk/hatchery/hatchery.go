@@ -64,7 +64,7 @@ func Create(ctx context.Context, h Interface) error {
 		return fmt.Errorf("Create> Init error: %v", err)
 	}
 
-	var chanRegister, chanGetModels <-chan time.Time
+	var chanRegister, chanGetModels, chanWarmPools <-chan time.Time
 	var modelType string
 
 	hWithModels, isWithModels := h.(InterfaceWithModels)
@@ -80,6 +80,9 @@ func Create(ctx context.Context, h Interface) error {
 		// using time.Tick leaks the underlying ticker but we don't care about it because it is an endless function
 		chanRegister = time.Tick(time.Duration(h.Configuration().Provision.RegisterFrequency) * time.Second) // nolint
 		chanGetModels = time.Tick(10 * time.Second)                                                          // nolint
+		if len(h.Configuration().Provision.WarmPools) > 0 {
+			chanWarmPools = time.Tick(10 * time.Second) // nolint
+		}
 
 		modelType = hWithModels.ModelType()
 	}
@@ -202,8 +205,9 @@ func Create(ctx context.Context, h Interface) error {
 				continue
 			}
 
-			//Check if hatchery if able to start a new worker
-			if !checkCapacities(ctx, h) {
+			//Check if hatchery if able to start a new worker, idle workers of warm pools can still take the job
+			hasCapacities := checkCapacities(ctx, h)
+			if !hasCapacities && !warmWorkers.hasIdle() {
 				log.Info(ctx, "hatchery %s is not able to provision new worker", h.Service().Name)
 				endTrace("no capacities")
 				continue
@@ -218,6 +222,7 @@ func Create(ctx context.Context, h Interface) error {
 				hostname:          hostname,
 				timestamp:         time.Now().Unix(),
 				workflowNodeRunID: j.WorkflowNodeRunID,
+				warmOnly:          !hasCapacities,
 			}
 
 			// Check at least one worker model can match
@@ -264,9 +269,19 @@ func Create(ctx context.Context, h Interface) error {
 				continue
 			}
 
+			warmCompatible := chosenModel != nil && !hasSpawnRequirements(workerRequest.requirements)
+			if !hasCapacities && (!warmCompatible || !warmWorkers.hasIdle(chosenModel.Group.Name+"/"+chosenModel.Name)) {
+				log.Info(ctx, "hatchery %s is not able to provision new worker", h.Service().Name)
+				endTrace("no capacities")
+				continue
+			}
+
 			if chosenModel != nil {
 				// We got a model, let's start a worker
 				workerRequest.model = chosenModel
+				if warmCompatible {
+					warmWorkers.recordJob(chosenModel.Group.Name+"/"+chosenModel.Name, j.ID)
+				}
 
 				// Interpolate model secrets
 				if err := ModelInterpolateSecrets(hWithModels, chosenModel); err != nil {
@@ -282,6 +297,11 @@ func Create(ctx context.Context, h Interface) error {
 			if err := workerRegister(ctx, hWithModels, workersStartChan); err != nil {
 				log.Warning(ctx, "Error on workerRegister: %s", err)
 			}
+
+		case <-chanWarmPools:
+			if err := reconcileWarmPools(ctx, hWithModels, workersStartChan); err != nil {
+				log.Warning(ctx, "Error on reconcileWarmPools: %s", err)
+			}
 		}
 	}
 }
@@ -294,14 +314,14 @@ func canRunJob(ctx context.Context, h Interface, j workerStarterRequest) bool {
 			return false
 		}
 
-		if r.Type == sdk.RegionRequirement && r.Value != h.Configuration().Provision.Region {
+		if r.Type == sdk.RegionRequirement && !canRunInRegion(h, r.Value) {
 			log.Debug("canRunJob> %d - job %d - job with region requirement: cannot spawn. hatchery-region:%s prerequisite:%s", j.timestamp, j.id, h.Configuration().Provision.Region, r.Value)
 			return false
 		}
 
 		// Skip others requirement as we can't check it
-		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement {
-			log.Debug("canRunJob> %d - job %d - job with service, plugin or memory requirement. Skip these check as we can't check it on hatchery routine", j.timestamp, j.id)
+		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement {
+			log.Debug("canRunJob> %d - job %d - job with service, plugin, memory or cpu requirement. Skip these check as we can't check it on hatchery routine", j.timestamp, j.id)
 			continue
 		}
 
@@ -309,6 +329,18 @@ func canRunJob(ctx context.Context, h Interface, j workerStarterRequest) bool {
 	return h.CanSpawn(ctx, nil, j.id, j.requirements)
 }
 
+// canRunInRegion checks if the hatchery spawns workers in the given region, its provision region
+// or one of the regions it accepts
+func canRunInRegion(h Interface, region string) bool {
+	if region == h.Configuration().Provision.Region {
+		return true
+	}
+	if hr, ok := h.(InterfaceWithRegions); ok {
+		return sdk.IsInArray(region, hr.Regions())
+	}
+	return false
+}
+
 // MemoryRegisterContainer is the RAM used for spawning
 // a docker container for register a worker model. 128 Mo
 const MemoryRegisterContainer int64 = 128
@@ -375,15 +407,15 @@ func canRunJobWithModel(ctx context.Context, h InterfaceWithModels, j workerStar
 			}
 		}
 
-		// service and memory requirements are only supported by docker model
-		if model.Type != sdk.Docker && (r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement) {
-			log.Debug("canRunJobWithModel> %d - job %d - job with service requirement or memory requirement: only for model docker. current model:%s", j.timestamp, j.id, model.Type)
+		// service, memory and cpu requirements are only supported by docker model
+		if model.Type != sdk.Docker && (r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement) {
+			log.Debug("canRunJobWithModel> %d - job %d - job with service, memory or cpu requirement: only for model docker. current model:%s", j.timestamp, j.id, model.Type)
 			return false
 		}
 
 		// Skip other requirement as we can't check it
-		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement {
-			log.Debug("canRunJobWithModel> %d - job %d - job with service, plugin, network or memory requirement. Skip these check as we can't check it on hatchery routine", j.timestamp, j.id)
+		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement {
+			log.Debug("canRunJobWithModel> %d - job %d - job with service, plugin, network, memory or cpu requirement. Skip these check as we can't check it on hatchery routine", j.timestamp, j.id)
 			continue
 		}
 
@@ -392,7 +424,7 @@ func canRunJobWithModel(ctx context.Context, h InterfaceWithModels, j workerStar
 			return false
 		}
 
-		if r.Type == sdk.RegionRequirement && r.Value != h.Configuration().Provision.Region {
+		if r.Type == sdk.RegionRequirement && !canRunInRegion(h, r.Value) {
 			log.Debug("canRunJobWithModel> %d - job %d - job with region requirement: cannot spawn. hatchery-region:%s prerequisite:%s", j.timestamp, j.id, h.Configuration().Provision.Region, r.Value)
 			return false
 		}
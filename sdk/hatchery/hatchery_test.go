@@ -0,0 +1,40 @@
+package hatchery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/service"
+)
+
+type regionHatchery struct {
+	Interface
+	region  string
+	regions []string
+}
+
+func (h regionHatchery) Configuration() service.HatcheryCommonConfiguration {
+	var cfg service.HatcheryCommonConfiguration
+	cfg.Provision.Region = h.region
+	return cfg
+}
+
+type multiRegionHatchery struct {
+	regionHatchery
+}
+
+func (h multiRegionHatchery) Regions() []string {
+	return h.regions
+}
+
+func Test_canRunInRegion(t *testing.T) {
+	h := regionHatchery{region: "eu", regions: []string{"us"}}
+	require.True(t, canRunInRegion(h, "eu"))
+	require.False(t, canRunInRegion(h, "us"))
+
+	mh := multiRegionHatchery{regionHatchery: h}
+	require.True(t, canRunInRegion(mh, "eu"))
+	require.True(t, canRunInRegion(mh, "us"))
+	require.False(t, canRunInRegion(mh, "asia"))
+}
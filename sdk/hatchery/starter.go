@@ -27,6 +27,10 @@ type workerStarterRequest struct {
 	timestamp           int64
 	workflowNodeRunID   int64
 	registerWorkerModel *sdk.Model
+	warmWorkerModel     *sdk.Model
+	warmWorkerName      string
+	// the hatchery has no capacity to spawn a new worker, only an idle worker can take the job
+	warmOnly bool
 }
 
 func PanicDump(h Interface) func(s string) (io.WriteCloser, error) {
@@ -58,6 +62,12 @@ func startWorkerStarters(ctx context.Context, h Interface) chan<- workerStarterR
 
 func workerStarter(ctx context.Context, h Interface, workerNum string, jobs <-chan workerStarterRequest) {
 	for j := range jobs {
+		// Start an idle worker for the warm pool
+		if j.warmWorkerModel != nil {
+			spawnWarmWorker(ctx, h, j)
+			continue
+		}
+
 		// Start a worker for a job
 		if m := j.registerWorkerModel; m == nil {
 			_ = spawnWorkerForJob(ctx, h, j)
@@ -114,6 +124,16 @@ func spawnWorkerForJob(ctx context.Context, h Interface, j workerStarterRequest)
 		telemetry.TagServiceName, h.Name(),
 		telemetry.TagServiceType, h.Type(),
 	)
+
+	// Use an idle worker of the warm pool if any, the job is booked on assignment
+	if assignWarmWorker(ctxJob, h, j) {
+		return true
+	}
+	if j.warmOnly {
+		log.Debug("hatchery> spawnWorkerForJob> no idle worker available for job %d", j.id)
+		return false
+	}
+
 	telemetry.Record(ctxJob, GetMetrics().SpawnedWorkers, 1)
 
 	log.Debug("hatchery> spawnWorkerForJob> %d", j.id)
@@ -240,7 +260,10 @@ func generateWorkerName(hatcheryName string, isRegister bool, modelName string)
 	if isRegister {
 		prefix = "register-"
 	}
+	return generateWorkerNameWithPrefix(prefix, hatcheryName, modelName)
+}
 
+func generateWorkerNameWithPrefix(prefix, hatcheryName, modelName string) string {
 	maxLength := 63
 	hName := hatcheryName + "-"
 	random := namesgenerator.GetRandomNameCDS(0)
@@ -43,6 +43,7 @@ type SpawnArguments struct {
 	NodeRunName  string            `json:"node_run_name"`
 	Requirements []sdk.Requirement `json:"requirements"`
 	RegisterOnly bool              `json:"register_only"`
+	Warm         bool              `json:"warm"`
 	HatcheryName string            `json:"hatchery_name"`
 	ProjectKey   string            `json:"project_key"`
 	WorkflowName string            `json:"workflow_name"`
@@ -94,6 +95,12 @@ type InterfaceWithModels interface {
 	WorkerModelSecretList(sdk.Model) (sdk.WorkerModelSecrets, error)
 }
 
+// InterfaceWithRegions is implemented by hatcheries able to spawn workers in other regions than their provision region
+type InterfaceWithRegions interface {
+	Interface
+	Regions() []string
+}
+
 type Metrics struct {
 	Jobs               *stats.Int64Measure
 	JobsWebsocket      *stats.Int64Measure
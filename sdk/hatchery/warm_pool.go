@@ -0,0 +1,333 @@
+package hatchery
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	defaultWarmPoolIdleTTL = 10 * time.Minute
+	// a warm worker not registered after this delay is considered as lost
+	warmWorkerStartTimeout = 15 * time.Minute
+)
+
+// warmPool keeps track of the idle workers spawned without job, they are assigned to jobs of their model.
+type warmPool struct {
+	mutex   sync.Mutex
+	workers map[string]*warmWorker
+	// jobs seen in queue by model path since the last reconciliation
+	jobs map[string]map[int64]struct{}
+}
+
+type warmWorker struct {
+	name      string
+	model     string
+	spawnedAt time.Time
+	// set when the worker is registered and waiting for a job
+	id         string
+	idleSince  time.Time
+	registered bool
+}
+
+var warmWorkers = newWarmPool()
+
+func newWarmPool() *warmPool {
+	return &warmPool{
+		workers: make(map[string]*warmWorker),
+		jobs:    make(map[string]map[int64]struct{}),
+	}
+}
+
+// warmPoolTarget returns the number of idle workers to keep for a model given the number of jobs waiting for it.
+func warmPoolTarget(cfg service.WarmPoolConfiguration, queueDepth int) int {
+	target := cfg.Min + queueDepth
+	if target > cfg.Max {
+		target = cfg.Max
+	}
+	if target < cfg.Min {
+		target = cfg.Min
+	}
+	return target
+}
+
+func warmPoolIdleTTL(cfg service.WarmPoolConfiguration) time.Duration {
+	if cfg.IdleTTL <= 0 {
+		return defaultWarmPoolIdleTTL
+	}
+	return time.Duration(cfg.IdleTTL) * time.Minute
+}
+
+// hasSpawnRequirements returns true if the job has requirements applied when its worker is spawned,
+// these jobs can't be run by an idle worker of a warm pool.
+func hasSpawnRequirements(reqs []sdk.Requirement) bool {
+	for _, r := range reqs {
+		switch r.Type {
+		case sdk.ServiceRequirement, sdk.MemoryRequirement, sdk.CPURequirement, sdk.VolumeRequirement:
+			return true
+		}
+	}
+	return false
+}
+
+// recordJob registers a job of the queue that can be run by the model.
+func (p *warmPool) recordJob(modelPath string, jobID int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.jobs[modelPath]; !ok {
+		p.jobs[modelPath] = make(map[int64]struct{})
+	}
+	p.jobs[modelPath][jobID] = struct{}{}
+}
+
+func (p *warmPool) add(name, modelPath string, now time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.workers[name] = &warmWorker{name: name, model: modelPath, spawnedAt: now}
+}
+
+func (p *warmPool) remove(name string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.workers, name)
+}
+
+// hasIdle returns true if the pool contains an idle worker, of one of the given models if any.
+func (p *warmPool) hasIdle(modelPaths ...string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, w := range p.workers {
+		if w.id == "" {
+			continue
+		}
+		if len(modelPaths) == 0 || sdk.IsInArray(w.model, modelPaths) {
+			return true
+		}
+	}
+	return false
+}
+
+// takeIdle removes from the pool an idle worker of the model, the oldest one first.
+func (p *warmPool) takeIdle(modelPath string) *warmWorker {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var res *warmWorker
+	for _, w := range p.workers {
+		if w.model != modelPath || w.id == "" {
+			continue
+		}
+		if res == nil || w.idleSince.Before(res.idleSince) {
+			res = w
+		}
+	}
+	if res != nil {
+		delete(p.workers, res.name)
+	}
+	return res
+}
+
+// update refreshes the state of the pool from the workers of the hatchery.
+// Workers that took a job, were disabled or never started are removed from the pool.
+func (p *warmPool) update(workers []sdk.Worker, now time.Time) {
+	byName := make(map[string]sdk.Worker, len(workers))
+	for _, w := range workers {
+		byName[w.Name] = w
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for name, ww := range p.workers {
+		w, ok := byName[name]
+		switch {
+		case !ok:
+			if ww.registered || now.Sub(ww.spawnedAt) > warmWorkerStartTimeout {
+				delete(p.workers, name)
+			}
+		case w.Status == sdk.StatusWorkerPending || w.Status == sdk.StatusWorkerRegistering:
+			if now.Sub(ww.spawnedAt) > warmWorkerStartTimeout {
+				delete(p.workers, name)
+			}
+		case w.Status == sdk.StatusWaiting && w.JobRunID == nil:
+			ww.registered = true
+			ww.id = w.ID
+			if ww.idleSince.IsZero() {
+				ww.idleSince = now
+			}
+		default:
+			delete(p.workers, name)
+		}
+	}
+}
+
+// plan returns the number of workers to spawn by model and the idle workers to remove,
+// then resets the jobs seen in queue.
+func (p *warmPool) plan(cfgs map[string]service.WarmPoolConfiguration, now time.Time) (map[string]int, []warmWorker) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	toSpawn := make(map[string]int)
+	var toRetire []warmWorker
+	for modelPath, cfg := range cfgs {
+		var idle []*warmWorker
+		var pending int
+		for _, w := range p.workers {
+			if w.model != modelPath {
+				continue
+			}
+			if w.id == "" {
+				pending++
+			} else {
+				idle = append(idle, w)
+			}
+		}
+
+		target := warmPoolTarget(cfg, len(p.jobs[modelPath]))
+		if missing := target - len(idle) - pending; missing > 0 {
+			toSpawn[modelPath] = missing
+			continue
+		}
+
+		// Remove the idle workers above the minimum for too long, the oldest first
+		sort.Slice(idle, func(i, j int) bool { return idle[i].idleSince.Before(idle[j].idleSince) })
+		ttl := warmPoolIdleTTL(cfg)
+		for i := 0; i < len(idle)+pending-cfg.Min && i < len(idle); i++ {
+			if now.Sub(idle[i].idleSince) < ttl {
+				break
+			}
+			toRetire = append(toRetire, *idle[i])
+			delete(p.workers, idle[i].name)
+		}
+	}
+
+	p.jobs = make(map[string]map[int64]struct{})
+	return toSpawn, toRetire
+}
+
+// reconcileWarmPools spawns and removes idle workers to match the warm pools configuration.
+func reconcileWarmPools(ctx context.Context, h InterfaceWithModels, workersStartChan chan<- workerStarterRequest) error {
+	cfgs := h.Configuration().Provision.WarmPools
+	if len(cfgs) == 0 {
+		return nil
+	}
+
+	workers, err := WorkerPool(ctx, h)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	warmWorkers.update(workers, now)
+	toSpawn, toRetire := warmWorkers.plan(cfgs, now)
+
+	for _, w := range toRetire {
+		log.Info(ctx, "hatchery> warm pool> removing idle worker %s of model %s", w.name, w.model)
+		if err := h.CDSClient().WorkerDisable(ctx, w.id); err != nil {
+			log.Warning(ctx, "hatchery> warm pool> unable to disable worker %s: %v", w.name, err)
+		}
+	}
+
+	for modelPath, nb := range toSpawn {
+		var model *sdk.Model
+		for i := range models {
+			if models[i].Group != nil && models[i].Group.Name+"/"+models[i].Name == modelPath {
+				model = &models[i]
+				break
+			}
+		}
+		if model == nil {
+			log.Warning(ctx, "hatchery> warm pool> worker model %s not found", modelPath)
+			continue
+		}
+		if model.Type != h.ModelType() || h.NeedRegistration(ctx, model) || model.NbSpawnErr > 5 {
+			log.Debug("hatchery> warm pool> cannot spawn worker for model %s", modelPath)
+			continue
+		}
+
+		for i := 0; i < nb; i++ {
+			if !checkCapacities(ctx, h) {
+				log.Info(ctx, "hatchery> warm pool> hatchery %s is not able to provision new idle worker", h.Name())
+				return nil
+			}
+			m := *model
+			if err := ModelInterpolateSecrets(h, &m); err != nil {
+				return err
+			}
+			name := generateWorkerNameWithPrefix("warm-", h.Service().Name, modelPath)
+			warmWorkers.add(name, modelPath, now)
+			log.Debug("hatchery> warm pool> request idle worker %s for model %s", name, modelPath)
+			workersStartChan <- workerStarterRequest{
+				warmWorkerModel: &m,
+				warmWorkerName:  name,
+			}
+		}
+	}
+	return nil
+}
+
+// spawnWarmWorker starts a worker without job, it will wait for a job to be assigned.
+func spawnWarmWorker(ctx context.Context, h Interface, j workerStarterRequest) {
+	maxProv := h.Configuration().Provision.MaxConcurrentProvisioning
+	if maxProv < 1 {
+		maxProv = defaultMaxProvisioning
+	}
+	if atomic.LoadInt64(&nbWorkerToStart) >= int64(maxProv) {
+		warmWorkers.remove(j.warmWorkerName)
+		return
+	}
+	atomic.AddInt64(&nbWorkerToStart, 1)
+	defer atomic.AddInt64(&nbWorkerToStart, -1)
+
+	m := j.warmWorkerModel
+	arg := SpawnArguments{
+		WorkerName:   j.warmWorkerName,
+		Model:        m,
+		Warm:         true,
+		HatcheryName: h.Service().Name,
+	}
+
+	jwt, err := NewWorkerToken(h.Service().Name, h.GetPrivateKey(), time.Now().Add(1*time.Hour), arg)
+	if err != nil {
+		log.Error(ctx, "hatchery> spawnWarmWorker> cannot get token for worker %s: %v", arg.WorkerName, err)
+		warmWorkers.remove(arg.WorkerName)
+		return
+	}
+	arg.WorkerToken = jwt
+
+	log.Info(ctx, "hatchery> spawnWarmWorker> starting idle worker %s of model %s", arg.WorkerName, arg.ModelName())
+	if err := h.SpawnWorker(ctx, arg); err != nil {
+		log.Warning(ctx, "hatchery> spawnWarmWorker> cannot spawn idle worker %s of model %s: %v", arg.WorkerName, arg.ModelName(), err)
+		warmWorkers.remove(arg.WorkerName)
+	}
+}
+
+// assignWarmWorker assigns a booked job to an idle worker of the model, returns false if no idle worker is available.
+func assignWarmWorker(ctx context.Context, h Interface, j workerStarterRequest) bool {
+	if j.model == nil || hasSpawnRequirements(j.requirements) {
+		return false
+	}
+	modelPath := j.model.Group.Name + "/" + j.model.Name
+	for {
+		w := warmWorkers.takeIdle(modelPath)
+		if w == nil {
+			return false
+		}
+		ctxAssign, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := h.CDSClient().QueueJobAssign(ctxAssign, j.id, w.id)
+		cancel()
+		if err != nil {
+			log.Info(ctx, "hatchery> assignWarmWorker> cannot assign job %d to worker %s: %v", j.id, w.name, err)
+			continue
+		}
+		log.Info(ctx, "hatchery> assignWarmWorker> job %d assigned to idle worker %s", j.id, w.name)
+		SendSpawnInfo(ctx, h, j.id, sdk.SpawnMsg{
+			ID:   sdk.MsgSpawnInfoHatcheryAssignWarmWorker.ID,
+			Args: []interface{}{h.Service().Name, w.name},
+		})
+		return true
+	}
+}
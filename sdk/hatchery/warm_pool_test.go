@@ -0,0 +1,89 @@
+package hatchery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_warmPoolTarget(t *testing.T) {
+	cfg := service.WarmPoolConfiguration{Min: 1, Max: 3}
+	assert.Equal(t, 1, warmPoolTarget(cfg, 0))
+	assert.Equal(t, 3, warmPoolTarget(cfg, 2))
+	assert.Equal(t, 3, warmPoolTarget(cfg, 10))
+	assert.Equal(t, 0, warmPoolTarget(service.WarmPoolConfiguration{}, 5))
+}
+
+func Test_warmPool(t *testing.T) {
+	cfgs := map[string]service.WarmPoolConfiguration{
+		"shared.infra/debian": {Min: 1, Max: 3, IdleTTL: 5},
+	}
+	now := time.Now()
+	p := newWarmPool()
+
+	// Empty pool, the minimum of idle workers must be spawned
+	toSpawn, toRetire := p.plan(cfgs, now)
+	assert.Equal(t, map[string]int{"shared.infra/debian": 1}, toSpawn)
+	assert.Empty(t, toRetire)
+
+	// Two jobs waiting for the model, the pool grows
+	p.add("warm-1", "shared.infra/debian", now)
+	p.recordJob("shared.infra/debian", 1)
+	p.recordJob("shared.infra/debian", 2)
+	p.recordJob("shared.infra/debian", 2)
+	p.recordJob("shared.infra/other", 3)
+	toSpawn, _ = p.plan(cfgs, now)
+	assert.Equal(t, map[string]int{"shared.infra/debian": 2}, toSpawn)
+
+	// Jobs are reset after each plan
+	toSpawn, _ = p.plan(cfgs, now)
+	assert.Empty(t, toSpawn)
+
+	p.add("warm-2", "shared.infra/debian", now)
+	p.add("warm-3", "shared.infra/debian", now)
+	p.add("warm-4", "shared.infra/debian", now.Add(-time.Hour))
+	assert.False(t, p.hasIdle())
+
+	p.update([]sdk.Worker{
+		{ID: "1", Name: "warm-1", Status: sdk.StatusWaiting},
+		{ID: "2", Name: "warm-2", Status: sdk.StatusWaiting},
+		{Name: "warm-3", Status: sdk.StatusWorkerPending},
+		{Name: "warm-4", Status: sdk.StatusWorkerPending},
+	}, now)
+	require.Len(t, p.workers, 3, "warm-4 never registered")
+	assert.True(t, p.hasIdle("shared.infra/debian"))
+	assert.False(t, p.hasIdle("shared.infra/other"))
+
+	// Idle workers above the minimum are removed after the idle TTL
+	_, toRetire = p.plan(cfgs, now.Add(time.Minute))
+	assert.Empty(t, toRetire)
+	_, toRetire = p.plan(cfgs, now.Add(10*time.Minute))
+	require.Len(t, toRetire, 2)
+	require.Len(t, p.workers, 1)
+	assert.Equal(t, "warm-3", p.workers["warm-3"].name)
+
+	// Worker registered then assigned to a job leaves the pool
+	p.update([]sdk.Worker{{ID: "3", Name: "warm-3", Status: sdk.StatusWaiting}}, now)
+	w := p.takeIdle("shared.infra/debian")
+	require.NotNil(t, w)
+	assert.Equal(t, "3", w.id)
+	assert.Nil(t, p.takeIdle("shared.infra/debian"))
+
+	p.add("warm-5", "shared.infra/debian", now)
+	p.update([]sdk.Worker{{ID: "5", Name: "warm-5", Status: sdk.StatusBuilding}}, now)
+	assert.Empty(t, p.workers)
+}
+
+func Test_hasSpawnRequirements(t *testing.T) {
+	assert.False(t, hasSpawnRequirements(nil))
+	assert.False(t, hasSpawnRequirements([]sdk.Requirement{{Type: sdk.BinaryRequirement, Value: "git"}, {Type: sdk.ModelRequirement, Value: "shared.infra/debian"}}))
+	assert.True(t, hasSpawnRequirements([]sdk.Requirement{{Type: sdk.ServiceRequirement, Value: "postgres:latest"}}))
+	assert.True(t, hasSpawnRequirements([]sdk.Requirement{{Type: sdk.MemoryRequirement, Value: "4096"}}))
+	assert.True(t, hasSpawnRequirements([]sdk.Requirement{{Type: sdk.CPURequirement, Value: "2"}}))
+	assert.True(t, hasSpawnRequirements([]sdk.Requirement{{Type: sdk.VolumeRequirement, Value: "type=bind"}}))
+}
@@ -4,11 +4,14 @@ package sdk
 const (
 	WebHookModelName              = "WebHook"
 	RepositoryWebHookModelName    = "RepositoryWebHook"
+	CommentCommandHookModelName   = "CommentCommandHook"
 	GerritHookModelName           = "GerritHook"
 	SchedulerModelName            = "Scheduler"
 	GitPollerModelName            = "Git Repository Poller"
 	KafkaHookModelName            = "Kafka hook"
 	RabbitMQHookModelName         = "RabbitMQ hook"
+	NATSHookModelName             = "NATS hook"
+	RedisStreamHookModelName      = "Redis Stream hook"
 	WorkflowModelName             = "Workflow"
 	HookConfigProject             = "project"
 	HookConfigWorkflow            = "workflow"
@@ -17,16 +20,28 @@ const (
 	HookConfigTargetHook          = "target_hook"
 	HookConfigWorkflowID          = "workflow_id"
 	HookConfigWebHookID           = "webHookID"
+	HookConfigWebHookSecret       = "webHookSecret"
 	HookConfigVCSServer           = "vcsServer"
 	HookConfigEventFilter         = "eventFilter"
 	HookConfigRepoFullName        = "repoFullName"
 	HookConfigModelType           = "model_type"
 	HookConfigModelName           = "model_name"
 	HookConfigIcon                = "hookIcon"
+	HookConfigCommandPrefix       = "commandPrefix"
+	HookConfigCommandGroups       = "allowedGroups"
+	HookConfigPathIncludes        = "pathIncludes"
+	HookConfigPathExcludes        = "pathExcludes"
 	WebHookModelConfigMethod      = "method"
+	OutgoingWebHookModelHeaders   = "headers"
+	OutgoingWebHookModelSecret    = "secretVariable"
+	OutgoingWebHookModelRetries   = "retries"
+	OutgoingWebHookModelRetryWait = "retryDelay"
 	RepositoryWebHookModelMethod  = "method"
 	SchedulerModelCron            = "cron"
 	SchedulerModelTimezone        = "timezone"
+	SchedulerModelJitter          = "jitter"
+	SchedulerModelCalendars       = "excludeCalendars"
+	SchedulerModelSkipIfBuilding  = "skipIfBuilding"
 	Payload                       = "payload"
 	HookModelIntegration          = "integration"
 	KafkaHookModelConsumerGroup   = "consumer group"
@@ -36,6 +51,12 @@ const (
 	RabbitMQHookModelExchangeType = "exchange_type"
 	RabbitMQHookModelExchangeName = "exchange_name"
 	RabbitMQHookModelConsumerTag  = "consumer_tag"
+	NATSHookModelSubject          = "subject"
+	NATSHookModelDurable          = "durable"
+	RedisStreamHookModelStream    = "stream"
+	RedisStreamHookModelGroup     = "consumer_group"
+	HookConfigMessageFilterPath   = "messageFilterPath"
+	HookConfigMessageFilterRegex  = "messageFilterRegex"
 	SchedulerUsername             = "cds.scheduler"
 	SchedulerFullname             = "CDS Scheduler"
 )
@@ -49,8 +70,11 @@ var (
 		&SchedulerModel,
 		&KafkaHookModel,
 		&RabbitMQHookModel,
+		&NATSHookModel,
+		&RedisStreamHookModel,
 		&WorkflowModel,
 		&GerritHookModel,
+		&CommentCommandHookModel,
 	}
 
 	BuiltinOutgoingHookModels = []*WorkflowHookModel{
@@ -118,6 +142,76 @@ var (
 		},
 	}
 
+	NATSHookModel = WorkflowHookModel{
+		Author:     "CDS",
+		Type:       WorkflowHookModelBuiltin,
+		Identifier: "github.com/ovh/cds/hook/builtin/nats",
+		Name:       NATSHookModelName,
+		Icon:       "Linkify",
+		DefaultConfig: WorkflowNodeHookConfig{
+			HookModelIntegration: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeIntegration,
+			},
+			NATSHookModelSubject: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			NATSHookModelDurable: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigMessageFilterPath: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigMessageFilterRegex: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+		},
+	}
+
+	RedisStreamHookModel = WorkflowHookModel{
+		Author:     "CDS",
+		Type:       WorkflowHookModelBuiltin,
+		Identifier: "github.com/ovh/cds/hook/builtin/redis-stream",
+		Name:       RedisStreamHookModelName,
+		Icon:       "Linkify",
+		DefaultConfig: WorkflowNodeHookConfig{
+			HookModelIntegration: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeIntegration,
+			},
+			RedisStreamHookModelStream: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			RedisStreamHookModelGroup: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigMessageFilterPath: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigMessageFilterRegex: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+		},
+	}
+
 	WebHookModel = WorkflowHookModel{
 		Author:     "CDS",
 		Type:       WorkflowHookModelBuiltin,
@@ -150,6 +244,46 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeMultiChoice,
 			},
+			HookConfigPathIncludes: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigPathExcludes: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+		},
+	}
+
+	CommentCommandHookModel = WorkflowHookModel{
+		Author:     "CDS",
+		Type:       WorkflowHookModelBuiltin,
+		Identifier: "github.com/ovh/cds/hook/builtin/commentcommand",
+		Name:       CommentCommandHookModelName,
+		Icon:       "comment",
+		DefaultConfig: WorkflowNodeHookConfig{
+			RepositoryWebHookModelMethod: {
+				Value:        "POST",
+				Configurable: false,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigEventFilter: {
+				Value:        "",
+				Configurable: false,
+				Type:         HookConfigTypeMultiChoice,
+			},
+			HookConfigCommandPrefix: {
+				Value:        "/cds",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigCommandGroups: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -165,6 +299,16 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeString,
 			},
+			HookConfigPathIncludes: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigPathExcludes: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -185,6 +329,21 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeString,
 			},
+			SchedulerModelJitter: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			SchedulerModelCalendars: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			SchedulerModelSkipIfBuilding: {
+				Value:        "false",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 			Payload: {
 				Value:        "{}",
 				Configurable: true,
@@ -237,6 +396,26 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeString,
 			},
+			OutgoingWebHookModelHeaders: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			OutgoingWebHookModelSecret: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			OutgoingWebHookModelRetries: {
+				Value:        "3",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			OutgoingWebHookModelRetryWait: {
+				Value:        "1s",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -275,6 +454,8 @@ func GetDefaultHookModel(modelName string) WorkflowHookModel {
 		return SchedulerModel
 	case RepositoryWebHookModelName:
 		return RepositoryWebHookModel
+	case CommentCommandHookModelName:
+		return CommentCommandHookModel
 	case WebHookModelName:
 		return WebHookModel
 	case GitPollerModelName:
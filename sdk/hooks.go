@@ -10,24 +10,26 @@ type Task struct {
 	Executions        []TaskExecution        `json:"executions"`
 	NbExecutionsTotal int                    `json:"nb_executions_total" cli:"nb_executions_total"`
 	NbExecutionsTodo  int                    `json:"nb_executions_todo" cli:"nb_executions_todo"`
+	LastWorkflowRun   int64                  `json:"last_workflow_run,omitempty"`
 }
 
 // TaskExecution represents an execution instance of a task. It the task is a webhook; this represents the call of the webhook
 type TaskExecution struct {
-	UUID                string                  `json:"uuid" cli:"uuid,key"`
-	Type                string                  `json:"type" cli:"type"`
-	Timestamp           int64                   `json:"timestamp" cli:"timestamp"`
-	NbErrors            int64                   `json:"nb_errors" cli:"nb_errors"`
-	LastError           string                  `json:"last_error,omitempty" cli:"last_error"`
-	ProcessingTimestamp int64                   `json:"processing_timestamp" cli:"processing_timestamp"`
-	WorkflowRun         int64                   `json:"workflow_run" cli:"workflow_run"`
-	Config              WorkflowNodeHookConfig  `json:"config" cli:"-"`
-	WebHook             *WebHookExecution       `json:"webhook,omitempty" cli:"-"`
-	Kafka               *KafkaTaskExecution     `json:"kafka,omitempty" cli:"-"`
-	RabbitMQ            *RabbitMQTaskExecution  `json:"rabbitmq,omitempty" cli:"-"`
-	ScheduledTask       *ScheduledTaskExecution `json:"scheduled_task,omitempty" cli:"-"`
-	GerritEvent         *GerritEventExecution   `json:"gerrit,omitempty" cli:"-"`
-	Status              string                  `json:"status" cli:"status"`
+	UUID                string                   `json:"uuid" cli:"uuid,key"`
+	Type                string                   `json:"type" cli:"type"`
+	Timestamp           int64                    `json:"timestamp" cli:"timestamp"`
+	NbErrors            int64                    `json:"nb_errors" cli:"nb_errors"`
+	LastError           string                   `json:"last_error,omitempty" cli:"last_error"`
+	ProcessingTimestamp int64                    `json:"processing_timestamp" cli:"processing_timestamp"`
+	WorkflowRun         int64                    `json:"workflow_run" cli:"workflow_run"`
+	Config              WorkflowNodeHookConfig   `json:"config" cli:"-"`
+	WebHook             *WebHookExecution        `json:"webhook,omitempty" cli:"-"`
+	Kafka               *KafkaTaskExecution      `json:"kafka,omitempty" cli:"-"`
+	RabbitMQ            *RabbitMQTaskExecution   `json:"rabbitmq,omitempty" cli:"-"`
+	MessageBus          *MessageBusTaskExecution `json:"message_bus,omitempty" cli:"-"`
+	ScheduledTask       *ScheduledTaskExecution  `json:"scheduled_task,omitempty" cli:"-"`
+	GerritEvent         *GerritEventExecution    `json:"gerrit,omitempty" cli:"-"`
+	Status              string                   `json:"status" cli:"status"`
 }
 
 // GerritEventExecution contains specific data for a gerrit event execution
@@ -41,6 +43,8 @@ type WebHookExecution struct {
 	RequestBody   []byte              `json:"request_body"`
 	RequestHeader map[string][]string `json:"request_header"`
 	RequestMethod string              `json:"request_method"`
+	// Deliveries contains the previous attempts of an outgoing webhook
+	Deliveries []OutgoingHookDelivery `json:"deliveries,omitempty"`
 }
 
 // KafkaTaskExecution contains specific data for a kafka hook
@@ -53,7 +57,14 @@ type RabbitMQTaskExecution struct {
 	Message []byte `json:"message"`
 }
 
+// MessageBusTaskExecution contains specific data for a message bus hook (NATS, Redis Stream)
+type MessageBusTaskExecution struct {
+	Subject string `json:"subject"`
+	Message []byte `json:"message"`
+}
+
 // ScheduledTaskExecution contains specific data for a scheduled task execution
 type ScheduledTaskExecution struct {
 	DateScheduledExecution string `json:"date_scheduled_execution"`
+	SkipReason             string `json:"skip_reason,omitempty"`
 }
@@ -10,6 +10,8 @@ import (
 const (
 	KafkaIntegrationModel         = "Kafka"
 	RabbitMQIntegrationModel      = "RabbitMQ"
+	NATSIntegrationModel          = "NATS"
+	RedisIntegrationModel         = "Redis"
 	OpenstackIntegrationModel     = "Openstack"
 	AWSIntegrationModel           = "AWS"
 	DefaultStorageIntegrationName = "shared.infra"
@@ -20,6 +22,8 @@ var (
 	BuiltinIntegrationModels = []*IntegrationModel{
 		&KafkaIntegration,
 		&RabbitMQIntegration,
+		&NATSIntegration,
+		&RedisIntegration,
 		&OpenstackIntegration,
 		&AWSIntegration,
 	}
@@ -68,6 +72,53 @@ var (
 		Disabled: false,
 		Hook:     true,
 	}
+	// NATSIntegration represents a NATS JetStream integration
+	NATSIntegration = IntegrationModel{
+		Name:       NATSIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/nats",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Comma separated list of NATS servers urls, ie: nats://nats1:4222,nats://nats2:4222",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			"token": IntegrationConfigValue{
+				Type:        IntegrationConfigTypePassword,
+				Description: "Authentication token, used instead of username and password",
+			},
+		},
+		Disabled: false,
+		Hook:     true,
+	}
+	// RedisIntegration represents a redis integration
+	RedisIntegration = IntegrationModel{
+		Name:       RedisIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/redis",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"host": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Redis server address, ie: redis:6379",
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			"db": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Redis database number, default is 0",
+			},
+		},
+		Disabled: false,
+		Hook:     true,
+	}
 	// OpenstackIntegration represents an openstack integration
 	OpenstackIntegration = IntegrationModel{
 		Name:       OpenstackIntegrationModel,
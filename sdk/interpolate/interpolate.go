@@ -12,6 +12,34 @@ import (
 
 var interpolateRegex = regexp.MustCompile("({{[\\.\"a-zA-Z0-9._\\-µ|\\s]+}})")
 
+// templateActions are the text/template control structures, these expressions are
+// always evaluated even if a variable is unknown.
+var templateActions = map[string]void{
+	"if":    {},
+	"else":  {},
+	"end":   {},
+	"range": {},
+	"with":  {},
+}
+
+// templateBuiltins are the text/template builtin functions.
+var templateBuiltins = map[string]void{
+	"and":     {},
+	"or":      {},
+	"not":     {},
+	"eq":      {},
+	"ne":      {},
+	"lt":      {},
+	"le":      {},
+	"gt":      {},
+	"ge":      {},
+	"len":     {},
+	"index":   {},
+	"print":   {},
+	"printf":  {},
+	"println": {},
+}
+
 type void struct{}
 type val map[string]interface{}
 
@@ -112,6 +140,10 @@ func Do(input string, vars map[string]string) (string, error) {
 
 					switch splittedExpression[i][0] {
 					case '.':
+						// the dot alone is the current value inside a range or with action
+						if splittedExpression[i] == "." {
+							continue
+						}
 						usedVariables[splittedExpression[i][1:]] = void{}
 					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 						quotedStuff = append(quotedStuff, splittedExpression[i:]...)
@@ -125,6 +157,10 @@ func Do(input string, vars map[string]string) (string, error) {
 					}
 				}
 
+				if isTemplateAction(splittedExpression) {
+					continue
+				}
+
 				unknownVariables := make([]string, 0, 1000)
 				for v := range usedVariables {
 					if _, is := flatData[v]; !is {
@@ -135,7 +171,9 @@ func Do(input string, vars map[string]string) (string, error) {
 				unknownHelpers := make([]string, 0, 1000)
 				for h := range usedHelpers {
 					if _, is := InterpolateHelperFuncs[h]; !is {
-						unknownHelpers = append(unknownHelpers, h)
+						if _, is := templateBuiltins[h]; !is {
+							unknownHelpers = append(unknownHelpers, h)
+						}
 					}
 				}
 
@@ -169,3 +207,15 @@ func Do(input string, vars map[string]string) (string, error) {
 
 	return buff.String(), nil
 }
+
+// isTemplateAction returns true if the expression starts with a control structure keyword.
+func isTemplateAction(splittedExpression []string) bool {
+	for _, s := range splittedExpression {
+		if s == "" || s == "-" {
+			continue
+		}
+		_, is := templateActions[s]
+		return is
+	}
+	return false
+}
@@ -437,6 +437,38 @@ func TestDo(t *testing.T) {
 			want:   `test_myWorkflow_863ddke1`,
 			enable: true,
 		},
+		{
+			name: "if else with builtin",
+			args: args{
+				input: `{"color": "{{if eq .cds.status "Success"}}good{{else}}danger{{end}}", "text": "{{.cds.workflow}} {{.cds.status | lower}}"}`,
+				vars: map[string]string{
+					"cds.workflow": "myWorkflow",
+					"cds.status":   "Fail",
+				},
+			},
+			want:   `{"color": "danger", "text": "myWorkflow fail"}`,
+			enable: true,
+		},
+		{
+			name: "if with unknown variable",
+			args: args{
+				input: `{{ if .cds.proj.token }}token:{{.cds.proj.token}}{{ end }}{{.cds.proj.other}}`,
+				vars:  map[string]string{},
+			},
+			want:   `{{.cds.proj.other}}`,
+			enable: true,
+		},
+		{
+			name: "with and toJSON",
+			args: args{
+				input: `{{with .git.branch}}{{toJSON .}}{{end}}`,
+				vars: map[string]string{
+					"git.branch": `feat/"quoted"`,
+				},
+			},
+			want:   `"feat/\"quoted\""`,
+			enable: true,
+		},
 	}
 	for _, tt := range tests {
 		if !tt.enable {
@@ -1,5 +1,14 @@
 package sdk
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxJobMatrixCombinations is the maximum number of job runs generated by a matrix job.
+const MaxJobMatrixCombinations = 256
+
 // Job is the element of a stage
 type Job struct {
 	PipelineActionID int64                  `json:"pipeline_action_id"`
@@ -8,6 +17,10 @@ type Job struct {
 	LastModified     int64                  `json:"last_modified"`
 	Action           Action                 `json:"action"`
 	Warnings         []PipelineBuildWarning `json:"warnings"`
+	Matrix           *JobMatrix             `json:"matrix,omitempty"`
+	Timeout          int64                  `json:"timeout,omitempty"` // job timeout in seconds, 0 for no timeout
+	// MatrixValues contains the axis values of a job run generated from a matrix job
+	MatrixValues JobMatrixValues `json:"matrix_values,omitempty"`
 }
 
 // IsValid returns job's validity.
@@ -16,5 +29,199 @@ func (j Job) IsValid() error {
 		return NewErrorFrom(ErrWrongRequest, "invalid given stage id")
 	}
 
+	if j.Timeout < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid timeout for job")
+	}
+
+	if j.Matrix != nil {
+		if err := j.Matrix.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	return j.Action.IsValid()
 }
+
+// JobMatrix runs a job once for each combination of the values of its axes.
+type JobMatrix struct {
+	Axes    map[string][]string `json:"axes"`
+	Exclude []JobMatrixValues   `json:"exclude,omitempty"`
+	Include []JobMatrixValues   `json:"include,omitempty"`
+}
+
+// JobMatrixValues contains a value by axis name.
+type JobMatrixValues map[string]string
+
+// String returns the values sorted by axis name, ie: "go=1.15, os=linux".
+func (v JobMatrixValues) String() string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res := make([]string, len(keys))
+	for i, k := range keys {
+		res[i] = k + "=" + v[k]
+	}
+	return strings.Join(res, ", ")
+}
+
+// Equal returns true if both values contain the same axis and values.
+func (v JobMatrixValues) Equal(other JobMatrixValues) bool {
+	if len(v) != len(other) {
+		return false
+	}
+	for k, val := range v {
+		if o, ok := other[k]; !ok || o != val {
+			return false
+		}
+	}
+	return true
+}
+
+// match returns true if the given values contain all values of v for the given axes.
+func (v JobMatrixValues) match(values JobMatrixValues, axes map[string][]string) bool {
+	for k, val := range v {
+		if _, isAxis := axes[k]; !isAxis {
+			continue
+		}
+		if values[k] != val {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValid returns an error if the matrix has no axis, an empty axis, an invalid exclude entry
+// or generates too many combinations.
+func (m JobMatrix) IsValid() error {
+	if len(m.Axes) == 0 && len(m.Include) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "job matrix should contain at least one axis")
+	}
+	for name, values := range m.Axes {
+		if !NamePatternRegex.MatchString(name) {
+			return NewErrorFrom(ErrWrongRequest, "invalid job matrix axis name %q, it should match %s", name, NamePattern)
+		}
+		if len(values) == 0 {
+			return NewErrorFrom(ErrWrongRequest, "job matrix axis %q should contain at least one value", name)
+		}
+	}
+	for _, e := range m.Exclude {
+		if len(e) == 0 {
+			return NewErrorFrom(ErrWrongRequest, "job matrix exclude entry should not be empty")
+		}
+		for k := range e {
+			if _, ok := m.Axes[k]; !ok {
+				return NewErrorFrom(ErrWrongRequest, "job matrix exclude entry contains unknown axis %q", k)
+			}
+		}
+	}
+	for _, i := range m.Include {
+		if len(i) == 0 {
+			return NewErrorFrom(ErrWrongRequest, "job matrix include entry should not be empty")
+		}
+		for k := range i {
+			if !NamePatternRegex.MatchString(k) {
+				return NewErrorFrom(ErrWrongRequest, "invalid job matrix include key %q, it should match %s", k, NamePattern)
+			}
+		}
+	}
+	if nb := m.count(); nb > MaxJobMatrixCombinations {
+		return NewErrorFrom(ErrWrongRequest, "job matrix generates %d combinations, maximum is %d", nb, MaxJobMatrixCombinations)
+	}
+	return nil
+}
+
+func (m JobMatrix) count() int {
+	nb := 1
+	for _, values := range m.Axes {
+		nb *= len(values)
+		if nb > MaxJobMatrixCombinations {
+			break
+		}
+	}
+	if len(m.Axes) == 0 {
+		nb = 0
+	}
+	return nb + len(m.Include)
+}
+
+// Combinations returns the values of each job run of the matrix.
+// All combinations of the axes are generated in axis name order, then excluded
+// combinations are removed. An include entry adds its extra values to the combinations
+// matching its axis values, if there is none it is added as a new combination.
+func (m JobMatrix) Combinations() []JobMatrixValues {
+	names := make([]string, 0, len(m.Axes))
+	for name := range m.Axes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var res []JobMatrixValues
+	if len(names) > 0 {
+		res = []JobMatrixValues{{}}
+		for _, name := range names {
+			next := make([]JobMatrixValues, 0, len(res)*len(m.Axes[name]))
+			for _, c := range res {
+				for _, v := range m.Axes[name] {
+					n := make(JobMatrixValues, len(c)+1)
+					for k := range c {
+						n[k] = c[k]
+					}
+					n[name] = v
+					next = append(next, n)
+				}
+			}
+			res = next
+		}
+	}
+
+	filtered := res[:0]
+combinationLoop:
+	for _, c := range res {
+		for _, e := range m.Exclude {
+			if e.match(c, m.Axes) {
+				continue combinationLoop
+			}
+		}
+		filtered = append(filtered, c)
+	}
+	res = filtered
+
+	for _, i := range m.Include {
+		var merged bool
+		for _, c := range res {
+			if !i.match(c, m.Axes) {
+				continue
+			}
+			for k, v := range i {
+				c[k] = v
+			}
+			merged = true
+		}
+		if !merged {
+			n := make(JobMatrixValues, len(i))
+			for k, v := range i {
+				n[k] = v
+			}
+			res = append(res, n)
+		}
+	}
+
+	return res
+}
+
+// MatrixJobs returns the jobs to run for a job, one for each combination of its matrix if any.
+func (j Job) MatrixJobs() []Job {
+	if j.Matrix == nil {
+		return []Job{j}
+	}
+	combinations := j.Matrix.Combinations()
+	res := make([]Job, len(combinations))
+	for i, c := range combinations {
+		res[i] = j
+		res[i].Action.Name = fmt.Sprintf("%s (%s)", j.Action.Name, c.String())
+		res[i].MatrixValues = c
+	}
+	return res
+}
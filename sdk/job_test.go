@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobMatrix_Combinations(t *testing.T) {
+	m := JobMatrix{
+		Axes: map[string][]string{
+			"os": {"linux", "darwin"},
+			"go": {"1.14", "1.15"},
+		},
+		Exclude: []JobMatrixValues{{"go": "1.14", "os": "darwin"}},
+		Include: []JobMatrixValues{
+			{"go": "1.15", "experimental": "true"},
+			{"go": "1.16", "os": "linux"},
+		},
+	}
+	require.NoError(t, m.IsValid())
+	assert.Equal(t, []JobMatrixValues{
+		{"go": "1.14", "os": "linux"},
+		{"go": "1.15", "os": "linux", "experimental": "true"},
+		{"go": "1.15", "os": "darwin", "experimental": "true"},
+		{"go": "1.16", "os": "linux"},
+	}, m.Combinations())
+}
+
+func TestJobMatrix_IsValid(t *testing.T) {
+	assert.Error(t, JobMatrix{}.IsValid())
+	assert.Error(t, JobMatrix{Axes: map[string][]string{"go": {}}}.IsValid())
+	assert.Error(t, JobMatrix{Axes: map[string][]string{"go version": {"1.15"}}}.IsValid())
+	assert.Error(t, JobMatrix{Axes: map[string][]string{"go": {"1.15"}}, Exclude: []JobMatrixValues{{"os": "linux"}}}.IsValid())
+
+	values := make([]string, 17)
+	assert.Error(t, JobMatrix{Axes: map[string][]string{"a": values, "b": values}}.IsValid())
+	assert.NoError(t, JobMatrix{Axes: map[string][]string{"a": values, "b": values[:15]}}.IsValid())
+}
+
+func TestJob_MatrixJobs(t *testing.T) {
+	j := Job{PipelineActionID: 1, Action: Action{Name: "test"}}
+	assert.Equal(t, []Job{j}, j.MatrixJobs())
+
+	j.Matrix = &JobMatrix{Axes: map[string][]string{"os": {"linux", "darwin"}, "go": {"1.15"}}}
+	jobs := j.MatrixJobs()
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "test (go=1.15, os=linux)", jobs[0].Action.Name)
+	assert.Equal(t, JobMatrixValues{"go": "1.15", "os": "linux"}, jobs[0].MatrixValues)
+	assert.Equal(t, "test (go=1.15, os=darwin)", jobs[1].Action.Name)
+	assert.Equal(t, int64(1), jobs[1].PipelineActionID)
+}
@@ -57,6 +57,7 @@ var (
 	MsgSpawnInfoHatcheryStarts              = &Message{"MsgSpawnInfoHatcheryStarts", trad{FR: "La Hatchery %s a démarré le lancement du worker avec le modèle %s", EN: "Hatchery %s starts spawn worker with model %s"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoHatcheryErrorSpawn          = &Message{"MsgSpawnInfoHatcheryErrorSpawn", trad{FR: "Une erreur est survenue lorsque la Hatchery %s a démarré un worker avec le modèle %s après %s, err:%s", EN: "Error while Hatchery %s spawn worker with model %s after %s, err:%s"}, nil, RunInfoTypeError}
 	MsgSpawnInfoHatcheryStartsSuccessfully  = &Message{"MsgSpawnInfoHatcheryStartsSuccessfully", trad{FR: "La Hatchery %s a démarré le worker %s avec succès en %s", EN: "Hatchery %s spawn worker %s successfully in %s"}, nil, RunInfoTypInfo}
+	MsgSpawnInfoHatcheryAssignWarmWorker    = &Message{"MsgSpawnInfoHatcheryAssignWarmWorker", trad{FR: "La Hatchery %s a attribué le job au worker inactif %s", EN: "Hatchery %s assigned the job to idle worker %s"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoHatcheryStartDockerPull     = &Message{"MsgSpawnInfoHatcheryStartDockerPull", trad{FR: "La Hatchery %s a démarré le docker pull de l'image %s...", EN: "Hatchery %s starts docker pull %s..."}, nil, RunInfoTypInfo}
 	MsgSpawnInfoHatcheryEndDockerPull       = &Message{"MsgSpawnInfoHatcheryEndDockerPull", trad{FR: "La Hatchery %s a terminé le docker pull de l'image %s", EN: "Hatchery %s docker pull %s done"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoHatcheryEndDockerPullErr    = &Message{"MsgSpawnInfoHatcheryEndDockerPullErr", trad{FR: "⚠ La Hatchery %s a terminé le docker pull de l'image %s en erreur: %s", EN: "⚠ Hatchery %s - docker pull %s done with error: %v"}, nil, RunInfoTypeError}
@@ -68,6 +69,8 @@ var (
 	MsgSpawnInfoWorkerForJob                = &Message{"MsgSpawnInfoWorkerForJob", trad{FR: "Ce worker %s a été créé pour lancer ce job", EN: "This worker %s was created to take this action"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoWorkerForJobError           = &Message{"MsgSpawnInfoWorkerForJobError", trad{FR: "⚠ Ce worker %s a été créé pour lancer ce job, mais ne possède pas tous les pré-requis. Vérifiez que les prérequis suivants:%s", EN: "⚠ This worker %s was created to take this action, but does not have all prerequisites. Please verify the following prerequisites:%s"}, nil, RunInfoTypeError}
 	MsgSpawnInfoJobError                    = &Message{"MsgSpawnInfoJobError", trad{FR: "⚠ Impossible de lancer ce job : %s", EN: "⚠ Unable to run this job: %s"}, nil, RunInfoTypInfo}
+	MsgSpawnInfoJobTimeout                  = &Message{"MsgSpawnInfoJobTimeout", trad{FR: "⚠ Le job a été arrêté car il a dépassé son délai maximal de %s", EN: "⚠ Job has been stopped because it exceeded its timeout of %s"}, nil, RunInfoTypeError}
+	MsgSpawnInfoStepTimeout                 = &Message{"MsgSpawnInfoStepTimeout", trad{FR: "⚠ L'étape %s a été arrêtée car elle a dépassé son délai maximal de %s", EN: "⚠ Step %s has been stopped because it exceeded its timeout of %s"}, nil, RunInfoTypeError}
 	MsgWorkflowStarting                     = &Message{"MsgWorkflowStarting", trad{FR: "Le workflow %s#%s a été démarré", EN: "Workflow %s#%s has been started"}, nil, RunInfoTypInfo}
 	MsgWorkflowError                        = &Message{"MsgWorkflowError", trad{FR: "⚠ Une erreur est survenue: %v", EN: "⚠ An error has occurred: %v"}, nil, RunInfoTypeError}
 	MsgWorkflowConditionError               = &Message{"MsgWorkflowConditionError", trad{FR: "Les conditions de lancement ne sont pas respectées.", EN: "Run conditions aren't ok."}, nil, RunInfoTypInfo}
@@ -89,6 +92,9 @@ var (
 	MsgWorkflowErrorBadVCSStrategy          = &Message{"MsgWorkflowErrorBadVCSStrategy", trad{FR: "Vos informations vcs_* sont incorrectes", EN: "Your vcs_* fields are incorrects"}, nil, RunInfoTypeError}
 	MsgWorkflowDeprecatedVersion            = &Message{"MsgWorkflowDeprecatedVersion", trad{FR: "La configuration yaml de votre workflow est dans un format déprécié. Exportez le avec la CLI `cdsctl workflow export %s %s`", EN: "The yaml workflow configuration format is deprecated. Export your workflow with CLI `cdsctl workflow export %s %s`"}, nil, RunInfoTypeWarning}
 	MsgWorkflowGeneratedFromTemplateVersion = &Message{"MsgWorkflowGeneratedFromTemplateVersion", trad{FR: "Le workflow a été généré à partir du modèle de workflow: %s.", EN: "The workflow was generated from the template: %s"}, nil, RunInfoTypInfo}
+	MsgWorkflowAsCodeDeleted                = &Message{"MsgWorkflowAsCodeDeleted", trad{FR: "Le workflow %s a été supprimé car il n'est plus déclaré dans le dépôt", EN: "Workflow %s has been deleted because it is not declared in the repository anymore"}, nil, RunInfoTypInfo}
+	MsgWorkflowAsCodeNotDeclared            = &Message{"MsgWorkflowAsCodeNotDeclared", trad{FR: "Le workflow %s n'est plus déclaré dans le dépôt, il n'a pas été supprimé car il n'a pas la métadonnée delete_if_undeclared", EN: "Workflow %s is not declared in the repository anymore, it was not deleted because it doesn't have the delete_if_undeclared metadata"}, nil, RunInfoTypeWarning}
+	MsgWorkflowAsCodeSyncError              = &Message{"MsgWorkflowAsCodeSyncError", trad{FR: "Le workflow %s n'a pas pu être synchronisé avec le dépôt: %s", EN: "Workflow %s could not be synchronized with the repository: %s"}, nil, RunInfoTypeWarning}
 	MsgTooMuchWorkflowRun                   = &Message{"MsgTooMuchWorkflowRun", trad{FR: "L'exécution de ce workflow est suspendu. Vous dépassez le nombre maximum d'éxécution autorisé (%.f). Merci de revoir la politique de retention de ce workflow", EN: "Workflow run is delayed. The maximum number of runs for this workflow has been reached ( %.f ). Please update your workflow retention policy"}, nil, RunInfoTypeWarning}
 )
 
@@ -131,6 +137,7 @@ var Messages = map[string]*Message{
 	MsgSpawnInfoHatcheryStarts.ID:              MsgSpawnInfoHatcheryStarts,
 	MsgSpawnInfoHatcheryErrorSpawn.ID:          MsgSpawnInfoHatcheryErrorSpawn,
 	MsgSpawnInfoHatcheryStartsSuccessfully.ID:  MsgSpawnInfoHatcheryStartsSuccessfully,
+	MsgSpawnInfoHatcheryAssignWarmWorker.ID:    MsgSpawnInfoHatcheryAssignWarmWorker,
 	MsgSpawnInfoHatcheryStartDockerPull.ID:     MsgSpawnInfoHatcheryStartDockerPull,
 	MsgSpawnInfoHatcheryEndDockerPull.ID:       MsgSpawnInfoHatcheryEndDockerPull,
 	MsgSpawnInfoHatcheryEndDockerPullErr.ID:    MsgSpawnInfoHatcheryEndDockerPullErr,
@@ -142,6 +149,8 @@ var Messages = map[string]*Message{
 	MsgSpawnInfoWorkerForJob.ID:                MsgSpawnInfoWorkerForJob,
 	MsgSpawnInfoWorkerForJobError.ID:           MsgSpawnInfoWorkerForJobError,
 	MsgSpawnInfoJobError.ID:                    MsgSpawnInfoJobError,
+	MsgSpawnInfoJobTimeout.ID:                  MsgSpawnInfoJobTimeout,
+	MsgSpawnInfoStepTimeout.ID:                 MsgSpawnInfoStepTimeout,
 	MsgWorkflowStarting.ID:                     MsgWorkflowStarting,
 	MsgWorkflowError.ID:                        MsgWorkflowError,
 	MsgWorkflowConditionError.ID:               MsgWorkflowConditionError,
@@ -163,6 +172,9 @@ var Messages = map[string]*Message{
 	MsgWorkflowErrorBadVCSStrategy.ID:          MsgWorkflowErrorBadVCSStrategy,
 	MsgWorkflowDeprecatedVersion.ID:            MsgWorkflowDeprecatedVersion,
 	MsgWorkflowGeneratedFromTemplateVersion.ID: MsgWorkflowGeneratedFromTemplateVersion,
+	MsgWorkflowAsCodeDeleted.ID:                MsgWorkflowAsCodeDeleted,
+	MsgWorkflowAsCodeNotDeclared.ID:            MsgWorkflowAsCodeNotDeclared,
+	MsgWorkflowAsCodeSyncError.ID:              MsgWorkflowAsCodeSyncError,
 	MsgTooMuchWorkflowRun.ID:                   MsgTooMuchWorkflowRun,
 }
 
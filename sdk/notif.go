@@ -75,6 +75,9 @@ type UserNotificationTemplate struct {
 	// For VCS
 	DisableComment *bool `json:"disable_comment,omitempty" yaml:"disable_comment,omitempty"`
 	DisableStatus  *bool `json:"disable_status,omitempty" yaml:"disable_status,omitempty"`
+
+	// For Gerrit
+	Gerrit *GerritReviewSettings `json:"gerrit,omitempty" yaml:"gerrit,omitempty"`
 }
 
 //userNotificationInput is a way to parse notification
@@ -0,0 +1,22 @@
+//go:build !windows
+// +build !windows
+
+package sdk
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// SetProcessGroup runs the command in its own process group, so it can be killed with all its children.
+func SetProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// KillProcessTree kills the process group of the command.
+func KillProcessTree(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,20 @@
+//go:build windows
+// +build windows
+
+package sdk
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// SetProcessGroup does nothing on windows, children are found by taskkill.
+func SetProcessGroup(cmd *exec.Cmd) {}
+
+// KillProcessTree kills the process of the command and all its children.
+func KillProcessTree(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ProjectCalendar is a list of periods defined on a project (holidays, freeze windows...).
+// Scheduler hooks can be configured to not trigger workflows during the periods of project calendars.
+type ProjectCalendar struct {
+	ID           int64           `json:"id" db:"id" cli:"-" yaml:"-"`
+	ProjectID    int64           `json:"project_id" db:"project_id" cli:"-" yaml:"-"`
+	Name         string          `json:"name" db:"name" cli:"name,key" yaml:"name"`
+	Description  string          `json:"description" db:"description" cli:"description" yaml:"description,omitempty"`
+	Periods      CalendarPeriods `json:"periods" db:"periods" cli:"-" yaml:"periods"`
+	Created      time.Time       `json:"created" db:"created" cli:"-" yaml:"-"`
+	LastModified time.Time       `json:"last_modified" db:"last_modified" cli:"last_modified" yaml:"-"`
+}
+
+// IsValid returns an error if the calendar is not valid.
+func (c ProjectCalendar) IsValid() error {
+	if !NamePatternRegex.MatchString(c.Name) {
+		return NewErrorFrom(ErrWrongRequest, "invalid calendar name %q, it should match %s", c.Name, NamePattern)
+	}
+	for i, p := range c.Periods {
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if p.Start.IsZero() || p.End.IsZero() {
+			return NewErrorFrom(ErrWrongRequest, "invalid period %s: start and end are mandatory", name)
+		}
+		if !p.End.After(p.Start) {
+			return NewErrorFrom(ErrWrongRequest, "invalid period %s: end should be after start", name)
+		}
+		if p.Yearly && p.End.After(p.Start.AddDate(1, 0, 0)) {
+			return NewErrorFrom(ErrWrongRequest, "invalid period %s: a yearly period should not last more than a year", name)
+		}
+	}
+	return nil
+}
+
+// PeriodAt returns the period of the calendar that contains the given time, or nil.
+func (c ProjectCalendar) PeriodAt(t time.Time) *CalendarPeriod {
+	for i := range c.Periods {
+		if c.Periods[i].Contains(t) {
+			return &c.Periods[i]
+		}
+	}
+	return nil
+}
+
+// CalendarPeriod is a period of a project calendar. A yearly period is repeated every year at the same dates.
+type CalendarPeriod struct {
+	Name   string    `json:"name,omitempty" yaml:"name,omitempty"`
+	Start  time.Time `json:"start" yaml:"start"`
+	End    time.Time `json:"end" yaml:"end"`
+	Yearly bool      `json:"yearly,omitempty" yaml:"yearly,omitempty"`
+}
+
+// Contains returns true if the given time is in the period, start is included and end is excluded.
+func (p CalendarPeriod) Contains(t time.Time) bool {
+	if !p.Yearly {
+		return !t.Before(p.Start) && t.Before(p.End)
+	}
+	// Move the period to the year of the given time, or to the previous year for periods over the new year
+	years := t.In(p.Start.Location()).Year() - p.Start.Year()
+	for _, y := range []int{years, years - 1} {
+		if !t.Before(p.Start.AddDate(y, 0, 0)) && t.Before(p.End.AddDate(y, 0, 0)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CalendarPeriods is a list of calendar periods.
+type CalendarPeriods []CalendarPeriod
+
+// Value returns driver.Value from calendar periods.
+func (c CalendarPeriods) Value() (driver.Value, error) {
+	j, err := json.Marshal(c)
+	return j, WrapError(err, "cannot marshal CalendarPeriods")
+}
+
+// Scan calendar periods.
+func (c *CalendarPeriods) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(json.Unmarshal(source, c), "cannot unmarshal CalendarPeriods")
+}
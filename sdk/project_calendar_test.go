@@ -0,0 +1,66 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestProjectCalendar(t *testing.T) {
+	var c ProjectCalendar
+	require.NoError(t, yaml.Unmarshal([]byte(`
+name: release-freeze
+periods:
+- name: end-of-year
+  start: 2020-12-18T18:00:00+01:00
+  end: 2021-01-04T08:00:00+01:00
+- name: christmas
+  start: 2000-12-24T00:00:00Z
+  end: 2000-12-26T00:00:00Z
+  yearly: true
+- name: new-year
+  start: 2000-12-31T12:00:00Z
+  end: 2001-01-02T00:00:00Z
+  yearly: true
+`), &c))
+	require.NoError(t, c.IsValid())
+	require.Len(t, c.Periods, 3)
+
+	tests := []struct {
+		date   string
+		period string
+	}{
+		{date: "2020-12-18T16:59:59Z"},
+		{date: "2020-12-18T17:00:00Z", period: "end-of-year"},
+		{date: "2021-01-04T06:59:59Z", period: "end-of-year"},
+		{date: "2021-01-04T07:00:00Z"},
+		{date: "2022-12-23T23:59:59Z"},
+		{date: "2022-12-24T10:00:00Z", period: "christmas"},
+		{date: "2022-12-26T00:00:00Z"},
+		{date: "2022-12-31T12:00:00Z", period: "new-year"},
+		{date: "2023-01-01T23:00:00Z", period: "new-year"},
+		{date: "2023-01-02T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		d, err := time.Parse(time.RFC3339, tt.date)
+		require.NoError(t, err)
+		p := c.PeriodAt(d)
+		if tt.period == "" {
+			require.Nil(t, p, tt.date)
+		} else {
+			require.NotNil(t, p, tt.date)
+			require.Equal(t, tt.period, p.Name, tt.date)
+		}
+	}
+
+	c.Periods = append(c.Periods, CalendarPeriod{Name: "wrong", Start: time.Now(), End: time.Now().Add(-time.Hour)})
+	require.Error(t, c.IsValid())
+
+	c.Periods = CalendarPeriods{{Name: "too-long", Start: time.Now(), End: time.Now().AddDate(2, 0, 0), Yearly: true}}
+	require.Error(t, c.IsValid())
+
+	c = ProjectCalendar{Name: "my calendar"}
+	require.Error(t, c.IsValid())
+}
@@ -17,3 +17,7 @@ type WorkflowRunToKeep struct {
 type UpdateMaxRunRequest struct {
 	MaxRuns int64 `json:"max_runs"`
 }
+
+type UpdateWorkflowPriorityRequest struct {
+	Priority int64 `json:"priority"`
+}
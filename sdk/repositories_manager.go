@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"sort"
 	"time"
 )
 
@@ -57,6 +58,26 @@ type VCSCommit struct {
 	Timestamp int64     `json:"authorTimestamp"`
 	Message   string    `json:"message"`
 	URL       string    `json:"url"`
+	// Files changed by the commit, only given when listing the commits between two refs. Repository managers that
+	// only give the changes between the two refs report them on the last commit.
+	Files []string `json:"files,omitempty"`
+}
+
+// VCSCommitsFiles returns the sorted list of the files changed by the given commits.
+func VCSCommitsFiles(commits []VCSCommit) []string {
+	var files []string
+	seen := make(map[string]struct{})
+	for _, c := range commits {
+		for _, f := range c.Files {
+			if _, ok := seen[f]; ok {
+				continue
+			}
+			seen[f] = struct{}{}
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+	return files
 }
 
 //VCSRemote represents remotes known by the repositories manager
@@ -130,6 +151,8 @@ type VCSPushEvent struct {
 	Branch   VCSBranch `json:"branch"`
 	Commit   VCSCommit `json:"commit"`
 	CloneURL string    `json:"clone_url"`
+	// ChangedFiles are the files changed since the last build of the branch
+	ChangedFiles []string `json:"changed_files,omitempty"`
 }
 
 //VCSCreateEvent represents a push events for polling
@@ -163,6 +186,7 @@ type VCSHook struct {
 	Disable     bool     `json:"disable"`
 	InsecureSSL bool     `json:"insecure_ssl"`
 	Workflow    bool     `json:"workflow"`
+	Secret      string   `json:"secret,omitempty"`
 }
 
 // VCSCommitStatus represents a status on a VCS repository
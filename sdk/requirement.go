@@ -13,6 +13,8 @@ const (
 	ServiceRequirement = "service"
 	//MemoryRequirement set memory limit on a container
 	MemoryRequirement = "memory"
+	// CPURequirement set CPU limit on a container
+	CPURequirement = "cpu"
 	// VolumeRequirement set Volume limit on a container
 	VolumeRequirement = "volume"
 	// OSArchRequirement checks the 'dist' of a worker eg {GOOS}/{GOARCH}
@@ -83,6 +85,7 @@ var (
 	// AvailableRequirementsType List of all requirements
 	AvailableRequirementsType = []string{
 		BinaryRequirement,
+		CPURequirement,
 		HostnameRequirement,
 		MemoryRequirement,
 		ModelRequirement,
@@ -168,6 +168,7 @@ var (
 		"git.url",
 		"git.http_url",
 		"git.server",
+		"git.changed.files",
 	}
 )
 
@@ -117,6 +117,33 @@ var (
 		"push",
 	}
 
+	GiteaEvents = []string{
+		"push",
+		"create",
+		"delete",
+		"fork",
+		"issues",
+		"issue_assign",
+		"issue_label",
+		"issue_milestone",
+		"issue_comment",
+		"pull_request",
+		"pull_request_assign",
+		"pull_request_label",
+		"pull_request_milestone",
+		"pull_request_comment",
+		"pull_request_review_approved",
+		"pull_request_review_rejected",
+		"pull_request_review_comment",
+		"pull_request_sync",
+		"repository",
+		"release",
+	}
+
+	GiteaEventsDefault = []string{
+		"push",
+	}
+
 	GitlabEventsDefault = []string{
 		"Push Hook",
 		"Tag Push Hook",
@@ -0,0 +1,125 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// MergeOpts represents options to checkout the merge result of a pull request
+type MergeOpts struct {
+	PullRequestID string
+	BaseBranch    string
+	HeadHash      string
+}
+
+// MergeResult contains the commits of the merge result of a pull request
+type MergeResult struct {
+	// Ref is the ref published by the repository manager, empty if the merge was computed locally
+	Ref       string
+	BaseHash  string
+	HeadHash  string
+	MergeHash string
+}
+
+// mergeResultRefs are the refs where repository managers publish the merge result of a pull request:
+// GitHub and Gitea, GitLab and Bitbucket Server.
+var mergeResultRefs = []string{
+	"refs/pull/%s/merge",
+	"refs/merge-requests/%s/merge",
+	"refs/pull-requests/%s/merge",
+}
+
+const mergeResultLocalRef = "refs/remotes/origin/cds-merge-result"
+
+// CheckoutMergeResult checks out the result of the merge of a pull request in its base branch, in a cloned repository.
+// The merge result published by the repository manager is used if it is up to date with the head commit,
+// else the merge is computed locally. The working tree is left on a detached HEAD.
+func CheckoutMergeResult(repo, dir string, auth *AuthOpts, opts *MergeOpts, output *OutputOpts) (MergeResult, error) {
+	var res MergeResult
+	if opts == nil || opts.BaseBranch == "" {
+		return res, sdk.WithStack(fmt.Errorf("base branch is mandatory to compute the merge result"))
+	}
+
+	var err error
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return res, sdk.WithStack(err)
+	}
+
+	res.HeadHash = opts.HeadHash
+	if res.HeadHash == "" {
+		res.HeadHash, err = gitRevParse(dir, "HEAD")
+		if err != nil {
+			return res, err
+		}
+	}
+
+	if opts.PullRequestID != "" {
+		for _, f := range mergeResultRefs {
+			ref := fmt.Sprintf(f, opts.PullRequestID)
+			fetchCmd := cmd{workdir: dir, cmd: "git", args: []string{"fetch", "--quiet", "origin", "+" + ref + ":" + mergeResultLocalRef}}
+			if err := runGitCommands(repo, []cmd{fetchCmd}, auth, nil); err != nil {
+				continue
+			}
+			// The merge result can be outdated if the repository manager didn't compute it again for the head commit
+			head, err := gitRevParse(dir, mergeResultLocalRef+"^2")
+			if err != nil || head != res.HeadHash {
+				continue
+			}
+			res.BaseHash, err = gitRevParse(dir, mergeResultLocalRef+"^1")
+			if err != nil {
+				return res, err
+			}
+			checkoutCmd := cmd{workdir: dir, cmd: "git", args: []string{"checkout", "--quiet", "--detach", mergeResultLocalRef}}
+			if err := runGitCommandRaw([]cmd{checkoutCmd}, output); err != nil {
+				return res, sdk.WithStack(fmt.Errorf("unable to checkout %s: %v", ref, err))
+			}
+			res.Ref = ref
+			res.MergeHash, err = gitRevParse(dir, "HEAD")
+			return res, err
+		}
+	}
+
+	// Compute the merge locally, the full history is needed to find the merge base
+	commands := []cmd{}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "shallow")); err == nil {
+		commands = append(commands, cmd{workdir: dir, cmd: "git", args: []string{"fetch", "--quiet", "--unshallow", "origin"}})
+	}
+	baseRef := "refs/remotes/origin/" + opts.BaseBranch
+	commands = append(commands, cmd{workdir: dir, cmd: "git", args: []string{"fetch", "--quiet", "origin", "+refs/heads/" + opts.BaseBranch + ":" + baseRef}})
+	if err := runGitCommands(repo, commands, auth, output); err != nil {
+		return res, sdk.WithStack(fmt.Errorf("unable to fetch base branch %s: %v", opts.BaseBranch, err))
+	}
+	res.BaseHash, err = gitRevParse(dir, baseRef)
+	if err != nil {
+		return res, err
+	}
+
+	mergeCmds := []cmd{
+		{workdir: dir, cmd: "git", args: []string{"checkout", "--quiet", "--detach", res.BaseHash}},
+		{workdir: dir, cmd: "git", args: []string{"-c", "user.name=CDS", "-c", "user.email=cds@localhost", "merge", "--no-ff", "--no-edit", res.HeadHash}},
+	}
+	if err := runGitCommandRaw(mergeCmds, output); err != nil {
+		abortCmd := cmd{workdir: dir, cmd: "git", args: []string{"merge", "--abort"}}
+		_ = runGitCommandRaw([]cmd{abortCmd}, nil)
+		return res, sdk.WithStack(fmt.Errorf("unable to merge %s in %s, there may be conflicts: %v", res.HeadHash, opts.BaseBranch, err))
+	}
+	res.MergeHash, err = gitRevParse(dir, "HEAD")
+	return res, err
+}
+
+func gitRevParse(dir, rev string) (string, error) {
+	out, err := gitRawCommandString([]cmd{{workdir: dir, cmd: "git", args: []string{"rev-parse", "--verify", "--quiet", rev}}})
+	if err != nil {
+		return "", err
+	}
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return "", sdk.WithStack(fmt.Errorf("unable to find revision %s", rev))
+	}
+	return out, nil
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk/vcs"
+)
+
+func TestCheckoutMergeResult(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "cds-git-merge")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp) // nolint
+
+	run := func(dir string, args ...string) string {
+		c := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@localhost"}, args...)...)
+		c.Dir = dir
+		out, err := c.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	write := func(dir, file, content string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, file), []byte(content), os.FileMode(0644)))
+	}
+
+	// Create a repository with a pull request from feature to master
+	origin := filepath.Join(tmp, "origin")
+	require.NoError(t, os.MkdirAll(origin, os.FileMode(0755)))
+	run(origin, "init", "--quiet")
+	run(origin, "checkout", "--quiet", "-b", "master")
+	write(origin, "README.md", "readme")
+	run(origin, "add", ".")
+	run(origin, "commit", "--quiet", "-m", "init")
+	run(origin, "checkout", "--quiet", "-b", "feature")
+	write(origin, "feature.txt", "feature")
+	run(origin, "add", ".")
+	run(origin, "commit", "--quiet", "-m", "feature")
+	head := run(origin, "rev-parse", "HEAD")
+	run(origin, "checkout", "--quiet", "master")
+	write(origin, "master.txt", "master")
+	run(origin, "add", ".")
+	run(origin, "commit", "--quiet", "-m", "master")
+	base := run(origin, "rev-parse", "HEAD")
+
+	auth := &AuthOpts{PrivateKey: vcs.SSHKey{Filename: filepath.Join(tmp, "key")}}
+	clone := func(name string) string {
+		dir := filepath.Join(tmp, name)
+		run(tmp, "clone", "--quiet", "--branch", "feature", origin, dir)
+		return dir
+	}
+
+	// Without merge result published by the repository manager, the merge is computed locally
+	dir := clone("local")
+	res, err := CheckoutMergeResult(origin, dir, auth, &MergeOpts{PullRequestID: "1", BaseBranch: "master"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "", res.Ref)
+	require.Equal(t, head, res.HeadHash)
+	require.Equal(t, base, res.BaseHash)
+	require.Equal(t, base, run(dir, "rev-parse", "HEAD^1"))
+	require.Equal(t, head, run(dir, "rev-parse", "HEAD^2"))
+	require.FileExists(t, filepath.Join(dir, "feature.txt"))
+	require.FileExists(t, filepath.Join(dir, "master.txt"))
+
+	// The merge result published by the repository manager is used when it is up to date
+	run(origin, "checkout", "--quiet", "--detach", base)
+	run(origin, "merge", "--quiet", "--no-ff", "--no-edit", head)
+	run(origin, "update-ref", "refs/pull/1/merge", "HEAD")
+	merge := run(origin, "rev-parse", "HEAD")
+	run(origin, "checkout", "--quiet", "master")
+
+	dir = clone("ref")
+	res, err = CheckoutMergeResult(origin, dir, auth, &MergeOpts{PullRequestID: "1", BaseBranch: "master", HeadHash: head}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "refs/pull/1/merge", res.Ref)
+	require.Equal(t, merge, res.MergeHash)
+	require.Equal(t, base, res.BaseHash)
+
+	// Conflicts are reported
+	run(origin, "checkout", "--quiet", "master")
+	write(origin, "feature.txt", "conflict")
+	run(origin, "add", ".")
+	run(origin, "commit", "--quiet", "-m", "conflict")
+	dir = clone("conflict")
+	_, err = CheckoutMergeResult(origin, dir, auth, &MergeOpts{PullRequestID: "2", BaseBranch: "master"}, nil)
+	require.Error(t, err)
+}
@@ -32,6 +32,11 @@ type WorkerRegistrationForm struct {
 	Arch               string
 }
 
+// WorkerAssignForm is sent by an hatchery to assign a booked job to one of its idle workers
+type WorkerAssignForm struct {
+	WorkerID string `json:"worker_id"`
+}
+
 // SpawnErrorForm represents the arguments needed to add error registration on worker model
 type SpawnErrorForm struct {
 	Error string
@@ -83,6 +88,7 @@ type WorkflowNodeJobRunData struct {
 	SubNumber       int64
 	SigningKey      string
 	GelfServiceAddr string
+	CDNHttpAddr     string
 	ProjectKey      string
 	WorkflowName    string
 	WorkflowID      int64
@@ -16,6 +16,10 @@ const (
 	DefaultHistoryLength int64 = 20
 )
 
+// WorkflowMetadataDeleteIfUndeclared is the metadata that allows to delete an as code workflow
+// when it is not declared in its repository anymore
+const WorkflowMetadataDeleteIfUndeclared = "delete_if_undeclared"
+
 // ColorRegexp represent the regexp for a format to hexadecimal color
 var ColorRegexp = regexp.MustCompile(`^#\w{3,8}$`)
 
@@ -43,6 +47,7 @@ type Workflow struct {
 	PurgeTags               PurgeTags                    `json:"purge_tags,omitempty" db:"purge_tags" cli:"-"`
 	RetentionPolicy         string                       `json:"retention_policy,omitempty" db:"retention_policy" cli:"-"`
 	MaxRuns                 int64                        `json:"max_runs,omitempty" db:"max_runs" cli:"-"`
+	Priority                int64                        `json:"priority,omitempty" db:"priority" cli:"-"`
 	Notifications           []WorkflowNotification       `json:"notifications,omitempty" db:"-" cli:"-"`
 	FromRepository          string                       `json:"from_repository,omitempty" db:"from_repository" cli:"from"`
 	DerivedFromWorkflowID   int64                        `json:"derived_from_workflow_id,omitempty" db:"derived_from_workflow_id" cli:"-"`
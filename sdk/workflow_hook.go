@@ -5,9 +5,11 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"path"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Those are icon for hooks
@@ -16,6 +18,7 @@ const (
 	GitHubIcon    = "Github"
 	BitbucketIcon = "Bitbucket"
 	GerritIcon    = "git"
+	GiteaIcon     = "git"
 )
 
 //NodeHook represents a hook which cann trigger the workflow from a given node
@@ -33,6 +36,11 @@ func (h NodeHook) IsRepositoryWebHook() bool {
 	return h.HookModelName == RepositoryWebHookModel.Name || h.HookModelID == RepositoryWebHookModel.ID
 }
 
+// IsCommentCommandHook returns true if the hook is triggered by commands written in pull request comments.
+func (h NodeHook) IsCommentCommandHook() bool {
+	return h.HookModelName == CommentCommandHookModel.Name || (h.HookModelID != 0 && h.HookModelID == CommentCommandHookModel.ID)
+}
+
 func (h NodeHook) GetConfigValue(k string) (string, bool) {
 	v, ok := h.Config[k]
 	if !ok {
@@ -69,6 +77,7 @@ func (h NodeHook) ConfigValueContainsEventsDefault() bool {
 		BitbucketCloudEventsDefault,
 		BitbucketEventsDefault,
 		GitHubEventsDefault,
+		GiteaEventsDefault,
 		GitlabEventsDefault,
 		GerritEventsDefault,
 	}
@@ -94,6 +103,8 @@ func (h NodeHook) ConfigValueContainsEventsDefault() bool {
 //Equals checks functional equality between two hooks
 func (h NodeHook) Equals(h1 NodeHook) bool {
 	var areRepoWebHook = (h1.HookModelID == h.HookModelID) && (h.HookModelID == RepositoryWebHookModel.ID)
+	// The events of a comment command hook are not configurable, they are set from the repository manager
+	var areCommentCommandHook = (h1.HookModelID == h.HookModelID) && h.IsCommentCommandHook()
 	var isEventFilter = func(s string) bool { return s == HookConfigEventFilter }
 	var isEmptyEventFilter = func(s string) bool { return s == "" }
 	var isDefaultEventFilter = func(v string) bool {
@@ -101,6 +112,7 @@ func (h NodeHook) Equals(h1 NodeHook) bool {
 			v == strings.Join(BitbucketCloudEventsDefault, ";") ||
 			v == strings.Join(BitbucketEventsDefault, ";") ||
 			v == strings.Join(GitHubEventsDefault, ";") ||
+			v == strings.Join(GiteaEventsDefault, ";") ||
 			v == strings.Join(GitlabEventsDefault, ";") ||
 			v == strings.Join(GerritEventsDefault, ";")
 	}
@@ -116,6 +128,9 @@ func (h NodeHook) Equals(h1 NodeHook) bool {
 		if !has {
 			return false
 		}
+		if areCommentCommandHook && isEventFilter(k) {
+			continue
+		}
 		if areRepoWebHook && isEventFilter(k) {
 			if isEmptyEventFilter(cfg.Value) && !isDefaultEventFilter(cfg1.Value) {
 				return false
@@ -129,6 +144,9 @@ func (h NodeHook) Equals(h1 NodeHook) bool {
 		if !has {
 			return false
 		}
+		if areCommentCommandHook && isEventFilter(k) {
+			continue
+		}
 		if areRepoWebHook && isEventFilter(k) {
 			if isEmptyEventFilter(cfg1.Value) && !isDefaultEventFilter(cfg.Value) {
 				return false
@@ -241,6 +259,26 @@ func (cfg WorkflowNodeHookConfig) Clone() WorkflowNodeHookConfig {
 	return m
 }
 
+// SchedulerJitter returns the jitter of a scheduler hook config, zero if not set.
+func (cfg WorkflowNodeHookConfig) SchedulerJitter() (time.Duration, error) {
+	value := strings.TrimSpace(cfg[SchedulerModelJitter].Value)
+	if value == "" {
+		return 0, nil
+	}
+	jitter, err := time.ParseDuration(value)
+	if err != nil || jitter < 0 {
+		return 0, NewErrorFrom(ErrInvalidHookConfiguration, "invalid jitter %q, it should be a positive duration like 10m", value)
+	}
+	return jitter, nil
+}
+
+// SchedulerCalendars returns the names of the project calendars excluded by a scheduler hook config.
+func (cfg WorkflowNodeHookConfig) SchedulerCalendars() []string {
+	return strings.FieldsFunc(cfg[SchedulerModelCalendars].Value, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	})
+}
+
 // WorkflowNodeHookConfigValue represents the value of a node hook config
 type WorkflowNodeHookConfigValue struct {
 	Value              string   `json:"value"`
@@ -277,3 +315,155 @@ type WorkflowHookModel struct {
 	DefaultConfig WorkflowNodeHookConfig `json:"default_config" db:"-"`
 	Disabled      bool                   `json:"disabled" db:"disabled"`
 }
+
+// Those are the commands that can be written in a pull request comment
+const (
+	HookCommentCommandRun   = "run"
+	HookCommentCommandRetry = "retry"
+	HookCommentCommandStop  = "stop"
+)
+
+// HookCommentCommand is a command written in a pull request comment to drive the workflow runs of the pull request.
+type HookCommentCommand struct {
+	Name          string            `json:"name"`
+	Args          []string          `json:"args,omitempty"`
+	PullRequestID int64             `json:"pull_request_id"`
+	VCSType       string            `json:"vcs_type"`
+	Author        string            `json:"author"`
+	Payload       map[string]string `json:"payload,omitempty"`
+}
+
+// HookCommentCommandResult is the result of a comment command, the message is replied on the pull request.
+type HookCommentCommandResult struct {
+	Message           string `json:"message"`
+	WorkflowRunNumber int64  `json:"workflow_run_number,omitempty"`
+}
+
+// ParseHookCommentCommand returns the command written on the first line of the comment starting with the given prefix.
+func ParseHookCommentCommand(prefix, comment string) (*HookCommentCommand, bool) {
+	if prefix == "" {
+		prefix = CommentCommandHookModel.DefaultConfig[HookConfigCommandPrefix].Value
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != prefix {
+			continue
+		}
+		return &HookCommentCommand{
+			Name: strings.ToLower(fields[1]),
+			Args: fields[2:],
+		}, true
+	}
+	return nil, false
+}
+
+// IsValid returns an error if the command is unknown or has invalid arguments.
+func (c HookCommentCommand) IsValid() error {
+	switch c.Name {
+	case HookCommentCommandRun, HookCommentCommandStop:
+		if len(c.Args) > 0 {
+			return NewErrorFrom(ErrWrongRequest, "command %q does not take arguments", c.Name)
+		}
+	case HookCommentCommandRetry:
+		if len(c.Args) > 1 {
+			return NewErrorFrom(ErrWrongRequest, "command %q takes at most one pipeline name", c.Name)
+		}
+	default:
+		return NewErrorFrom(ErrWrongRequest, "unknown command %q, available commands are %s, %s [pipeline] and %s",
+			c.Name, HookCommentCommandRun, HookCommentCommandRetry, HookCommentCommandStop)
+	}
+	if c.PullRequestID == 0 {
+		return NewErrorFrom(ErrWrongRequest, "missing pull request id")
+	}
+	return nil
+}
+
+// HookPathFilter filters the events of a repository hook on the files changed by the commits.
+// Patterns are separated by ";" and "**" matches any number of directories.
+type HookPathFilter struct {
+	Includes []string
+	Excludes []string
+}
+
+// NewHookPathFilter returns the path filter set in the configuration of a hook.
+func NewHookPathFilter(config WorkflowNodeHookConfig) HookPathFilter {
+	split := func(s string) []string {
+		var patterns []string
+		for _, p := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == '\n' }) {
+			if p = strings.TrimSpace(p); p != "" {
+				patterns = append(patterns, strings.TrimPrefix(p, "/"))
+			}
+		}
+		return patterns
+	}
+	return HookPathFilter{
+		Includes: split(config[HookConfigPathIncludes].Value),
+		Excludes: split(config[HookConfigPathExcludes].Value),
+	}
+}
+
+// IsEmpty returns true if the filter has no pattern.
+func (f HookPathFilter) IsEmpty() bool {
+	return len(f.Includes) == 0 && len(f.Excludes) == 0
+}
+
+// Match returns true if at least one of the files is included and not excluded.
+func (f HookPathFilter) Match(files []string) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	for _, file := range files {
+		file = strings.TrimPrefix(file, "/")
+		included := len(f.Includes) == 0
+		for _, p := range f.Includes {
+			if MatchPathPattern(p, file) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			continue
+		}
+		excluded := false
+		for _, p := range f.Excludes {
+			if MatchPathPattern(p, file) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPathPattern reports whether the file path matches the glob pattern. The pattern uses the syntax of path.Match,
+// "**" matches zero or more directories and a pattern ending with "/" matches all the files of the directory.
+func MatchPathPattern(pattern, file string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	return matchPathSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+func matchPathSegments(patterns, segments []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchPathSegments(patterns[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, err := path.Match(patterns[0], segments[0]); err != nil || !ok {
+			return false
+		}
+		patterns, segments = patterns[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
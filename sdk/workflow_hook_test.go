@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -60,3 +61,100 @@ func TestNodeHook_ConfigValueContainsEventsDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestParseHookCommentCommand(t *testing.T) {
+	var tests = []struct {
+		prefix   string
+		comment  string
+		found    bool
+		expected HookCommentCommand
+		valid    bool
+	}{
+		{comment: "/cds run", found: true, expected: HookCommentCommand{Name: "run", Args: []string{}}, valid: true},
+		{comment: "Looks flaky\n/cds   Retry  build-pipeline \nthanks", found: true, expected: HookCommentCommand{Name: "retry", Args: []string{"build-pipeline"}}, valid: true},
+		{prefix: "!ci", comment: "!ci stop", found: true, expected: HookCommentCommand{Name: "stop", Args: []string{}}, valid: true},
+		{prefix: "!ci", comment: "/cds stop"},
+		{comment: "please /cds run"},
+		{comment: "/cds"},
+		{comment: "/cds deploy prod", found: true, expected: HookCommentCommand{Name: "deploy", Args: []string{"prod"}}},
+		{comment: "/cds stop now", found: true, expected: HookCommentCommand{Name: "stop", Args: []string{"now"}}},
+		{comment: "/cds retry a b", found: true, expected: HookCommentCommand{Name: "retry", Args: []string{"a", "b"}}},
+	}
+
+	for _, tt := range tests {
+		cmd, found := ParseHookCommentCommand(tt.prefix, tt.comment)
+		require.Equal(t, tt.found, found, tt.comment)
+		if !found {
+			continue
+		}
+		require.Equal(t, tt.expected, *cmd)
+		cmd.PullRequestID = 1
+		if tt.valid {
+			require.NoError(t, cmd.IsValid(), tt.comment)
+		} else {
+			require.Error(t, cmd.IsValid(), tt.comment)
+		}
+	}
+}
+
+func TestHookPathFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		includes string
+		excludes string
+		files    []string
+		match    bool
+	}{
+		{name: "no filter", files: []string{"README.md"}, match: true},
+		{name: "included file", includes: "services/api/**", files: []string{"README.md", "services/api/main.go"}, match: true},
+		{name: "no included file", includes: "services/api/**", files: []string{"README.md", "services/ui/main.ts"}, match: false},
+		{name: "included directory", includes: "services/api/", files: []string{"services/api/internal/db/db.go"}, match: true},
+		{name: "several patterns", includes: "services/api/**; libs/**", files: []string{"libs/log/log.go"}, match: true},
+		{name: "double star in the middle", includes: "services/**/*.go", files: []string{"services/api/internal/db/db.go"}, match: true},
+		{name: "star does not match directories", includes: "services/*.go", files: []string{"services/api/main.go"}, match: false},
+		{name: "excluded file", includes: "services/api/**", excludes: "**/*.md", files: []string{"services/api/README.md"}, match: false},
+		{name: "only excludes", excludes: "docs/**;**/*.md", files: []string{"docs/index.md", "main.go"}, match: true},
+		{name: "all files excluded", excludes: "docs/**;**/*.md", files: []string{"docs/index.md", "README.md"}, match: false},
+		{name: "no files", includes: "services/api/**", files: nil, match: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewHookPathFilter(WorkflowNodeHookConfig{
+				HookConfigPathIncludes: {Value: tt.includes},
+				HookConfigPathExcludes: {Value: tt.excludes},
+			})
+			require.Equal(t, tt.match, f.Match(tt.files))
+		})
+	}
+}
+
+func TestWorkflowNodeHookConfig_SchedulerJitter(t *testing.T) {
+	tests := []struct {
+		value  string
+		jitter time.Duration
+		err    bool
+	}{
+		{value: "", jitter: 0},
+		{value: " 10m ", jitter: 10 * time.Minute},
+		{value: "1h30m", jitter: 90 * time.Minute},
+		{value: "10", err: true},
+		{value: "-5m", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			jitter, err := WorkflowNodeHookConfig{SchedulerModelJitter: {Value: tt.value}}.SchedulerJitter()
+			if tt.err {
+				require.True(t, ErrorIs(err, ErrInvalidHookConfiguration))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.jitter, jitter)
+		})
+	}
+}
+
+func TestWorkflowNodeHookConfig_SchedulerCalendars(t *testing.T) {
+	require.Empty(t, WorkflowNodeHookConfig{}.SchedulerCalendars())
+	require.Equal(t, []string{"holidays", "freeze", "weekends"},
+		WorkflowNodeHookConfig{SchedulerModelCalendars: {Value: "holidays; freeze,weekends"}}.SchedulerCalendars())
+}
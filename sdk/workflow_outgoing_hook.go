@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/ovh/cds/sdk/interpolate"
+)
+
+// OutgoingWebHookTemplate is an outgoing webhook request to validate or preview before saving it.
+// Parameters are used to interpolate the templates, they can be completed with the build
+// parameters of an existing workflow run.
+type OutgoingWebHookTemplate struct {
+	Method     string            `json:"method"`
+	URL        string            `json:"url"`
+	Payload    string            `json:"payload"`
+	Headers    string            `json:"headers"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	RunNumber  int64             `json:"run_number,omitempty"`
+	NodeName   string            `json:"node_name,omitempty"`
+}
+
+// OutgoingWebHookPreview is an outgoing webhook request rendered with parameters.
+type OutgoingWebHookPreview struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Body    string      `json:"body"`
+	Headers http.Header `json:"headers"`
+}
+
+// Render interpolates the templates of the outgoing webhook with its parameters.
+func (t OutgoingWebHookTemplate) Render() (*OutgoingWebHookPreview, error) {
+	var p OutgoingWebHookPreview
+	var err error
+	if p.Method, err = interpolate.Do(t.Method, t.Parameters); err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid method template: %v", err)
+	}
+	if p.URL, err = interpolate.Do(t.URL, t.Parameters); err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid url template: %v", err)
+	}
+	if p.Body, err = interpolate.Do(t.Payload, t.Parameters); err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid payload template: %v", err)
+	}
+	headers, err := interpolate.Do(t.Headers, t.Parameters)
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid headers template: %v", err)
+	}
+	p.Headers = http.Header{}
+	p.Headers.Set("Content-Type", "application/json")
+	h, err := ParseOutgoingWebHookHeaders(headers)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range h {
+		p.Headers[k] = v
+	}
+	return &p, nil
+}
+
+// IsValid checks that the templates can be rendered and that the result is a valid request.
+// A JSON payload must be valid JSON once rendered.
+func (t OutgoingWebHookTemplate) IsValid() error {
+	p, err := t.Render()
+	if err != nil {
+		return err
+	}
+	if p.URL == "" {
+		return NewErrorFrom(ErrInvalidData, "url is mandatory")
+	}
+	if p.Method != "" {
+		switch strings.ToUpper(p.Method) {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return NewErrorFrom(ErrInvalidData, "invalid method %s", p.Method)
+		}
+	}
+	if strings.Contains(p.Headers.Get("Content-Type"), "json") && strings.TrimSpace(p.Body) != "" && !json.Valid([]byte(p.Body)) {
+		return NewErrorFrom(ErrInvalidData, "payload is not a valid JSON: %s", p.Body)
+	}
+	return nil
+}
+
+// ParseOutgoingWebHookHeaders parses headers given as "Name: value" lines, empty lines are ignored.
+func ParseOutgoingWebHookHeaders(s string) (http.Header, error) {
+	h := http.Header{}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i <= 0 {
+			return nil, NewErrorFrom(ErrInvalidData, "invalid header %q, it should be 'Name: value'", line)
+		}
+		name := strings.TrimSpace(line[:i])
+		if strings.ContainsAny(name, " \t") {
+			return nil, NewErrorFrom(ErrInvalidData, "invalid header name %q", name)
+		}
+		h.Add(textproto.CanonicalMIMEHeaderKey(name), strings.TrimSpace(line[i+1:]))
+	}
+	return h, nil
+}
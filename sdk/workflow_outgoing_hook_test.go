@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutgoingWebHookTemplate(t *testing.T) {
+	tmpl := OutgoingWebHookTemplate{
+		Method: "POST",
+		URL:    "https://hooks.slack.com/services/{{.cds.proj.slack_path}}",
+		Payload: `{
+  "text": {{toJSON (printf "%s #%s is %s" .cds.workflow .cds.version .cds.status)}},
+  "color": "{{if eq .cds.status "Success"}}good{{else}}danger{{end}}"
+}`,
+		Headers: `X-Workflow: {{.cds.workflow}}
+content-type: application/json; charset=utf-8
+
+{{if .git.branch}}X-Branch: {{.git.branch}}{{end}}`,
+		Parameters: map[string]string{
+			"cds.workflow": "my-workflow",
+			"cds.version":  "12",
+			"cds.status":   "Fail",
+			"git.branch":   "master",
+		},
+	}
+
+	p, err := tmpl.Render()
+	require.NoError(t, err)
+	require.Equal(t, "https://hooks.slack.com/services/{{.cds.proj.slack_path}}", p.URL)
+	require.Equal(t, "{\n  \"text\": \"my-workflow #12 is Fail\",\n  \"color\": \"danger\"\n}", p.Body)
+	require.Equal(t, "my-workflow", p.Headers.Get("X-Workflow"))
+	require.Equal(t, "application/json; charset=utf-8", p.Headers.Get("Content-Type"))
+	require.Equal(t, "master", p.Headers.Get("X-Branch"))
+	require.NoError(t, tmpl.IsValid())
+
+	tmpl.Parameters["cds.status"] = "Success"
+	p, err = tmpl.Render()
+	require.NoError(t, err)
+	require.Contains(t, p.Body, `"color": "good"`)
+
+	// Invalid template
+	tmpl.Payload = `{"text": "{{if .cds.status}}missing end"}`
+	_, err = tmpl.Render()
+	require.Error(t, err)
+
+	// Invalid JSON payload
+	tmpl.Payload = `{"text": {{.cds.status}}}`
+	require.Error(t, tmpl.IsValid())
+
+	// Invalid header
+	tmpl.Payload = `{}`
+	tmpl.Headers = "X-Workflow"
+	require.Error(t, tmpl.IsValid())
+}
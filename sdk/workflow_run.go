@@ -374,12 +374,27 @@ type WorkflowNodeRun struct {
 
 // WorkflowNodeOutgoingHookRunCallback is the callback coming from hooks uservice avec an outgoing hook execution
 type WorkflowNodeOutgoingHookRunCallback struct {
-	NodeHookID        int64     `json:"workflow_node_outgoing_hook_id"`
-	Start             time.Time `json:"start"`
-	Done              time.Time `json:"done"`
-	Status            string    `json:"status"`
-	Log               string    `json:"log"`
-	WorkflowRunNumber *int64    `json:"workflow_run_number"`
+	NodeHookID        int64                  `json:"workflow_node_outgoing_hook_id"`
+	Start             time.Time              `json:"start"`
+	Done              time.Time              `json:"done"`
+	Status            string                 `json:"status"`
+	Log               string                 `json:"log"`
+	WorkflowRunNumber *int64                 `json:"workflow_run_number"`
+	Deliveries        []OutgoingHookDelivery `json:"deliveries,omitempty"`
+}
+
+// OutgoingHookDelivery is an attempt to deliver an outgoing webhook
+type OutgoingHookDelivery struct {
+	HookRunID  string    `json:"hook_run_id,omitempty" cli:"hook_run_id"`
+	NodeName   string    `json:"node_name,omitempty" cli:"node"`
+	Attempt    int       `json:"attempt" cli:"attempt"`
+	Date       time.Time `json:"date" cli:"date"`
+	Method     string    `json:"method" cli:"method"`
+	URL        string    `json:"url" cli:"url"`
+	StatusCode int       `json:"status_code" cli:"status_code"`
+	Latency    int64     `json:"latency_ms" cli:"latency_ms"`
+	Error      string    `json:"error,omitempty" cli:"error"`
+	Response   string    `json:"response,omitempty" cli:"response"`
 }
 
 // WorkflowNodeRunVulnerabilityReport represents vulnerabilities report for the current node run
@@ -498,6 +513,9 @@ type WorkflowNodeJobRun struct {
 	ContainsService           bool               `json:"contains_service,omitempty"`
 	HatcheryName              string             `json:"hatchery_name,omitempty"`
 	WorkerName                string             `json:"worker_name,omitempty"`
+	Priority                  int64              `json:"priority,omitempty"`   // priority of the workflow when the job was queued
+	QueueRank                 int                `json:"queue_rank,omitempty"` // position in the queue computed by the API, 1 is the next job to run
+	Boosted                   bool               `json:"boosted,omitempty"`    // true if the job waited too long in the queue and was moved ahead
 }
 
 // WorkflowNodeJobRunSummary is a light representation of WorkflowNodeJobRun for CDS event
@@ -610,10 +628,17 @@ func (q WorkflowQueue) Sort() {
 		n[j.ProjectID] = nb
 	}
 
-	sort.Slice(q, func(i, j int) bool {
+	sort.SliceStable(q, func(i, j int) bool {
+		// Keep the order computed by the API, jobs without rank come last
+		r1, r2 := q[i].QueueRank, q[j].QueueRank
+		if r1 != r2 && (r1 == 0 || r2 == 0) {
+			return r2 == 0
+		}
+		if r1 != r2 {
+			return r1 < r2
+		}
 		p1 := n[q[i].ProjectID]
 		p2 := n[q[j].ProjectID]
 		return p1 < p2
 	})
-
 }
@@ -240,6 +240,25 @@ func TestWorkflowQueue_Sort(t *testing.T) {
 	}
 }
 
+func TestWorkflowQueue_SortRanked(t *testing.T) {
+	q := WorkflowQueue{
+		{ID: 1, ProjectID: 1, QueueRank: 3},
+		{ID: 2, ProjectID: 1, QueueRank: 1},
+		{ID: 3, ProjectID: 2, QueueRank: 2},
+	}
+	q.Sort()
+	assert.Equal(t, []int64{2, 3, 1}, []int64{q[0].ID, q[1].ID, q[2].ID})
+
+	// A job without rank doesn't drop the order of the ranked ones
+	q = WorkflowQueue{
+		{ID: 1, ProjectID: 1, QueueRank: 2},
+		{ID: 2, ProjectID: 1},
+		{ID: 3, ProjectID: 2, QueueRank: 1},
+	}
+	q.Sort()
+	assert.Equal(t, []int64{3, 1, 2}, []int64{q[0].ID, q[1].ID, q[2].ID})
+}
+
 func TestWorkflowRunVersion_IsValid(t *testing.T) {
 	require.NoError(t, WorkflowRunVersion{Value: "1.2.3"}.IsValid())
 	require.NoError(t, WorkflowRunVersion{Value: "1.2.3-snapshot.1"}.IsValid())
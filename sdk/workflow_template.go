@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	json "encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -36,6 +38,35 @@ func (w *WorkflowTemplateRequest) Scan(src interface{}) error {
 	return WrapError(json.Unmarshal(source, w), "cannot unmarshal WorkflowTemplateRequest")
 }
 
+// IsValid returns the request validity for given template, the value of each parameter should match its type.
+func (w WorkflowTemplateRequest) IsValid(wt WorkflowTemplate) error {
+	if w.ProjectKey == "" {
+		return NewErrorFrom(ErrInvalidData, "Project key is required")
+	}
+	if w.WorkflowName == "" {
+		return NewErrorFrom(ErrInvalidData, "Missing workflow name")
+	}
+	regexp := NamePatternRegex
+	if !regexp.MatchString(w.WorkflowName) {
+		return NewErrorFrom(ErrInvalidData, "Invalid given workflow name '%s', should match %s pattern", w.WorkflowName, NamePattern)
+	}
+
+	for _, p := range wt.Parameters {
+		v, ok := w.Parameters[p.Key]
+		if !ok || v == "" {
+			if p.Required {
+				return NewErrorFrom(ErrInvalidData, "Param %s is required", p.Key)
+			}
+			continue
+		}
+		if err := p.CheckValue(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // WorkflowTemplateParsed struct.
 type WorkflowTemplateParsed struct {
 	Workflow     *template.Template
@@ -64,6 +95,8 @@ type WorkflowTemplate struct {
 	LastAudit     *AuditWorkflowTemplate `json:"last_audit,omitempty" db:"-"`
 	Editable      bool                   `json:"editable,omitempty" db:"-"`
 	ChangeMessage string                 `json:"change_message,omitempty" db:"-"`
+	// Dependencies are the templates imported or extended by the template, indexed by path as given in its files
+	Dependencies map[string]WorkflowTemplate `json:"-" db:"-"`
 }
 
 // Value returns driver.Value from workflow template.
@@ -132,48 +165,7 @@ func (w *WorkflowTemplate) IsValid() error {
 
 // CheckParams returns template parameters validity.
 func (w *WorkflowTemplate) CheckParams(r WorkflowTemplateRequest) error {
-	if r.ProjectKey == "" {
-		return NewErrorFrom(ErrInvalidData, "Project key is required")
-	}
-	if r.WorkflowName == "" {
-		return NewErrorFrom(ErrInvalidData, "Missing workflow name")
-	}
-	regexp := NamePatternRegex
-	if !regexp.MatchString(r.WorkflowName) {
-		return NewErrorFrom(ErrInvalidData, "Invalid given workflow name '%s', should match %s pattern", r.WorkflowName, NamePattern)
-	}
-
-	for _, p := range w.Parameters {
-		v, ok := r.Parameters[p.Key]
-		if !ok && p.Required {
-			return NewErrorFrom(ErrInvalidData, "Param %s is required", p.Key)
-		}
-		if ok {
-			if p.Required && v == "" {
-				return NewErrorFrom(ErrInvalidData, "Param %s is required", p.Key)
-			}
-			switch p.Type {
-			case ParameterTypeBoolean:
-				if v != "" && !(v == "true" || v == "false") {
-					return NewErrorFrom(ErrInvalidData, "Given value it's not a boolean for %s", p.Key)
-				}
-			case ParameterTypeRepository:
-				sp := strings.Split(v, "/")
-				if len(sp) != 3 {
-					return NewErrorFrom(ErrInvalidData, "Given value don't match vcs/repository pattern for %s", p.Key)
-				}
-			case ParameterTypeJSON:
-				if v != "" {
-					var res interface{}
-					if err := json.Unmarshal([]byte(v), &res); err != nil {
-						return NewErrorFrom(ErrInvalidData, "Given value it's not json for %s", p.Key)
-					}
-				}
-			}
-		}
-	}
-
-	return nil
+	return r.IsValid(*w)
 }
 
 // Update workflow template field from new data.
@@ -264,18 +256,27 @@ type TemplateParameterType string
 
 // Parameter types.
 const (
-	ParameterTypeString     TemplateParameterType = "string"
-	ParameterTypeBoolean    TemplateParameterType = "boolean"
-	ParameterTypeRepository TemplateParameterType = "repository"
-	ParameterTypeSSHKey     TemplateParameterType = "ssh-key"
-	ParameterTypePGPKey     TemplateParameterType = "pgp-key"
-	ParameterTypeJSON       TemplateParameterType = "json"
+	ParameterTypeString      TemplateParameterType = "string"
+	ParameterTypeBoolean     TemplateParameterType = "boolean"
+	ParameterTypeRepository  TemplateParameterType = "repository"
+	ParameterTypeSSHKey      TemplateParameterType = "ssh-key"
+	ParameterTypePGPKey      TemplateParameterType = "pgp-key"
+	ParameterTypeJSON        TemplateParameterType = "json"
+	ParameterTypeList        TemplateParameterType = "list"
+	ParameterTypeMultiSelect TemplateParameterType = "multi-select"
+	ParameterTypeNumber      TemplateParameterType = "number"
+	ParameterTypeRegexp      TemplateParameterType = "regexp"
+	ParameterTypeEnvironment TemplateParameterType = "environment"
+	ParameterTypeIntegration TemplateParameterType = "integration"
+	ParameterTypeSecret      TemplateParameterType = "secret"
 )
 
 // IsValid returns parameter type validity.
 func (t TemplateParameterType) IsValid() bool {
 	switch t {
-	case ParameterTypeString, ParameterTypeBoolean, ParameterTypeRepository, ParameterTypeSSHKey, ParameterTypePGPKey, ParameterTypeJSON:
+	case ParameterTypeString, ParameterTypeBoolean, ParameterTypeRepository, ParameterTypeSSHKey, ParameterTypePGPKey, ParameterTypeJSON,
+		ParameterTypeList, ParameterTypeMultiSelect, ParameterTypeNumber, ParameterTypeRegexp, ParameterTypeEnvironment,
+		ParameterTypeIntegration, ParameterTypeSecret:
 		return true
 	}
 	return false
@@ -286,6 +287,13 @@ type WorkflowTemplateParameter struct {
 	Key      string                `json:"key"`
 	Type     TemplateParameterType `json:"type"`
 	Required bool                  `json:"required"`
+	// Values are the allowed values for list and multi-select parameters
+	Values []string `json:"values,omitempty"`
+	// Min and Max are the optional bounds of number parameters
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// Pattern is the regular expression that should match the whole value of regexp parameters
+	Pattern string `json:"pattern,omitempty"`
 }
 
 // WorkflowTemplateParameters struct.
@@ -377,9 +385,89 @@ func (w *WorkflowTemplateParameter) IsValid() error {
 	if w.Key == "" || !w.Type.IsValid() {
 		return NewErrorFrom(ErrInvalidData, "Invalid given key or type for parameter")
 	}
+	switch w.Type {
+	case ParameterTypeList, ParameterTypeMultiSelect:
+		if len(w.Values) == 0 {
+			return NewErrorFrom(ErrInvalidData, "Missing allowed values for parameter %s", w.Key)
+		}
+	case ParameterTypeNumber:
+		if w.Min != nil && w.Max != nil && *w.Min > *w.Max {
+			return NewErrorFrom(ErrInvalidData, "Invalid min and max for parameter %s", w.Key)
+		}
+	case ParameterTypeRegexp:
+		if w.Pattern == "" {
+			return NewErrorFrom(ErrInvalidData, "Missing pattern for parameter %s", w.Key)
+		}
+		if _, err := regexp.Compile(w.Pattern); err != nil {
+			return NewErrorFrom(ErrInvalidData, "Invalid pattern for parameter %s: %v", w.Key, err)
+		}
+	}
+	return nil
+}
+
+// CheckValue returns an error if given value doesn't match the parameter type.
+func (w WorkflowTemplateParameter) CheckValue(v string) error {
+	switch w.Type {
+	case ParameterTypeBoolean:
+		if !(v == "true" || v == "false") {
+			return NewErrorFrom(ErrInvalidData, "Given value it's not a boolean for %s", w.Key)
+		}
+	case ParameterTypeRepository:
+		sp := strings.Split(v, "/")
+		if len(sp) != 3 {
+			return NewErrorFrom(ErrInvalidData, "Given value don't match vcs/repository pattern for %s", w.Key)
+		}
+	case ParameterTypeJSON:
+		var res interface{}
+		if err := json.Unmarshal([]byte(v), &res); err != nil {
+			return NewErrorFrom(ErrInvalidData, "Given value it's not json for %s", w.Key)
+		}
+	case ParameterTypeList:
+		if !IsInArray(v, w.Values) {
+			return NewErrorFrom(ErrInvalidData, "Given value %s for %s should be one of %s", v, w.Key, strings.Join(w.Values, ", "))
+		}
+	case ParameterTypeMultiSelect:
+		vs, err := ParseTemplateMultiSelectValue(v)
+		if err != nil {
+			return NewErrorFrom(ErrInvalidData, "Given value it's not a list of strings for %s", w.Key)
+		}
+		for _, v := range vs {
+			if !IsInArray(v, w.Values) {
+				return NewErrorFrom(ErrInvalidData, "Given value %s for %s should be one of %s", v, w.Key, strings.Join(w.Values, ", "))
+			}
+		}
+	case ParameterTypeNumber:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return NewErrorFrom(ErrInvalidData, "Given value it's not a number for %s", w.Key)
+		}
+		if w.Min != nil && f < *w.Min {
+			return NewErrorFrom(ErrInvalidData, "Given value for %s should be greater than or equal to %v", w.Key, *w.Min)
+		}
+		if w.Max != nil && f > *w.Max {
+			return NewErrorFrom(ErrInvalidData, "Given value for %s should be less than or equal to %v", w.Key, *w.Max)
+		}
+	case ParameterTypeRegexp:
+		reg, err := regexp.Compile("^(?:" + w.Pattern + ")$")
+		if err != nil {
+			return NewErrorFrom(ErrInvalidData, "Invalid pattern for parameter %s: %v", w.Key, err)
+		}
+		if !reg.MatchString(v) {
+			return NewErrorFrom(ErrInvalidData, "Given value %s for %s don't match pattern %s", v, w.Key, w.Pattern)
+		}
+	}
 	return nil
 }
 
+// ParseTemplateMultiSelectValue returns the values of a multi-select parameter given as a JSON array.
+func ParseTemplateMultiSelectValue(v string) ([]string, error) {
+	var vs []string
+	if err := json.Unmarshal([]byte(v), &vs); err != nil {
+		return nil, WithStack(err)
+	}
+	return vs, nil
+}
+
 // WorkflowTemplateInstance struct.
 type WorkflowTemplateInstance struct {
 	ID                      int64                   `json:"id" db:"id"`
@@ -389,6 +477,8 @@ type WorkflowTemplateInstance struct {
 	WorkflowTemplateVersion int64                   `json:"workflow_template_version" db:"workflow_template_version"`
 	Request                 WorkflowTemplateRequest `json:"request" db:"request"`
 	WorkflowName            string                  `json:"workflow_name" db:"workflow_name"`
+	// PinnedVersion is the template version that should be used for the instance, 0 to follow the latest version
+	PinnedVersion int64 `json:"pinned_version" db:"pinned_version"`
 	// aggregates
 	FirstAudit *AuditWorkflowTemplateInstance `json:"first_audit,omitempty" db:"-"`
 	LastAudit  *AuditWorkflowTemplateInstance `json:"last_audit,omitempty" db:"-"`
@@ -397,6 +487,34 @@ type WorkflowTemplateInstance struct {
 	Workflow   *Workflow                      `json:"workflow,omitempty" db:"-"`
 }
 
+// CheckPinnedVersion returns an error if the instance is pinned to another version than given one.
+func (w WorkflowTemplateInstance) CheckPinnedVersion(version int64) error {
+	if w.PinnedVersion > 0 && w.PinnedVersion != version {
+		return NewErrorFrom(ErrWrongRequest, "workflow %s is pinned to version %d of the template, it can't be generated from version %d",
+			w.Request.WorkflowName, w.PinnedVersion, version)
+	}
+	return nil
+}
+
+// WorkflowTemplateInstancePin is a request to pin a workflow template instance to a template version, 0 to unpin.
+type WorkflowTemplateInstancePin struct {
+	Version int64 `json:"version"`
+}
+
+// WorkflowTemplateInstanceUpgrade describes the changes on a workflow generated by a template instance
+// when upgrading it to another version of the template.
+type WorkflowTemplateInstanceUpgrade struct {
+	InstanceID    int64                   `json:"instance_id" cli:"id,key"`
+	ProjectKey    string                  `json:"project_key" cli:"project"`
+	WorkflowName  string                  `json:"workflow_name" cli:"workflow"`
+	FromVersion   int64                   `json:"from_version" cli:"from"`
+	ToVersion     int64                   `json:"to_version" cli:"to"`
+	PinnedVersion int64                   `json:"pinned_version,omitempty" cli:"pinned"`
+	Request       WorkflowTemplateRequest `json:"request"`
+	Diff          string                  `json:"diff,omitempty"`
+	Error         string                  `json:"error,omitempty" cli:"error"`
+}
+
 // WorkflowTemplateInstancesToIDs returns ids of given workflow template instances.
 func WorkflowTemplateInstancesToIDs(wtis []*WorkflowTemplateInstance) []int64 {
 	ids := make([]int64, len(wtis))
@@ -432,6 +550,8 @@ type WorkflowTemplateBulk struct {
 	UserID             string                         `json:"user_id" db:"authentified_user_id"`
 	WorkflowTemplateID int64                          `json:"workflow_template_id" db:"workflow_template_id"`
 	Operations         WorkflowTemplateBulkOperations `json:"operations" db:"operations"`
+	// Version of the template to apply, 0 for the latest version
+	Version int64 `json:"version" db:"version"`
 }
 
 // IsDone returns true if all operations are complete.
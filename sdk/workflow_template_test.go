@@ -0,0 +1,121 @@
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestWorkflowTemplateParameterIsValid(t *testing.T) {
+	min, max := float64(10), float64(1)
+	cases := []struct {
+		Name  string
+		Param sdk.WorkflowTemplateParameter
+		Valid bool
+	}{
+		{Name: "string", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: sdk.ParameterTypeString}, Valid: true},
+		{Name: "unknown type", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: "unknown"}},
+		{Name: "list", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: sdk.ParameterTypeList, Values: []string{"a"}}, Valid: true},
+		{Name: "list without values", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: sdk.ParameterTypeList}},
+		{Name: "multi-select without values", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: sdk.ParameterTypeMultiSelect}},
+		{Name: "number with invalid bounds", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: sdk.ParameterTypeNumber, Min: &min, Max: &max}},
+		{Name: "regexp", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: sdk.ParameterTypeRegexp, Pattern: "[a-z]+"}, Valid: true},
+		{Name: "regexp without pattern", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: sdk.ParameterTypeRegexp}},
+		{Name: "regexp with invalid pattern", Param: sdk.WorkflowTemplateParameter{Key: "p", Type: sdk.ParameterTypeRegexp, Pattern: "[a-z"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := c.Param.IsValid()
+			if c.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkflowTemplateRequestIsValid(t *testing.T) {
+	min, max := float64(1), float64(5)
+	wt := sdk.WorkflowTemplate{
+		Parameters: []sdk.WorkflowTemplateParameter{
+			{Key: "bool", Type: sdk.ParameterTypeBoolean},
+			{Key: "list", Type: sdk.ParameterTypeList, Values: []string{"dev", "prod"}, Required: true},
+			{Key: "multi", Type: sdk.ParameterTypeMultiSelect, Values: []string{"linux", "windows"}},
+			{Key: "number", Type: sdk.ParameterTypeNumber, Min: &min, Max: &max},
+			{Key: "regexp", Type: sdk.ParameterTypeRegexp, Pattern: "v[0-9]+"},
+			{Key: "secret", Type: sdk.ParameterTypeSecret},
+		},
+	}
+
+	cases := []struct {
+		Name   string
+		Params map[string]string
+		Error  string
+	}{
+		{
+			Name:   "valid values",
+			Params: map[string]string{"bool": "true", "list": "dev", "multi": `["linux","windows"]`, "number": "2.5", "regexp": "v12", "secret": "my-secret"},
+		},
+		{
+			Name:   "missing required",
+			Params: map[string]string{"list": ""},
+			Error:  "Param list is required",
+		},
+		{
+			Name:   "invalid boolean",
+			Params: map[string]string{"list": "dev", "bool": "yes"},
+			Error:  "Given value it's not a boolean for bool",
+		},
+		{
+			Name:   "value not in list",
+			Params: map[string]string{"list": "staging"},
+			Error:  "Given value staging for list should be one of dev, prod",
+		},
+		{
+			Name:   "invalid multi-select",
+			Params: map[string]string{"list": "dev", "multi": "linux"},
+			Error:  "Given value it's not a list of strings for multi",
+		},
+		{
+			Name:   "value not in multi-select",
+			Params: map[string]string{"list": "dev", "multi": `["linux","darwin"]`},
+			Error:  "Given value darwin for multi should be one of linux, windows",
+		},
+		{
+			Name:   "invalid number",
+			Params: map[string]string{"list": "dev", "number": "two"},
+			Error:  "Given value it's not a number for number",
+		},
+		{
+			Name:   "number too big",
+			Params: map[string]string{"list": "dev", "number": "6"},
+			Error:  "Given value for number should be less than or equal to 5",
+		},
+		{
+			Name:   "regexp should match the whole value",
+			Params: map[string]string{"list": "dev", "regexp": "v12-beta"},
+			Error:  "Given value v12-beta for regexp don't match pattern v[0-9]+",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := sdk.WorkflowTemplateRequest{
+				ProjectKey:   "PROJ",
+				WorkflowName: "my-workflow",
+				Parameters:   c.Params,
+			}.IsValid(wt)
+			if c.Error == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, c.Error, sdk.Cause(err).Error())
+		})
+	}
+}
@@ -15,6 +15,7 @@ export class Action {
     actions: Array<Action>;
     optional: boolean;
     always_executed: boolean;
+    timeout: number;
     enabled: boolean;
     deprecated: boolean;
     group: Group;
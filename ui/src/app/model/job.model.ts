@@ -10,6 +10,9 @@ export class Job {
     warnings: Array<ActionWarning>;
     worker_name: string;
     worker_id: string;
+    matrix: JobMatrix;
+    matrix_values: {[axis: string]: string};
+    timeout: number;
 
     // UI parameter
     hasChanged: boolean;
@@ -22,6 +25,12 @@ export class Job {
     }
 }
 
+export class JobMatrix {
+    axes: {[axis: string]: Array<string>};
+    exclude: Array<{[axis: string]: string}>;
+    include: Array<{[axis: string]: string}>;
+}
+
 export class StepStatus {
     step_order: number;
     status: string;
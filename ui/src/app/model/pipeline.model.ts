@@ -16,6 +16,7 @@ export class PipelineStatus {
     static SKIPPED = 'Skipped';
     static NEVER_BUILT = 'Never Built';
     static STOPPED = 'Stopped';
+    static TIMEOUT = 'Timeout';
     static PENDING = 'Pending';
 
     static neverRun(status: string) {
@@ -28,7 +29,7 @@ export class PipelineStatus {
 
     static isDone(status: string) {
         return status === this.SUCCESS || status === this.STOPPED || status === this.FAIL ||
-            status === this.SKIPPED || status === this.DISABLED;
+            status === this.TIMEOUT || status === this.SKIPPED || status === this.DISABLED;
     }
 }
 
@@ -4,6 +4,7 @@ export class VCSStrategy {
     password: string;
     ssh_key: string;
     pgp_key: string;
+    merge_result: boolean;
 
     constructor() {
         this.connection_type = VCSConnections.HTTPS;
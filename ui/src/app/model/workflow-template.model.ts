@@ -28,6 +28,10 @@ export class WorkflowTemplateParameter {
     key: string;
     type: string;
     required: boolean;
+    values: Array<string>;
+    min: number;
+    max: number;
+    pattern: string;
 }
 
 export class PipelineTemplate {
@@ -44,6 +44,7 @@ export class TaskExecution {
     rabbitmq: RabbitMQ;
     gerrit: GerritExecution;
     kafka: Kafka;
+    message_bus: MessageBus;
     scheduled_task?: any;
     status: HookStatus;
 }
@@ -66,3 +67,8 @@ export class Kafka {
     message: string;
 }
 
+export class MessageBus {
+    subject: string;
+    message: string;
+}
+
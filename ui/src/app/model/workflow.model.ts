@@ -724,4 +724,16 @@ export class UserNotificationTemplate {
     body: string;
     disable_comment: boolean;
     disable_status: boolean;
+    gerrit: GerritReviewSettings;
+}
+
+export class GerritReviewSettings {
+    labels: Array<GerritLabelVote>;
+    inline_comments: boolean;
+}
+
+export class GerritLabelVote {
+    label: string;
+    on_success: number;
+    on_failure: number;
 }
@@ -164,6 +164,9 @@ export class WorkflowTemplateParamFormComponent implements OnInit, OnDestroy {
                                 }
                             }
                             break;
+                        case 'multi-select':
+                            this.parameterValues[parameter.key] = JSON.parse(v);
+                            break;
                         default:
                             this.parameterValues[parameter.key] = v;
                             break;
@@ -203,6 +206,16 @@ export class WorkflowTemplateParamFormComponent implements OnInit, OnDestroy {
                             this.parameterValues[parameter.key + '-repository'];
                     }
                     break;
+                case 'multi-select':
+                    if (this.parameterValues[parameter.key] && this.parameterValues[parameter.key].length > 0) {
+                        parameters[parameter.key] = JSON.stringify(this.parameterValues[parameter.key]);
+                    }
+                    break;
+                case 'number':
+                    if (this.parameterValues[parameter.key] !== undefined && this.parameterValues[parameter.key] !== null) {
+                        parameters[parameter.key] = String(this.parameterValues[parameter.key]);
+                    }
+                    break;
                 default:
                     if (this.parameterValues[parameter.key]) {
                         parameters[parameter.key] = this.parameterValues[parameter.key];
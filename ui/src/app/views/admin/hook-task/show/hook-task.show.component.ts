@@ -102,6 +102,8 @@ export class HookTaskShowComponent {
                 this.selectedExecutionBody = this.decodeBody(e.rabbitmq.message);
             } else if (e.kafka) {
                 this.selectedExecutionBody = this.decodeBody(e.kafka.message);
+            } else if (e.message_bus) {
+                this.selectedExecutionBody = this.decodeBody(e.message_bus.message);
             }
         };
     }
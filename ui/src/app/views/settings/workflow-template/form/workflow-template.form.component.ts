@@ -129,7 +129,8 @@ export class WorkflowTemplateFormComponent {
     importFromURL: boolean;
 
     constructor(private _sharedService: SharedService) {
-        this.templateParameterTypes = ['boolean', 'string', 'repository', 'json', 'ssh-key', 'pgp-key'];
+        this.templateParameterTypes = ['boolean', 'string', 'repository', 'json', 'ssh-key', 'pgp-key', 'list',
+            'multi-select', 'number', 'regexp', 'environment', 'integration', 'secret'];
 
         this.resetParameterValue();
     }
@@ -149,7 +149,8 @@ export class WorkflowRunNodePipelineComponent implements OnInit, OnDestroy {
                         // compute warning
                         if (rj.job.step_status) {
                             rj.job.step_status.forEach(ss => {
-                                if (ss.status === PipelineStatus.FAIL && rj.job.action.actions[ss.step_order] &&
+                                if ((ss.status === PipelineStatus.FAIL || ss.status === PipelineStatus.TIMEOUT) &&
+                                    rj.job.action.actions[ss.step_order] &&
                                     rj.job.action.actions[ss.step_order].optional) {
                                     warnings++;
                                 }
@@ -203,6 +204,7 @@ export class WorkflowRunNodePipelineComponent implements OnInit, OnDestroy {
                     break;
                 case this.pipelineStatusEnum.SUCCESS:
                 case this.pipelineStatusEnum.FAIL:
+                case this.pipelineStatusEnum.TIMEOUT:
                 case this.pipelineStatusEnum.STOPPED:
                     let dd = DurationService.duration(new Date(v.start), new Date(v.done));
                     let item = this.jobTime.get(k);
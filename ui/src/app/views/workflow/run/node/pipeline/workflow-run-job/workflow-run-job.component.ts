@@ -147,7 +147,8 @@ export class WorkflowRunJobComponent implements OnInit, OnChanges, OnDestroy {
                 block.optional = a.optional;
                 this.steps.push(block);
             }
-            this.steps[i + 1].failed = PipelineStatus.FAIL === this.nodeJobRun.job.step_status[i].status;
+            this.steps[i + 1].failed = PipelineStatus.FAIL === this.nodeJobRun.job.step_status[i].status ||
+                PipelineStatus.TIMEOUT === this.nodeJobRun.job.step_status[i].status;
         });
         this.computeStepsDuration();
 
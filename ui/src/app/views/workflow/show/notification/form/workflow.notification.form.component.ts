@@ -1,7 +1,18 @@
 import { ChangeDetectionStrategy, ChangeDetectorRef, Component, EventEmitter, Input, OnInit, Output } from '@angular/core';
 import { Project } from 'app/model/project.model';
 // tslint:disable-next-line: max-line-length
-import { notificationOnFailure, notificationOnSuccess, notificationTypes, WNode, WNodeType, Workflow, WorkflowNotification, WorkflowTriggerConditionCache } from 'app/model/workflow.model';
+import {
+    GerritLabelVote,
+    GerritReviewSettings,
+    notificationOnFailure,
+    notificationOnSuccess,
+    notificationTypes,
+    WNode,
+    WNodeType,
+    Workflow,
+    WorkflowNotification,
+    WorkflowTriggerConditionCache
+} from 'app/model/workflow.model';
 import { NotificationService } from 'app/service/notification/notification.service';
 import cloneDeep from 'lodash-es/cloneDeep';
 import { finalize, first } from 'rxjs/operators';
@@ -40,6 +51,7 @@ export class WorkflowNotificationFormComponent implements OnInit {
     selectedUsers: string;
     commentEnabled = true;
     statusEnabled = true;
+    gerrit: GerritReviewSettings = new GerritReviewSettings();
     alwaysSend = true;
     loadingNotifTemplate = false;
     triggerConditions: WorkflowTriggerConditionCache;
@@ -98,6 +110,8 @@ export class WorkflowNotificationFormComponent implements OnInit {
             this.statusEnabled = !this.notification.settings.template.disable_status;
             this.commentEnabled = !this.notification.settings.template.disable_comment;
             this.alwaysSend = this.notification.settings.on_success === 'always';
+            this.gerrit = Object.assign(new GerritReviewSettings(), this.notification.settings.template.gerrit);
+            this.gerrit.labels = (this.gerrit.labels || []).map(l => Object.assign(new GerritLabelVote(), l));
         }
 
     }
@@ -107,6 +121,14 @@ export class WorkflowNotificationFormComponent implements OnInit {
         this.notification.source_node_ref = this.notification.source_node_ref.map(id => id.toString());
     }
 
+    addGerritLabel(): void {
+        this.gerrit.labels.push(<GerritLabelVote>{ label: '', on_success: 1, on_failure: -1 });
+    }
+
+    removeGerritLabel(index: number): void {
+        this.gerrit.labels.splice(index, 1);
+    }
+
     deleteNotification(): void {
         this.deleteNotificationEvent.emit(this.notification);
     }
@@ -126,6 +148,19 @@ export class WorkflowNotificationFormComponent implements OnInit {
         if (this.notification.type === 'vcs') {
             this.notification.settings.template.disable_comment = !this.commentEnabled;
             this.notification.settings.template.disable_status = !this.statusEnabled;
+            let labels = this.gerrit.labels.filter(l => !!l.label);
+            if (this.gerrit.inline_comments || labels.length > 0) {
+                this.notification.settings.template.gerrit = <GerritReviewSettings>{
+                    labels: labels.map(l => <GerritLabelVote>{
+                        label: l.label,
+                        on_success: Number(l.on_success) || 0,
+                        on_failure: Number(l.on_failure) || 0
+                    }),
+                    inline_comments: this.gerrit.inline_comments
+                };
+            } else {
+                delete this.notification.settings.template.gerrit;
+            }
             if (this.alwaysSend) {
                 this.notification.settings.on_success = 'always';
             } else {